@@ -26,6 +26,10 @@ func main() {
 // It returns an exit code: 0 for success, positive for errors,
 // or exitVersion (-1) when --version was handled (no os.Exit needed).
 func run(args []string, stdout, stderr io.Writer) int {
+	if len(args) > 1 && args[1] == app.DiffCommand {
+		return app.RunDiff(args[2:], stdout, stderr)
+	}
+
 	if app.HasVersionFlag(args[1:]) {
 		app.PrintVersion(stdout)
 		return exitVersion
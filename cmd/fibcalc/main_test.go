@@ -2,10 +2,15 @@ package main
 
 import (
 	"bytes"
+	"math/big"
 	"os/exec"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/cli"
 )
 
 // --- Unit tests calling run() directly (instrumented for coverage) ---
@@ -177,6 +182,51 @@ func TestRun_Completion(t *testing.T) {
 	})
 }
 
+func TestRun_Diff(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	write := func(name string, value int64) string {
+		path := filepath.Join(dir, name)
+		if err := cli.WriteResultToFile(big.NewInt(value), 50, time.Second, "fast", cli.OutputConfig{OutputFile: path}); err != nil {
+			t.Fatalf("WriteResultToFile failed: %v", err)
+		}
+		return path
+	}
+	a := write("a.txt", 12586269025)
+	b := write("b.txt", 12586269025)
+	c := write("c.txt", 12586279025)
+
+	t.Run("identical", func(t *testing.T) {
+		t.Parallel()
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"fibcalc", "diff", a, b}, &stdout, &stderr); code != 0 {
+			t.Errorf("Expected exit code 0, got %d (stderr: %s)", code, stderr.String())
+		}
+		if !strings.Contains(stdout.String(), "identical") {
+			t.Errorf("Expected identical report, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("differing", func(t *testing.T) {
+		t.Parallel()
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"fibcalc", "diff", a, c}, &stdout, &stderr); code != 3 {
+			t.Errorf("Expected exit code 3, got %d", code)
+		}
+		if !strings.Contains(stdout.String(), "First differing digit: position 7") {
+			t.Errorf("Expected first divergence at digit 7, got:\n%s", stdout.String())
+		}
+	})
+
+	t.Run("missing argument", func(t *testing.T) {
+		t.Parallel()
+		var stdout, stderr bytes.Buffer
+		if code := run([]string{"fibcalc", "diff", a}, &stdout, &stderr); code != 4 {
+			t.Errorf("Expected exit code 4, got %d", code)
+		}
+	})
+}
+
 // --- Subprocess tests for os.Exit behavior in main() ---
 
 // testBinaryPath builds the binary once and returns its path.
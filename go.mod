@@ -14,6 +14,7 @@ require (
 	github.com/rs/zerolog v1.34.0
 	github.com/shirou/gopsutil/v4 v4.26.1
 	golang.org/x/sys v0.40.0
+	golang.org/x/term v0.36.0
 )
 
 require (
@@ -44,6 +45,5 @@ require (
 	github.com/tklauser/numcpus v0.11.0 // indirect
 	github.com/xo/terminfo v0.0.0-20220910002029-abceb7e1c41e // indirect
 	github.com/yusufpapurcu/wmi v1.2.4 // indirect
-	golang.org/x/term v0.36.0 // indirect
 	golang.org/x/text v0.8.0 // indirect
 )
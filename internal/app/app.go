@@ -25,6 +25,9 @@ type Application struct {
 	Config    config.AppConfig
 	Factory   fibonacci.CalculatorFactory
 	ErrWriter io.Writer
+	// AvailableMemory reports the available system memory for the default
+	// memory guard; nil uses memory.AvailableSystemMemory.
+	AvailableMemory func() (uint64, error)
 }
 
 // AppOption configures an Application during construction.
@@ -35,6 +38,12 @@ func WithFactory(f fibonacci.CalculatorFactory) AppOption {
 	return func(a *Application) { a.Factory = f }
 }
 
+// WithAvailableMemory sets the source of the available system memory used
+// by the default memory guard.
+func WithAvailableMemory(f func() (uint64, error)) AppOption {
+	return func(a *Application) { a.AvailableMemory = f }
+}
+
 // New creates a new Application instance by parsing command-line arguments.
 func New(args []string, errWriter io.Writer, opts ...AppOption) (*Application, error) {
 	app := &Application{ErrWriter: errWriter}
@@ -60,7 +69,7 @@ func New(args []string, errWriter io.Writer, opts ...AppOption) (*Application, e
 		return nil, err
 	}
 
-	if cfgWithProfile, loaded := calibration.LoadCachedCalibration(cfg, cfg.CalibrationProfile); loaded {
+	if cfgWithProfile, loaded := calibration.LoadCachedCalibrationWithWarnings(cfg, cfg.CalibrationProfile, errWriter); loaded {
 		cfg = cfgWithProfile
 	} else {
 		cfg = config.ApplyAdaptiveThresholds(cfg)
@@ -76,6 +85,18 @@ func (a *Application) Run(ctx context.Context, out io.Writer) int {
 		return a.runCompletion(out)
 	}
 
+	if a.Config.ExportFuzzCorpus != "" {
+		return a.runExportFuzzCorpus(out)
+	}
+
+	if a.Config.CalibrationDiff != nil {
+		return a.runCalibrationDiff(out)
+	}
+
+	if a.Config.PrintConfig != "" {
+		return a.runPrintConfig(out)
+	}
+
 	zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	ui.InitTheme(false)
 
@@ -85,10 +106,18 @@ func (a *Application) Run(ctx context.Context, out io.Writer) int {
 
 	a.Config = a.runAutoCalibrationIfEnabled(ctx, out)
 
-	if a.Config.TUI {
+	if a.Config.TUI || a.Config.TUIDemo {
 		return a.runTUI(ctx, out)
 	}
 
+	if a.Config.RoundTrip {
+		return a.runRoundTrip(ctx, out)
+	}
+
+	if a.Config.ProfileAllocs {
+		return a.runProfileAllocs(ctx, out)
+	}
+
 	return a.runCalculate(ctx, out)
 }
 
@@ -102,9 +131,53 @@ func (a *Application) runCompletion(out io.Writer) int {
 	return apperrors.ExitSuccess
 }
 
-// runCalibration runs the full calibration mode.
+// runExportFuzzCorpus writes the fuzz test seed corpus to the configured
+// directory.
+func (a *Application) runExportFuzzCorpus(out io.Writer) int {
+	written, err := fibonacci.WriteFuzzCorpus(a.Config.ExportFuzzCorpus, fibonacci.FuzzSeedCorpus())
+	if err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error exporting fuzz corpus: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	if !a.Config.Quiet {
+		fmt.Fprintf(out, "Wrote %d fuzz corpus files to %s\n", written, a.Config.ExportFuzzCorpus)
+	}
+	return apperrors.ExitSuccess
+}
+
+// runCalibrationDiff prints the comparison of the two --calibration-diff
+// profiles.
+func (a *Application) runCalibrationDiff(out io.Writer) int {
+	diff, err := calibration.DiffProfileFiles(a.Config.CalibrationDiff[0], a.Config.CalibrationDiff[1])
+	if err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error comparing calibration profiles: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	fmt.Fprint(out, diff)
+	return apperrors.ExitSuccess
+}
+
+// runPrintConfig prints the resolved configuration, including the thresholds
+// of the cached calibration profile or the adaptive estimates applied by New.
+func (a *Application) runPrintConfig(out io.Writer) int {
+	if err := a.Config.WriteConfig(out, a.Config.PrintConfig); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error printing configuration: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	return apperrors.ExitSuccess
+}
+
+// runCalibration runs the full calibration mode, over the index range of
+// --calibrate-min-n and --calibrate-max-n when set.
 func (a *Application) runCalibration(ctx context.Context, out io.Writer) int {
-	return calibration.RunCalibration(ctx, out, a.Factory.GetAll(), cli.DisplayProgress, cli.CLIColorProvider{})
+	opts := calibration.CalibrationOptions{
+		ProfilePath: a.Config.CalibrationProfile,
+		SaveProfile: true,
+		MinN:        a.Config.CalibrateMinN,
+		MaxN:        a.Config.CalibrateMaxN,
+		ReportPath:  a.Config.CalibrationReport,
+	}
+	return calibration.RunCalibrationWithOptions(ctx, out, a.Factory.GetAll(), opts, cli.DisplayProgress, cli.CLIColorProvider{})
 }
 
 // runAutoCalibrationIfEnabled runs auto-calibration if enabled.
@@ -3,12 +3,21 @@ package app
 import (
 	"bytes"
 	"context"
+	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
+	"math/bits"
 	"os"
+	"path/filepath"
 	"runtime"
+	"slices"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -458,6 +467,36 @@ func TestAnalyzeResultsWithOutputFile(t *testing.T) {
 	}
 }
 
+func TestAnalyzeResultsWithSVGCard(t *testing.T) {
+	t.Parallel()
+	svgPath := filepath.Join(t.TempDir(), "card.svg")
+
+	app := &Application{
+		Config:    config.AppConfig{N: 10, EmitSVG: svgPath},
+		Factory:   fibonacci.GlobalFactory(),
+		ErrWriter: &bytes.Buffer{},
+	}
+	results := []orchestration.CalculationResult{
+		{Name: "fast", Result: big.NewInt(55), Duration: time.Millisecond},
+	}
+
+	var outBuf bytes.Buffer
+	exitCode := app.analyzeResultsWithOutput(results, cli.OutputConfig{SVGFile: svgPath}, &outBuf)
+	if exitCode != apperrors.ExitSuccess {
+		t.Errorf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	data, err := os.ReadFile(svgPath)
+	if err != nil {
+		t.Fatalf("SVG card was not written: %v", err)
+	}
+	if !strings.Contains(string(data), "F(10)") {
+		t.Errorf("SVG card missing F(10):\n%s", data)
+	}
+	if !strings.Contains(outBuf.String(), "SVG card saved to") {
+		t.Errorf("expected a saved notice, got:\n%s", outBuf.String())
+	}
+}
+
 func TestAnalyzeResultsWithOutputVariety(t *testing.T) {
 	t.Parallel()
 	app := &Application{
@@ -557,6 +596,36 @@ func TestRunCalibration(t *testing.T) {
 				apperrors.ExitErrorCanceled, exitCode)
 		}
 	})
+
+	t.Run("Calibration over an index range with a report", func(t *testing.T) {
+		t.Parallel()
+		dir := t.TempDir()
+		profilePath := filepath.Join(dir, "calibration.json")
+		reportPath := filepath.Join(dir, "report.json")
+		app := &Application{
+			Config: config.AppConfig{
+				Calibrate:          true,
+				CalibrationProfile: profilePath,
+				CalibrateMinN:      1000,
+				CalibrateMaxN:      3000,
+				CalibrationReport:  reportPath,
+				Timeout:            1 * time.Minute,
+			},
+			Factory:   createMockFactory(big.NewInt(55), nil),
+			ErrWriter: io.Discard,
+		}
+
+		if exitCode := app.runCalibration(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+		}
+		profile, loaded := calibration.LoadOrCreateProfile(profilePath)
+		if !loaded || len(profile.Buckets) != 1 || profile.Buckets[0].MinN != 1000 || profile.Buckets[0].MaxN != 3000 {
+			t.Errorf("the profile should hold one bucket for N from 1000 to 3000, got %+v", profile.Buckets)
+		}
+		if _, err := os.Stat(reportPath); err != nil {
+			t.Errorf("the calibration report was not written: %v", err)
+		}
+	})
 }
 
 // TestRunAutoCalibrationIfEnabled tests the runAutoCalibrationIfEnabled method.
@@ -941,6 +1010,28 @@ func TestRunLastDigitsViaRun(t *testing.T) {
 	}
 }
 
+// TestRunLastDigitsTimeout checks that --timeout stops a last-digits
+// calculation with a large modulus.
+func TestRunLastDigitsTimeout(t *testing.T) {
+	t.Parallel()
+	var outBuf, errBuf bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			N:          math.MaxUint64,
+			Algo:       "fast",
+			LastDigits: 300_000,
+			Timeout:    time.Millisecond,
+			Quiet:      true,
+		},
+		Factory:   createMockFactory(big.NewInt(55), nil),
+		ErrWriter: &errBuf,
+	}
+
+	if exitCode := app.Run(context.Background(), &outBuf); exitCode != apperrors.ExitErrorTimeout {
+		t.Errorf("Expected exit code %d, got %d (stderr: %s)", apperrors.ExitErrorTimeout, exitCode, errBuf.String())
+	}
+}
+
 // TestRunCalculateMemoryLimit tests the memory limit validation paths
 // in runCalculate.
 func TestRunCalculateMemoryLimit(t *testing.T) {
@@ -1064,6 +1155,72 @@ func TestRunCalculateMemoryLimit(t *testing.T) {
 	})
 }
 
+// TestRunCalculateSystemMemoryGuard tests the default memory guard against a
+// mocked available system memory.
+func TestRunCalculateSystemMemoryGuard(t *testing.T) {
+	t.Parallel()
+	newApp := func(available uint64, err error, errOut io.Writer) *Application {
+		return &Application{
+			Config: config.AppConfig{
+				N:       10_000_000,
+				Algo:    "fast",
+				Timeout: 1 * time.Minute,
+				Quiet:   true,
+			},
+			Factory:         createMockFactory(big.NewInt(55), nil),
+			ErrWriter:       errOut,
+			AvailableMemory: func() (uint64, error) { return available, err },
+		}
+	}
+
+	t.Run("Estimate exceeds available memory", func(t *testing.T) {
+		t.Parallel()
+		var errOut bytes.Buffer
+		exitCode := newApp(1<<20, nil, &errOut).Run(context.Background(), io.Discard)
+		if exitCode != apperrors.ExitErrorGeneric {
+			t.Errorf("Expected exit code %d, got %d", apperrors.ExitErrorGeneric, exitCode)
+		}
+		if !strings.Contains(errOut.String(), "Failure (Memory)") {
+			t.Errorf("Expected a memory error, got:\n%s", errOut.String())
+		}
+	})
+
+	t.Run("Estimate fits available memory", func(t *testing.T) {
+		t.Parallel()
+		var errOut bytes.Buffer
+		if exitCode := newApp(1<<40, nil, &errOut).Run(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+			t.Errorf("Expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+		}
+	})
+
+	t.Run("Iterative estimate fits available memory", func(t *testing.T) {
+		t.Parallel()
+		// 8 MiB is too little for the fast doubling estimate of F(10^7),
+		// but enough for the two values of the iterative algorithm.
+		var errOut bytes.Buffer
+		if exitCode := newApp(8<<20, nil, &errOut).Run(context.Background(), io.Discard); exitCode != apperrors.ExitErrorGeneric {
+			t.Errorf("fast: expected exit code %d, got %d", apperrors.ExitErrorGeneric, exitCode)
+		}
+		errOut.Reset()
+		app := newApp(8<<20, nil, &errOut)
+		app.Config.Algo = fibonacci.IterativeAlgorithm
+		app.Factory = fibonacci.NewTestFactory(map[string]fibonacci.Calculator{
+			fibonacci.IterativeAlgorithm: &fibonacci.MockCalculator{Result: big.NewInt(55)},
+		})
+		if exitCode := app.Run(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+			t.Errorf("iterative: expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+		}
+	})
+
+	t.Run("Unknown available memory", func(t *testing.T) {
+		t.Parallel()
+		var errOut bytes.Buffer
+		if exitCode := newApp(0, errors.New("no meminfo"), &errOut).Run(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+			t.Errorf("Expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+		}
+	})
+}
+
 // TestAnalyzeResultsQuietModeWithOutputFile tests quiet mode output
 // with file saving in analyzeResultsWithOutput.
 func TestAnalyzeResultsQuietModeWithOutputFile(t *testing.T) {
@@ -1150,47 +1307,6 @@ func TestSaveResultIfNeeded(t *testing.T) {
 	})
 }
 
-// TestFindBestResult tests the findBestResult helper function.
-func TestFindBestResult(t *testing.T) {
-	t.Parallel()
-
-	t.Run("All errors returns nil", func(t *testing.T) {
-		t.Parallel()
-		results := []orchestration.CalculationResult{
-			{Name: "a", Err: fmt.Errorf("error a")},
-			{Name: "b", Err: fmt.Errorf("error b")},
-		}
-		best := findBestResult(results)
-		if best != nil {
-			t.Error("Expected nil for all-error results")
-		}
-	})
-
-	t.Run("Selects fastest successful result", func(t *testing.T) {
-		t.Parallel()
-		results := []orchestration.CalculationResult{
-			{Name: "slow", Result: big.NewInt(55), Duration: 100 * time.Millisecond},
-			{Name: "fast", Result: big.NewInt(55), Duration: 10 * time.Millisecond},
-			{Name: "err", Err: fmt.Errorf("failed")},
-		}
-		best := findBestResult(results)
-		if best == nil {
-			t.Fatal("Expected non-nil result")
-		}
-		if best.Name != "fast" {
-			t.Errorf("Expected fastest result 'fast', got '%s'", best.Name)
-		}
-	})
-
-	t.Run("Empty results returns nil", func(t *testing.T) {
-		t.Parallel()
-		best := findBestResult(nil)
-		if best != nil {
-			t.Error("Expected nil for nil results")
-		}
-	})
-}
-
 // TestNewWithCustomFactory tests creating an Application with
 // a custom factory via the WithFactory option.
 func TestNewWithCustomFactory(t *testing.T) {
@@ -1249,6 +1365,81 @@ func TestRunCalculateVerboseMode(t *testing.T) {
 	}
 }
 
+// TestRunCalculateDynamicThresholds verifies that --dynamic-thresholds saves
+// the learned thresholds into the calibration profile, and that a failure to
+// save them only warns.
+func TestRunCalculateDynamicThresholds(t *testing.T) {
+	t.Parallel()
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+	newApp := func(errOut io.Writer) *Application {
+		return &Application{
+			Config: config.AppConfig{
+				N:                  10_000,
+				Algo:               "fast",
+				Timeout:            time.Minute,
+				Quiet:              true,
+				DynamicThresholds:  true,
+				CalibrationProfile: profilePath,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: errOut,
+		}
+	}
+
+	// Without a profile to refine, the result is still printed.
+	var errOut bytes.Buffer
+	if exitCode := newApp(&errOut).Run(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if !strings.Contains(errOut.String(), "learned thresholds not saved") {
+		t.Errorf("expected a warning, got %q", errOut.String())
+	}
+
+	if err := calibration.NewProfile().SaveProfile(profilePath); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	errOut.Reset()
+	if exitCode := newApp(&errOut).Run(context.Background(), io.Discard); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+	}
+	profile, loaded := calibration.LoadOrCreateProfileWithWarnings(profilePath, &errOut)
+	if !loaded || profile.LearnedFFTThreshold <= 0 || profile.LearnedParallelThreshold <= 0 {
+		t.Errorf("profile lacks the learned thresholds: loaded=%v, %+v, warnings %q", loaded, profile, errOut.String())
+	}
+}
+
+// TestRunCalculateDetailsMemory verifies that --details reports the memory
+// behavior of the calculation, and that it is left out otherwise.
+func TestRunCalculateDetailsMemory(t *testing.T) {
+	t.Parallel()
+	for _, details := range []bool{true, false} {
+		var outBuf bytes.Buffer
+		app := &Application{
+			Config: config.AppConfig{
+				N:       1_000_000,
+				Algo:    "fast",
+				Timeout: 1 * time.Minute,
+				Details: details,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: io.Discard,
+		}
+
+		if exitCode := app.Run(context.Background(), &outBuf); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+		}
+		output := testutil.StripAnsiCodes(outBuf.String())
+		for _, field := range []string{"--- Memory behavior ---", "Allocated", "GC cycles", "Peak heap from the OS"} {
+			if strings.Contains(output, field) != details {
+				t.Errorf("details=%v: output contains %q = %v. Output:\n%s", details, field, !details, output)
+			}
+		}
+		if details && strings.Contains(output, "Allocated               : 0 B") {
+			t.Errorf("F(1,000,000) should allocate memory. Output:\n%s", output)
+		}
+	}
+}
+
 // TestRunCalculateCalculatorError tests that calculator errors are handled.
 func TestRunCalculateCalculatorError(t *testing.T) {
 	t.Parallel()
@@ -1272,3 +1463,922 @@ func TestRunCalculateCalculatorError(t *testing.T) {
 		t.Error("Expected non-success exit code for calculator error")
 	}
 }
+
+// TestRunCalculateExplainMemory verifies that --explain-memory reports both
+// the pre-run estimate and a positive actual peak.
+func TestRunCalculateExplainMemory(t *testing.T) {
+	t.Parallel()
+	var outBuf bytes.Buffer
+
+	app := &Application{
+		Config: config.AppConfig{
+			N:             500_000,
+			Algo:          "fast",
+			Timeout:       1 * time.Minute,
+			Quiet:         true,
+			ExplainMemory: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &bytes.Buffer{},
+	}
+
+	exitCode := app.Run(context.Background(), &outBuf)
+	if exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+
+	output := testutil.StripAnsiCodes(outBuf.String())
+	if !strings.Contains(output, "Estimated (pre-run)") {
+		t.Errorf("Output should contain the memory estimate. Output:\n%s", output)
+	}
+	var actual, unit string
+	idx := strings.Index(output, "Actual peak heap in use : ")
+	if idx < 0 {
+		t.Fatalf("Output should contain the actual peak heap. Output:\n%s", output)
+	}
+	if _, err := fmt.Sscanf(output[idx+len("Actual peak heap in use : "):], "%s %s", &actual, &unit); err != nil {
+		t.Fatalf("Failed to parse actual peak: %v", err)
+	}
+	if actual == "0" {
+		t.Errorf("Actual peak should be positive, got %s %s", actual, unit)
+	}
+}
+
+// TestRunCalculateFibonacciWord verifies the --fib-word-length and
+// --fib-word-ones modes.
+func TestRunCalculateFibonacciWord(t *testing.T) {
+	t.Parallel()
+	var outBuf bytes.Buffer
+
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:          "fast",
+			Timeout:       1 * time.Minute,
+			Quiet:         true,
+			FibWordLength: 13,
+			FibWordOnes:   13,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &bytes.Buffer{},
+	}
+
+	exitCode := app.Run(context.Background(), &outBuf)
+	if exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if want := "0100101001001\n5\n"; outBuf.String() != want {
+		t.Errorf("Expected output %q, got %q", want, outBuf.String())
+	}
+}
+
+// TestRunCalculateBinet verifies that --algo binet prints the approximation
+// in scientific notation, without running the exact pipeline, and rejects
+// an out-of-range precision.
+func TestRunCalculateBinet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		cfg       config.AppConfig
+		wantCode  int
+		wantOut   string
+		wantError string
+	}{
+		{"Quiet", config.AppConfig{N: 100, BinetPrecision: 64, Quiet: true}, apperrors.ExitSuccess, "3.54224848179261915×10^20\n", ""},
+		{"Zero", config.AppConfig{N: 0}, apperrors.ExitSuccess, "F(0) = 0 (exact)\n", ""},
+		{"Invalid precision", config.AppConfig{N: 100, BinetPrecision: 8}, apperrors.ExitErrorConfig, "", "binet-precision"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var outBuf, errBuf bytes.Buffer
+			tt.cfg.Algo = fibonacci.BinetAlgorithm
+			tt.cfg.Timeout = time.Minute
+			app := &Application{Config: tt.cfg, Factory: fibonacci.NewDefaultFactory(), ErrWriter: &errBuf}
+
+			if exitCode := app.Run(context.Background(), &outBuf); exitCode != tt.wantCode {
+				t.Fatalf("Expected exit code %d, got %d (stderr: %s)", tt.wantCode, exitCode, errBuf.String())
+			}
+			if outBuf.String() != tt.wantOut {
+				t.Errorf("Expected output %q, got %q", tt.wantOut, outBuf.String())
+			}
+			if !strings.Contains(errBuf.String(), tt.wantError) {
+				t.Errorf("Expected %q in the error output, got %q", tt.wantError, errBuf.String())
+			}
+		})
+	}
+}
+
+// TestAnalyzeResultsOutputLimitError verifies that --limit-output-mode error
+// refuses to display an oversized value.
+func TestAnalyzeResultsOutputLimitError(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "fast", Result: big.NewInt(12586269025), Duration: time.Millisecond},
+	}
+
+	for _, tc := range []struct {
+		name     string
+		limit    int
+		wantCode int
+	}{
+		{"Over the limit", 5, apperrors.ExitErrorGeneric},
+		{"Under the limit", 11, apperrors.ExitSuccess},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var outBuf, errBuf bytes.Buffer
+			app := &Application{
+				Config: config.AppConfig{
+					N:                50,
+					LimitOutputBytes: tc.limit,
+					LimitOutputMode:  config.LimitOutputError,
+				},
+				ErrWriter: &errBuf,
+			}
+			exitCode := app.analyzeResultsWithOutput(results, cli.OutputConfig{Quiet: true}, &outBuf)
+			if exitCode != tc.wantCode {
+				t.Fatalf("Expected exit code %d, got %d", tc.wantCode, exitCode)
+			}
+			if tc.wantCode != apperrors.ExitSuccess {
+				if outBuf.Len() != 0 {
+					t.Errorf("Expected no output, got %q", outBuf.String())
+				}
+				if !strings.Contains(errBuf.String(), "--limit-output-bytes") {
+					t.Errorf("Expected limit error message, got %q", errBuf.String())
+				}
+			} else if !strings.Contains(outBuf.String(), "12586269025") {
+				t.Errorf("Expected full value, got %q", outBuf.String())
+			}
+		})
+	}
+}
+
+// TestRunCalculateNegativeIndex verifies that a negative -n is routed to
+// fibonacci.FibonacciSigned.
+func TestRunCalculateNegativeIndex(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		n    uint64
+		want string
+	}{
+		{6, "-8\n"},
+		{7, "13\n"},
+	} {
+		var outBuf bytes.Buffer
+		app := &Application{
+			Config: config.AppConfig{
+				N:         tc.n,
+				NegativeN: true,
+				Algo:      "fast",
+				Timeout:   1 * time.Minute,
+				Quiet:     true,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: &bytes.Buffer{},
+		}
+
+		if exitCode := app.Run(context.Background(), &outBuf); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("F(-%d): expected exit code %d, got %d", tc.n, apperrors.ExitSuccess, exitCode)
+		}
+		if outBuf.String() != tc.want {
+			t.Errorf("F(-%d): expected %q, got %q", tc.n, tc.want, outBuf.String())
+		}
+	}
+}
+
+// TestRunCalculateNegativeIndexMemoryGuard verifies that negative indices go
+// through the same memory guard as positive ones.
+func TestRunCalculateNegativeIndexMemoryGuard(t *testing.T) {
+	t.Parallel()
+	var errOut bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			N:         10_000_000,
+			NegativeN: true,
+			Algo:      "fast",
+			Timeout:   time.Minute,
+			Quiet:     true,
+		},
+		Factory:         fibonacci.NewDefaultFactory(),
+		ErrWriter:       &errOut,
+		AvailableMemory: func() (uint64, error) { return 1 << 20, nil },
+	}
+	if exitCode := app.Run(context.Background(), io.Discard); exitCode != apperrors.ExitErrorGeneric {
+		t.Errorf("Expected exit code %d, got %d", apperrors.ExitErrorGeneric, exitCode)
+	}
+	if !strings.Contains(errOut.String(), "Failure (Memory)") {
+		t.Errorf("Expected a memory error, got:\n%s", errOut.String())
+	}
+}
+
+// TestRunCalculateMinN verifies that --min-n rejects benchmarking FFT at a
+// trivially small index with guidance towards a larger n.
+func TestRunCalculateMinN(t *testing.T) {
+	t.Parallel()
+	var errBuf bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:    "fft",
+			N:       10,
+			MinN:    1,
+			Timeout: 1 * time.Minute,
+			Quiet:   true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &errBuf,
+	}
+
+	if exitCode := app.Run(context.Background(), io.Discard); exitCode != apperrors.ExitErrorConfig {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitErrorConfig, exitCode)
+	}
+	floor := fibonacci.MinMeaningfulN("fft", fibonacci.Options{})
+	if !strings.Contains(errBuf.String(), fmt.Sprintf("use -n %d or larger", floor)) {
+		t.Errorf("Expected guidance towards n >= %d, got %q", floor, errBuf.String())
+	}
+
+	err := (&Application{Config: config.AppConfig{Algo: "fast", N: 1000, MinN: 100}, Factory: fibonacci.NewDefaultFactory()}).checkMinN()
+	if err != nil {
+		t.Errorf("n above the floor was rejected: %v", err)
+	}
+	err = (&Application{Config: config.AppConfig{Algo: "fast", N: 1000, MinN: 5000}, Factory: fibonacci.NewDefaultFactory()}).checkMinN()
+	var validationErr apperrors.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "n" {
+		t.Errorf("Expected a ValidationError for n, got %v", err)
+	}
+}
+
+// TestRunJobSpec verifies that a --job spec alone configures a complete run.
+func TestRunJobSpec(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	jobPath := filepath.Join(dir, "job.json")
+	resultPath := filepath.Join(dir, "result.txt")
+	spec := fmt.Sprintf(`{"n": 100, "algo": "matrix", "format": "quiet", "timeout": "1m", "output": %q}`, resultPath)
+	if err := os.WriteFile(jobPath, []byte(spec), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var errBuf, outBuf bytes.Buffer
+	app, err := New([]string{"fibcalc", "--job", jobPath}, &errBuf)
+	if err != nil {
+		t.Fatalf("New() returned unexpected error: %v (%s)", err, errBuf.String())
+	}
+	if exitCode := app.Run(context.Background(), &outBuf); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d (%s)", apperrors.ExitSuccess, exitCode, errBuf.String())
+	}
+	if got := strings.TrimSpace(outBuf.String()); got != "354224848179261915075" {
+		t.Errorf("Expected quiet output of F(100), got %q", got)
+	}
+	if data, err := os.ReadFile(resultPath); err != nil || !strings.Contains(string(data), "354224848179261915075") {
+		t.Errorf("Expected F(100) in the job's output file, got %q (err %v)", data, err)
+	}
+}
+
+// TestRunCalculateZeckendorf verifies that --zeckendorf prints the
+// representation of the computed value after the result.
+func TestRunCalculateZeckendorf(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:       "fast",
+			N:          12,
+			Timeout:    1 * time.Minute,
+			Quiet:      true,
+			Zeckendorf: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if got := out.String(); got != "144\nF(12)\n" {
+		t.Errorf("Expected F(12) = 144 and its representation, got %q", got)
+	}
+}
+
+// TestRunCalculateCompareRepeat verifies that --compare-repeat ranks the
+// algorithms after the comparison summary.
+func TestRunCalculateCompareRepeat(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:          "all",
+			N:             1000,
+			Timeout:       1 * time.Minute,
+			CompareRepeat: 3,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	output := out.String()
+	summary := strings.Index(output, "Comparison Summary")
+	ranking := strings.Index(output, "Repeated Comparison (3 runs)")
+	if summary < 0 || ranking < summary {
+		t.Fatalf("Output should rank the algorithms after the summary. Output:\n%s", output)
+	}
+	if !strings.Contains(output, "Winner: ") && !strings.Contains(output, "tie within noise") {
+		t.Errorf("Output should declare a winner or a tie. Output:\n%s", output)
+	}
+}
+
+// TestRunCalculateCompareRepeatTimeout verifies that each --compare-repeat
+// run has its own timeout, so that runs adding up to more than --timeout are
+// all ranked.
+func TestRunCalculateCompareRepeatTimeout(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	mock := &fibonacci.MockCalculator{Fn: func(ctx context.Context, _ uint64) (*big.Int, error) {
+		calls.Add(1)
+		select {
+		case <-time.After(20 * time.Millisecond):
+			return big.NewInt(55), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}}
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:          "all",
+			N:             10,
+			Timeout:       100 * time.Millisecond,
+			CompareRepeat: 8,
+		},
+		Factory:   fibonacci.NewTestFactory(map[string]fibonacci.Calculator{"fast": mock}),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if got := calls.Load(); got != 8 {
+		t.Errorf("the calculator ran %d times, want 8", got)
+	}
+}
+
+// TestRunCalculateRepeat verifies that --repeat runs the calculation the
+// requested number of times, warm-up included, and prints the statistics.
+func TestRunCalculateRepeat(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	mock := &fibonacci.MockCalculator{Fn: func(context.Context, uint64) (*big.Int, error) {
+		calls.Add(1)
+		return big.NewInt(55), nil
+	}}
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:    "fast",
+			N:       10,
+			Timeout: 1 * time.Minute,
+			Quiet:   true,
+			Repeat:  3,
+		},
+		Factory:   fibonacci.NewTestFactory(map[string]fibonacci.Calculator{"fast": mock}),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if got := calls.Load(); got != 3 {
+		t.Errorf("the calculator ran %d times, want 3", got)
+	}
+	// Quiet mode prints the result and one compact line per algorithm.
+	output := out.String()
+	if strings.Contains(output, "Repeated runs") || !strings.Contains(output, "median=") || strings.Contains(output, "\x1b[") {
+		t.Errorf("Output should summarize the repeated runs on one uncolored line. Output:\n%s", output)
+	}
+	if lines := strings.Split(strings.TrimSpace(output), "\n"); len(lines) != 2 {
+		t.Errorf("Output should have the result and one statistics line, got %d lines:\n%s", len(lines), output)
+	}
+}
+
+// TestRunCalculateRepeatTimeout verifies that each repeated run has its own
+// timeout: a run that times out is counted as failed and the next one runs.
+func TestRunCalculateRepeatTimeout(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	mock := &fibonacci.MockCalculator{Fn: func(ctx context.Context, _ uint64) (*big.Int, error) {
+		if calls.Add(1) == 2 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return big.NewInt(55), nil
+	}}
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:    "fast",
+			N:       10,
+			Timeout: 50 * time.Millisecond,
+			Quiet:   true,
+			Repeat:  4,
+		},
+		Factory:   fibonacci.NewTestFactory(map[string]fibonacci.Calculator{"fast": mock}),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if got := calls.Load(); got != 4 {
+		t.Errorf("the calculator ran %d times, want 4", got)
+	}
+	if output := out.String(); !strings.Contains(output, "failed=1") {
+		t.Errorf("Output should report the timed out run. Output:\n%s", output)
+	}
+}
+
+// TestRunCalculateScientific verifies that --scientific prints the value in
+// scientific notation, also in quiet mode and without -c.
+func TestRunCalculateScientific(t *testing.T) {
+	t.Parallel()
+	for _, quiet := range []bool{false, true} {
+		var out bytes.Buffer
+		app := &Application{
+			Config: config.AppConfig{
+				Algo:       "fast",
+				N:          100,
+				Timeout:    1 * time.Minute,
+				Quiet:      quiet,
+				Scientific: true,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: io.Discard,
+		}
+
+		if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("quiet=%v: expected exit code %d, got %d", quiet, apperrors.ExitSuccess, exitCode)
+		}
+		if !strings.Contains(out.String(), "3.54225×10^20 (21 digits)") {
+			t.Errorf("quiet=%v: output should contain F(100) in scientific notation. Output:\n%s", quiet, out.String())
+		}
+	}
+}
+
+// TestRunCalculateBase verifies that --base prints the value in the chosen
+// base: raw digits in quiet mode, grouped digits otherwise.
+func TestRunCalculateBase(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		quiet bool
+		want  string
+	}{
+		{true, "1333db76a7c594bfc3\n"},
+		{false, "13_33db_76a7_c594_bfc3"},
+	}
+	for _, tt := range tests {
+		var out bytes.Buffer
+		app := &Application{
+			Config: config.AppConfig{
+				Algo:      "fast",
+				N:         100,
+				Timeout:   1 * time.Minute,
+				Quiet:     tt.quiet,
+				ShowValue: true,
+				Base:      16,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: io.Discard,
+		}
+
+		if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("quiet=%v: expected exit code %d, got %d", tt.quiet, apperrors.ExitSuccess, exitCode)
+		}
+		if tt.quiet && out.String() != tt.want || !strings.Contains(out.String(), tt.want) {
+			t.Errorf("quiet=%v: output should contain F(100) in hexadecimal %q. Output:\n%s", tt.quiet, tt.want, out.String())
+		}
+	}
+}
+
+// TestRunCalculateFFTNotice verifies that an explicit --algo fft below the FFT
+// threshold prints a notice, still runs FFT, and stays silent in quiet mode.
+func TestRunCalculateFFTNotice(t *testing.T) {
+	t.Parallel()
+	newApp := func(n uint64, quiet bool) *Application {
+		return &Application{
+			Config: config.AppConfig{
+				Algo:      "fft",
+				N:         n,
+				Timeout:   1 * time.Minute,
+				Quiet:     quiet,
+				ShowValue: true,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: io.Discard,
+		}
+	}
+
+	var out bytes.Buffer
+	if exitCode := newApp(50, false).Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if !strings.Contains(out.String(), "below the FFT threshold") {
+		t.Errorf("Expected the FFT notice, got %q", out.String())
+	}
+	if !strings.Contains(out.String(), "12,586,269,025") {
+		t.Errorf("Expected F(50) = 12586269025 in the output, got %q", out.String())
+	}
+
+	out.Reset()
+	if exitCode := newApp(50, true).Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if out.String() != "12586269025\n" {
+		t.Errorf("Expected only the value in quiet mode, got %q", out.String())
+	}
+
+	if notice := newApp(1_000_000, false).fftNotice(); notice != "" {
+		t.Errorf("Expected no notice above the FFT threshold, got %q", notice)
+	}
+}
+
+// TestRunCalculateBenchJSON verifies that --bench-json replaces the standard
+// output with a stream of `go test -json` events, one benchmark per algorithm.
+func TestRunCalculateBenchJSON(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:      "all",
+			N:         1000,
+			Timeout:   1 * time.Minute,
+			BenchJSON: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	benchmarks := 0
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var event cli.BenchEvent
+		if err := dec.Decode(&event); err != nil {
+			t.Fatalf("Expected only JSON events, got error: %v", err)
+		}
+		if strings.HasSuffix(event.Output, " ns/op\n") {
+			benchmarks++
+		}
+	}
+	if want := len(orchestration.GetCalculatorsToRun("all", app.Factory)); benchmarks != want {
+		t.Errorf("Expected %d benchmark lines, got %d", want, benchmarks)
+	}
+}
+
+// TestRunCalculateJSON verifies that --json writes a single JSON document,
+// free of ANSI decoration, describing every algorithm.
+func TestRunCalculateJSON(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:      "all",
+			N:         100,
+			Timeout:   1 * time.Minute,
+			ShowValue: true,
+			JSON:      true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if strings.Contains(out.String(), "\x1b[") {
+		t.Errorf("JSON output should not contain ANSI escapes. Output:\n%s", out.String())
+	}
+	var report cli.JSONReport
+	dec := json.NewDecoder(&out)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&report); err != nil {
+		t.Fatalf("Expected a JSON document, got error: %v", err)
+	}
+	if dec.More() {
+		t.Error("Expected a single JSON document")
+	}
+	if report.N != 100 || !report.Consistent || report.Fastest == "" {
+		t.Errorf("Unexpected report %+v", report)
+	}
+	if want := len(orchestration.GetCalculatorsToRun("all", app.Factory)); len(report.Results) != want {
+		t.Fatalf("Expected %d results, got %d", want, len(report.Results))
+	}
+	if report.Results[0].Algorithm != report.Fastest {
+		t.Errorf("Fastest = %q, want the first result %q", report.Fastest, report.Results[0].Algorithm)
+	}
+	for _, res := range report.Results {
+		if res.Digits != 21 || res.BitLen != 69 || res.Value != "354224848179261915075" || res.DurationNs <= 0 {
+			t.Errorf("Unexpected result for F(100): %+v", res)
+		}
+	}
+}
+
+// TestRunCalculateCSV verifies that --csv writes one row per algorithm and
+// that --quiet drops the header.
+func TestRunCalculateCSV(t *testing.T) {
+	t.Parallel()
+	for _, quiet := range []bool{false, true} {
+		var out bytes.Buffer
+		app := &Application{
+			Config: config.AppConfig{
+				Algo:    "all",
+				N:       100,
+				Timeout: 1 * time.Minute,
+				Quiet:   quiet,
+				CSV:     true,
+			},
+			Factory:   fibonacci.NewDefaultFactory(),
+			ErrWriter: io.Discard,
+		}
+
+		if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+			t.Fatalf("quiet=%v: expected exit code %d, got %d", quiet, apperrors.ExitSuccess, exitCode)
+		}
+		records, err := csv.NewReader(&out).ReadAll()
+		if err != nil {
+			t.Fatalf("quiet=%v: expected CSV output, got error: %v", quiet, err)
+		}
+		if !quiet {
+			if len(records) == 0 || !slices.Equal(records[0], cli.CSVHeader) {
+				t.Fatalf("Expected the header row first, got %q", records)
+			}
+			records = records[1:]
+		}
+		if want := len(orchestration.GetCalculatorsToRun("all", app.Factory)); len(records) != want {
+			t.Fatalf("quiet=%v: expected %d rows, got %q", quiet, want, records)
+		}
+		for i, record := range records {
+			wantFastest := strconv.FormatBool(i == 0)
+			if record[2] != "69" || record[3] != "21" || record[4] != "true" || record[5] != wantFastest {
+				t.Errorf("quiet=%v: unexpected row for F(100): %q", quiet, record)
+			}
+		}
+	}
+}
+
+func TestRunCalculateMarkdown(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:     "all",
+			N:        100,
+			Timeout:  1 * time.Minute,
+			Markdown: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+	if want := len(orchestration.GetCalculatorsToRun("all", app.Factory)) + 2; len(lines) != want {
+		t.Fatalf("Expected a header, a separator and one row per algorithm (%d lines), got:\n%s", want, out.String())
+	}
+	if !strings.HasPrefix(lines[1], "|---|") || !strings.HasPrefix(lines[2], "| **") {
+		t.Errorf("Expected a table with the fastest algorithm bolded first, got:\n%s", out.String())
+	}
+}
+
+func TestRunCalculateBenchSteps(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:       "fast",
+			N:          100_000,
+			Timeout:    1 * time.Minute,
+			Quiet:      true,
+			BenchSteps: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if want := fmt.Sprintf("Doubling steps (%d)", bits.Len64(100_000)); !strings.Contains(out.String(), want) {
+		t.Errorf("Expected %q in the output, got:\n%s", want, out.String())
+	}
+}
+
+// namedMockCalculator is a fibonacci.MockCalculator with a custom name.
+type namedMockCalculator struct {
+	*fibonacci.MockCalculator
+	name string
+}
+
+func (c namedMockCalculator) Name() string { return c.name }
+
+// TestRunCalculateFailOnInconsistency forces two algorithms to disagree and
+// checks the exit code and the JSON mismatch record in every output mode.
+func TestRunCalculateFailOnInconsistency(t *testing.T) {
+	t.Parallel()
+	factory := fibonacci.NewTestFactory(map[string]fibonacci.Calculator{
+		"fast":   namedMockCalculator{&fibonacci.MockCalculator{Result: big.NewInt(55)}, "Good"},
+		"matrix": namedMockCalculator{&fibonacci.MockCalculator{Result: big.NewInt(56)}, "Broken"},
+	})
+
+	tests := []struct {
+		name     string
+		cfg      config.AppConfig
+		wantCode int
+	}{
+		{"text", config.AppConfig{}, apperrors.ExitErrorMismatch},
+		{"quiet", config.AppConfig{Quiet: true}, apperrors.ExitErrorMismatch},
+		{"bench-json", config.AppConfig{BenchJSON: true}, apperrors.ExitErrorMismatch},
+		{"json", config.AppConfig{JSON: true}, apperrors.ExitErrorMismatch},
+		{"csv", config.AppConfig{CSV: true}, apperrors.ExitErrorMismatch},
+		{"quiet, reporting only", config.AppConfig{Quiet: true, IgnoreInconsistency: true}, apperrors.ExitSuccess},
+		{"text, reporting only", config.AppConfig{IgnoreInconsistency: true}, apperrors.ExitSuccess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var out, errBuf bytes.Buffer
+			cfg := tt.cfg
+			cfg.Algo, cfg.N, cfg.Timeout = "all", 10, time.Minute
+			app := &Application{Config: cfg, Factory: factory, ErrWriter: &errBuf}
+
+			if exitCode := app.Run(context.Background(), &out); exitCode != tt.wantCode {
+				t.Fatalf("Expected exit code %d, got %d", tt.wantCode, exitCode)
+			}
+			var record struct {
+				N        uint64                  `json:"n"`
+				Mismatch *orchestration.Mismatch `json:"mismatch"`
+			}
+			if err := json.Unmarshal(errBuf.Bytes(), &record); err != nil {
+				t.Fatalf("Expected a JSON mismatch record on stderr, got %q: %v", errBuf.String(), err)
+			}
+			if record.N != 10 || record.Mismatch == nil || record.Mismatch.Kind != orchestration.MismatchInconsistent ||
+				len(record.Mismatch.Algorithms) != 1 {
+				t.Errorf("Unexpected mismatch record %s", errBuf.String())
+			}
+			if tt.name == "text, reporting only" {
+				if text := out.String(); !strings.Contains(text, "Warning") || strings.Contains(text, "CRITICAL") || !strings.Contains(text, "Result binary size") {
+					t.Errorf("Expected a warning and the reference result, got:\n%s", text)
+				}
+			}
+		})
+	}
+}
+
+// TestRunProfileAllocs verifies that --profile-allocs prints allocation
+// totals and call sites for F(100000).
+// Not parallel: the memory profiling rate is a process-wide setting.
+func TestRunCalibrationDiff(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	profile := calibration.NewProfile()
+	profile.OptimalFFTThreshold = 500000
+	if err := profile.SaveProfile(a); err != nil {
+		t.Fatal(err)
+	}
+	profile.OptimalFFTThreshold = 1000000
+	if err := profile.SaveProfile(b); err != nil {
+		t.Fatal(err)
+	}
+
+	var out, errOut bytes.Buffer
+	app := &Application{
+		Config:    config.AppConfig{CalibrationDiff: []string{a, b}},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &errOut,
+	}
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+	}
+	if !strings.Contains(out.String(), "optimal_fft_threshold") || !strings.Contains(out.String(), "1 field(s) differ") {
+		t.Errorf("Output should show the differing FFT threshold:\n%s", out.String())
+	}
+
+	app.Config.CalibrationDiff = []string{a, filepath.Join(dir, "missing.json")}
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitErrorGeneric {
+		t.Errorf("Expected exit code %d for a missing profile, got %d", apperrors.ExitErrorGeneric, exitCode)
+	}
+}
+
+func TestProgressFormat(t *testing.T) {
+	t.Parallel()
+	for format, want := range map[string]string{
+		"":                         config.ProgressFormatPlain,
+		config.ProgressFormatAuto:  config.ProgressFormatPlain,
+		config.ProgressFormatText:  config.ProgressFormatText,
+		config.ProgressFormatPlain: config.ProgressFormatPlain,
+		config.ProgressFormatJSONL: config.ProgressFormatJSONL,
+	} {
+		app := &Application{Config: config.AppConfig{ProgressFormat: format}}
+		// A buffer is not a terminal, so auto selects plain lines.
+		if got := app.progressFormat(&bytes.Buffer{}); got != want {
+			t.Errorf("progressFormat() with %q = %q, want %q", format, got, want)
+		}
+	}
+}
+
+// TestRunProgressFormatJSONL verifies that JSON lines progress is written to
+// the error stream and not mixed with the result text.
+func TestRunProgressFormatJSONL(t *testing.T) {
+	t.Parallel()
+	var out, errBuf bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			N: 100_000, Algo: "fast", Timeout: time.Minute,
+			ProgressFormat: config.ProgressFormatJSONL,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &errBuf,
+	}
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	if strings.Contains(out.String(), `"algo_index"`) {
+		t.Errorf("Expected no progress events on stdout, got:\n%s", out.String())
+	}
+	if !strings.Contains(errBuf.String(), `"algo_index"`) {
+		t.Errorf("Expected progress events on stderr, got %q", errBuf.String())
+	}
+}
+
+func TestRunPrintConfig(t *testing.T) {
+	t.Parallel()
+	var out, errOut bytes.Buffer
+	app := &Application{
+		Config:    config.AppConfig{N: 1000, Algo: "fast", Timeout: time.Minute, FFTThreshold: 500000, PrintConfig: config.PrintConfigJSON},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: &errOut,
+	}
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d: %s", apperrors.ExitSuccess, exitCode, errOut.String())
+	}
+	var dump map[string]any
+	if err := json.Unmarshal(out.Bytes(), &dump); err != nil {
+		t.Fatalf("Output should be JSON: %v\n%s", err, out.String())
+	}
+	if dump["fft_threshold"] != float64(500000) || dump["algo"] != "fast" {
+		t.Errorf("Output should show the resolved configuration:\n%s", out.String())
+	}
+}
+
+func TestRunProfileAllocs(t *testing.T) {
+	var out bytes.Buffer
+	app := &Application{
+		Config: config.AppConfig{
+			Algo:          "fast",
+			N:             100_000,
+			Timeout:       1 * time.Minute,
+			ProfileAllocs: true,
+		},
+		Factory:   fibonacci.NewDefaultFactory(),
+		ErrWriter: io.Discard,
+	}
+
+	if exitCode := app.Run(context.Background(), &out); exitCode != apperrors.ExitSuccess {
+		t.Fatalf("Expected exit code %d, got %d", apperrors.ExitSuccess, exitCode)
+	}
+	output := out.String()
+	if !strings.Contains(output, "Allocation profile: Fast Doubling") || !strings.Contains(output, "F(100000)") {
+		t.Fatalf("Output should name the algorithm and index. Output:\n%s", output)
+	}
+	var size float64
+	var unit string
+	var objects, sites int
+	idx := strings.Index(output, "Total: ")
+	if idx < 0 {
+		t.Fatalf("Output should contain the totals. Output:\n%s", output)
+	}
+	if _, err := fmt.Sscanf(output[idx:], "Total: %f %s in %d objects at %d call sites", &size, &unit, &objects, &sites); err != nil {
+		t.Fatalf("Failed to parse totals: %v. Output:\n%s", err, output)
+	}
+	if size <= 0 || objects <= 0 || sites <= 0 {
+		t.Errorf("Totals should be positive, got %v %s in %d objects at %d sites", size, unit, objects, sites)
+	}
+
+	listed := 0
+	for _, line := range strings.Split(output, "\n") {
+		if strings.Contains(line, "%") && strings.Contains(line, "internal/") && strings.Contains(line, ".go:") {
+			listed++
+		}
+	}
+	if listed == 0 || listed > profileAllocsTop {
+		t.Errorf("Expected between 1 and %d allocation sites, got %d. Output:\n%s", profileAllocsTop, listed, output)
+	}
+	if runtime.MemProfileRate == 1 {
+		t.Error("the memory profiling rate should be restored")
+	}
+}
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/format"
+)
+
+// runBinet prints the approximation of F(n) by Binet's formula (--algo
+// binet) in scientific notation, with as many significant digits as its
+// error bound guarantees. The value is approximate, so it is never compared
+// with other algorithms.
+func (a *Application) runBinet(out io.Writer) int {
+	prec := a.Config.BinetPrecision
+	if prec == 0 {
+		prec = fibonacci.DefaultBinetPrecision
+	}
+	approx, err := fibonacci.BinetApproximate(a.Config.N, uint(max(prec, 0)))
+	if err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error: %v\n", err)
+		return apperrors.ExitErrorConfig
+	}
+
+	if approx.Value.Sign() == 0 {
+		if a.Config.Quiet {
+			fmt.Fprintln(out, "0")
+		} else {
+			fmt.Fprintf(out, "F(%d) = 0 (exact)\n", a.Config.N)
+		}
+		return apperrors.ExitSuccess
+	}
+
+	// RelError < 2^exp guarantees about -exp·log10(2) significant digits.
+	exp := approx.RelError.MantExp(nil)
+	digits := int(float64(-exp) * math.Log10(2))
+	digits = min(max(digits, 1), int(float64(prec)*math.Log10(2)))
+	value := format.FormatScientificFloat(approx.Value, digits)
+	if a.Config.Quiet {
+		fmt.Fprintln(out, value)
+		return apperrors.ExitSuccess
+	}
+	fmt.Fprintf(out, "F(%d) ≈ %s\n", a.Config.N, value)
+	fmt.Fprintf(out, "Approximate: Binet's formula at %d bits, relative error ≤ %s\n",
+		prec, format.FormatScientificFloat(approx.RelError, 2))
+	return apperrors.ExitSuccess
+}
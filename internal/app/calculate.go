@@ -2,18 +2,27 @@ package app
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
 	"os/signal"
+	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/agbru/fibcalc/internal/bigfft"
+	"github.com/agbru/fibcalc/internal/calibration"
 	"github.com/agbru/fibcalc/internal/cli"
+	"github.com/agbru/fibcalc/internal/config"
 	apperrors "github.com/agbru/fibcalc/internal/errors"
 	"github.com/agbru/fibcalc/internal/fibonacci"
 	"github.com/agbru/fibcalc/internal/fibonacci/memory"
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/metrics"
 	"github.com/agbru/fibcalc/internal/orchestration"
 	"github.com/agbru/fibcalc/internal/ui"
 )
@@ -25,55 +34,416 @@ func (a *Application) runCalculate(ctx context.Context, out io.Writer) int {
 		return a.runLastDigits(ctx, out)
 	}
 
-	// Memory budget validation
-	if a.Config.MemoryLimit != "" {
-		if code := a.validateMemoryBudget(out); code != apperrors.ExitSuccess {
-			return code
+	// Negative index: F(-n) = (-1)^(n+1) F(n)
+	if a.Config.NegativeN {
+		return a.runNegafibonacci(ctx, out)
+	}
+
+	// Fibonacci word mode: properties of the Fibonacci word, not F(N)
+	if a.Config.FibWordLength > 0 || a.Config.FibWordOnes > 0 {
+		return a.runFibonacciWord(out)
+	}
+
+	// Approximation by Binet's formula, which never builds F(n) in full
+	if a.Config.Algo == fibonacci.BinetAlgorithm {
+		return a.runBinet(out)
+	}
+
+	if code := a.checkMemory(out); code != apperrors.ExitSuccess {
+		return code
+	}
+
+	// Benchmark guard: refuse indices too small to measure the algorithm
+	if a.Config.MinN > 0 {
+		if err := a.checkMinN(); err != nil {
+			fmt.Fprintf(a.ErrWriter, "Error: %v\n", err)
+			return apperrors.ExitErrorConfig
 		}
 	}
 
-	// Setup lifecycle (timeout + signals)
-	ctx, cancelTimeout := context.WithTimeout(ctx, a.Config.Timeout)
-	defer cancelTimeout()
-	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	// Setup lifecycle (signals + timeout); the runs of --repeat each get
+	// their own timeout from signalCtx
+	signalCtx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
 	defer stopSignals()
+	ctx, cancelTimeout := context.WithTimeout(signalCtx, a.Config.Timeout)
+	defer cancelTimeout()
 
-	// Get calculators to run
+	// Get calculators to run, reporting which phase hits the timeout
 	calculatorsToRun := orchestration.GetCalculatorsToRun(a.Config.Algo, a.Factory)
+	for i, calc := range calculatorsToRun {
+		calculatorsToRun[i] = fibonacci.NewPhaseTimeoutCalculator(calc, a.Config.Timeout)
+	}
 
-	// Skip verbose output in quiet mode
-	if !a.Config.Quiet {
+	// Skip verbose output in quiet and machine-readable modes
+	machineOutput := a.Config.BenchJSON || a.Config.JSON || a.Config.CSV || a.Config.Markdown
+	if !a.Config.Quiet && !machineOutput {
+		if notice := a.fftNotice(); notice != "" {
+			fmt.Fprintln(out, notice)
+		}
 		cli.PrintExecutionConfig(a.Config, out)
 		cli.PrintExecutionMode(calculatorsToRun, out)
 	}
 
-	// Choose progress reporter based on quiet mode
+	// Choose progress reporter based on quiet mode and the progress format;
+	// JSON lines go to the error stream, apart from the human-readable result
 	var progressReporter orchestration.ProgressReporter
 	progressOut := out
-	if a.Config.Quiet {
+	if a.Config.Quiet || machineOutput {
 		progressOut = io.Discard
 		progressReporter = orchestration.NullProgressReporter{}
 	} else {
-		progressReporter = cli.CLIProgressReporter{}
+		weights := orchestration.ProgressWeights(calculatorsToRun, a.Config.N, a.calculationOptions())
+		switch progressFormat := a.progressFormat(out); progressFormat {
+		case config.ProgressFormatJSONL:
+			progressOut = a.ErrWriter
+			progressReporter = cli.JSONLProgressReporter{Weights: weights}
+		default:
+			progressReporter = cli.CLIProgressReporter{
+				Weights:      weights,
+				SpinnerStyle: a.Config.SpinnerStyle,
+				SpinnerSpeed: a.Config.SpinnerSpeed,
+				Plain:        progressFormat == config.ProgressFormatPlain,
+			}
+		}
 	}
 
-	// Execute calculations
-	opts := fibonacci.Options{
-		ParallelThreshold: a.Config.Threshold,
-		FFTThreshold:      a.Config.FFTThreshold,
-		StrassenThreshold: a.Config.StrassenThreshold,
+	// Sample the heap high-water mark when a memory explanation is requested
+	var sampler *metrics.PeakHeapSampler
+	if a.Config.ExplainMemory {
+		sampler = metrics.StartPeakHeapSampler(0)
+	}
+
+	// Collect the timing of each doubling step when requested
+	calcCtx := ctx
+	var steps *fibonacci.StepCollector
+	if a.Config.BenchSteps {
+		steps = &fibonacci.StepCollector{}
+		calcCtx = fibonacci.WithStepRecorder(ctx, steps)
+	}
+
+	// Read the memory statistics around the calculation for --details
+	var memCollector *metrics.MemoryCollector
+	var memBefore metrics.MemorySnapshot
+	if a.Config.Details && !machineOutput {
+		memCollector = metrics.NewMemoryCollector()
+		memBefore = memCollector.Snapshot()
+	}
+
+	// Execute calculations, recording what dynamic thresholds learned
+	opts := a.calculationOptions()
+	var learned learnedRecorder
+	if a.Config.DynamicThresholds {
+		opts.OnThresholdsLearned = learned.record
+	}
+	results := orchestration.ExecuteCalculations(calcCtx, calculatorsToRun, a.Config.N, opts, progressReporter, progressOut)
+	a.saveLearnedThresholds(&learned)
+
+	var memReport *metrics.MemoryReport
+	if memCollector != nil {
+		report := metrics.NewMemoryReport(memBefore, memCollector.Snapshot())
+		memReport = &report
 	}
-	results := orchestration.ExecuteCalculations(ctx, calculatorsToRun, a.Config.N, opts, progressReporter, progressOut)
 
 	// Build output config for the CLI options
 	outputCfg := cli.OutputConfig{
 		OutputFile: a.Config.OutputFile,
+		SVGFile:    a.Config.EmitSVG,
 		Quiet:      a.Config.Quiet,
 		Verbose:    a.Config.Verbose,
-		ShowValue:  a.Config.ShowValue,
+		ShowValue:  a.Config.ShowValue || a.Config.Scientific,
+		Scientific: a.Config.Scientific,
+		Base:       a.Config.OutputBase(),
+		Edges:      a.Config.Edges,
+		Memory:     memReport,
+	}
+	if a.Config.LimitOutputMode != config.LimitOutputError {
+		outputCfg.MaxValueBytes = a.Config.LimitOutputBytes
+	}
+
+	if a.Config.BenchJSON {
+		if sampler != nil {
+			sampler.Stop()
+		}
+		return a.presentBenchJSON(results, outputCfg, out)
+	}
+	if a.Config.JSON {
+		if sampler != nil {
+			sampler.Stop()
+		}
+		return a.presentJSON(results, outputCfg, out)
+	}
+	if a.Config.CSV {
+		if sampler != nil {
+			sampler.Stop()
+		}
+		return a.presentCSV(results, outputCfg, out)
+	}
+	if a.Config.Markdown {
+		if sampler != nil {
+			sampler.Stop()
+		}
+		return a.presentMarkdown(results, outputCfg, out)
+	}
+
+	exitCode := a.analyzeResultsWithOutput(results, outputCfg, out)
+
+	if a.Config.CompareRepeat > 1 {
+		ranking := a.repeatComparison(signalCtx, calculatorsToRun, results)
+		cli.DisplayRepeatRanking(out, ranking, a.Config.CompareRepeat)
+	}
+
+	if a.Config.Repeat > 1 && exitCode == apperrors.ExitSuccess {
+		stats := a.repeatRuns(signalCtx, calculatorsToRun)
+		if a.Config.Quiet {
+			cli.DisplayQuietRepeatStats(out, stats)
+		} else {
+			cli.DisplayRepeatStats(out, stats, a.Config.Repeat)
+		}
+	}
+
+	if steps != nil {
+		cli.DisplayStepTimings(out, steps.Steps())
 	}
 
-	return a.analyzeResultsWithOutput(results, outputCfg, out)
+	if sampler != nil {
+		cli.DisplayMemoryExplanation(a.memoryEstimate(), sampler.Stop(), out)
+	}
+
+	if a.Config.Zeckendorf && exitCode == apperrors.ExitSuccess {
+		if best := orchestration.FindBestResult(results); best != nil {
+			indices, err := fibonacci.Zeckendorf(ctx, best.Result)
+			if err != nil {
+				return apperrors.HandleCalculationError(err, 0, a.ErrWriter, cli.CLIColorProvider{})
+			}
+			cli.DisplayZeckendorf(out, indices, a.Config.Quiet)
+		}
+	}
+
+	return exitCode
+}
+
+// repeatComparison runs the calculators again, silently, until each has run
+// --compare-repeat times, and ranks them by median duration. Each run has its
+// own --timeout, like the first one.
+//
+// Parameters:
+//   - ctx: The context canceled on SIGINT or SIGTERM, without the timeout of
+//     the first run.
+//   - calculators: The calculators being compared.
+//   - first: The results of the first run, already presented.
+//
+// Returns:
+//   - orchestration.Ranking: The ranking over all successful runs.
+func (a *Application) repeatComparison(ctx context.Context, calculators []fibonacci.Calculator, first []orchestration.CalculationResult) orchestration.Ranking {
+	samples := make(map[string][]time.Duration, len(calculators))
+	orchestration.CollectDurations(samples, first)
+	for run := 1; run < a.Config.CompareRepeat && ctx.Err() == nil; run++ {
+		runCtx, cancel := context.WithTimeout(ctx, a.Config.Timeout)
+		results := orchestration.ExecuteCalculations(runCtx, calculators, a.Config.N, a.calculationOptions(), orchestration.NullProgressReporter{}, io.Discard)
+		cancel()
+		orchestration.CollectDurations(samples, results)
+	}
+	return orchestration.RankDurations(samples)
+}
+
+// largeOutputGuard builds the confirmation required before printing a huge
+// value to the terminal. The y/N prompt is only offered when stdin is a
+// terminal as well.
+func (a *Application) largeOutputGuard() cli.LargeOutputGuard {
+	guard := cli.LargeOutputGuard{Threshold: a.Config.WarnLargeOutput, Confirmed: a.Config.Yes}
+	if ui.IsTerminal(os.Stdin) {
+		guard.Prompt = os.Stdin
+	}
+	return guard
+}
+
+// calculationOptions builds the fibonacci.Options derived from the
+// configured thresholds.
+func (a *Application) calculationOptions() fibonacci.Options {
+	opts := fibonacci.Options{
+		ParallelThreshold: a.Config.Threshold,
+		FFTThreshold:      a.Config.FFTThreshold,
+		StrassenThreshold: a.Config.StrassenThreshold,
+		DisablePooling:    a.Config.NoPooling,
+		KBonacciOrder:     a.Config.KBonacci,
+		BinetPrecision:    a.Config.BinetPrecision,
+	}
+	opts.EnableDynamicThresholds = a.Config.DynamicThresholds
+	return opts
+}
+
+// learnedRecorder keeps the thresholds last learned by a calculation with
+// --dynamic-thresholds; calculators may report them concurrently.
+type learnedRecorder struct {
+	mu      sync.Mutex
+	learned *threshold.LearnedThresholds
+}
+
+// record is a fibonacci.Options.OnThresholdsLearned callback.
+func (r *learnedRecorder) record(learned threshold.LearnedThresholds) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.learned = &learned
+}
+
+// saveLearnedThresholds records the thresholds learned during the run in the
+// calibration profile. A failure only warns: the result is still valid.
+func (a *Application) saveLearnedThresholds(r *learnedRecorder) {
+	r.mu.Lock()
+	learned := r.learned
+	r.mu.Unlock()
+	if learned == nil {
+		return
+	}
+	if err := calibration.SaveLearnedThresholds(a.Config.CalibrationProfile, *learned); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Warning: learned thresholds not saved: %v\n", err)
+	}
+}
+
+// presentBenchJSON writes the results as `go test -json` benchmark events.
+// The standard analysis still runs, silently, so mismatches and --output keep
+// their usual effect on the exit code.
+func (a *Application) presentBenchJSON(results []orchestration.CalculationResult, outputCfg cli.OutputConfig, out io.Writer) int {
+	exitCode := a.analyzeResultsWithOutput(results, outputCfg, io.Discard)
+	mismatch := orchestration.FindMismatch(results, a.Config.ExpectedValue())
+	if err := cli.DisplayBenchJSON(out, results, a.Config.N, mismatch); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error writing benchmark events: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	return exitCode
+}
+
+// presentJSON writes the results as one JSON document, collected by running
+// the standard analysis through a cli.JSONResultPresenter; the analysis'
+// human-readable status lines are discarded. Mismatches and --output keep
+// their usual effect on the exit code.
+func (a *Application) presentJSON(results []orchestration.CalculationResult, outputCfg cli.OutputConfig, out io.Writer) int {
+	presenter := &cli.JSONResultPresenter{N: a.Config.N, IncludeValue: a.Config.ShowValue, Expected: a.Config.ExpectedValue()}
+	presOpts := orchestration.PresentationOptions{N: a.Config.N, Expected: presenter.Expected}
+	exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, io.Discard)
+	if exitCode == apperrors.ExitErrorMismatch {
+		exitCode = a.reportMismatch(orchestration.FindMismatch(results, presOpts.Expected))
+	}
+	if best := orchestration.FindBestResult(results); best != nil && exitCode == apperrors.ExitSuccess {
+		if err := a.saveResultIfNeeded(best, outputCfg); err != nil {
+			exitCode = apperrors.ExitErrorGeneric
+		}
+	}
+	if err := presenter.WriteJSON(out); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error writing JSON results: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	return exitCode
+}
+
+// presentCSV writes one CSV row per algorithm, collected by running the
+// standard analysis through a cli.CSVResultPresenter. Mismatches and --output
+// keep their usual effect on the exit code.
+func (a *Application) presentCSV(results []orchestration.CalculationResult, outputCfg cli.OutputConfig, out io.Writer) int {
+	presenter := &cli.CSVResultPresenter{Expected: a.Config.ExpectedValue(), OmitHeader: a.Config.Quiet}
+	presOpts := orchestration.PresentationOptions{N: a.Config.N, Expected: presenter.Expected}
+	exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, io.Discard)
+	if exitCode == apperrors.ExitErrorMismatch {
+		exitCode = a.reportMismatch(orchestration.FindMismatch(results, presOpts.Expected))
+	}
+	if best := orchestration.FindBestResult(results); best != nil && exitCode == apperrors.ExitSuccess {
+		if err := a.saveResultIfNeeded(best, outputCfg); err != nil {
+			exitCode = apperrors.ExitErrorGeneric
+		}
+	}
+	if err := presenter.WriteCSV(out); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error writing CSV results: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	return exitCode
+}
+
+// presentMarkdown writes the results as a Markdown table, collected by
+// running the standard analysis through a cli.MarkdownResultPresenter.
+// Mismatches and --output keep their usual effect on the exit code.
+func (a *Application) presentMarkdown(results []orchestration.CalculationResult, outputCfg cli.OutputConfig, out io.Writer) int {
+	presenter := &cli.MarkdownResultPresenter{Expected: a.Config.ExpectedValue()}
+	presOpts := orchestration.PresentationOptions{N: a.Config.N, Expected: presenter.Expected}
+	exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, io.Discard)
+	if exitCode == apperrors.ExitErrorMismatch {
+		exitCode = a.reportMismatch(orchestration.FindMismatch(results, presOpts.Expected))
+	}
+	if best := orchestration.FindBestResult(results); best != nil && exitCode == apperrors.ExitSuccess {
+		if err := a.saveResultIfNeeded(best, outputCfg); err != nil {
+			exitCode = apperrors.ExitErrorGeneric
+		}
+	}
+	if err := presenter.WriteMarkdown(out); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Error writing Markdown results: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+	return exitCode
+}
+
+// fftNotice explains that FFT multiplication cannot pay off when the FFT
+// algorithm was explicitly selected for an F(N) below the FFT threshold. The
+// calculation still runs with FFT as requested.
+//
+// Returns:
+//   - string: The notice, or "" if it does not apply.
+func (a *Application) fftNotice() string {
+	if a.Config.Algo != "fft" {
+		return ""
+	}
+	opts := a.calculationOptions()
+	floor := fibonacci.MinMeaningfulN("fft", opts)
+	if a.Config.N >= floor {
+		return ""
+	}
+	threshold := opts.FFTThreshold
+	if threshold == 0 {
+		threshold = fibonacci.DefaultFFTThreshold
+	}
+	bits := uint64(float64(a.Config.N) * fibonacci.FibonacciGrowthFactor)
+	return fmt.Sprintf("Note: F(%d) has about %d bits, below the FFT threshold of %d bits (n >= %d). "+
+		"FFT multiplication will not pay off at this size and --algo fast is faster; running FFT as requested.",
+		a.Config.N, bits, threshold, floor)
+}
+
+// checkMinN rejects N below the --min-n floor or below the smallest index
+// meaningful for any of the selected algorithms.
+//
+// Returns:
+//   - error: An apperrors.ValidationError for field "n" with guidance on the
+//     index to use, or nil if N is large enough.
+func (a *Application) checkMinN() error {
+	algos := []string{a.Config.Algo}
+	if a.Config.Algo == "all" {
+		algos = algos[:0]
+		for _, name := range a.Factory.List() {
+			if !fibonacci.ExcludedFromAll(name) {
+				algos = append(algos, name)
+			}
+		}
+	}
+
+	floor, reason := a.Config.MinN, "the --min-n floor"
+	for _, algo := range algos {
+		if m := fibonacci.MinMeaningfulN(algo, a.calculationOptions()); m > floor {
+			floor, reason = m, fmt.Sprintf("the smallest index meaningful to benchmark %q", algo)
+		}
+	}
+	if a.Config.N >= floor {
+		return nil
+	}
+	return apperrors.ValidationError{
+		Field:   "n",
+		Message: fmt.Sprintf("n=%d is below %s (%d); use -n %d or larger for a meaningful measurement", a.Config.N, reason, floor, floor),
+	}
+}
+
+// checkMemory validates the memory budget, against the available system
+// memory without an explicit limit.
+func (a *Application) checkMemory(out io.Writer) int {
+	if a.Config.MemoryLimit != "" {
+		return a.validateMemoryBudget(out)
+	}
+	return a.checkSystemMemory()
 }
 
 // validateMemoryBudget checks if the estimated memory usage fits within the configured limit.
@@ -83,7 +453,7 @@ func (a *Application) validateMemoryBudget(out io.Writer) int {
 		fmt.Fprintf(out, "Invalid --memory-limit: %v\n", err)
 		return apperrors.ExitErrorConfig
 	}
-	est := memory.EstimateMemoryUsage(a.Config.N)
+	est := a.memoryEstimate()
 	if est.TotalBytes > limit {
 		fmt.Fprintf(out, "Estimated memory %s exceeds limit %s.\n",
 			memory.FormatMemoryEstimate(est),
@@ -93,6 +463,9 @@ func (a *Application) validateMemoryBudget(out io.Writer) int {
 		}
 		return apperrors.ExitErrorConfig
 	}
+	// Also bound each FFT product, so that an underestimate fails with a
+	// MemoryError instead of exhausting memory.
+	bigfft.SetMemoryBudget(limit)
 	if !a.Config.Quiet {
 		fmt.Fprintf(out, "Memory estimate: %s (limit: %s)\n",
 			memory.FormatMemoryEstimate(est), a.Config.MemoryLimit)
@@ -100,6 +473,58 @@ func (a *Application) validateMemoryBudget(out io.Writer) int {
 	return apperrors.ExitSuccess
 }
 
+// progressFormat resolves --progress-format: auto selects plain lines when
+// out is not a terminal, so that redirected output gets no spinner frames.
+// JSON lines are only written when requested explicitly.
+func (a *Application) progressFormat(out io.Writer) string {
+	switch a.Config.ProgressFormat {
+	case config.ProgressFormatText, config.ProgressFormatPlain, config.ProgressFormatJSONL:
+		return a.Config.ProgressFormat
+	}
+	if ui.IsTerminal(out) {
+		return config.ProgressFormatText
+	}
+	return config.ProgressFormatPlain
+}
+
+// memoryEstimate estimates the memory needed to compute F(N) with the
+// selected algorithm: the addition-only iterative algorithm holds two values,
+// the others are estimated after fast doubling.
+func (a *Application) memoryEstimate() memory.MemoryEstimate {
+	if a.Config.Algo == fibonacci.IterativeAlgorithm {
+		return memory.EstimateIterativeMemoryUsage(a.Config.N)
+	}
+	return memory.EstimateMemoryUsage(a.Config.N)
+}
+
+// systemMemoryPercent is the share of the available system memory a
+// calculation may use when --memory-limit is not set.
+const systemMemoryPercent = 80
+
+// checkSystemMemory refuses, with a MemoryError, a calculation whose
+// estimated memory exceeds systemMemoryPercent of the available system
+// memory. The check is skipped when the system memory is unknown.
+func (a *Application) checkSystemMemory() int {
+	availableMemory := a.AvailableMemory
+	if availableMemory == nil {
+		availableMemory = memory.AvailableSystemMemory
+	}
+	available, err := availableMemory()
+	if err != nil {
+		return apperrors.ExitSuccess
+	}
+	limit := available / 100 * systemMemoryPercent
+	est := a.memoryEstimate()
+	if est.TotalBytes <= limit {
+		return apperrors.ExitSuccess
+	}
+	memErr := apperrors.MemoryError{Requested: est.TotalBytes, Available: available, Limit: limit}
+	code := apperrors.HandleCalculationError(memErr, 0, a.ErrWriter, cli.CLIColorProvider{})
+	fmt.Fprintf(a.ErrWriter, "Estimated memory %s exceeds %d%% of the available memory.\n", memory.FormatMemoryEstimate(est), systemMemoryPercent)
+	fmt.Fprintf(a.ErrWriter, "Consider using --last-digits K for O(K) memory usage, or set --memory-limit explicitly.\n")
+	return code
+}
+
 // runLastDigits computes only the last K decimal digits of F(N) using modular
 // arithmetic, requiring O(K) memory regardless of N.
 func (a *Application) runLastDigits(ctx context.Context, out io.Writer) int {
@@ -119,12 +544,11 @@ func (a *Application) runLastDigits(ctx context.Context, out io.Writer) int {
 	}
 
 	start := time.Now()
-	result, err := fibonacci.FastDoublingMod(n, mod)
+	result, err := fibonacci.FastDoublingModContext(ctx, n, mod)
 	elapsed := time.Since(start)
 
 	if err != nil {
-		fmt.Fprintf(a.ErrWriter, "Error: %v\n", err)
-		return apperrors.ExitErrorGeneric
+		return apperrors.HandleCalculationError(err, elapsed, a.ErrWriter, cli.CLIColorProvider{})
 	}
 
 	// Format with leading zeros to exactly k digits
@@ -141,12 +565,64 @@ func (a *Application) runLastDigits(ctx context.Context, out io.Writer) int {
 	return apperrors.ExitSuccess
 }
 
+// runNegafibonacci computes F(n) for a negative index n via
+// fibonacci.FibonacciSigned. Validate rejects the options it does not
+// support, such as --output and the machine-readable formats.
+func (a *Application) runNegafibonacci(ctx context.Context, out io.Writer) int {
+	if code := a.checkMemory(out); code != apperrors.ExitSuccess {
+		return code
+	}
+
+	ctx, cancelTimeout := context.WithTimeout(ctx, a.Config.Timeout)
+	defer cancelTimeout()
+	ctx, stopSignals := signal.NotifyContext(ctx, syscall.SIGINT, syscall.SIGTERM)
+	defer stopSignals()
+
+	n := a.Config.SignedN()
+	if !a.Config.Quiet {
+		fmt.Fprintf(out, "Computing F(%d) = (-1)^(%d) F(%d)...\n", n, a.Config.N+1, a.Config.N)
+	}
+
+	start := time.Now()
+	result, err := fibonacci.FibonacciSigned(ctx, n, a.calculationOptions())
+	elapsed := time.Since(start)
+	if err != nil {
+		return apperrors.HandleCalculationError(err, elapsed, a.ErrWriter, cli.CLIColorProvider{})
+	}
+
+	value := result.Text(a.Config.OutputBase())
+	if a.Config.Quiet {
+		fmt.Fprintln(out, cli.FormatLimitedValue(value, a.Config.LimitOutputBytes))
+		return apperrors.ExitSuccess
+	}
+	edges, limit := cli.TruncationEdges(a.Config.Edges, cli.DisplayEdges)
+	if len(value) > limit && !a.Config.Verbose {
+		fmt.Fprintf(out, "F(%d) (truncated) = %s...%s\n", n, value[:edges], value[len(value)-edges:])
+	} else {
+		fmt.Fprintf(out, "F(%d) = %s\n", n, cli.FormatLimitedValue(value, a.Config.LimitOutputBytes))
+	}
+	fmt.Fprintf(out, "Computed in %s\n", elapsed.Round(time.Millisecond))
+	return apperrors.ExitSuccess
+}
+
 func (a *Application) analyzeResultsWithOutput(results []orchestration.CalculationResult, outputCfg cli.OutputConfig, out io.Writer) int {
-	bestResult := findBestResult(results)
+	bestResult := orchestration.FindBestResult(results)
+
+	if code := a.checkOutputLimit(bestResult, outputCfg); code != apperrors.ExitSuccess {
+		return code
+	}
 
 	// Handle quiet mode for single result
 	if outputCfg.Quiet && bestResult != nil {
-		cli.DisplayQuietResult(out, bestResult.Result, a.Config.N, bestResult.Duration)
+		if mismatch := orchestration.FindMismatch(results, a.Config.ExpectedValue()); mismatch != nil {
+			if mismatch.Kind == orchestration.MismatchExpected {
+				fmt.Fprintf(a.ErrWriter, "Result differs from the expected value for: %s\n", strings.Join(mismatch.Algorithms, ", "))
+			}
+			if code := a.reportMismatch(mismatch); code != apperrors.ExitSuccess {
+				return code
+			}
+		}
+		cli.DisplayQuietValue(out, bestResult.Result, a.Config.N, bestResult.Duration, outputCfg)
 
 		// Save to file if requested
 		if err := a.saveResultIfNeeded(bestResult, outputCfg); err != nil {
@@ -158,12 +634,26 @@ func (a *Application) analyzeResultsWithOutput(results []orchestration.Calculati
 
 	// Use standard analysis for non-quiet mode
 	presOpts := orchestration.PresentationOptions{
-		N:         a.Config.N,
-		Verbose:   a.Config.Verbose,
-		Details:   a.Config.Details,
-		ShowValue: a.Config.ShowValue,
+		N:                   a.Config.N,
+		Verbose:             a.Config.Verbose,
+		Details:             a.Config.Details,
+		ShowValue:           outputCfg.ShowValue,
+		Expected:            a.Config.ExpectedValue(),
+		IgnoreInconsistency: a.Config.IgnoreInconsistency,
+	}
+	presenter := cli.CLIResultPresenter{
+		MaxValueBytes: outputCfg.MaxValueBytes,
+		Scientific:    outputCfg.Scientific,
+		Base:          outputCfg.Base,
+		Edges:         outputCfg.Edges,
+		Symbol:        cli.SequenceSymbol(a.Config.Algo),
+		Memory:        outputCfg.Memory,
+		LargeOutput:   a.largeOutputGuard(),
+	}
+	exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, out)
+	if mismatch := orchestration.FindMismatch(results, presOpts.Expected); mismatch != nil {
+		exitCode = a.reportMismatch(mismatch)
 	}
-	exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, cli.CLIResultPresenter{}, cli.CLIResultPresenter{}, out)
 
 	// Handle file output for non-quiet mode
 	if bestResult != nil && exitCode == apperrors.ExitSuccess {
@@ -175,30 +665,78 @@ func (a *Application) analyzeResultsWithOutput(results []orchestration.Calculati
 			fmt.Fprintf(out, "\n%s✓ Result saved to: %s%s%s\n",
 				ui.ColorGreen(), ui.ColorCyan(), outputCfg.OutputFile, ui.ColorReset())
 		}
+		if outputCfg.SVGFile != "" {
+			fmt.Fprintf(out, "%s✓ SVG card saved to: %s%s%s\n",
+				ui.ColorGreen(), ui.ColorCyan(), outputCfg.SVGFile, ui.ColorReset())
+		}
 	}
 
 	return exitCode
 }
 
-func findBestResult(results []orchestration.CalculationResult) *orchestration.CalculationResult {
-	var bestResult *orchestration.CalculationResult
-	for i := range results {
-		if results[i].Err == nil {
-			if bestResult == nil || results[i].Duration < bestResult.Duration {
-				bestResult = &results[i]
-			}
-		}
+// mismatchRecord is the machine-readable line written to the error stream
+// when results disagree, whatever the output mode.
+type mismatchRecord struct {
+	N        uint64                  `json:"n"`
+	Mismatch *orchestration.Mismatch `json:"mismatch"`
+}
+
+// reportMismatch writes the mismatch to ErrWriter as a JSON mismatchRecord
+// and returns the resulting exit code: ExitErrorMismatch, or ExitSuccess for
+// an inconsistency between algorithms under --fail-on-inconsistency=false.
+func (a *Application) reportMismatch(mismatch *orchestration.Mismatch) int {
+	if mismatch == nil {
+		return apperrors.ExitErrorMismatch
+	}
+	if data, err := json.Marshal(mismatchRecord{N: a.Config.N, Mismatch: mismatch}); err == nil {
+		fmt.Fprintln(a.ErrWriter, string(data))
+	}
+	if mismatch.Kind == orchestration.MismatchInconsistent && a.Config.IgnoreInconsistency {
+		return apperrors.ExitSuccess
 	}
-	return bestResult
+	return apperrors.ExitErrorMismatch
+}
+
+// checkOutputLimit enforces --limit-output-bytes in "error" mode: when the
+// value that would be displayed is larger than the limit, nothing is printed
+// and the calculation fails instead of flooding the output.
+func (a *Application) checkOutputLimit(best *orchestration.CalculationResult, outputCfg cli.OutputConfig) int {
+	limit := a.Config.LimitOutputBytes
+	if limit <= 0 || a.Config.LimitOutputMode != config.LimitOutputError || best == nil || outputCfg.Scientific {
+		return apperrors.ExitSuccess
+	}
+
+	var size int
+	switch {
+	case outputCfg.Quiet:
+		size = len(best.Result.Text(a.Config.OutputBase()))
+	case outputCfg.ShowValue && outputCfg.Verbose:
+		grouped, _ := format.FormatInBase(best.Result, a.Config.OutputBase())
+		size = len(grouped)
+	default:
+		// Only a digit-truncated excerpt is displayed.
+		return apperrors.ExitSuccess
+	}
+	if size <= limit {
+		return apperrors.ExitSuccess
+	}
+	fmt.Fprintf(a.ErrWriter, "Error: the result value is %d bytes, exceeding --limit-output-bytes %d (use --output to save it to a file)\n", size, limit)
+	return apperrors.ExitErrorGeneric
 }
 
 func (a *Application) saveResultIfNeeded(res *orchestration.CalculationResult, cfg cli.OutputConfig) error {
-	if cfg.OutputFile == "" {
-		return nil
+	if cfg.OutputFile != "" {
+		if err := cli.WriteResultToFile(res.Result, a.Config.N, res.Duration, res.Name, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving result: %v\n", err)
+			return err
+		}
 	}
-	if err := cli.WriteResultToFile(res.Result, a.Config.N, res.Duration, res.Name, cfg); err != nil {
-		fmt.Fprintf(os.Stderr, "Error saving result: %v\n", err)
-		return err
+	if cfg.SVGFile != "" {
+		card := cli.NewSVGCard(res.Result, a.Config.N, res.Duration, res.Name)
+		if err := cli.WriteSVGCard(cfg.SVGFile, card); err != nil {
+			fmt.Fprintf(os.Stderr, "Error writing SVG card: %v\n", err)
+			return err
+		}
 	}
 	return nil
 }
@@ -0,0 +1,50 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/agbru/fibcalc/internal/cli"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// DiffCommand is the name of the results-diff subcommand.
+const DiffCommand = "diff"
+
+// RunDiff implements "fibcalc diff <fileA> <fileB>": it reads two files
+// written by cli.WriteResultToFile and reports whether they hold the same
+// result. No computation is performed.
+//
+// Parameters:
+//   - args: The subcommand arguments (the two file paths).
+//   - out: The writer for the report.
+//   - errOut: The writer for usage and read errors.
+//
+// Returns:
+//   - int: ExitSuccess if the results are identical, ExitErrorMismatch if
+//     they differ, ExitErrorConfig for bad usage, or ExitErrorGeneric if a
+//     file cannot be read.
+func RunDiff(args []string, out, errOut io.Writer) int {
+	if len(args) != 2 {
+		fmt.Fprintf(errOut, "Usage: fibcalc %s <fileA> <fileB>\n", DiffCommand)
+		return apperrors.ExitErrorConfig
+	}
+
+	a, na, err := cli.ReadResultFromFile(args[0])
+	if err != nil {
+		fmt.Fprintf(errOut, "Error reading %s: %v\n", args[0], err)
+		return apperrors.ExitErrorGeneric
+	}
+	b, nb, err := cli.ReadResultFromFile(args[1])
+	if err != nil {
+		fmt.Fprintf(errOut, "Error reading %s: %v\n", args[1], err)
+		return apperrors.ExitErrorGeneric
+	}
+
+	diff := cli.DiffResults(a, na, b, nb)
+	fmt.Fprint(out, cli.FormatResultDiff(args[0], args[1], diff))
+	if !diff.Equal {
+		return apperrors.ExitErrorMismatch
+	}
+	return apperrors.ExitSuccess
+}
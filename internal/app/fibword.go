@@ -0,0 +1,41 @@
+package app
+
+import (
+	"fmt"
+	"io"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+// runFibonacciWord prints the requested Fibonacci word properties
+// (--fib-word-length and/or --fib-word-ones).
+func (a *Application) runFibonacciWord(out io.Writer) int {
+	if k := a.Config.FibWordLength; k > 0 {
+		word, err := fibonacci.FibonacciWordPrefix(k)
+		if err != nil {
+			fmt.Fprintf(a.ErrWriter, "Error: %v\n", err)
+			return apperrors.ExitErrorConfig
+		}
+		if a.Config.Quiet {
+			fmt.Fprintf(out, "%s\n", word)
+		} else {
+			fmt.Fprintf(out, "First %d symbols of the Fibonacci word:\n%s\n", k, word)
+		}
+	}
+
+	if k := a.Config.FibWordOnes; k > 0 {
+		ones, err := fibonacci.FibonacciWordOnes(k)
+		if err != nil {
+			fmt.Fprintf(a.ErrWriter, "Error: %v\n", err)
+			return apperrors.ExitErrorConfig
+		}
+		if a.Config.Quiet {
+			fmt.Fprintln(out, ones)
+		} else {
+			fmt.Fprintf(out, "1s in the first %d symbols of the Fibonacci word: %d\n", k, ones)
+		}
+	}
+
+	return apperrors.ExitSuccess
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// profileAllocsTop is the number of call sites listed per algorithm.
+const profileAllocsTop = 10
+
+// modulePrefix identifies the functions of this module in stack traces.
+const modulePrefix = "github.com/agbru/fibcalc/"
+
+// AllocSite aggregates the allocations attributed to one call site.
+type AllocSite struct {
+	// Function is the fully qualified name of the allocating function.
+	Function string
+	// File and Line locate the allocating call.
+	File string
+	Line int
+	// Bytes and Objects are the totals allocated at this site.
+	Bytes   int64
+	Objects int64
+}
+
+// AllocProfile summarizes the allocations made during a profiled operation.
+type AllocProfile struct {
+	// Sites lists the call sites, largest allocated bytes first.
+	Sites []AllocSite
+	// TotalBytes and TotalObjects are the totals over all sites.
+	TotalBytes   int64
+	TotalObjects int64
+}
+
+// runProfileAllocs computes F(N) once per selected algorithm with every
+// allocation recorded by the memory profiler, and prints the call sites that
+// allocated the most. It shows whether the buffer pools actually remove the
+// allocations they are meant to remove; see also --no-pooling.
+func (a *Application) runProfileAllocs(ctx context.Context, out io.Writer) int {
+	ctx, cancelTimeout := context.WithTimeout(ctx, a.Config.Timeout)
+	defer cancelTimeout()
+
+	calculators := orchestration.GetCalculatorsToRun(a.Config.Algo, a.Factory)
+	if len(calculators) == 0 {
+		fmt.Fprintf(a.ErrWriter, "Allocation profile: no calculator available for algorithm %q\n", a.Config.Algo)
+		return apperrors.ExitErrorConfig
+	}
+
+	opts := a.calculationOptions()
+	for i, calc := range calculators {
+		start := time.Now()
+		profile, err := profileAllocations(func() error {
+			_, err := calc.Calculate(ctx, nil, 0, a.Config.N, opts)
+			return err
+		})
+		if err != nil {
+			return apperrors.HandleCalculationError(err, time.Since(start), a.ErrWriter, nil)
+		}
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		writeAllocProfile(out, calc.Name(), a.Config.N, profile, profileAllocsTop)
+	}
+	return apperrors.ExitSuccess
+}
+
+// profileAllocations runs fn with the memory profiler recording every
+// allocation, and returns the allocations fn made. The profiling rate is
+// process-wide, so allocations of concurrent goroutines are included too.
+//
+// Parameters:
+//   - fn: The operation to profile.
+//
+// Returns:
+//   - AllocProfile: The allocations made while fn ran.
+//   - error: The error returned by fn.
+func profileAllocations(fn func() error) (AllocProfile, error) {
+	prevRate := runtime.MemProfileRate
+	runtime.MemProfileRate = 1
+	defer func() { runtime.MemProfileRate = prevRate }()
+
+	// runtime.GC publishes the profile up to the current point.
+	runtime.GC()
+	before := memProfileRecords()
+	err := fn()
+	runtime.GC()
+	after := memProfileRecords()
+	return summarizeAllocs(before, after), err
+}
+
+// snapshotFunction is the function allocating the profile snapshots, whose
+// own allocations are not reported.
+const snapshotFunction = modulePrefix + "internal/app.memProfileRecords"
+
+// allocTotals holds the cumulative allocations of one stack.
+type allocTotals struct{ bytes, objects int64 }
+
+// stackTotals sums the records of each stack: the profile keeps one record
+// per stack and allocation size.
+func stackTotals(records []runtime.MemProfileRecord) map[[32]uintptr]allocTotals {
+	totals := make(map[[32]uintptr]allocTotals, len(records))
+	for _, r := range records {
+		t := totals[r.Stack0]
+		t.bytes += r.AllocBytes
+		t.objects += r.AllocObjects
+		totals[r.Stack0] = t
+	}
+	return totals
+}
+
+// stackDepth returns the number of frames in a profile stack, which is
+// terminated by a zero entry when shorter than the array.
+func stackDepth(stack [32]uintptr) int {
+	for i, pc := range stack {
+		if pc == 0 {
+			return i
+		}
+	}
+	return len(stack)
+}
+
+// memProfileRecords returns a snapshot of the memory profile.
+func memProfileRecords() []runtime.MemProfileRecord {
+	n, _ := runtime.MemProfile(nil, true)
+	for {
+		// Leave room for records added between the two calls.
+		records := make([]runtime.MemProfileRecord, n+50)
+		var ok bool
+		if n, ok = runtime.MemProfile(records, true); ok {
+			return records[:n]
+		}
+	}
+}
+
+// summarizeAllocs aggregates by call site the allocations recorded between
+// two snapshots of the memory profile. The snapshots themselves are left out.
+func summarizeAllocs(before, after []runtime.MemProfileRecord) AllocProfile {
+	baseline := stackTotals(before)
+	sites := make(map[string]*AllocSite)
+	var profile AllocProfile
+	for stack, total := range stackTotals(after) {
+		base := baseline[stack]
+		bytes, objects := total.bytes-base.bytes, total.objects-base.objects
+		if bytes <= 0 {
+			continue
+		}
+		site := allocSite(stack[:stackDepth(stack)])
+		if site.Function == snapshotFunction {
+			continue
+		}
+		key := fmt.Sprintf("%s:%d", site.Function, site.Line)
+		agg, ok := sites[key]
+		if !ok {
+			agg = &site
+			sites[key] = agg
+		}
+		agg.Bytes += bytes
+		agg.Objects += objects
+		profile.TotalBytes += bytes
+		profile.TotalObjects += objects
+	}
+
+	for _, site := range sites {
+		profile.Sites = append(profile.Sites, *site)
+	}
+	sort.Slice(profile.Sites, func(i, j int) bool {
+		if profile.Sites[i].Bytes != profile.Sites[j].Bytes {
+			return profile.Sites[i].Bytes > profile.Sites[j].Bytes
+		}
+		return profile.Sites[i].Function < profile.Sites[j].Function
+	})
+	return profile
+}
+
+// allocSite attributes an allocation stack to the innermost frame of this
+// module, so that allocations made inside math/big or the runtime are
+// charged to the code that requested them. Stacks that never enter the
+// module are charged to their innermost non-runtime frame.
+func allocSite(stack []uintptr) AllocSite {
+	var fallback *AllocSite
+	frames := runtime.CallersFrames(stack)
+	for {
+		frame, more := frames.Next()
+		site := AllocSite{Function: frame.Function, File: frame.File, Line: frame.Line}
+		if strings.HasPrefix(frame.Function, modulePrefix) {
+			return site
+		}
+		if fallback == nil && frame.Function != "" && !strings.HasPrefix(frame.Function, "runtime.") {
+			fallback = &site
+		}
+		if !more {
+			break
+		}
+	}
+	if fallback != nil {
+		return *fallback
+	}
+	return AllocSite{Function: "(unknown)"}
+}
+
+// writeAllocProfile prints the totals and the top allocation sites of a
+// profiled calculation.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - algo: The name of the profiled algorithm.
+//   - n: The computed index.
+//   - profile: The allocation profile.
+//   - top: The maximum number of sites to list.
+func writeAllocProfile(out io.Writer, algo string, n uint64, profile AllocProfile, top int) {
+	fmt.Fprintf(out, "Allocation profile: %s, F(%d)\n", algo, n)
+	fmt.Fprintf(out, "  Total: %s in %d objects at %d call sites\n",
+		format.FormatBytes(uint64(profile.TotalBytes)), profile.TotalObjects, len(profile.Sites))
+	if len(profile.Sites) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "  %-10s %6s %8s  %s\n", "Bytes", "Share", "Objects", "Site")
+	for _, site := range profile.Sites[:min(top, len(profile.Sites))] {
+		share := 100 * float64(site.Bytes) / float64(profile.TotalBytes)
+		fmt.Fprintf(out, "  %-10s %5.1f%% %8d  %s (%s:%d)\n",
+			format.FormatBytes(uint64(site.Bytes)), share, site.Objects,
+			strings.TrimPrefix(site.Function, modulePrefix), filepath.Base(site.File), site.Line)
+	}
+}
@@ -0,0 +1,56 @@
+package app
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/cli"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// repeatRuns runs each calculator --repeat - 1 more times after the warm-up
+// run, one calculator at a time so that the runs do not compete for the CPU,
+// and summarizes their durations and heap allocations. Each run has its own
+// --timeout; a timed out run is counted as failed and the next one starts.
+//
+// Parameters:
+//   - ctx: The context canceled on SIGINT or SIGTERM, without the timeout of
+//     the warm-up run.
+//   - calculators: The calculators to run.
+//
+// Returns:
+//   - []cli.RepeatStats: The statistics of each calculator, in order.
+func (a *Application) repeatRuns(ctx context.Context, calculators []fibonacci.Calculator) []cli.RepeatStats {
+	opts := a.calculationOptions()
+	stats := make([]cli.RepeatStats, 0, len(calculators))
+	for _, calc := range calculators {
+		st := cli.RepeatStats{Name: calc.Name()}
+		var durations []time.Duration
+		var allocs, bytes uint64
+		for run := 1; run < a.Config.Repeat && ctx.Err() == nil; run++ {
+			runCtx, cancel := context.WithTimeout(ctx, a.Config.Timeout)
+			var before, after runtime.MemStats
+			runtime.ReadMemStats(&before)
+			res := orchestration.ExecuteCalculations(runCtx, []fibonacci.Calculator{calc}, a.Config.N, opts, orchestration.NullProgressReporter{}, io.Discard)[0]
+			runtime.ReadMemStats(&after)
+			cancel()
+
+			if res.Err != nil {
+				st.Failed++
+				continue
+			}
+			durations = append(durations, res.Duration)
+			allocs += after.Mallocs - before.Mallocs
+			bytes += after.TotalAlloc - before.TotalAlloc
+		}
+		st.Durations = orchestration.ComputeDurationStats(durations)
+		if n := uint64(len(durations)); n > 0 {
+			st.Allocs, st.Bytes = allocs/n, bytes/n
+		}
+		stats = append(stats, st)
+	}
+	return stats
+}
@@ -0,0 +1,72 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/cli"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// runRoundTrip computes F(N), saves it with cli.WriteResultToFile, reads the
+// file back with cli.ReadResultFromFile and verifies that the re-parsed value
+// matches. It exercises the full write/read path so that changes to the
+// result file format which break re-parsing are caught.
+//
+// The result is written to the configured output file if one is set, or to a
+// temporary file that is removed afterwards.
+func (a *Application) runRoundTrip(ctx context.Context, out io.Writer) int {
+	ctx, cancelTimeout := context.WithTimeout(ctx, a.Config.Timeout)
+	defer cancelTimeout()
+
+	calculators := orchestration.GetCalculatorsToRun(a.Config.Algo, a.Factory)
+	if len(calculators) == 0 {
+		fmt.Fprintf(a.ErrWriter, "Round-trip: no calculator available for algorithm %q\n", a.Config.Algo)
+		return apperrors.ExitErrorConfig
+	}
+	calc := calculators[0]
+	n := a.Config.N
+
+	start := time.Now()
+	result, err := calc.Calculate(ctx, nil, 0, n, a.calculationOptions())
+	duration := time.Since(start)
+	if err != nil {
+		return apperrors.HandleCalculationError(err, duration, a.ErrWriter, nil)
+	}
+
+	path := a.Config.OutputFile
+	if path == "" {
+		dir, err := os.MkdirTemp("", "fibcalc-roundtrip-")
+		if err != nil {
+			fmt.Fprintf(a.ErrWriter, "Round-trip: failed to create temporary directory: %v\n", err)
+			return apperrors.ExitErrorGeneric
+		}
+		defer os.RemoveAll(dir)
+		path = filepath.Join(dir, "result.txt")
+	}
+
+	if err := cli.WriteResultToFile(result, n, duration, calc.Name(), cli.OutputConfig{OutputFile: path}); err != nil {
+		fmt.Fprintf(a.ErrWriter, "Round-trip: save failed: %v\n", err)
+		return apperrors.ExitErrorGeneric
+	}
+
+	loaded, loadedN, err := cli.ReadResultFromFile(path)
+	if err != nil {
+		fmt.Fprintf(a.ErrWriter, "Round-trip: load failed: %v\n", err)
+		return apperrors.ExitErrorMismatch
+	}
+	if loadedN != n || loaded.Cmp(result) != 0 {
+		fmt.Fprintf(a.ErrWriter, "Round-trip: mismatch for F(%d): re-read value of F(%d) differs from computed value\n", n, loadedN)
+		return apperrors.ExitErrorMismatch
+	}
+
+	if !a.Config.Quiet {
+		fmt.Fprintf(out, "Round-trip OK: F(%d) (%d bits) saved, re-read and verified with %s.\n", n, result.BitLen(), calc.Name())
+	}
+	return apperrors.ExitSuccess
+}
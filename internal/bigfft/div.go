@@ -0,0 +1,176 @@
+// This file implements division of huge integers by Newton–Raphson
+// reciprocal iteration on top of the FFT multiplication.
+
+package bigfft
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"runtime/debug"
+)
+
+// defaultDivThresholdWords is the default divisor and quotient size (in
+// words) below which DivMod uses big.Int.QuoRem. The Newton iteration costs
+// a few full-size multiplications, so it only pays off well into the FFT
+// range: on amd64 it breaks even with math/big's recursive division around
+// 16K-word divisors and is about 4x faster at 500K words.
+const defaultDivThresholdWords = 16000
+
+// divThreshold is the size (in words) above which DivMod uses the Newton
+// iteration. Both the divisor and the quotient must exceed it.
+var divThreshold = defaultDivThresholdWords
+
+// recipBaseBits is the precision below which a reciprocal is computed
+// exactly with math/big instead of by a further Newton step.
+const recipBaseBits = 32 * _W
+
+// recipGuardBits is the number of extra bits carried by the reciprocal and
+// truncated operands, which keeps the quotient estimate within a few units.
+const recipGuardBits = _W
+
+// errDivisionByZero is returned by DivMod for a zero divisor.
+var errDivisionByZero = errors.New("bigfft.DivMod: division by zero")
+
+// DivMod sets q to the quotient x/y and r to the remainder x - y*q, with the
+// same truncated semantics as big.Int.QuoRem: q is rounded toward zero and r
+// has the sign of x. For large operands the quotient is obtained from a
+// Newton–Raphson reciprocal of y whose products use the FFT multiplication,
+// and is then corrected exactly, so the result is always exact.
+//
+// q or r may be nil if that output is not needed; q and r may alias x or y
+// but not each other.
+//
+// Parameters:
+//   - q: Receives the quotient (may be nil).
+//   - r: Receives the remainder (may be nil).
+//   - x: The dividend.
+//   - y: The divisor.
+//
+// Returns:
+//   - error: An error if y is zero, q and r are the same non-nil value, or a
+//     multiplication fails.
+func DivMod(q, r, x, y *big.Int) (err error) {
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic in bigfft.DivMod: %v\nStack: %s", rec, debug.Stack())
+		}
+	}()
+	return divMod(q, r, x, y, divThreshold)
+}
+
+// divMod implements DivMod with an explicit Newton threshold, in words.
+func divMod(q, r, x, y *big.Int, thresholdWords int) error {
+	if y.Sign() == 0 {
+		return errDivisionByZero
+	}
+	if q != nil && q == r {
+		return errors.New("bigfft.DivMod: q and r must be distinct")
+	}
+
+	a := new(big.Int).Abs(x)
+	b := new(big.Int).Abs(y)
+	var quo, rem *big.Int
+	ywords, qwords := len(b.Bits()), len(a.Bits())-len(b.Bits())
+	if ywords <= thresholdWords || qwords <= thresholdWords {
+		quo, rem = new(big.Int).QuoRem(a, b, new(big.Int))
+	} else {
+		var err error
+		if quo, rem, err = divModNewton(a, b); err != nil {
+			return err
+		}
+	}
+
+	if x.Sign()*y.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	if x.Sign() < 0 {
+		rem.Neg(rem)
+	}
+	if q != nil {
+		q.Set(quo)
+	}
+	if r != nil {
+		r.Set(rem)
+	}
+	return nil
+}
+
+// divModNewton divides a by b (both positive, a >= b) using a reciprocal of b
+// and returns the exact quotient and remainder.
+func divModNewton(a, b *big.Int) (*big.Int, *big.Int, error) {
+	n, m := a.BitLen(), b.BitLen()
+	p := n - m + recipGuardBits
+	inv, err := reciprocal(b, p) // ≈ 2^(p+m) / b
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Only the top bits of a contribute to the quotient estimate.
+	shift := max(m-recipGuardBits, 0)
+	at := new(big.Int).Rsh(a, uint(shift))
+	quo, err := Mul(at, inv)
+	if err != nil {
+		return nil, nil, err
+	}
+	quo.Rsh(quo, uint(p+m-shift))
+
+	// Correct the estimate: rem = a - quo*b, then fold any excess back into
+	// quo. The estimate is off by a few units, so the fix-up division has a
+	// tiny quotient.
+	prod, err := Mul(quo, b)
+	if err != nil {
+		return nil, nil, err
+	}
+	rem := prod.Sub(a, prod)
+	if rem.Sign() < 0 || rem.Cmp(b) >= 0 {
+		d, mod := new(big.Int).QuoRem(rem, b, new(big.Int))
+		quo.Add(quo, d)
+		rem = mod
+		if rem.Sign() < 0 {
+			quo.Sub(quo, bigOne)
+			rem.Add(rem, b)
+		}
+	}
+	return quo, rem, nil
+}
+
+// bigOne is the constant 1.
+var bigOne = big.NewInt(1)
+
+// reciprocal returns an approximation of 2^(p+m) / b, where m is the bit
+// length of b, accurate to p bits (it never exceeds the exact value by more
+// than a few units). Each level doubles the precision of the previous one
+// with a Newton step X' = 2X - b*X²/2^(p+m), whose square and product are
+// computed with Sqr and Mul.
+func reciprocal(b *big.Int, p int) (*big.Int, error) {
+	// Only the top p+guard bits of b affect the result at precision p.
+	m := b.BitLen()
+	if s := m - (p + recipGuardBits); s > 0 {
+		b = new(big.Int).Rsh(b, uint(s))
+		m -= s
+	}
+
+	if p <= recipBaseBits {
+		num := new(big.Int).Lsh(bigOne, uint(p+m))
+		return num.Quo(num, b), nil
+	}
+
+	h := p/2 + 1
+	y, err := reciprocal(b, h)
+	if err != nil {
+		return nil, err
+	}
+	x := y.Lsh(y, uint(p-h)) // ≈ 2^(p+m) / b at precision h
+
+	x2, err := Sqr(x)
+	if err != nil {
+		return nil, err
+	}
+	bx2, err := Mul(b, x2)
+	if err != nil {
+		return nil, err
+	}
+	bx2.Rsh(bx2, uint(p+m))
+	return x.Lsh(x, 1).Sub(x, bx2), nil
+}
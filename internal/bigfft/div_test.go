@@ -0,0 +1,126 @@
+package bigfft
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// randomInt returns a random non-negative integer of the given word length,
+// with its top word non-zero.
+func randomInt(r *rand.Rand, words int) *big.Int {
+	w := make([]big.Word, words)
+	for i := range w {
+		w[i] = big.Word(r.Uint64())
+	}
+	if words > 0 && w[words-1] == 0 {
+		w[words-1] = 1
+	}
+	return new(big.Int).SetBits(w)
+}
+
+// checkDivMod compares divMod with big.Int.QuoRem for x/y.
+func checkDivMod(t *testing.T, x, y *big.Int, thresholdWords int) {
+	t.Helper()
+	wantQ, wantR := new(big.Int).QuoRem(x, y, new(big.Int))
+	q, r := new(big.Int), new(big.Int)
+	if err := divMod(q, r, x, y, thresholdWords); err != nil {
+		t.Fatalf("divMod failed: %v", err)
+	}
+	if q.Cmp(wantQ) != 0 || r.Cmp(wantR) != 0 {
+		t.Fatalf("divMod(%d words / %d words, threshold %d) mismatch:\n  q=%s\n  want %s\n  r=%s\n  want %s",
+			len(x.Bits()), len(y.Bits()), thresholdWords, q, wantQ, r, wantR)
+	}
+}
+
+// TestDivModStraddlingThreshold divides operands just below and above a
+// lowered Newton threshold, so both code paths are compared with QuoRem.
+func TestDivModStraddlingThreshold(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(42))
+	const threshold = 40
+	for _, ywords := range []int{threshold - 1, threshold, threshold + 1, 3 * threshold, 200} {
+		for _, qwords := range []int{threshold - 1, threshold + 1, 2 * threshold, 300} {
+			x := randomInt(r, ywords+qwords)
+			y := randomInt(r, ywords)
+			checkDivMod(t, x, y, threshold)
+		}
+	}
+}
+
+// TestDivModEdgeCases covers exact divisions, all-ones operands and signs.
+func TestDivModEdgeCases(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(7))
+	y := randomInt(r, 100)
+	q := randomInt(r, 150)
+	exact := new(big.Int).Mul(q, y)
+	checkDivMod(t, exact, y, 8)
+	checkDivMod(t, new(big.Int).Sub(exact, bigOne), y, 8)
+
+	ones := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 64*300), bigOne)
+	onesY := new(big.Int).Sub(new(big.Int).Lsh(bigOne, 64*120), bigOne)
+	checkDivMod(t, ones, onesY, 8)
+	checkDivMod(t, ones, new(big.Int).Lsh(bigOne, 64*120), 8)
+
+	x := randomInt(r, 300)
+	for _, sx := range []int{1, -1} {
+		for _, sy := range []int{1, -1} {
+			xs := new(big.Int).Mul(x, big.NewInt(int64(sx)))
+			ys := new(big.Int).Mul(y, big.NewInt(int64(sy)))
+			checkDivMod(t, xs, ys, 8)
+		}
+	}
+	checkDivMod(t, y, x, 8) // |x| < |y|
+}
+
+// TestDivMod exercises the public entry point with the default threshold,
+// aliasing and error cases.
+func TestDivMod(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(3))
+	x := randomInt(r, 2*divThreshold+10)
+	y := randomInt(r, divThreshold+5)
+	wantQ, wantR := new(big.Int).QuoRem(x, y, new(big.Int))
+
+	// q aliases x, r aliases y.
+	q, rem := new(big.Int).Set(x), new(big.Int).Set(y)
+	if err := DivMod(q, rem, q, rem); err != nil {
+		t.Fatalf("DivMod failed: %v", err)
+	}
+	if q.Cmp(wantQ) != 0 || rem.Cmp(wantR) != 0 {
+		t.Fatal("DivMod mismatch with aliased arguments")
+	}
+
+	if err := DivMod(nil, nil, x, y); err != nil {
+		t.Errorf("DivMod with nil outputs failed: %v", err)
+	}
+	if err := DivMod(q, rem, x, new(big.Int)); err == nil {
+		t.Error("expected an error for division by zero")
+	}
+	if err := DivMod(q, q, x, y); err == nil {
+		t.Error("expected an error when q and r are the same")
+	}
+}
+
+// FuzzDivMod compares divMod with big.Int.QuoRem for operand sizes around a
+// small Newton threshold.
+func FuzzDivMod(f *testing.F) {
+	f.Add(int64(1), uint8(20), uint8(30), uint8(16))
+	f.Add(int64(2), uint8(16), uint8(17), uint8(16))
+	f.Add(int64(3), uint8(100), uint8(5), uint8(4))
+	f.Add(int64(4), uint8(64), uint8(200), uint8(8))
+
+	f.Fuzz(func(t *testing.T, seed int64, ywords, qwords, threshold uint8) {
+		if ywords == 0 {
+			return
+		}
+		r := rand.New(rand.NewSource(seed))
+		x := randomInt(r, int(ywords)+int(qwords))
+		y := randomInt(r, int(ywords))
+		if seed%2 != 0 {
+			x.Neg(x)
+		}
+		checkDivMod(t, x, y, int(threshold))
+	})
+}
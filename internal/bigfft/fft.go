@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"math/big"
 	"runtime/debug"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -30,13 +31,44 @@ func (n nat) String() string {
 // approximately 115kbits on 64-bit systems (1800 * 64 = 115200 bits).
 const defaultFFTThresholdWords = 1800
 
-// fftThreshold is the size (in words) above which FFT is used over
-// standard math/big multiplication. This can be modified for tuning purposes.
-var fftThreshold = defaultFFTThresholdWords
+// fftThresholdWords is the size (in words) above which FFT is used over
+// standard math/big multiplication, set by SetFFTThreshold.
+var fftThresholdWords atomic.Int64
+
+func init() {
+	fftThresholdWords.Store(defaultFFTThresholdWords)
+}
+
+// SetFFTThreshold sets the operand size (in words) above which Mul, MulTo,
+// Sqr and SqrTo switch from big.Int.Mul, which already uses Karatsuba
+// internally, to FFT multiplication. Both operands of a product must exceed
+// it. A value of 0 or less restores the default of 1800 words.
+//
+// The threshold is global and read once per operation, so it is meant to be
+// set at startup or by calibration, not while products are in flight.
+//
+// Parameters:
+//   - words: The threshold in words, or 0 for the default.
+func SetFFTThreshold(words int) {
+	if words <= 0 {
+		words = defaultFFTThresholdWords
+	}
+	fftThresholdWords.Store(int64(words))
+}
+
+// GetFFTThreshold returns the operand size (in words) above which FFT
+// multiplication is used.
+//
+// Returns:
+//   - int: The threshold in words.
+func GetFFTThreshold() int {
+	return int(fftThresholdWords.Load())
+}
 
 // Mul computes the product x*y and returns z.
 // It can be used instead of the Mul method of
-// *big.Int from math/big package.
+// *big.Int from math/big package. FFT products that would exceed the memory
+// budget (see SetMemoryBudget) fail with an apperrors.MemoryError.
 func Mul(x, y *big.Int) (res *big.Int, err error) {
 	defer func() {
 		if r := recover(); r != nil {
@@ -45,9 +77,14 @@ func Mul(x, y *big.Int) (res *big.Int, err error) {
 	}()
 	xwords := len(x.Bits())
 	ywords := len(y.Bits())
-	if xwords > fftThreshold && ywords > fftThreshold {
+	if threshold := GetFFTThreshold(); xwords > threshold && ywords > threshold {
+		if err := CheckMemoryBudget(xwords, ywords); err != nil {
+			return nil, err
+		}
+		opCounters.fftMul.Add(1)
 		return mulFFT(x, y)
 	}
+	opCounters.bigMul.Add(1)
 	return new(big.Int).Mul(x, y), nil
 }
 
@@ -61,7 +98,11 @@ func MulTo(z, x, y *big.Int) (res *big.Int, err error) {
 	}()
 	xwords := len(x.Bits())
 	ywords := len(y.Bits())
-	if xwords > fftThreshold && ywords > fftThreshold {
+	if threshold := GetFFTThreshold(); xwords > threshold && ywords > threshold {
+		if err := CheckMemoryBudget(xwords, ywords); err != nil {
+			return nil, err
+		}
+		opCounters.fftMul.Add(1)
 		var xb, yb nat = x.Bits(), y.Bits()
 		// Reuse z's existing buffer if available
 		zb, err := fftmulTo(z.Bits(), xb, yb)
@@ -74,6 +115,7 @@ func MulTo(z, x, y *big.Int) (res *big.Int, err error) {
 		}
 		return z, nil
 	}
+	opCounters.bigMul.Add(1)
 	return z.Mul(x, y), nil
 }
 
@@ -87,9 +129,14 @@ func Sqr(x *big.Int) (res *big.Int, err error) {
 		}
 	}()
 	xwords := len(x.Bits())
-	if xwords > fftThreshold {
+	if xwords > GetFFTThreshold() {
+		if err := CheckMemoryBudget(xwords, 0); err != nil {
+			return nil, err
+		}
+		opCounters.fftSqr.Add(1)
 		return sqrFFT(x)
 	}
+	opCounters.bigSqr.Add(1)
 	return new(big.Int).Mul(x, x), nil
 }
 
@@ -100,7 +147,11 @@ func SqrTo(z, x *big.Int) (res *big.Int, err error) {
 		}
 	}()
 	xwords := len(x.Bits())
-	if xwords > fftThreshold {
+	if xwords > GetFFTThreshold() {
+		if err := CheckMemoryBudget(xwords, 0); err != nil {
+			return nil, err
+		}
+		opCounters.fftSqr.Add(1)
 		var xb nat = x.Bits()
 		zb, err := fftsqrTo(z.Bits(), xb)
 		if err != nil {
@@ -110,6 +161,7 @@ func SqrTo(z, x *big.Int) (res *big.Int, err error) {
 		// x*x is always non-negative, no sign handling needed
 		return z, nil
 	}
+	opCounters.bigSqr.Add(1)
 	return z.Mul(x, x), nil
 }
 
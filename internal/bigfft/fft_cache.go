@@ -398,6 +398,7 @@ func (p *Poly) TransformCachedWithBump(n int, ba *BumpAllocator) (PolValues, err
 
 // MulCached multiplies p and q using cached transforms when beneficial.
 func (p *Poly) MulCached(q *Poly) (Poly, error) {
+	countPolyMul()
 	n := valueSize(p.K, p.M, 2)
 
 	pv, err := p.TransformCached(n)
@@ -408,7 +409,7 @@ func (p *Poly) MulCached(q *Poly) (Poly, error) {
 	if err != nil {
 		return Poly{}, err
 	}
-	rv, err := pv.Mul(&qv)
+	rv, err := pv.mul(&qv, GetPoolAllocator())
 	if err != nil {
 		return Poly{}, err
 	}
@@ -422,6 +423,13 @@ func (p *Poly) MulCached(q *Poly) (Poly, error) {
 
 // MulCachedWithBump multiplies p and q using cached transforms and bump allocator.
 func (p *Poly) MulCachedWithBump(q *Poly, ba *BumpAllocator) (Poly, error) {
+	countPolyMul()
+	return p.mulCachedWithBump(q, ba)
+}
+
+// mulCachedWithBump is MulCachedWithBump without counting the product, for
+// Mul and MulTo, which count it themselves.
+func (p *Poly) mulCachedWithBump(q *Poly, ba *BumpAllocator) (Poly, error) {
 	n := valueSize(p.K, p.M, 2)
 
 	pv, err := p.TransformCachedWithBump(n, ba)
@@ -432,7 +440,7 @@ func (p *Poly) MulCachedWithBump(q *Poly, ba *BumpAllocator) (Poly, error) {
 	if err != nil {
 		return Poly{}, err
 	}
-	rv, err := pv.MulWithBump(&qv, ba)
+	rv, err := pv.mul(&qv, NewBumpAllocatorAdapter(ba))
 	if err != nil {
 		return Poly{}, err
 	}
@@ -446,13 +454,14 @@ func (p *Poly) MulCachedWithBump(q *Poly, ba *BumpAllocator) (Poly, error) {
 
 // SqrCached computes p*p using cached transform when beneficial.
 func (p *Poly) SqrCached() (Poly, error) {
+	countPolySqr()
 	n := valueSize(p.K, p.M, 2)
 
 	pv, err := p.TransformCached(n)
 	if err != nil {
 		return Poly{}, err
 	}
-	rv, err := pv.Sqr()
+	rv, err := pv.sqr(GetPoolAllocator())
 	if err != nil {
 		return Poly{}, err
 	}
@@ -466,13 +475,20 @@ func (p *Poly) SqrCached() (Poly, error) {
 
 // SqrCachedWithBump computes p*p using cached transform and bump allocator.
 func (p *Poly) SqrCachedWithBump(ba *BumpAllocator) (Poly, error) {
+	countPolySqr()
+	return p.sqrCachedWithBump(ba)
+}
+
+// sqrCachedWithBump is SqrCachedWithBump without counting the squaring, for
+// Sqr and SqrTo, which count it themselves.
+func (p *Poly) sqrCachedWithBump(ba *BumpAllocator) (Poly, error) {
 	n := valueSize(p.K, p.M, 2)
 
 	pv, err := p.TransformCachedWithBump(n, ba)
 	if err != nil {
 		return Poly{}, err
 	}
-	rv, err := pv.SqrWithBump(ba)
+	rv, err := pv.sqr(NewBumpAllocatorAdapter(ba))
 	if err != nil {
 		return Poly{}, err
 	}
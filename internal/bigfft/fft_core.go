@@ -63,7 +63,7 @@ func fftmulTo(dst, x, y nat) (nat, error) {
 	yp := polyFromNat(y, k, m)
 
 	// Use cached multiplication when cache is enabled
-	rp, err := xp.MulCachedWithBump(&yp, ba)
+	rp, err := xp.mulCachedWithBump(&yp, ba)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func fftsqrTo(dst, x nat) (nat, error) {
 	xp := polyFromNat(x, k, m)
 
 	// Use cached squaring when cache is enabled
-	rp, err := xp.SqrCachedWithBump(ba)
+	rp, err := xp.sqrCachedWithBump(ba)
 	if err != nil {
 		return nil, err
 	}
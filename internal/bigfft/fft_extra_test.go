@@ -9,20 +9,18 @@ func TestFFT_Sqr(t *testing.T) {
 	t.Parallel()
 	// Test Sqr function directly
 	// Choose a size that triggers FFT
-	// fftThreshold = 1800 words
+	// GetFFTThreshold() = 1800 words by default
 	// 1 word = 64 bits = 8 bytes
 	// 1800 words = 14400 bytes = 115200 bits
 
 	// Create a large number slightly above threshold to ensure FFT path is taken
-	// For testing, we can temporarily lower the threshold if possible,
-	// or just trust the threshold logic.
-	// But `fftThreshold` is unexported.
-	// However, `Sqr` is exported.
+	// The threshold is global, so this parallel test keeps the default
+	// rather than lowering it with SetFFTThreshold.
 
 	// Let's create a moderately large number and verify correctness
 	// even if it doesn't trigger FFT (it will use Mul fallback),
 	// coverage will increase for the Sqr function entry point.
-	// To strictly hit sqrFFT, we need xwords > fftThreshold.
+	// To strictly hit sqrFFT, we need xwords > GetFFTThreshold().
 
 	// Creating a huge number in test might be slow but let's try a reasonable size.
 	// 2000 words to be safe.
@@ -99,12 +99,14 @@ func trim(n nat) nat {
 // Mul multiplies p and q modulo X^K-1, where K = 1<<p.K.
 // The product is done via a Fourier transform.
 func (p *Poly) Mul(q *Poly) (Poly, error) {
+	countPolyMul()
 	return p.mul(q, GetPoolAllocator())
 }
 
 // MulWithBump multiplies p and q using a bump allocator for temporary allocations.
 // This provides better cache locality and reduces GC pressure.
 func (p *Poly) MulWithBump(q *Poly, ba *BumpAllocator) (Poly, error) {
+	countPolyMul()
 	return p.mul(q, NewBumpAllocatorAdapter(ba))
 }
 
@@ -319,12 +321,14 @@ func (v *PolValues) InvNTransform() Poly {
 
 // Mul returns the pointwise product of p and q.
 func (p *PolValues) Mul(q *PolValues) (PolValues, error) {
+	countPolyMul()
 	return p.mul(q, GetPoolAllocator())
 }
 
 // MulWithBump returns the pointwise product of p and q, using a bump allocator
 // for temporary buffers.
 func (p *PolValues) MulWithBump(q *PolValues, ba *BumpAllocator) (PolValues, error) {
+	countPolyMul()
 	return p.mul(q, NewBumpAllocatorAdapter(ba))
 }
 
@@ -357,12 +361,14 @@ func (p *PolValues) mul(q *PolValues, alloc TempAllocator) (PolValues, error) {
 // Sqr returns the pointwise square of p (p[i] * p[i] for each i).
 // This is optimized for squaring as we don't need a second set of values.
 func (p *PolValues) Sqr() (PolValues, error) {
+	countPolySqr()
 	return p.sqr(GetPoolAllocator())
 }
 
 // SqrWithBump returns the pointwise square of p, using a bump allocator
 // for temporary buffers.
 func (p *PolValues) SqrWithBump(ba *BumpAllocator) (PolValues, error) {
+	countPolySqr()
 	return p.sqr(NewBumpAllocatorAdapter(ba))
 }
 
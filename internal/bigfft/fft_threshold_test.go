@@ -0,0 +1,87 @@
+package bigfft
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// TestFFTThreshold checks the threshold setter and that products are correct
+// on both sides of the threshold. It does not run in parallel: the threshold
+// is global.
+func TestFFTThreshold(t *testing.T) {
+	defer SetFFTThreshold(0)
+
+	if got := GetFFTThreshold(); got != defaultFFTThresholdWords {
+		t.Fatalf("GetFFTThreshold() = %d, want the default %d", got, defaultFFTThresholdWords)
+	}
+	SetFFTThreshold(-5)
+	if got := GetFFTThreshold(); got != defaultFFTThresholdWords {
+		t.Errorf("negative threshold: got %d, want the default %d", got, defaultFFTThresholdWords)
+	}
+
+	r := rand.New(rand.NewSource(1786))
+	x, y := randomInt(r, 300), randomInt(r, 250)
+	y.Neg(y)
+	wantMul := new(big.Int).Mul(x, y)
+	wantSqr := new(big.Int).Mul(x, x)
+
+	// 1000 words keeps every product on big.Int.Mul; 16 words sends them
+	// all through the FFT path.
+	for _, threshold := range []int{1000, 16} {
+		SetFFTThreshold(threshold)
+		if got := GetFFTThreshold(); got != threshold {
+			t.Fatalf("GetFFTThreshold() = %d, want %d", got, threshold)
+		}
+		checks := map[string]struct {
+			op   func() (*big.Int, error)
+			want *big.Int
+		}{
+			"Mul":   {func() (*big.Int, error) { return Mul(x, y) }, wantMul},
+			"MulTo": {func() (*big.Int, error) { return MulTo(new(big.Int), x, y) }, wantMul},
+			"Sqr":   {func() (*big.Int, error) { return Sqr(x) }, wantSqr},
+			"SqrTo": {func() (*big.Int, error) { return SqrTo(new(big.Int), x) }, wantSqr},
+		}
+		for name, c := range checks {
+			got, err := c.op()
+			if err != nil {
+				t.Fatalf("threshold %d, %s: unexpected error: %v", threshold, name, err)
+			}
+			if got.Cmp(c.want) != 0 {
+				t.Errorf("threshold %d, %s: wrong product", threshold, name)
+			}
+		}
+	}
+}
+
+// benchmarkMulSweep times big.Int.Mul against the FFT product for each
+// operand size, independently of the current threshold, to locate the
+// crossover on the running machine:
+//
+//	go test ./internal/bigfft -run=^$ -bench=MulThresholdSweep
+func benchmarkMulSweep(b *testing.B, sizes []int) {
+	r := rand.New(rand.NewSource(1))
+	for _, words := range sizes {
+		x, y := randomInt(r, words), randomInt(r, words)
+		b.Run(fmt.Sprintf("words=%d/mathbig", words), func(b *testing.B) {
+			z := new(big.Int)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				z.Mul(x, y)
+			}
+		})
+		b.Run(fmt.Sprintf("words=%d/fft", words), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := mulFFT(x, y); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMulThresholdSweep(b *testing.B) {
+	benchmarkMulSweep(b, []int{250, 500, 1000, 1800, 3000, 5000, 10000})
+}
@@ -0,0 +1,95 @@
+// This file guards FFT multiplications against allocations that exceed the
+// available memory.
+
+package bigfft
+
+import (
+	"sync/atomic"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// memoryBudget is the explicit per-operation budget in bytes set by
+// SetMemoryBudget, or 0 for the automatic budget.
+var memoryBudget atomic.Uint64
+
+// autoBudgetMinBytes is the estimate below which the automatic budget is not
+// checked: querying the system memory costs a syscall, which only stays
+// negligible next to multiplications of this size.
+const autoBudgetMinBytes = 256 << 20
+
+// autoBudgetPercent is the share of the system's available memory a single
+// FFT operation may use under the automatic budget.
+const autoBudgetPercent = 90
+
+// SetMemoryBudget sets the maximum number of bytes a single FFT
+// multiplication or squaring may allocate. Operations whose estimated
+// footprint exceeds it fail with an apperrors.MemoryError instead of
+// allocating. A budget of 0 (the default) restores the automatic budget,
+// which is 90% of the memory the system reports as available.
+//
+// Parameters:
+//   - bytes: The budget in bytes, or 0 for the automatic budget.
+func SetMemoryBudget(bytes uint64) {
+	memoryBudget.Store(bytes)
+}
+
+// MemoryBudget returns the explicit budget set by SetMemoryBudget, or 0 if
+// the automatic budget is in effect.
+//
+// Returns:
+//   - uint64: The budget in bytes, or 0.
+func MemoryBudget() uint64 {
+	return memoryBudget.Load()
+}
+
+// estimateFFTBytes estimates the peak memory of an FFT product of operands of
+// xwords and ywords words (ywords is 0 for a squaring): the transformed
+// values of each operand and of the product, K coefficients of n+1 words
+// each, plus the result.
+func estimateFFTBytes(xwords, ywords int) uint64 {
+	words := xwords + ywords
+	operands := 2
+	if ywords == 0 {
+		words = 2 * xwords
+		operands = 1
+	}
+	k, m := GetFFTParams(words)
+	n := valueSize(k, m, 2)
+	values := uint64(1<<k) * uint64(n+1)
+	return (uint64(operands+1)*values + uint64(words)) * uint64(_W/8)
+}
+
+// CheckMemoryBudget returns an apperrors.MemoryError if an FFT product of
+// the given operand sizes would exceed the memory budget. Mul, MulTo, Sqr and
+// SqrTo check it themselves; callers that transform operands directly with
+// PolyFromInt must check it before the transforms.
+//
+// Parameters:
+//   - xwords: The size of the first operand in words.
+//   - ywords: The size of the second operand in words, or 0 for a squaring.
+//
+// Returns:
+//   - error: An apperrors.MemoryError if the budget is exceeded, nil otherwise.
+func CheckMemoryBudget(xwords, ywords int) error {
+	need := estimateFFTBytes(xwords, ywords)
+	if budget := memoryBudget.Load(); budget > 0 {
+		if need > budget {
+			return apperrors.MemoryError{Requested: need, Available: budget, Limit: budget}
+		}
+		return nil
+	}
+	if need < autoBudgetMinBytes {
+		return nil
+	}
+	vm, err := mem.VirtualMemory()
+	if err != nil || vm.Available == 0 {
+		// Unknown system memory: let the allocation decide.
+		return nil
+	}
+	if limit := vm.Available / 100 * autoBudgetPercent; need > limit {
+		return apperrors.MemoryError{Requested: need, Available: vm.Available, Limit: limit}
+	}
+	return nil
+}
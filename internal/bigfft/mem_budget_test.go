@@ -0,0 +1,62 @@
+package bigfft
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// TestMemoryBudget forces a tiny budget and checks that FFT products fail
+// with a MemoryError while small products are unaffected. It does not run in
+// parallel: the budget is global.
+func TestMemoryBudget(t *testing.T) {
+	SetMemoryBudget(1024)
+	defer SetMemoryBudget(0)
+
+	words := make([]big.Word, GetFFTThreshold()+10)
+	for i := range words {
+		words[i] = big.Word(i + 1)
+	}
+	x := new(big.Int).SetBits(words)
+
+	checks := map[string]func() (*big.Int, error){
+		"Mul":   func() (*big.Int, error) { return Mul(x, x) },
+		"MulTo": func() (*big.Int, error) { return MulTo(new(big.Int), x, x) },
+		"Sqr":   func() (*big.Int, error) { return Sqr(x) },
+		"SqrTo": func() (*big.Int, error) { return SqrTo(new(big.Int), x) },
+	}
+	for name, op := range checks {
+		res, err := op()
+		var memErr apperrors.MemoryError
+		if !errors.As(err, &memErr) {
+			t.Errorf("%s: got %v, want a MemoryError", name, err)
+			continue
+		}
+		if res != nil || memErr.Limit != 1024 || memErr.Requested <= 1024 {
+			t.Errorf("%s: res=%v, err=%+v", name, res, memErr)
+		}
+	}
+
+	// Below the FFT threshold, math/big is used and the budget does not apply.
+	if _, err := Mul(big.NewInt(3), big.NewInt(5)); err != nil {
+		t.Errorf("small Mul failed: %v", err)
+	}
+
+	SetMemoryBudget(0)
+	if _, err := Mul(x, x); err != nil {
+		t.Errorf("Mul with the automatic budget failed: %v", err)
+	}
+}
+
+func TestEstimateFFTBytes(t *testing.T) {
+	t.Parallel()
+	if sqr, mul := estimateFFTBytes(1<<20, 0), estimateFFTBytes(1<<20, 1<<20); sqr == 0 || mul <= sqr {
+		t.Errorf("estimates sqr=%d mul=%d, want 0 < sqr < mul", sqr, mul)
+	}
+	// The transformed operands alone are at least as large as the inputs.
+	if got := estimateFFTBytes(1<<20, 1<<20); got < 2<<20*8 {
+		t.Errorf("estimate %d is below the operand size", got)
+	}
+}
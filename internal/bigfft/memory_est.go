@@ -12,7 +12,8 @@ type MemoryEstimate struct {
 }
 
 // EstimateMemoryNeeds estimates the memory requirements for calculating F(n).
-// This is a heuristic estimation used for pool pre-warming.
+// This is a coarse heuristic; PreWarmPools sizes its buffers from the FFT
+// parameters of the calculation instead.
 func EstimateMemoryNeeds(n uint64) MemoryEstimate {
 	// F(n) has approximately n * log10(phi) / log10(2) bits
 	// log2(phi) ≈ 0.69424
@@ -0,0 +1,78 @@
+// This file counts the products computed by Mul, MulTo, Sqr and SqrTo, and by
+// the Poly and PolValues methods.
+
+package bigfft
+
+import "sync/atomic"
+
+// OpCounts is a snapshot of the operation counters, split between the FFT
+// path and the products delegated to big.Int.Mul because an operand is below
+// the FFT threshold (see SetFFTThreshold). Products computed from transformed
+// polynomials (PolyFromInt and the Poly methods), such as the doubling step
+// with transform reuse, are FFT products too, and are also reported
+// separately in PolyMul and PolySqr.
+type OpCounts struct {
+	// FFTMul is the number of products computed by FFT: Mul and MulTo
+	// products above the threshold, plus PolyMul.
+	FFTMul uint64
+	// FFTSqr is the number of squarings computed by FFT: Sqr and SqrTo
+	// squarings above the threshold, plus PolySqr.
+	FFTSqr uint64
+	// PolyMul is the number of products of polynomials or transformed values
+	// (Poly.Mul, Poly.MulCached, PolValues.Mul and their WithBump variants).
+	PolyMul uint64
+	// PolySqr is the number of squarings of polynomials or transformed values
+	// (Poly.SqrCached, PolValues.Sqr and their WithBump variants).
+	PolySqr uint64
+	// BigMul is the number of Mul and MulTo products delegated to big.Int.
+	BigMul uint64
+	// BigSqr is the number of Sqr and SqrTo squarings delegated to big.Int.
+	BigSqr uint64
+}
+
+// opCounters holds the live counters behind an OpCounts. Unlike the pool
+// statistics they are always on: each operation pays one atomic increment,
+// which is negligible next to the product itself.
+var opCounters struct {
+	fftMul, fftSqr, bigMul, bigSqr, polyMul, polySqr atomic.Uint64
+}
+
+// countPolyMul records a product of transformed polynomials.
+func countPolyMul() {
+	opCounters.polyMul.Add(1)
+	opCounters.fftMul.Add(1)
+}
+
+// countPolySqr records a squaring of transformed polynomials.
+func countPolySqr() {
+	opCounters.polySqr.Add(1)
+	opCounters.fftSqr.Add(1)
+}
+
+// OperationCounts returns the number of products computed since the start of
+// the process or the last ResetOperationCounts. The counters are global to
+// the process: concurrent calculations all add to them.
+//
+// Returns:
+//   - OpCounts: The current counter values.
+func OperationCounts() OpCounts {
+	return OpCounts{
+		FFTMul:  opCounters.fftMul.Load(),
+		FFTSqr:  opCounters.fftSqr.Load(),
+		BigMul:  opCounters.bigMul.Load(),
+		BigSqr:  opCounters.bigSqr.Load(),
+		PolyMul: opCounters.polyMul.Load(),
+		PolySqr: opCounters.polySqr.Load(),
+	}
+}
+
+// ResetOperationCounts zeroes the operation counters, e.g. before the
+// calculation to measure.
+func ResetOperationCounts() {
+	opCounters.fftMul.Store(0)
+	opCounters.fftSqr.Store(0)
+	opCounters.bigMul.Store(0)
+	opCounters.bigSqr.Store(0)
+	opCounters.polyMul.Store(0)
+	opCounters.polySqr.Store(0)
+}
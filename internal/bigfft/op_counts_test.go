@@ -0,0 +1,67 @@
+package bigfft
+
+import (
+	"math/big"
+	"testing"
+)
+
+// TestOperationCounts checks that each entry point, including the Poly and
+// PolValues methods, counts its products on the path it takes. It does not run in parallel: the counters and the
+// threshold are global.
+func TestOperationCounts(t *testing.T) {
+	SetFFTThreshold(4)
+	defer SetFFTThreshold(0)
+	defer ResetOperationCounts()
+	ResetOperationCounts()
+
+	large := new(big.Int).Lsh(big.NewInt(3), 64*10)
+	small := big.NewInt(7)
+
+	for _, op := range []func() (*big.Int, error){
+		func() (*big.Int, error) { return Mul(large, large) },
+		func() (*big.Int, error) { return MulTo(new(big.Int), large, large) },
+		func() (*big.Int, error) { return Mul(large, small) },
+		func() (*big.Int, error) { return Sqr(large) },
+		func() (*big.Int, error) { return SqrTo(new(big.Int), large) },
+		func() (*big.Int, error) { return SqrTo(new(big.Int), small) },
+	} {
+		if _, err := op(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	want := OpCounts{FFTMul: 2, BigMul: 1, FFTSqr: 2, BigSqr: 1}
+	if got := OperationCounts(); got != want {
+		t.Errorf("OperationCounts() = %+v, want %+v", got, want)
+	}
+
+	// Products of transformed polynomials count as Poly and FFT products.
+	ResetOperationCounts()
+	k, m := GetFFTParams(2 * len(large.Bits()))
+	p := PolyFromInt(large, k, m)
+	pv, err := p.Transform(ValueSize(k, m, 2))
+	if err != nil {
+		t.Fatalf("Transform failed: %v", err)
+	}
+	if _, err := pv.Mul(&pv); err != nil {
+		t.Fatalf("PolValues.Mul failed: %v", err)
+	}
+	if _, err := pv.Sqr(); err != nil {
+		t.Fatalf("PolValues.Sqr failed: %v", err)
+	}
+	if _, err := p.Mul(&p); err != nil {
+		t.Fatalf("Poly.Mul failed: %v", err)
+	}
+	if _, err := p.SqrCached(); err != nil {
+		t.Fatalf("Poly.SqrCached failed: %v", err)
+	}
+	want = OpCounts{FFTMul: 2, FFTSqr: 2, PolyMul: 2, PolySqr: 2}
+	if got := OperationCounts(); got != want {
+		t.Errorf("OperationCounts() = %+v after Poly products, want %+v", got, want)
+	}
+
+	ResetOperationCounts()
+	if got := OperationCounts(); got != (OpCounts{}) {
+		t.Errorf("OperationCounts() = %+v after reset, want zero", got)
+	}
+}
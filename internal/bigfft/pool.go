@@ -6,44 +6,126 @@ import (
 	"math/big"
 	"math/bits"
 	"sync"
+	"sync/atomic"
 )
 
+// ─────────────────────────────────────────────────────────────────────────────
+// Pooling Toggle
+// ─────────────────────────────────────────────────────────────────────────────
+
+// poolingDisabled routes every acquire* call to a direct make() and turns the
+// matching release* calls into no-ops. It exists to quantify the value of
+// pooling on full calculations and defaults to false (pooling enabled).
+var poolingDisabled atomic.Bool
+
+// SetPoolingEnabled enables or disables buffer pooling for FFT operations.
+// Disabling pooling makes every temporary buffer a fresh heap allocation,
+// which is only useful for benchmarking and experimentation.
+//
+// Parameters:
+//   - enabled: Whether pooled buffers should be used.
+func SetPoolingEnabled(enabled bool) {
+	poolingDisabled.Store(!enabled)
+}
+
+// PoolingEnabled reports whether buffer pooling is currently enabled.
+func PoolingEnabled() bool {
+	return !poolingDisabled.Load()
+}
+
 // ─────────────────────────────────────────────────────────────────────────────
 // Word Slice Pools
 // ─────────────────────────────────────────────────────────────────────────────
 
 // wordSlicePool pools []big.Word slices by size class.
 // We use size classes to avoid fragmentation: 64, 256, 1K, 4K, 16K, 64K, 256K, 1M, 4M, 16M words.
-// Extended size classes support very large Fibonacci calculations (F > 10M).
+// Extended size classes support very large Fibonacci calculations (F > 10M),
+// and the 64M to 1G word classes can be enabled with SetMaxPoolClass.
 var wordSlicePools = [...]sync.Pool{
-	{New: func() any { return make([]big.Word, 64) }},
-	{New: func() any { return make([]big.Word, 256) }},
-	{New: func() any { return make([]big.Word, 1024) }},
-	{New: func() any { return make([]big.Word, 4096) }},
-	{New: func() any { return make([]big.Word, 16384) }},
-	{New: func() any { return make([]big.Word, 65536) }},
-	{New: func() any { return make([]big.Word, 262144) }},
-	{New: func() any { return make([]big.Word, 1048576) }},  // 1M words = 8MB on 64-bit
-	{New: func() any { return make([]big.Word, 4194304) }},  // 4M words = 32MB on 64-bit
-	{New: func() any { return make([]big.Word, 16777216) }}, // 16M words = 128MB on 64-bit
+	{New: func() any { return counted(&wordSliceCounters[0], make([]big.Word, 64)) }},
+	{New: func() any { return counted(&wordSliceCounters[1], make([]big.Word, 256)) }},
+	{New: func() any { return counted(&wordSliceCounters[2], make([]big.Word, 1024)) }},
+	{New: func() any { return counted(&wordSliceCounters[3], make([]big.Word, 4096)) }},
+	{New: func() any { return counted(&wordSliceCounters[4], make([]big.Word, 16384)) }},
+	{New: func() any { return counted(&wordSliceCounters[5], make([]big.Word, 65536)) }},
+	{New: func() any { return counted(&wordSliceCounters[6], make([]big.Word, 262144)) }},
+	{New: func() any { return counted(&wordSliceCounters[7], make([]big.Word, 1048576)) }},  // 1M words = 8MB on 64-bit
+	{New: func() any { return counted(&wordSliceCounters[8], make([]big.Word, 4194304)) }},  // 4M words = 32MB on 64-bit
+	{New: func() any { return counted(&wordSliceCounters[9], make([]big.Word, 16777216)) }}, // 16M words = 128MB on 64-bit
+	// Opt-in classes, pooled only after SetMaxPoolClass raises the limit.
+	{New: func() any { return counted(&wordSliceCounters[10], make([]big.Word, 67108864)) }},   // 64M words = 512MB on 64-bit
+	{New: func() any { return counted(&wordSliceCounters[11], make([]big.Word, 268435456)) }},  // 256M words = 2GB on 64-bit
+	{New: func() any { return counted(&wordSliceCounters[12], make([]big.Word, 1073741824)) }}, // 1G words = 8GB on 64-bit
+}
+
+// wordSliceSizes defines the size classes for word slice pools. Only the
+// first wordSliceClasses of them are pooled; see SetMaxPoolClass.
+var wordSliceSizes = [...]int{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216, 67108864, 268435456, 1073741824}
+
+// defaultWordSliceClasses is the number of word slice classes pooled by
+// default, up to 16M words.
+const defaultWordSliceClasses = 10
+
+// wordSliceClasses is the number of word slice size classes currently
+// pooled. Requests larger than the last pooled class are allocated directly.
+var wordSliceClasses atomic.Int32
+
+func init() {
+	wordSliceClasses.Store(defaultWordSliceClasses)
 }
 
-// wordSliceSizes defines the size classes for word slice pools.
-var wordSliceSizes = [...]int{64, 256, 1024, 4096, 16384, 65536, 262144, 1048576, 4194304, 16777216}
+// SetMaxPoolClass sets the largest word slice size class that is pooled.
+// The size is rounded up to the next class (a power of 4 between 64 and 1G
+// words); larger requests are allocated directly and left to the GC. Raising
+// the limit lets long-running processes that repeatedly compute very large
+// numbers reuse their biggest buffers instead of reallocating them; lowering
+// it caps the memory the pools can retain. Buffers of classes that are no
+// longer pooled are dropped on release and collected normally.
+//
+// It is safe to call at any time, but is intended to be set once at startup.
+//
+// Parameters:
+//   - words: The size, in words, of the largest buffer to pool.
+//
+// Returns:
+//   - int: The effective largest pooled class, in words.
+func SetMaxPoolClass(words int) int {
+	idx := len(wordSliceSizes) - 1
+	if words <= wordSliceSizes[idx] {
+		idx = wordSliceClassIndex(words)
+	}
+	wordSliceClasses.Store(int32(idx + 1))
+	return wordSliceSizes[idx]
+}
+
+// MaxPoolClass returns the largest word slice size class that is pooled.
+//
+// Returns:
+//   - int: The largest pooled class, in words.
+func MaxPoolClass() int {
+	return wordSliceSizes[wordSliceClasses.Load()-1]
+}
 
 // getWordSlicePoolIndex returns the pool index for a given size.
 // Returns -1 if the size is too large for pooling.
 //
 // Uses O(1) bitwise computation instead of linear search.
+func getWordSlicePoolIndex(size int) int {
+	if size > MaxPoolClass() {
+		return -1
+	}
+	return wordSliceClassIndex(size)
+}
+
+// wordSliceClassIndex returns the index of the smallest class that holds
+// size words, ignoring the pooled limit. size must not exceed the last class.
+//
 // wordSliceSizes are powers of 4 starting from 4^3 = 64:
 // index i corresponds to size 4^(i+3), so bits.Len(size) maps directly to the index.
-func getWordSlicePoolIndex(size int) int {
+func wordSliceClassIndex(size int) int {
 	if size <= 0 {
 		return 0
 	}
-	if size > wordSliceSizes[len(wordSliceSizes)-1] {
-		return -1
-	}
 	// bits.Len(uint(size-1)) gives the number of bits needed to represent size-1.
 	// For size <= 64 (4^3), this is <= 6, yielding idx <= 0 after the formula.
 	// For size <= 256 (4^4), bits.Len is <= 8, yielding idx = 1. Etc.
@@ -57,7 +139,7 @@ func getWordSlicePoolIndex(size int) int {
 // getWordSlicePoolIndexLinear is the original O(n) linear search implementation,
 // kept as a reference for testing the optimized bitwise version.
 func getWordSlicePoolIndexLinear(size int) int {
-	for i, s := range wordSliceSizes {
+	for i, s := range wordSliceSizes[:wordSliceClasses.Load()] {
 		if size <= s {
 			return i
 		}
@@ -76,11 +158,16 @@ func getWordSlicePoolIndexLinear(size int) int {
 //
 // This ensures the slice is returned to the pool even if an error occurs.
 func acquireWordSlice(size int) []big.Word {
+	if poolingDisabled.Load() {
+		return make([]big.Word, size)
+	}
 	idx := getWordSlicePoolIndex(size)
 	if idx < 0 {
 		// Too large for pooling, allocate directly
+		wordSliceDirect.direct()
 		return make([]big.Word, size)
 	}
+	wordSliceCounters[idx].get()
 	slice := wordSlicePools[idx].Get().([]big.Word)
 	// Clear the slice before returning (Go 1.21+ built-in)
 	clear(slice)
@@ -90,10 +177,15 @@ func acquireWordSlice(size int) []big.Word {
 // acquireWordSliceUnsafe returns a word slice from the pool without clearing it.
 // Use this only when the caller will immediately overwrite all elements (e.g., via copy).
 func acquireWordSliceUnsafe(size int) []big.Word {
+	if poolingDisabled.Load() {
+		return make([]big.Word, size)
+	}
 	idx := getWordSlicePoolIndex(size)
 	if idx < 0 {
+		wordSliceDirect.direct()
 		return make([]big.Word, size)
 	}
+	wordSliceCounters[idx].get()
 	slice := wordSlicePools[idx].Get().([]big.Word)
 	return slice[:size]
 }
@@ -108,7 +200,7 @@ func acquireWordSliceUnsafe(size int) []big.Word {
 // Parameters:
 //   - slice: The slice to return to the pool. Safe to call with nil.
 func releaseWordSlice(slice []big.Word) {
-	if slice == nil {
+	if slice == nil || poolingDisabled.Load() {
 		return
 	}
 	// Get the original capacity to determine which pool it came from
@@ -116,6 +208,7 @@ func releaseWordSlice(slice []big.Word) {
 	idx := getWordSlicePoolIndex(cap)
 	if idx >= 0 && wordSliceSizes[idx] == cap {
 		// Restore full capacity before returning to pool
+		wordSliceCounters[idx].put()
 		wordSlicePools[idx].Put(slice[:cap])
 	}
 	// If capacity doesn't match a pool size, it was directly allocated - let GC handle it
@@ -129,15 +222,15 @@ func releaseWordSlice(slice []big.Word) {
 // Fermat numbers are typically n+1 words where n is derived from FFT parameters.
 // Extended size classes support very large FFT operations.
 var fermatPools = [...]sync.Pool{
-	{New: func() any { return make(fermat, 32) }},
-	{New: func() any { return make(fermat, 128) }},
-	{New: func() any { return make(fermat, 512) }},
-	{New: func() any { return make(fermat, 2048) }},
-	{New: func() any { return make(fermat, 8192) }},
-	{New: func() any { return make(fermat, 32768) }},
-	{New: func() any { return make(fermat, 131072) }},  // 128K
-	{New: func() any { return make(fermat, 524288) }},  // 512K
-	{New: func() any { return make(fermat, 2097152) }}, // 2M
+	{New: func() any { return counted(&fermatCounters[0], make(fermat, 32)) }},
+	{New: func() any { return counted(&fermatCounters[1], make(fermat, 128)) }},
+	{New: func() any { return counted(&fermatCounters[2], make(fermat, 512)) }},
+	{New: func() any { return counted(&fermatCounters[3], make(fermat, 2048)) }},
+	{New: func() any { return counted(&fermatCounters[4], make(fermat, 8192)) }},
+	{New: func() any { return counted(&fermatCounters[5], make(fermat, 32768)) }},
+	{New: func() any { return counted(&fermatCounters[6], make(fermat, 131072)) }},  // 128K
+	{New: func() any { return counted(&fermatCounters[7], make(fermat, 524288)) }},  // 512K
+	{New: func() any { return counted(&fermatCounters[8], make(fermat, 2097152)) }}, // 2M
 }
 
 // fermatSizes defines the size classes for fermat pools.
@@ -183,10 +276,15 @@ func getFermatPoolIndexLinear(size int) int {
 //
 // This ensures the slice is returned to the pool even if an error occurs.
 func acquireFermat(size int) fermat {
+	if poolingDisabled.Load() {
+		return make(fermat, size)
+	}
 	idx := getFermatPoolIndex(size)
 	if idx < 0 {
+		fermatDirect.direct()
 		return make(fermat, size)
 	}
+	fermatCounters[idx].get()
 	f := fermatPools[idx].Get().(fermat)
 	// Clear and resize (Go 1.21+ built-in)
 	clear(f)
@@ -202,12 +300,13 @@ func acquireFermat(size int) fermat {
 // Parameters:
 //   - f: The fermat slice to return to the pool. Safe to call with nil.
 func releaseFermat(f fermat) {
-	if f == nil {
+	if f == nil || poolingDisabled.Load() {
 		return
 	}
 	cap := cap(f)
 	idx := getFermatPoolIndex(cap)
 	if idx >= 0 && fermatSizes[idx] == cap {
+		fermatCounters[idx].put()
 		fermatPools[idx].Put(f[:cap])
 	}
 }
@@ -219,13 +318,13 @@ func releaseFermat(f fermat) {
 // natSlicePool pools []nat slices used for polynomial coefficients.
 // Extended to support larger FFT sizes.
 var natSlicePools = [...]sync.Pool{
-	{New: func() any { return make([]nat, 8) }},
-	{New: func() any { return make([]nat, 32) }},
-	{New: func() any { return make([]nat, 128) }},
-	{New: func() any { return make([]nat, 512) }},
-	{New: func() any { return make([]nat, 2048) }},
-	{New: func() any { return make([]nat, 8192) }},
-	{New: func() any { return make([]nat, 32768) }},
+	{New: func() any { return counted(&natSliceCounters[0], make([]nat, 8)) }},
+	{New: func() any { return counted(&natSliceCounters[1], make([]nat, 32)) }},
+	{New: func() any { return counted(&natSliceCounters[2], make([]nat, 128)) }},
+	{New: func() any { return counted(&natSliceCounters[3], make([]nat, 512)) }},
+	{New: func() any { return counted(&natSliceCounters[4], make([]nat, 2048)) }},
+	{New: func() any { return counted(&natSliceCounters[5], make([]nat, 8192)) }},
+	{New: func() any { return counted(&natSliceCounters[6], make([]nat, 32768)) }},
 }
 
 // natSliceSizes defines the size classes for nat slice pools.
@@ -270,10 +369,15 @@ func getNatSlicePoolIndexLinear(size int) int {
 //
 // This ensures the slice is returned to the pool even if an error occurs.
 func acquireNatSlice(size int) []nat {
+	if poolingDisabled.Load() {
+		return make([]nat, size)
+	}
 	idx := getNatSlicePoolIndex(size)
 	if idx < 0 {
+		natSliceDirect.direct()
 		return make([]nat, size)
 	}
+	natSliceCounters[idx].get()
 	slice := natSlicePools[idx].Get().([]nat)
 	// Clear the slice (Go 1.21+ built-in)
 	clear(slice)
@@ -289,12 +393,13 @@ func acquireNatSlice(size int) []nat {
 // Parameters:
 //   - slice: The slice to return to the pool. Safe to call with nil.
 func releaseNatSlice(slice []nat) {
-	if slice == nil {
+	if slice == nil || poolingDisabled.Load() {
 		return
 	}
 	cap := cap(slice)
 	idx := getNatSlicePoolIndex(cap)
 	if idx >= 0 && natSliceSizes[idx] == cap {
+		natSliceCounters[idx].put()
 		natSlicePools[idx].Put(slice[:cap])
 	}
 }
@@ -306,13 +411,13 @@ func releaseNatSlice(slice []nat) {
 // fermatSlicePool pools []fermat slices used for polynomial values.
 // Extended to support larger FFT sizes.
 var fermatSlicePools = [...]sync.Pool{
-	{New: func() any { return make([]fermat, 8) }},
-	{New: func() any { return make([]fermat, 32) }},
-	{New: func() any { return make([]fermat, 128) }},
-	{New: func() any { return make([]fermat, 512) }},
-	{New: func() any { return make([]fermat, 2048) }},
-	{New: func() any { return make([]fermat, 8192) }},
-	{New: func() any { return make([]fermat, 32768) }},
+	{New: func() any { return counted(&fermatSliceCounters[0], make([]fermat, 8)) }},
+	{New: func() any { return counted(&fermatSliceCounters[1], make([]fermat, 32)) }},
+	{New: func() any { return counted(&fermatSliceCounters[2], make([]fermat, 128)) }},
+	{New: func() any { return counted(&fermatSliceCounters[3], make([]fermat, 512)) }},
+	{New: func() any { return counted(&fermatSliceCounters[4], make([]fermat, 2048)) }},
+	{New: func() any { return counted(&fermatSliceCounters[5], make([]fermat, 8192)) }},
+	{New: func() any { return counted(&fermatSliceCounters[6], make([]fermat, 32768)) }},
 }
 
 // fermatSliceSizes defines the size classes for []fermat pools.
@@ -357,10 +462,15 @@ func getFermatSlicePoolIndexLinear(size int) int {
 //
 // This ensures the slice is returned to the pool even if an error occurs.
 func acquireFermatSlice(size int) []fermat {
+	if poolingDisabled.Load() {
+		return make([]fermat, size)
+	}
 	idx := getFermatSlicePoolIndex(size)
 	if idx < 0 {
+		fermatSliceDirect.direct()
 		return make([]fermat, size)
 	}
+	fermatSliceCounters[idx].get()
 	slice := fermatSlicePools[idx].Get().([]fermat)
 	// Clear the slice (Go 1.21+ built-in)
 	clear(slice)
@@ -376,12 +486,13 @@ func acquireFermatSlice(size int) []fermat {
 // Parameters:
 //   - slice: The slice to return to the pool. Safe to call with nil.
 func releaseFermatSlice(slice []fermat) {
-	if slice == nil {
+	if slice == nil || poolingDisabled.Load() {
 		return
 	}
 	cap := cap(slice)
 	idx := getFermatSlicePoolIndex(cap)
 	if idx >= 0 && fermatSliceSizes[idx] == cap {
+		fermatSliceCounters[idx].put()
 		fermatSlicePools[idx].Put(slice[:cap])
 	}
 }
@@ -404,7 +515,7 @@ type fftState struct {
 // fftStatePool pools fftState objects.
 var fftStatePool = sync.Pool{
 	New: func() any {
-		return &fftState{}
+		return counted(&fftStateCounters, &fftState{})
 	},
 }
 
@@ -417,6 +528,10 @@ var fftStatePool = sync.Pool{
 // This ensures the state and its internal buffers are returned to the pool
 // even if an error occurs or a panic is triggered.
 func acquireFFTState(n int, k uint) *fftState {
+	if poolingDisabled.Load() {
+		return &fftState{tmp: make(fermat, n+1), tmp2: make(fermat, n+1), n: n, k: k}
+	}
+	fftStateCounters.get()
 	state := fftStatePool.Get().(*fftState)
 
 	// Allocate or reuse tmp buffers
@@ -453,11 +568,12 @@ func acquireFFTState(n int, k uint) *fftState {
 // Parameters:
 //   - state: The fftState to return to the pool. Safe to call with nil.
 func releaseFFTState(state *fftState) {
-	if state == nil {
+	if state == nil || poolingDisabled.Load() {
 		return
 	}
 	// Keep the allocations for reuse
 	// Note: The internal tmp and tmp2 buffers are kept with the state
 	// and will be reused on the next acquisition, reducing allocations.
+	fftStateCounters.put()
 	fftStatePool.Put(state)
 }
@@ -14,6 +14,34 @@ func TestPreWarmPools(t *testing.T) {
 	PreWarmPools(100000)
 }
 
+func TestPrewarmSizes(t *testing.T) {
+	t.Parallel()
+	for _, n := range []uint64{1_000, 1_000_000, 100_000_000} {
+		sizes := prewarmSizes(n)
+		// The squaring of F(n/2), whose product has the size of F(n).
+		words := int((uint64(float64(n)*0.69424) + 63) / 64)
+		k, m := fftSizeSqr(make(nat, (words+1)/2))
+		valueWords := valueSize(k, m, 2) + 1
+
+		if got, want := getFermatPoolIndex(sizes.MaxFermatSize), getFermatPoolIndex(valueWords); got != want {
+			t.Errorf("n=%d: fermat class %d, want %d", n, got, want)
+		}
+		if got, want := getNatSlicePoolIndex(sizes.MaxNatSliceSize), getNatSlicePoolIndex(1<<k); got != want {
+			t.Errorf("n=%d: nat slice class %d, want %d", n, got, want)
+		}
+		if got, want := getFermatSlicePoolIndex(sizes.MaxFermatSliceSize), getFermatSlicePoolIndex(1<<k); got != want {
+			t.Errorf("n=%d: fermat slice class %d, want %d", n, got, want)
+		}
+		if sizes.MaxWordSliceSize != sizes.MaxFermatSliceSize*sizes.MaxFermatSize {
+			t.Errorf("n=%d: word slice of %d words, want %d values of %d words",
+				n, sizes.MaxWordSliceSize, sizes.MaxFermatSliceSize, sizes.MaxFermatSize)
+		}
+		if getFermatPoolIndex(sizes.MaxFermatSize) == len(fermatSizes)-1 {
+			t.Errorf("n=%d: pre-warming the largest fermat class (%d words)", n, fermatSizes[len(fermatSizes)-1])
+		}
+	}
+}
+
 func TestAcquireReleaseWordSlice(t *testing.T) {
 	t.Parallel()
 	// Normal size
@@ -0,0 +1,156 @@
+// This file tracks how effectively the FFT buffer pools are reused.
+
+package bigfft
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Statistics Toggle
+// ─────────────────────────────────────────────────────────────────────────────
+
+// poolStatsEnabled gates every counter update, so that the hot path pays a
+// single atomic load while statistics are off (the default).
+var poolStatsEnabled atomic.Bool
+
+// SetPoolStatsEnabled enables or disables pool usage counting. Counting adds
+// contended atomic increments to every acquire and release, so it is meant
+// for profiling runs only.
+//
+// Parameters:
+//   - enabled: Whether pool usage should be counted.
+func SetPoolStatsEnabled(enabled bool) {
+	poolStatsEnabled.Store(enabled)
+}
+
+// PoolStatsEnabled reports whether pool usage is currently counted.
+func PoolStatsEnabled() bool {
+	return poolStatsEnabled.Load()
+}
+
+// ─────────────────────────────────────────────────────────────────────────────
+// Counters
+// ─────────────────────────────────────────────────────────────────────────────
+
+// PoolStat is a snapshot of the usage counters of one pool size class.
+type PoolStat struct {
+	// Gets is the number of buffers requested from the pool.
+	Gets uint64
+	// News is the number of buffers the pool had to allocate (cache misses).
+	News uint64
+	// Puts is the number of buffers returned to the pool.
+	Puts uint64
+	// Direct is the number of requests too large for any size class, which
+	// were allocated directly and bypass the pool.
+	Direct uint64
+}
+
+// poolCounters holds the live counters behind a PoolStat.
+type poolCounters struct {
+	gets, news, puts, directs atomic.Uint64
+}
+
+func (c *poolCounters) get() {
+	if poolStatsEnabled.Load() {
+		c.gets.Add(1)
+	}
+}
+
+func (c *poolCounters) put() {
+	if poolStatsEnabled.Load() {
+		c.puts.Add(1)
+	}
+}
+
+func (c *poolCounters) direct() {
+	if poolStatsEnabled.Load() {
+		c.directs.Add(1)
+	}
+}
+
+// snapshot returns the current counter values.
+func (c *poolCounters) snapshot() PoolStat {
+	return PoolStat{Gets: c.gets.Load(), News: c.news.Load(), Puts: c.puts.Load(), Direct: c.directs.Load()}
+}
+
+// reset zeroes the counters.
+func (c *poolCounters) reset() {
+	c.gets.Store(0)
+	c.news.Store(0)
+	c.puts.Store(0)
+	c.directs.Store(0)
+}
+
+// counted records a pool allocation (cache miss) in c and returns v. It wraps
+// the value built by a sync.Pool New function.
+func counted[T any](c *poolCounters, v T) any {
+	if poolStatsEnabled.Load() {
+		c.news.Add(1)
+	}
+	return v
+}
+
+// Per-class counters of each pool family, and the oversized requests of each
+// family that bypass the pools.
+var (
+	wordSliceCounters   [len(wordSliceSizes)]poolCounters
+	fermatCounters      [len(fermatSizes)]poolCounters
+	natSliceCounters    [len(natSliceSizes)]poolCounters
+	fermatSliceCounters [len(fermatSliceSizes)]poolCounters
+	fftStateCounters    poolCounters
+
+	wordSliceDirect   poolCounters
+	fermatDirect      poolCounters
+	natSliceDirect    poolCounters
+	fermatSliceDirect poolCounters
+)
+
+// poolFamily describes the counters of one pool family for reporting.
+type poolFamily struct {
+	name     string
+	sizes    []int
+	counters []poolCounters
+	direct   *poolCounters
+}
+
+// poolFamilies lists the sized pool families, in reporting order.
+func poolFamilies() []poolFamily {
+	return []poolFamily{
+		{"word", wordSliceSizes[:], wordSliceCounters[:], &wordSliceDirect},
+		{"fermat", fermatSizes[:], fermatCounters[:], &fermatDirect},
+		{"nat-slice", natSliceSizes[:], natSliceCounters[:], &natSliceDirect},
+		{"fermat-slice", fermatSliceSizes[:], fermatSliceCounters[:], &fermatSliceDirect},
+	}
+}
+
+// PoolStats returns a snapshot of the pool usage counters. Keys name the pool
+// family and size class, e.g. "word/4096" or "fermat/32"; the "<family>/oversized"
+// entries only carry Direct counts, and "fft-state" is the FFT state pool.
+// Counters only advance while SetPoolStatsEnabled(true) is in effect.
+//
+// Returns:
+//   - map[string]PoolStat: The counters of every pool class.
+func PoolStats() map[string]PoolStat {
+	stats := make(map[string]PoolStat)
+	for _, family := range poolFamilies() {
+		for i, size := range family.sizes {
+			stats[fmt.Sprintf("%s/%d", family.name, size)] = family.counters[i].snapshot()
+		}
+		stats[family.name+"/oversized"] = family.direct.snapshot()
+	}
+	stats["fft-state"] = fftStateCounters.snapshot()
+	return stats
+}
+
+// ResetPoolStats zeroes all pool usage counters, e.g. between benchmark runs.
+func ResetPoolStats() {
+	for _, family := range poolFamilies() {
+		for i := range family.counters {
+			family.counters[i].reset()
+		}
+		family.direct.reset()
+	}
+	fftStateCounters.reset()
+}
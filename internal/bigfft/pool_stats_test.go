@@ -0,0 +1,55 @@
+package bigfft
+
+import "testing"
+
+// TestPoolStats checks the counters of one acquire/release cycle per family.
+// It does not run in parallel: the counters are global.
+func TestPoolStats(t *testing.T) {
+	SetPoolStatsEnabled(true)
+	defer func() {
+		SetPoolStatsEnabled(false)
+		ResetPoolStats()
+	}()
+	ResetPoolStats()
+
+	releaseWordSlice(acquireWordSlice(100))
+	releaseFermat(acquireFermat(100))
+	releaseNatSlice(acquireNatSlice(10))
+	releaseFermatSlice(acquireFermatSlice(10))
+	releaseWordSlice(acquireWordSlice(MaxPoolClass() + 1))
+
+	stats := PoolStats()
+	for _, key := range []string{"word/256", "fermat/128", "nat-slice/32", "fermat-slice/32"} {
+		s, ok := stats[key]
+		if !ok {
+			t.Fatalf("missing stats for %s", key)
+		}
+		if s.Gets != 1 || s.Puts != 1 || s.Direct != 0 {
+			t.Errorf("%s = %+v, want 1 get and 1 put", key, s)
+		}
+		if s.News > s.Gets {
+			t.Errorf("%s: %d news for %d gets", key, s.News, s.Gets)
+		}
+	}
+	if s := stats["word/oversized"]; s.Direct != 1 || s.Gets != 0 {
+		t.Errorf("word/oversized = %+v, want 1 direct allocation", s)
+	}
+
+	ResetPoolStats()
+	for key, s := range PoolStats() {
+		if s != (PoolStat{}) {
+			t.Errorf("%s = %+v after reset, want zero", key, s)
+		}
+	}
+}
+
+// TestPoolStatsDisabled verifies that nothing is counted by default.
+func TestPoolStatsDisabled(t *testing.T) {
+	if PoolStatsEnabled() {
+		t.Fatal("pool statistics should be disabled by default")
+	}
+	releaseWordSlice(acquireWordSlice(100))
+	if s := PoolStats()["word/256"]; s != (PoolStat{}) {
+		t.Errorf("word/256 = %+v while disabled, want zero", s)
+	}
+}
@@ -187,6 +187,102 @@ func TestFFTStatePool(t *testing.T) {
 	releaseFFTState(state)
 }
 
+func TestPoolingDisabled(t *testing.T) {
+	// Not parallel: pooling is a process-wide setting.
+	SetPoolingEnabled(false)
+	defer SetPoolingEnabled(true)
+
+	if PoolingEnabled() {
+		t.Fatal("PoolingEnabled() = true after SetPoolingEnabled(false)")
+	}
+
+	// Without pooling, slices are exactly the requested size.
+	if w := acquireWordSlice(100); len(w) != 100 || cap(w) != 100 {
+		t.Errorf("acquireWordSlice(100): len=%d cap=%d, want 100/100", len(w), cap(w))
+	}
+	if f := acquireFermat(100); len(f) != 100 || cap(f) != 100 {
+		t.Errorf("acquireFermat(100): len=%d cap=%d, want 100/100", len(f), cap(f))
+	}
+	state := acquireFFTState(100, 4)
+	if len(state.tmp) != 101 || len(state.tmp2) != 101 {
+		t.Errorf("acquireFFTState: tmp=%d tmp2=%d, want 101", len(state.tmp), len(state.tmp2))
+	}
+	releaseFFTState(state)
+	releaseWordSlice(make([]big.Word, 64))
+}
+
+func TestSetMaxPoolClass(t *testing.T) {
+	// Not parallel: the pooled limit is a process-wide setting.
+	defer SetMaxPoolClass(wordSliceSizes[defaultWordSliceClasses-1])
+
+	if got := MaxPoolClass(); got != 16777216 {
+		t.Fatalf("default MaxPoolClass() = %d, want 16777216", got)
+	}
+
+	// Shrinking rounds up to a class and stops pooling larger requests.
+	if got := SetMaxPoolClass(1000); got != 1024 {
+		t.Fatalf("SetMaxPoolClass(1000) = %d, want 1024", got)
+	}
+	if w := acquireWordSlice(4096); cap(w) != 4096 || getWordSlicePoolIndex(4096) != -1 {
+		t.Errorf("4096 words should be directly allocated, got cap %d", cap(w))
+	}
+	for size := 0; size <= 4096+100; size++ {
+		if got, want := getWordSlicePoolIndex(size), getWordSlicePoolIndexLinear(size); got != want {
+			t.Fatalf("getWordSlicePoolIndex(%d) = %d, want %d", size, got, want)
+		}
+	}
+
+	// The extended limit covers the opt-in classes, with the same formula.
+	if got := SetMaxPoolClass(1 << 40); got != wordSliceSizes[len(wordSliceSizes)-1] {
+		t.Fatalf("SetMaxPoolClass(1<<40) = %d, want the largest class", got)
+	}
+	for i, size := range wordSliceSizes {
+		if got := getWordSlicePoolIndex(size); got != i {
+			t.Errorf("getWordSlicePoolIndex(%d) = %d, want %d", size, got, i)
+		}
+		if got := getWordSlicePoolIndex(size/2 + 1); i > 0 && got != i {
+			t.Errorf("getWordSlicePoolIndex(%d) = %d, want %d", size/2+1, got, i)
+		}
+	}
+	if got := getWordSlicePoolIndex(MaxPoolClass() + 1); got != -1 {
+		t.Errorf("getWordSlicePoolIndex(max+1) = %d, want -1", got)
+	}
+}
+
+// TestSetMaxPoolClassReuse verifies that a newly pooled class is released and
+// re-acquired without a fresh allocation. The class is re-enabled after being
+// excluded, which exercises the same path as raising the limit past 16M words
+// without allocating gigabytes in a test.
+func TestSetMaxPoolClassReuse(t *testing.T) {
+	// Not parallel: the pooled limit is a process-wide setting.
+	defer SetMaxPoolClass(wordSliceSizes[defaultWordSliceClasses-1])
+	const size = 65536
+
+	SetMaxPoolClass(size / 4)
+	direct := acquireWordSlice(size)
+	releaseWordSlice(direct)
+	if again := acquireWordSlice(size); &again[0] == &direct[0] {
+		t.Fatal("a buffer above the limit should not be pooled")
+	}
+
+	SetMaxPoolClass(size)
+	first := acquireWordSlice(size)
+	if cap(first) != size {
+		t.Fatalf("cap = %d, want %d", cap(first), size)
+	}
+	// sync.Pool may drop a released buffer (randomly under the race
+	// detector, or on GC), so allow a few attempts.
+	for attempt := 0; attempt < 20; attempt++ {
+		releaseWordSlice(first)
+		second := acquireWordSlice(size)
+		if &second[0] == &first[0] {
+			return
+		}
+		first = second
+	}
+	t.Error("released buffer was never re-acquired from the pool")
+}
+
 func TestReleaseNilSafe(t *testing.T) {
 	t.Parallel()
 	// These should not panic
@@ -204,7 +300,7 @@ func TestReleaseNilSafe(t *testing.T) {
 func TestGetWordSlicePoolIndexConsistency(t *testing.T) {
 	t.Parallel()
 	// Test all sizes from 0 to max+100, verifying bitwise matches linear
-	maxSize := wordSliceSizes[len(wordSliceSizes)-1]
+	maxSize := MaxPoolClass()
 	for size := 0; size <= maxSize+100; size++ {
 		got := getWordSlicePoolIndex(size)
 		want := getWordSlicePoolIndexLinear(size)
@@ -259,7 +355,7 @@ func TestPoolIndexBoundaryValues(t *testing.T) {
 
 	t.Run("wordSlice", func(t *testing.T) {
 		t.Parallel()
-		for i, size := range wordSliceSizes {
+		for i, size := range wordSliceSizes[:defaultWordSliceClasses] {
 			// At the boundary, should return this index
 			if got := getWordSlicePoolIndex(size); got != i {
 				t.Errorf("getWordSlicePoolIndex(%d) = %d, want %d", size, got, i)
@@ -272,7 +368,7 @@ func TestPoolIndexBoundaryValues(t *testing.T) {
 			}
 		}
 		// Above max should return -1
-		if got := getWordSlicePoolIndex(wordSliceSizes[len(wordSliceSizes)-1] + 1); got != -1 {
+		if got := getWordSlicePoolIndex(MaxPoolClass() + 1); got != -1 {
 			t.Errorf("getWordSlicePoolIndex(max+1) = %d, want -1", got)
 		}
 	})
@@ -37,8 +37,15 @@ import (
 // needs for calculating F(n). This reduces allocation overhead during the
 // calculation by ensuring pools have ready-to-use buffers.
 //
-// The function estimates the required buffer sizes and pre-allocates an
-// adaptive number of buffers in each relevant pool size class based on n:
+// Only the size classes used by the largest FFT multiplication of the
+// calculation are pre-warmed: the squaring whose product has the size of
+// F(n). Its FFT parameters (see GetFFTParams) give the coefficient count and
+// the length of the Fermat values, hence the classes of the fermat, nat
+// slice, fermat slice and value word slice pools. Classes larger than the
+// calculation needs are left empty.
+//
+// An adaptive number of buffers is pre-allocated in each of these classes
+// based on n:
 //   - N < 100,000: 2 buffers (minimal overhead)
 //   - 100,000 ≤ N < 1,000,000: 4 buffers
 //   - 1,000,000 ≤ N < 10,000,000: 5 buffers
@@ -50,7 +57,7 @@ import (
 // Parameters:
 //   - n: The Fibonacci index to calculate (used for estimation).
 func PreWarmPools(n uint64) {
-	est := EstimateMemoryNeeds(n)
+	sizes := prewarmSizes(n)
 
 	// Determine the number of buffers based on calculation size
 	numBuffers := 2 // Default for small calculations
@@ -63,7 +70,7 @@ func PreWarmPools(n uint64) {
 	}
 
 	// Pre-warm word slice pools
-	wordIdx := getWordSlicePoolIndex(est.MaxWordSliceSize)
+	wordIdx := getWordSlicePoolIndex(sizes.MaxWordSliceSize)
 	if wordIdx >= 0 {
 		for i := 0; i < numBuffers; i++ {
 			buf := make([]big.Word, wordSliceSizes[wordIdx])
@@ -72,7 +79,7 @@ func PreWarmPools(n uint64) {
 	}
 
 	// Pre-warm fermat pools
-	fermatIdx := getFermatPoolIndex(est.MaxFermatSize)
+	fermatIdx := getFermatPoolIndex(sizes.MaxFermatSize)
 	if fermatIdx >= 0 {
 		for i := 0; i < numBuffers; i++ {
 			buf := make(fermat, fermatSizes[fermatIdx])
@@ -81,7 +88,7 @@ func PreWarmPools(n uint64) {
 	}
 
 	// Pre-warm nat slice pools
-	natIdx := getNatSlicePoolIndex(est.MaxNatSliceSize)
+	natIdx := getNatSlicePoolIndex(sizes.MaxNatSliceSize)
 	if natIdx >= 0 {
 		for i := 0; i < numBuffers; i++ {
 			buf := make([]nat, natSliceSizes[natIdx])
@@ -90,7 +97,7 @@ func PreWarmPools(n uint64) {
 	}
 
 	// Pre-warm fermat slice pools
-	fermatSliceIdx := getFermatSlicePoolIndex(est.MaxFermatSliceSize)
+	fermatSliceIdx := getFermatSlicePoolIndex(sizes.MaxFermatSliceSize)
 	if fermatSliceIdx >= 0 {
 		for i := 0; i < numBuffers; i++ {
 			buf := make([]fermat, fermatSliceSizes[fermatSliceIdx])
@@ -99,6 +106,24 @@ func PreWarmPools(n uint64) {
 	}
 }
 
+// prewarmSizes returns the buffer sizes requested from the pools by the FFT
+// squaring whose product has the size of F(n), the largest multiplication of
+// a doubling calculation of F(n): Fermat values of valueSize+1 words, slices
+// of K coefficients and the K*(valueSize+1) word backing array of the values.
+func prewarmSizes(n uint64) MemoryEstimate {
+	// F(n) has approximately n * log2(phi) bits.
+	words := int((uint64(float64(n)*0.69424) + 63) / 64)
+	k, m := GetFFTParams(words)
+	coeffs := 1 << k
+	valueWords := valueSize(k, m, 2) + 1
+	return MemoryEstimate{
+		MaxWordSliceSize:   coeffs * valueWords,
+		MaxFermatSize:      valueWords,
+		MaxNatSliceSize:    coeffs,
+		MaxFermatSliceSize: coeffs,
+	}
+}
+
 // poolsWarmed tracks whether pools have been pre-warmed.
 // Using sync/atomic for lock-free, thread-safe initialization.
 var poolsWarmed atomic.Bool
@@ -29,6 +29,26 @@ type CalibrationOptions struct {
 	SaveProfile bool
 	// LoadProfile indicates whether to try loading an existing profile.
 	LoadProfile bool
+	// MinN and MaxN, when MaxN is non-zero, calibrate the thresholds of the
+	// indices in [MinN, MaxN] only: the trials compute the middle of the
+	// range and the result is saved as a bucket of the existing profile (see
+	// CalibrationProfile.SetBucket). By default the trials compute
+	// fibonacci.CalibrationN and the result replaces the profile.
+	MinN, MaxN uint64
+	// ReportPath, if set, is the file the CalibrationReport of the run is
+	// written to: as JSON if it ends in ".json", as a table otherwise.
+	ReportPath string
+	// Thresholds lists the candidate parallel thresholds to measure. If
+	// empty, uses GenerateParallelThresholds.
+	Thresholds []int
+}
+
+// calibrationN returns the index computed by the calibration trials.
+func (o CalibrationOptions) calibrationN() uint64 {
+	if o.MaxN == 0 {
+		return fibonacci.CalibrationN
+	}
+	return o.MinN + (o.MaxN-o.MinN)/2
 }
 
 // calibrationResult holds the result of a single threshold test.
@@ -67,7 +87,7 @@ func RunCalibrationWithOptions(ctx context.Context, out io.Writer, calculatorReg
 
 	// Try to load existing profile if requested
 	if opts.LoadProfile {
-		profile, loaded := LoadOrCreateProfile(opts.ProfilePath)
+		profile, loaded := LoadOrCreateProfileWithWarnings(opts.ProfilePath, out)
 		if loaded && profile.IsValid() {
 			fmt.Fprintf(out, "%sLoaded existing calibration profile from %s%s\n",
 				ui.ColorGreen(), GetDefaultProfilePath(), ui.ColorReset())
@@ -84,10 +104,35 @@ func RunCalibrationWithOptions(ctx context.Context, out io.Writer, calculatorReg
 		return apperrors.ExitErrorGeneric
 	}
 
-	// Use adaptive thresholds based on CPU characteristics
-	thresholdsToTest := GenerateParallelThresholds()
-	fmt.Fprintf(out, "%sUsing adaptive thresholds for %d CPU cores%s\n",
-		ui.ColorCyan(), runtime.NumCPU(), ui.ColorReset())
+	if opts.MinN > opts.MaxN {
+		fmt.Fprintf(out, "%sInvalid calibration range: N from %d to %d.%s\n", ui.ColorRed(), opts.MinN, opts.MaxN, ui.ColorReset())
+		return apperrors.ExitErrorConfig
+	}
+	n := opts.calibrationN()
+	if opts.MaxN > 0 {
+		fmt.Fprintf(out, "%sCalibrating for N from %d to %d (trials at N=%d)%s\n",
+			ui.ColorCyan(), opts.MinN, opts.MaxN, n, ui.ColorReset())
+	}
+
+	thresholdsToTest := opts.Thresholds
+	if len(thresholdsToTest) == 0 {
+		// Use adaptive thresholds based on CPU characteristics
+		thresholdsToTest = GenerateParallelThresholds()
+		fmt.Fprintf(out, "%sUsing adaptive thresholds for %d CPU cores%s\n",
+			ui.ColorCyan(), runtime.NumCPU(), ui.ColorReset())
+	}
+
+	// When saving, each measurement is checkpointed to the profile so that
+	// an interrupted sweep resumes where it stopped.
+	var base *CalibrationProfile
+	if opts.SaveProfile {
+		base = openCheckpoint(opts, n, out)
+		if done := len(base.Checkpoint.Completed); done > 0 {
+			fmt.Fprintf(out, "%sResuming interrupted calibration: %d candidate(s) already measured%s\n",
+				ui.ColorCyan(), done, ui.ColorReset())
+		}
+	}
+	checkpointing := base != nil
 
 	results := make([]calibrationResult, 0, len(thresholdsToTest))
 	bestDuration := time.Duration(1<<63 - 1)
@@ -99,32 +144,52 @@ func RunCalibrationWithOptions(ctx context.Context, out io.Writer, calculatorReg
 	wg.Add(1)
 	go progressDisplay(&wg, progressChan, 1, out)
 
-	for _, threshold := range thresholdsToTest {
-		if ctx.Err() != nil {
-			fmt.Fprintf(out, "\n%sCalibration interrupted.%s\n", ui.ColorYellow(), ui.ColorReset())
-			close(progressChan)
-			wg.Wait()
-			return apperrors.ExitErrorCanceled
+	interrupted := func() {
+		close(progressChan)
+		wg.Wait()
+		if checkpointing {
+			fmt.Fprintf(out, "%sProgress saved; run the calibration again to resume.%s\n", ui.ColorYellow(), ui.ColorReset())
 		}
+	}
+
+	for _, threshold := range thresholdsToTest {
+		var res calibrationResult
+		if timing, ok := base.checkpointed(threshold); ok {
+			res = timing.result()
+		} else {
+			if ctx.Err() != nil {
+				fmt.Fprintf(out, "\n%sCalibration interrupted.%s\n", ui.ColorYellow(), ui.ColorReset())
+				interrupted()
+				return apperrors.ExitErrorCanceled
+			}
 
-		startTime := time.Now()
-		_, err := calculator.Calculate(ctx, progressChan, 0, fibonacci.CalibrationN, fibonacci.Options{ParallelThreshold: threshold})
-		duration := time.Since(startTime)
-
-		if err != nil {
-			fmt.Fprintf(out, "%s❌ Failure (%v)%s\n", ui.ColorRed(), err, ui.ColorReset())
-			results = append(results, calibrationResult{threshold, 0, err})
-			if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-				close(progressChan)
-				wg.Wait()
-				return apperrors.HandleCalculationError(err, duration, out, colorProvider)
+			startTime := time.Now()
+			_, err := calculator.Calculate(ctx, progressChan, 0, n, fibonacci.Options{ParallelThreshold: threshold})
+			duration := time.Since(startTime)
+
+			res = calibrationResult{threshold, duration, err}
+			if err != nil {
+				fmt.Fprintf(out, "%s❌ Failure (%v)%s\n", ui.ColorRed(), err, ui.ColorReset())
+				res.Duration = 0
+				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+					interrupted()
+					return apperrors.HandleCalculationError(err, duration, out, colorProvider)
+				}
+			}
+
+			if checkpointing {
+				base.Checkpoint.record(newCandidateTiming(res))
+				if err := base.SaveProfile(opts.ProfilePath); err != nil {
+					fmt.Fprintf(out, "%sWarning: failed to checkpoint calibration: %v%s\n",
+						ui.ColorYellow(), err, ui.ColorReset())
+					checkpointing = false
+				}
 			}
-			continue
 		}
 
-		results = append(results, calibrationResult{threshold, duration, nil})
-		if duration < bestDuration {
-			bestDuration, bestThreshold = duration, threshold
+		results = append(results, res)
+		if res.Err == nil && res.Duration < bestDuration {
+			bestDuration, bestThreshold = res.Duration, threshold
 		}
 	}
 	close(progressChan)
@@ -144,14 +209,40 @@ func RunCalibrationWithOptions(ctx context.Context, out io.Writer, calculatorReg
 	fmt.Fprintf(out, "\n%s✅ Recommendation for this machine: %s--threshold %d%s\n",
 		ui.ColorGreen(), ui.ColorYellow(), bestThreshold, ui.ColorReset())
 
+	if opts.ReportPath != "" {
+		report := newCalibrationReport(n, results, bestThreshold)
+		if err := report.WriteFile(opts.ReportPath); err != nil {
+			fmt.Fprintf(out, "%sWarning: failed to write calibration report: %v%s\n",
+				ui.ColorYellow(), err, ui.ColorReset())
+		} else {
+			fmt.Fprintf(out, "%sCalibration report written to %s%s\n",
+				ui.ColorGreen(), opts.ReportPath, ui.ColorReset())
+		}
+	}
+
 	// Save profile if requested
 	if opts.SaveProfile {
 		profile := NewProfile()
-		profile.OptimalParallelThreshold = bestThreshold
-		profile.OptimalFFTThreshold = config.EstimateOptimalFFTThreshold()
-		profile.OptimalStrassenThreshold = config.EstimateOptimalStrassenThreshold()
-		profile.CalibrationN = fibonacci.CalibrationN
-		profile.CalibrationTime = calibrationDuration.String()
+		if opts.MaxN > 0 {
+			// A bucket refines the existing profile rather than replacing it;
+			// a new profile also takes the bucket's thresholds as its default.
+			profile = base
+			profile.Checkpoint = nil
+			profile.SetBucket(ThresholdBucket{
+				MinN:              opts.MinN,
+				MaxN:              opts.MaxN,
+				ParallelThreshold: bestThreshold,
+				FFTThreshold:      config.EstimateOptimalFFTThreshold(),
+				StrassenThreshold: config.EstimateOptimalStrassenThreshold(),
+			})
+		}
+		if opts.MaxN == 0 || profile.CalibrationN == 0 {
+			profile.OptimalParallelThreshold = bestThreshold
+			profile.OptimalFFTThreshold = config.EstimateOptimalFFTThreshold()
+			profile.OptimalStrassenThreshold = config.EstimateOptimalStrassenThreshold()
+			profile.CalibrationN = n
+			profile.CalibrationTime = calibrationDuration.String()
+		}
 
 		if err := profile.SaveProfile(opts.ProfilePath); err != nil {
 			fmt.Fprintf(out, "%sWarning: failed to save profile: %v%s\n",
@@ -165,6 +256,36 @@ func RunCalibrationWithOptions(ctx context.Context, out io.Writer, calculatorReg
 	return apperrors.ExitSuccess
 }
 
+// openCheckpoint returns the profile the measurements of a sweep are
+// checkpointed to: the profile at opts.ProfilePath if it is valid for the
+// current hardware (see IsValid), keeping its checkpoint if it is for the
+// same sweep so that the sweep resumes, or a new profile.
+//
+// Parameters:
+//   - opts: The calibration options.
+//   - n: The index computed by the trials.
+//   - out: The writer for profile warnings.
+//
+// Returns:
+//   - *CalibrationProfile: The profile, with a non-nil Checkpoint.
+func openCheckpoint(opts CalibrationOptions, n uint64, out io.Writer) *CalibrationProfile {
+	profile, _ := loadTrustedProfile(opts.ProfilePath, out)
+	c := profile.Checkpoint
+	if c == nil || c.N != n || c.MinN != opts.MinN || c.MaxN != opts.MaxN {
+		profile.Checkpoint = &CalibrationCheckpoint{N: n, MinN: opts.MinN, MaxN: opts.MaxN}
+	}
+	return profile
+}
+
+// checkpointed returns the checkpointed measurement of threshold, if any.
+// It is safe to call on a nil profile.
+func (p *CalibrationProfile) checkpointed(threshold int) (CandidateTiming, bool) {
+	if p == nil || p.Checkpoint == nil {
+		return CandidateTiming{}, false
+	}
+	return p.Checkpoint.lookup(threshold)
+}
+
 // AutoCalibrate runs a quick startup calibration to fine-tune performance
 // parameters.
 //
@@ -203,15 +324,24 @@ func AutoCalibrateWithProfile(parentCtx context.Context, cfg config.AppConfig, o
 	}
 
 	// Try to load existing profile first
-	if profile, loaded := LoadOrCreateProfile(profilePath); loaded && profile.IsValid() {
-		// Use cached calibration
+	if profile, loaded := LoadOrCreateProfileWithWarnings(profilePath, out); loaded && profile.IsValid() {
+		// Use cached calibration: the bucket nearest to N if the profile
+		// has any, the learned or profile-wide thresholds otherwise.
 		updated := cfg
-		updated.Threshold = profile.OptimalParallelThreshold
-		updated.FFTThreshold = profile.OptimalFFTThreshold
-		updated.StrassenThreshold = profile.OptimalStrassenThreshold
-
-		fmt.Fprintf(out, "%sUsing cached calibration%s: parallelism=%s%d%s bits, FFT=%s%d%s bits, Strassen=%s%d%s bits\n",
-			ui.ColorGreen(), ui.ColorReset(),
+		source := "cached calibration"
+		if bucket, ok := profile.NearestBucket(cfg.N); ok {
+			updated.Threshold, updated.FFTThreshold, updated.StrassenThreshold = bucket.ParallelThreshold, bucket.FFTThreshold, bucket.StrassenThreshold
+			source = fmt.Sprintf("cached calibration for N from %d to %d", bucket.MinN, bucket.MaxN)
+		} else {
+			var learned bool
+			updated.Threshold, updated.FFTThreshold, learned = profile.LearnedOrOptimal()
+			updated.StrassenThreshold = profile.OptimalStrassenThreshold
+			if learned {
+				source = "cached calibration with learned thresholds"
+			}
+		}
+		fmt.Fprintf(out, "%sUsing %s%s: parallelism=%s%d%s bits, FFT=%s%d%s bits, Strassen=%s%d%s bits\n",
+			ui.ColorGreen(), source, ui.ColorReset(),
 			ui.ColorYellow(), updated.Threshold, ui.ColorReset(),
 			ui.ColorYellow(), updated.FFTThreshold, ui.ColorReset(),
 			ui.ColorYellow(), updated.StrassenThreshold, ui.ColorReset())
@@ -267,18 +397,23 @@ func AutoCalibrateWithProfile(parentCtx context.Context, cfg config.AppConfig, o
 }
 
 // LoadCachedCalibration attempts to load a cached calibration profile and
-// apply it to the configuration. Returns the updated config and true if
+// apply its thresholds for cfg.N to the configuration (see
+// CalibrationProfile.ThresholdsFor). Returns the updated config and true if
 // a valid cached profile was found.
 func LoadCachedCalibration(cfg config.AppConfig, profilePath string) (updated config.AppConfig, ok bool) {
-	profile, loaded := LoadOrCreateProfile(profilePath)
+	return LoadCachedCalibrationWithWarnings(cfg, profilePath, io.Discard)
+}
+
+// LoadCachedCalibrationWithWarnings is LoadCachedCalibration with profile
+// checksum warnings written to warn.
+func LoadCachedCalibrationWithWarnings(cfg config.AppConfig, profilePath string, warn io.Writer) (updated config.AppConfig, ok bool) {
+	profile, loaded := LoadOrCreateProfileWithWarnings(profilePath, warn)
 	if !loaded || !profile.IsValid() {
 		return cfg, false
 	}
 
 	updated = cfg
-	updated.Threshold = profile.OptimalParallelThreshold
-	updated.FFTThreshold = profile.OptimalFFTThreshold
-	updated.StrassenThreshold = profile.OptimalStrassenThreshold
+	updated.Threshold, updated.FFTThreshold, updated.StrassenThreshold = profile.ThresholdsFor(cfg.N)
 	return updated, true
 }
 
@@ -2,16 +2,19 @@ package calibration
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"io"
 	"math/big"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/agbru/fibcalc/internal/config"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
 	"github.com/agbru/fibcalc/internal/fibonacci"
 	"github.com/agbru/fibcalc/internal/progress"
 )
@@ -37,6 +40,17 @@ func (m *MockBlockingCalculator) Calculate(ctx context.Context, progressChan cha
 	return big.NewInt(1), nil
 }
 
+// recordingCalculator records the index of each calculation.
+type recordingCalculator struct {
+	calls *[]uint64
+}
+
+func (m *recordingCalculator) Name() string { return "recording" }
+func (m *recordingCalculator) Calculate(ctx context.Context, progressChan chan<- progress.ProgressUpdate, calcIndex int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+	*m.calls = append(*m.calls, n)
+	return big.NewInt(1), nil
+}
+
 func TestRunCalibrationWithOptions_LoadProfile(t *testing.T) {
 	tmpDir := t.TempDir()
 	profilePath := filepath.Join(tmpDir, "profile.json")
@@ -63,6 +77,187 @@ func TestRunCalibrationWithOptions_LoadProfile(t *testing.T) {
 	}
 }
 
+// TestRunCalibrationWithOptions_Bucket verifies that calibrating a range of
+// indices adds a bucket to the existing profile and exports the report.
+func TestRunCalibrationWithOptions_Bucket(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	profilePath := filepath.Join(tmpDir, "profile.json")
+	reportPath := filepath.Join(tmpDir, "report.json")
+
+	profile := NewProfile()
+	profile.OptimalParallelThreshold = 1234
+	profile.CalibrationN = fibonacci.CalibrationN
+	if err := profile.SaveProfile(profilePath); err != nil {
+		t.Fatalf("Failed to save profile: %v", err)
+	}
+
+	var calls []uint64
+	calc := &recordingCalculator{calls: &calls}
+	registry := map[string]fibonacci.Calculator{"fast": calc}
+	opts := CalibrationOptions{
+		ProfilePath: profilePath,
+		SaveProfile: true,
+		MinN:        1000,
+		MaxN:        3000,
+		ReportPath:  reportPath,
+	}
+	if code := RunCalibrationWithOptions(context.Background(), io.Discard, registry, opts, noopProgressDisplay, noopColorProvider{}); code != 0 {
+		t.Fatalf("Expected exit code 0, got %d", code)
+	}
+	for _, n := range calls {
+		if n != 2000 {
+			t.Fatalf("trial computed N=%d, want the middle of the range 2000", n)
+		}
+	}
+
+	loaded, err := loadProfile(profilePath)
+	if err != nil {
+		t.Fatalf("loadProfile failed: %v", err)
+	}
+	if loaded.OptimalParallelThreshold != 1234 {
+		t.Errorf("OptimalParallelThreshold = %d, the profile-wide thresholds should be kept", loaded.OptimalParallelThreshold)
+	}
+	if len(loaded.Buckets) != 1 || loaded.Buckets[0].MinN != 1000 || loaded.Buckets[0].MaxN != 3000 {
+		t.Errorf("Buckets = %+v, want one bucket for N from 1000 to 3000", loaded.Buckets)
+	}
+
+	data, err := os.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var report CalibrationReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("report is not JSON: %v", err)
+	}
+	if report.N != 2000 || len(report.Candidates) != len(calls) {
+		t.Errorf("report N=%d with %d candidates, want N=2000 with %d", report.N, len(report.Candidates), len(calls))
+	}
+}
+
+func TestRunCalibrationWithOptions_InvalidRange(t *testing.T) {
+	t.Parallel()
+	registry := map[string]fibonacci.Calculator{"fast": &MockCalculator{name: "fast"}}
+	opts := CalibrationOptions{MinN: 3000, MaxN: 1000}
+	if code := RunCalibrationWithOptions(context.Background(), io.Discard, registry, opts, noopProgressDisplay, noopColorProvider{}); code != apperrors.ExitErrorConfig {
+		t.Errorf("Expected exit code %d, got %d", apperrors.ExitErrorConfig, code)
+	}
+}
+
+// interruptingCalculator records the threshold of each calculation and
+// cancels the calibration during its stopAt-th one, as Ctrl+C would.
+type interruptingCalculator struct {
+	calls  []int
+	stopAt int
+	cancel context.CancelFunc
+}
+
+func (m *interruptingCalculator) Name() string { return "interrupting" }
+func (m *interruptingCalculator) Calculate(ctx context.Context, progressChan chan<- progress.ProgressUpdate, calcIndex int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+	m.calls = append(m.calls, opts.ParallelThreshold)
+	if len(m.calls) == m.stopAt {
+		m.cancel()
+		return nil, ctx.Err()
+	}
+	return big.NewInt(1), nil
+}
+
+// TestRunCalibrationWithOptions_Resume interrupts a sweep after two of four
+// candidates and verifies that the next run measures only the other two.
+func TestRunCalibrationWithOptions_Resume(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+	profilePath := filepath.Join(tmpDir, "profile.json")
+	opts := CalibrationOptions{
+		ProfilePath: profilePath,
+		SaveProfile: true,
+		Thresholds:  []int{0, 1024, 2048, 4096},
+		ReportPath:  filepath.Join(tmpDir, "report.json"),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	first := &interruptingCalculator{stopAt: 3, cancel: cancel}
+	registry := map[string]fibonacci.Calculator{"fast": first}
+	if code := RunCalibrationWithOptions(ctx, io.Discard, registry, opts, noopProgressDisplay, noopColorProvider{}); code != apperrors.ExitErrorCanceled {
+		t.Fatalf("interrupted run: exit code %d, want %d", code, apperrors.ExitErrorCanceled)
+	}
+
+	partial, err := loadProfile(profilePath)
+	if err != nil {
+		t.Fatalf("no checkpoint saved: %v", err)
+	}
+	if !partial.IsPartial() || partial.Checkpoint.N != fibonacci.CalibrationN {
+		t.Fatalf("profile after interruption = %+v, want a partial profile", partial)
+	}
+	var done []int
+	for _, c := range partial.Checkpoint.Completed {
+		done = append(done, c.Threshold)
+	}
+	if !slices.Equal(done, []int{0, 1024}) {
+		t.Errorf("checkpointed candidates = %v, want [0 1024]", done)
+	}
+	if _, loaded := LoadOrCreateProfile(profilePath); loaded {
+		t.Error("a partial profile should not provide thresholds")
+	}
+
+	second := &interruptingCalculator{}
+	registry = map[string]fibonacci.Calculator{"fast": second}
+	var out strings.Builder
+	if code := RunCalibrationWithOptions(context.Background(), &out, registry, opts, noopProgressDisplay, noopColorProvider{}); code != apperrors.ExitSuccess {
+		t.Fatalf("resumed run: exit code %d, want 0. Output:\n%s", code, out.String())
+	}
+	if !slices.Equal(second.calls, []int{2048, 4096}) {
+		t.Errorf("resumed run measured %v, want only the remaining [2048 4096]", second.calls)
+	}
+	if !strings.Contains(out.String(), "2 candidate(s) already measured") {
+		t.Errorf("output should mention the resumption:\n%s", out.String())
+	}
+
+	complete, err := loadProfile(profilePath)
+	if err != nil {
+		t.Fatalf("loadProfile failed: %v", err)
+	}
+	if complete.Checkpoint != nil || complete.IsPartial() || complete.CalibrationN != fibonacci.CalibrationN {
+		t.Errorf("profile after completion = %+v, want a complete profile without checkpoint", complete)
+	}
+	data, err := os.ReadFile(opts.ReportPath)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var report CalibrationReport
+	if err := json.Unmarshal(data, &report); err != nil || len(report.Candidates) != 4 {
+		t.Errorf("report should list all 4 candidates (error %v):\n%s", err, data)
+	}
+}
+
+// TestRunCalibrationWithOptions_ResumeOtherHardware verifies that a
+// checkpoint saved on other hardware is not resumed.
+func TestRunCalibrationWithOptions_ResumeOtherHardware(t *testing.T) {
+	t.Parallel()
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+	profile := NewProfile()
+	profile.NumCPU++
+	profile.Checkpoint = &CalibrationCheckpoint{
+		N:         fibonacci.CalibrationN,
+		Completed: []CandidateTiming{{Threshold: 0, DurationNs: 1}, {Threshold: 1024, DurationNs: 1}},
+	}
+	if err := profile.SaveProfile(profilePath); err != nil {
+		t.Fatalf("Failed to save profile: %v", err)
+	}
+
+	calc := &interruptingCalculator{}
+	registry := map[string]fibonacci.Calculator{"fast": calc}
+	opts := CalibrationOptions{ProfilePath: profilePath, SaveProfile: true, Thresholds: []int{0, 1024, 2048}}
+	if code := RunCalibrationWithOptions(context.Background(), io.Discard, registry, opts, noopProgressDisplay, noopColorProvider{}); code != apperrors.ExitSuccess {
+		t.Fatalf("exit code %d, want 0", code)
+	}
+	if !slices.Equal(calc.calls, opts.Thresholds) {
+		t.Errorf("measured %v, want every candidate %v", calc.calls, opts.Thresholds)
+	}
+}
+
 func TestRunCalibrationWithOptions_CalculationError(t *testing.T) {
 	registry := map[string]fibonacci.Calculator{
 		"fast": &MockFailingCalculator{},
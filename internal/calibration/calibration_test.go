@@ -6,6 +6,7 @@ import (
 	"io"
 	"math/big"
 	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"github.com/agbru/fibcalc/internal/config"
 	apperrors "github.com/agbru/fibcalc/internal/errors"
 	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
 	"github.com/agbru/fibcalc/internal/progress"
 )
 
@@ -188,6 +190,27 @@ func TestLoadCachedCalibration(t *testing.T) {
 		}
 	})
 
+	t.Run("Bucket nearest to N", func(t *testing.T) {
+		t.Parallel()
+		profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+		profile := NewProfile()
+		profile.OptimalParallelThreshold = 4096
+		profile.SetBucket(ThresholdBucket{MinN: 1000, MaxN: 10_000, ParallelThreshold: 1024, FFTThreshold: 250_000, StrassenThreshold: 128})
+		if err := profile.SaveProfile(profilePath); err != nil {
+			t.Fatalf("Failed to save profile: %v", err)
+		}
+
+		updated, success := LoadCachedCalibration(config.AppConfig{N: 5000}, profilePath)
+		if !success {
+			t.Fatal("Should return true for valid profile")
+		}
+		if updated.Threshold != 1024 || updated.FFTThreshold != 250_000 || updated.StrassenThreshold != 128 {
+			t.Errorf("thresholds = (%d, %d, %d), want the bucket's (1024, 250000, 128)",
+				updated.Threshold, updated.FFTThreshold, updated.StrassenThreshold)
+		}
+	})
+
 	t.Run("Invalid profile", func(t *testing.T) {
 		t.Parallel()
 		tmpDir := t.TempDir()
@@ -247,6 +270,69 @@ func TestAutoCalibrateWithProfile(t *testing.T) {
 		}
 	})
 
+	t.Run("Seed from learned thresholds", func(t *testing.T) {
+		t.Parallel()
+		profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+		profile := NewProfile()
+		profile.OptimalParallelThreshold = 4096
+		profile.OptimalFFTThreshold = 1000000
+		profile.OptimalStrassenThreshold = 256
+		if err := profile.SaveProfile(profilePath); err != nil {
+			t.Fatalf("Failed to save profile: %v", err)
+		}
+		dtm := threshold.NewDynamicThresholdManager(750000, 8192)
+		if err := SaveLearnedThresholds(profilePath, dtm.Learned()); err != nil {
+			t.Fatalf("SaveLearnedThresholds failed: %v", err)
+		}
+
+		registry := map[string]fibonacci.Calculator{
+			"fast": &MockCalculator{name: "fast"},
+		}
+		var outBuf bytes.Buffer
+		updated, ok := AutoCalibrateWithProfile(context.Background(), config.AppConfig{Timeout: time.Second}, &outBuf, registry, profilePath)
+		if !ok {
+			t.Fatalf("AutoCalibrateWithProfile should use the profile. Output: %s", outBuf.String())
+		}
+		if updated.Threshold != 8192 || updated.FFTThreshold != 750000 || updated.StrassenThreshold != 256 {
+			t.Errorf("thresholds = (%d, %d, %d), want learned (8192, 750000) and calibrated Strassen 256",
+				updated.Threshold, updated.FFTThreshold, updated.StrassenThreshold)
+		}
+		if !strings.Contains(outBuf.String(), "learned thresholds") {
+			t.Errorf("Output should mention the learned thresholds. Got: %s", outBuf.String())
+		}
+	})
+
+	t.Run("Select the bucket nearest to N", func(t *testing.T) {
+		t.Parallel()
+		profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+		profile := NewProfile()
+		profile.OptimalParallelThreshold = 4096
+		profile.SetBucket(ThresholdBucket{MinN: 1000, MaxN: 10_000, ParallelThreshold: 1024, FFTThreshold: 250_000, StrassenThreshold: 128})
+		profile.SetBucket(ThresholdBucket{MinN: 1_000_000, MaxN: 10_000_000, ParallelThreshold: 8192, FFTThreshold: 750_000, StrassenThreshold: 512})
+		if err := profile.SaveProfile(profilePath); err != nil {
+			t.Fatalf("Failed to save profile: %v", err)
+		}
+
+		registry := map[string]fibonacci.Calculator{
+			"fast": &MockCalculator{name: "fast"},
+		}
+		var outBuf bytes.Buffer
+		cfg := config.AppConfig{N: 20_000_000, Timeout: time.Second}
+		updated, ok := AutoCalibrateWithProfile(context.Background(), cfg, &outBuf, registry, profilePath)
+		if !ok {
+			t.Fatalf("AutoCalibrateWithProfile should use the profile. Output: %s", outBuf.String())
+		}
+		if updated.Threshold != 8192 || updated.FFTThreshold != 750_000 || updated.StrassenThreshold != 512 {
+			t.Errorf("thresholds = (%d, %d, %d), want the second bucket (8192, 750000, 512)",
+				updated.Threshold, updated.FFTThreshold, updated.StrassenThreshold)
+		}
+		if !strings.Contains(outBuf.String(), "for N from 1000000 to 10000000") {
+			t.Errorf("Output should mention the selected bucket. Got: %s", outBuf.String())
+		}
+	})
+
 	t.Run("Quick calibration fallback", func(t *testing.T) {
 		t.Parallel()
 		tmpDir := t.TempDir()
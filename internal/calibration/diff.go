@@ -0,0 +1,154 @@
+package calibration
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"text/tabwriter"
+)
+
+// ProfileField is one field of two compared calibration profiles, named
+// after its key in the profile file.
+type ProfileField struct {
+	Name string
+	A, B string
+}
+
+// Differs reports whether the two profiles disagree on the field.
+func (f ProfileField) Differs() bool {
+	return f.A != f.B
+}
+
+// ProfileDiff is the comparison of the hardware descriptors and thresholds
+// of two calibration profiles (see DiffProfiles).
+type ProfileDiff struct {
+	// Fields lists every compared field, hardware descriptors first.
+	Fields []ProfileField
+}
+
+// DiffProfiles compares the hardware descriptors and thresholds of two
+// calibration profiles, e.g. calibrated on different machines.
+//
+// Parameters:
+//   - a: The first profile; must not be nil.
+//   - b: The second profile; must not be nil.
+//
+// Returns:
+//   - ProfileDiff: The compared fields.
+func DiffProfiles(a, b *CalibrationProfile) ProfileDiff {
+	field := func(name string, get func(p *CalibrationProfile) any) ProfileField {
+		return ProfileField{Name: name, A: fmt.Sprint(get(a)), B: fmt.Sprint(get(b))}
+	}
+	fields := []ProfileField{
+		field("cpu_model", func(p *CalibrationProfile) any { return p.CPUModel }),
+		field("num_cpu", func(p *CalibrationProfile) any { return p.NumCPU }),
+		field("goarch", func(p *CalibrationProfile) any { return p.GOARCH }),
+		field("goos", func(p *CalibrationProfile) any { return p.GOOS }),
+		field("go_version", func(p *CalibrationProfile) any { return p.GoVersion }),
+		field("word_size", func(p *CalibrationProfile) any { return p.WordSize }),
+		field("optimal_parallel_threshold", func(p *CalibrationProfile) any { return p.OptimalParallelThreshold }),
+		field("optimal_fft_threshold", func(p *CalibrationProfile) any { return p.OptimalFFTThreshold }),
+		field("optimal_strassen_threshold", func(p *CalibrationProfile) any { return p.OptimalStrassenThreshold }),
+		field("learned_parallel_threshold", func(p *CalibrationProfile) any { return p.LearnedParallelThreshold }),
+		field("learned_fft_threshold", func(p *CalibrationProfile) any { return p.LearnedFFTThreshold }),
+		field("calibration_n", func(p *CalibrationProfile) any { return p.CalibrationN }),
+	}
+	return ProfileDiff{Fields: append(fields, diffBuckets(a.Buckets, b.Buckets)...)}
+}
+
+// diffBuckets compares the buckets of two profiles range by range: each
+// range calibrated in either profile is one field, named after the range,
+// with the thresholds of both profiles or "-" where a profile lacks it.
+func diffBuckets(a, b []ThresholdBucket) []ProfileField {
+	type bucketRange struct{ minN, maxN uint64 }
+	thresholds := func(buckets []ThresholdBucket) map[bucketRange]string {
+		m := make(map[bucketRange]string, len(buckets))
+		for _, bk := range buckets {
+			m[bucketRange{bk.MinN, bk.MaxN}] = fmt.Sprintf("parallel=%d fft=%d strassen=%d",
+				bk.ParallelThreshold, bk.FFTThreshold, bk.StrassenThreshold)
+		}
+		return m
+	}
+	inA, inB := thresholds(a), thresholds(b)
+
+	var ranges []bucketRange
+	for r := range inA {
+		ranges = append(ranges, r)
+	}
+	for r := range inB {
+		if _, ok := inA[r]; !ok {
+			ranges = append(ranges, r)
+		}
+	}
+	slices.SortFunc(ranges, func(x, y bucketRange) int {
+		if c := cmp.Compare(x.minN, y.minN); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.maxN, y.maxN)
+	})
+
+	fields := make([]ProfileField, 0, len(ranges))
+	for _, r := range ranges {
+		f := ProfileField{Name: fmt.Sprintf("bucket %d-%d", r.minN, r.maxN), A: "-", B: "-"}
+		if s, ok := inA[r]; ok {
+			f.A = s
+		}
+		if s, ok := inB[r]; ok {
+			f.B = s
+		}
+		fields = append(fields, f)
+	}
+	return fields
+}
+
+// DiffProfileFiles loads two profile files and compares them with
+// DiffProfiles. The profiles are compared as saved, whatever the current
+// hardware.
+//
+// Returns:
+//   - ProfileDiff: The compared fields.
+//   - error: An error if either file cannot be read or parsed.
+func DiffProfileFiles(pathA, pathB string) (ProfileDiff, error) {
+	a, err := loadProfile(pathA)
+	if err != nil {
+		return ProfileDiff{}, fmt.Errorf("%s: %w", pathA, err)
+	}
+	b, err := loadProfile(pathB)
+	if err != nil {
+		return ProfileDiff{}, fmt.Errorf("%s: %w", pathB, err)
+	}
+	return DiffProfiles(a, b), nil
+}
+
+// Differences returns the fields the two profiles disagree on.
+func (d ProfileDiff) Differences() []ProfileField {
+	return slices.DeleteFunc(slices.Clone(d.Fields), func(f ProfileField) bool { return !f.Differs() })
+}
+
+// Empty reports whether the two profiles agree on every compared field.
+func (d ProfileDiff) Empty() bool {
+	return !slices.ContainsFunc(d.Fields, ProfileField.Differs)
+}
+
+// String renders the fields side by side, marking the differences with a
+// "*" in the first column.
+func (d ProfileDiff) String() string {
+	var sb strings.Builder
+	tw := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, " \tField\tA\tB")
+	for _, f := range d.Fields {
+		mark := " "
+		if f.Differs() {
+			mark = "*"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", mark, f.Name, f.A, f.B)
+	}
+	tw.Flush()
+	if d.Empty() {
+		sb.WriteString("\nThe profiles are identical.\n")
+	} else {
+		fmt.Fprintf(&sb, "\n%d field(s) differ.\n", len(d.Differences()))
+	}
+	return sb.String()
+}
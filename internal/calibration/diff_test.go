@@ -0,0 +1,110 @@
+package calibration
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDiffProfilesIdentical(t *testing.T) {
+	t.Parallel()
+	a := NewProfile()
+	a.OptimalFFTThreshold = 500_000
+	b := *a
+
+	diff := DiffProfiles(a, &b)
+	if !diff.Empty() || len(diff.Differences()) != 0 {
+		t.Errorf("identical profiles differ on %+v", diff.Differences())
+	}
+	if len(diff.Fields) == 0 {
+		t.Error("Fields should list the compared fields")
+	}
+	if s := diff.String(); !strings.Contains(s, "identical") || strings.Contains(s, "*") {
+		t.Errorf("String() of an empty diff:\n%s", s)
+	}
+}
+
+func TestDiffProfilesDiffering(t *testing.T) {
+	t.Parallel()
+	laptop := NewProfile()
+	laptop.NumCPU = 8
+	laptop.OptimalFFTThreshold = 500_000
+	server := *laptop
+	server.NumCPU = 64
+	server.OptimalFFTThreshold = 1_000_000
+
+	diff := DiffProfiles(laptop, &server)
+	want := []ProfileField{
+		{Name: "num_cpu", A: "8", B: "64"},
+		{Name: "optimal_fft_threshold", A: "500000", B: "1000000"},
+	}
+	if diff.Empty() || !slices.Equal(diff.Differences(), want) {
+		t.Errorf("Differences() = %+v, want %+v", diff.Differences(), want)
+	}
+
+	s := diff.String()
+	for _, line := range strings.Split(s, "\n") {
+		differs := strings.Contains(line, "num_cpu") || strings.Contains(line, "optimal_fft_threshold")
+		if strings.HasPrefix(line, "*") != differs {
+			t.Errorf("line %q: marked=%v, want %v", line, strings.HasPrefix(line, "*"), differs)
+		}
+	}
+	if !strings.Contains(s, "2 field(s) differ") {
+		t.Errorf("String() should count the differences:\n%s", s)
+	}
+}
+
+func TestDiffProfilesBuckets(t *testing.T) {
+	t.Parallel()
+	a := NewProfile()
+	a.SetBucket(ThresholdBucket{MinN: 1_000, MaxN: 10_000, ParallelThreshold: 4096, FFTThreshold: 500_000})
+	a.SetBucket(ThresholdBucket{MinN: 10_001, MaxN: 100_000, ParallelThreshold: 4096, FFTThreshold: 500_000})
+	b := NewProfile()
+	b.SetBucket(ThresholdBucket{MinN: 1_000, MaxN: 10_000, ParallelThreshold: 4096, FFTThreshold: 500_000})
+	b.SetBucket(ThresholdBucket{MinN: 10_001, MaxN: 100_000, ParallelThreshold: 8192, FFTThreshold: 500_000})
+
+	// Same number of buckets, different thresholds in the second one.
+	want := []ProfileField{{
+		Name: "bucket 10001-100000",
+		A:    "parallel=4096 fft=500000 strassen=0",
+		B:    "parallel=8192 fft=500000 strassen=0",
+	}}
+	if got := DiffProfiles(a, b).Differences(); !slices.Equal(got, want) {
+		t.Errorf("Differences() = %+v, want %+v", got, want)
+	}
+
+	// A range calibrated in only one profile.
+	b.SetBucket(ThresholdBucket{MinN: 100_001, MaxN: 1_000_000, StrassenThreshold: 256})
+	got := DiffProfiles(a, b).Differences()
+	if len(got) != 2 || got[1] != (ProfileField{Name: "bucket 100001-1000000", A: "-", B: "parallel=0 fft=0 strassen=256"}) {
+		t.Errorf("Differences() = %+v, want the 100001-1000000 bucket missing from a", got)
+	}
+}
+
+func TestDiffProfileFiles(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	a, b := filepath.Join(dir, "a.json"), filepath.Join(dir, "b.json")
+	profile := NewProfile()
+	if err := profile.SaveProfile(a); err != nil {
+		t.Fatal(err)
+	}
+	profile.OptimalParallelThreshold = 2048
+	if err := profile.SaveProfile(b); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffProfileFiles(a, b)
+	if err != nil {
+		t.Fatalf("DiffProfileFiles() error = %v", err)
+	}
+	if d := diff.Differences(); len(d) != 1 || d[0].Name != "optimal_parallel_threshold" {
+		t.Errorf("Differences() = %+v", d)
+	}
+
+	missing := filepath.Join(dir, "missing.json")
+	if _, err := DiffProfileFiles(a, missing); err == nil || !strings.Contains(err.Error(), missing) {
+		t.Errorf("DiffProfileFiles() with a missing file: error = %v, want one naming it", err)
+	}
+}
@@ -3,12 +3,21 @@
 package calibration
 
 import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sync"
 	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
 )
 
 // CalibrationProfile stores the results of a calibration run.
@@ -35,8 +44,98 @@ type CalibrationProfile struct {
 
 	// Version for forward compatibility
 	ProfileVersion int `json:"profile_version"`
+
+	// Checksum detects hand edits of the fields above (see ComputeChecksum).
+	// Profiles written before it existed have no checksum.
+	Checksum string `json:"checksum,omitempty"`
+
+	// Thresholds learned at runtime by the dynamic threshold manager and
+	// recorded by SaveLearnedThresholds. They are covered by the checksum
+	// when set, and then take precedence over the calibrated values (see
+	// LearnedOrOptimal).
+	LearnedFFTThreshold      int       `json:"learned_fft_threshold,omitempty"`
+	LearnedParallelThreshold int       `json:"learned_parallel_threshold,omitempty"`
+	LearnedAt                time.Time `json:"learned_at,omitzero"`
+
+	// Buckets holds thresholds calibrated for specific ranges of indices,
+	// sorted by MinN (see SetBucket and ThresholdsFor). Profiles without
+	// buckets use the thresholds above for every N.
+	Buckets []ThresholdBucket `json:"buckets,omitempty"`
+
+	// Checkpoint records the candidates already measured by an interrupted
+	// calibration sweep, so that the next run resumes it (see
+	// RunCalibrationWithOptions). It is not covered by the checksum and is
+	// cleared when the sweep completes.
+	Checkpoint *CalibrationCheckpoint `json:"checkpoint,omitempty"`
+}
+
+// CalibrationCheckpoint is the progress of a calibration sweep.
+type CalibrationCheckpoint struct {
+	// N, MinN and MaxN identify the sweep: the index computed by the trials
+	// and the range of the bucket being calibrated, if any (see
+	// CalibrationOptions).
+	N    uint64 `json:"n"`
+	MinN uint64 `json:"min_n,omitempty"`
+	MaxN uint64 `json:"max_n,omitempty"`
+	// Completed lists the candidates measured so far, in the order they were
+	// run.
+	Completed []CandidateTiming `json:"completed"`
+}
+
+// record adds the measurement of a candidate, replacing any previous
+// measurement of the same threshold so that resuming is idempotent.
+func (c *CalibrationCheckpoint) record(timing CandidateTiming) {
+	i := slices.IndexFunc(c.Completed, func(t CandidateTiming) bool { return t.Threshold == timing.Threshold })
+	if i >= 0 {
+		c.Completed[i] = timing
+		return
+	}
+	c.Completed = append(c.Completed, timing)
+}
+
+// lookup returns the measurement of threshold, if it was completed.
+func (c *CalibrationCheckpoint) lookup(threshold int) (CandidateTiming, bool) {
+	i := slices.IndexFunc(c.Completed, func(t CandidateTiming) bool { return t.Threshold == threshold })
+	if i < 0 {
+		return CandidateTiming{}, false
+	}
+	return c.Completed[i], true
 }
 
+// ThresholdBucket stores the thresholds calibrated for a range of indices,
+// for workloads clustered around specific sizes.
+type ThresholdBucket struct {
+	// MinN and MaxN bound the indices the bucket applies to, inclusive.
+	MinN uint64 `json:"min_n"`
+	MaxN uint64 `json:"max_n"`
+
+	ParallelThreshold int `json:"parallel_threshold"`
+	FFTThreshold      int `json:"fft_threshold"`
+	StrassenThreshold int `json:"strassen_threshold"`
+}
+
+// distance returns how far n lies outside the bucket's range, 0 if inside.
+func (b ThresholdBucket) distance(n uint64) uint64 {
+	switch {
+	case n < b.MinN:
+		return b.MinN - n
+	case n > b.MaxN:
+		return n - b.MaxN
+	default:
+		return 0
+	}
+}
+
+var (
+	// ErrProfileChecksumMissing is returned by VerifyChecksum for legacy
+	// profiles saved without a checksum.
+	ErrProfileChecksumMissing = errors.New("calibration profile has no checksum")
+
+	// ErrProfileChecksumMismatch is returned by VerifyChecksum when the
+	// profile was modified after it was saved.
+	ErrProfileChecksumMismatch = errors.New("calibration profile checksum mismatch")
+)
+
 const (
 	// CurrentProfileVersion is the current version of the profile format.
 	// Increment this when making breaking changes to the profile structure.
@@ -99,25 +198,188 @@ func loadProfile(path string) (*CalibrationProfile, error) {
 	return &profile, nil
 }
 
-// SaveProfile saves the calibration profile to the specified path.
-// If path is empty, uses the default profile path.
+// ComputeChecksum returns the SHA-256 checksum, in hex, of the profile's
+// hardware identification, thresholds and calibration metadata. The Checksum
+// field itself is not covered. The buckets and learned thresholds are covered
+// only when present, so that the checksums of profiles saved before they
+// existed still match.
+func (p *CalibrationProfile) ComputeChecksum() string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%q|%d|%q|%q|%q|%d|%d|%d|%d|%s|%d|%q|%d",
+		p.CPUModel, p.NumCPU, p.GOARCH, p.GOOS, p.GoVersion, p.WordSize,
+		p.OptimalParallelThreshold, p.OptimalFFTThreshold, p.OptimalStrassenThreshold,
+		p.CalibratedAt.UTC().Format(time.RFC3339Nano), p.CalibrationN, p.CalibrationTime,
+		p.ProfileVersion)
+	for _, b := range p.Buckets {
+		fmt.Fprintf(h, "|%d-%d:%d,%d,%d", b.MinN, b.MaxN, b.ParallelThreshold, b.FFTThreshold, b.StrassenThreshold)
+	}
+	if p.LearnedFFTThreshold != 0 || p.LearnedParallelThreshold != 0 || !p.LearnedAt.IsZero() {
+		fmt.Fprintf(h, "|learned:%d,%d,%s", p.LearnedFFTThreshold, p.LearnedParallelThreshold,
+			p.LearnedAt.UTC().Format(time.RFC3339Nano))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// VerifyChecksum checks the stored checksum against the profile's fields.
+// It returns ErrProfileChecksumMissing for legacy profiles without a
+// checksum and ErrProfileChecksumMismatch if the profile was edited.
+func (p *CalibrationProfile) VerifyChecksum() error {
+	if p.Checksum == "" {
+		return ErrProfileChecksumMissing
+	}
+	if p.Checksum != p.ComputeChecksum() {
+		return ErrProfileChecksumMismatch
+	}
+	return nil
+}
+
+// profileMu serializes the profile writes of the process, so that the
+// read-modify-write of SaveLearnedThresholds does not lose a concurrent save.
+var profileMu sync.Mutex
+
+// SaveProfile saves the calibration profile to the specified path, updating
+// its Checksum first. If path is empty, uses the default profile path. The
+// file is replaced atomically.
 func (p *CalibrationProfile) SaveProfile(path string) error {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	return p.save(path)
+}
+
+// save implements SaveProfile; the caller holds profileMu.
+func (p *CalibrationProfile) save(path string) error {
 	if path == "" {
 		path = GetDefaultProfilePath()
 	}
 
+	p.Checksum = p.ComputeChecksum()
+
 	data, err := json.MarshalIndent(p, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal profile: %w", err)
 	}
 
-	if err := os.WriteFile(path, data, 0600); err != nil {
+	// Write a temporary file and rename it, so that readers never see a
+	// partially written profile
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
 		return fmt.Errorf("failed to write profile: %w", err)
 	}
 
 	return nil
 }
 
+// SaveLearnedThresholds records thresholds learned at runtime in the
+// calibration profile at path, updating its checksum, so that
+// --auto-calibrate starts from them. The profile must exist and be valid for
+// the current hardware: learned thresholds refine a calibration, they do not
+// replace one.
+//
+// Parameters:
+//   - path: The profile path, or "" for the default one.
+//   - learned: The thresholds, e.g. from DynamicThresholdManager.Learned.
+//
+// Returns:
+//   - error: An error if there is no valid profile at path or it cannot be
+//     written.
+func SaveLearnedThresholds(path string, learned threshold.LearnedThresholds) error {
+	if path == "" {
+		path = GetDefaultProfilePath()
+	}
+	profileMu.Lock()
+	defer profileMu.Unlock()
+	profile, loaded := loadTrustedProfile(path, io.Discard)
+	if !loaded {
+		return fmt.Errorf("no valid calibration profile at %s; run --calibrate first", path)
+	}
+	profile.LearnedFFTThreshold = learned.FFTThreshold
+	profile.LearnedParallelThreshold = learned.ParallelThreshold
+	profile.LearnedAt = learned.LearnedAt
+	return profile.save(path)
+}
+
+// LearnedOrOptimal returns the thresholds to start from: the learned ones
+// where present, the calibrated ones otherwise.
+//
+// Returns:
+//   - parallel: The parallel threshold in bits.
+//   - fft: The FFT threshold in bits.
+//   - learned: Whether any learned value was used.
+func (p *CalibrationProfile) LearnedOrOptimal() (parallel, fft int, learned bool) {
+	parallel, fft = p.OptimalParallelThreshold, p.OptimalFFTThreshold
+	if p.LearnedParallelThreshold > 0 {
+		parallel, learned = p.LearnedParallelThreshold, true
+	}
+	if p.LearnedFFTThreshold > 0 {
+		fft, learned = p.LearnedFFTThreshold, true
+	}
+	return parallel, fft, learned
+}
+
+// SetBucket adds b to the profile's buckets, replacing any bucket with the
+// same range, and keeps the buckets sorted by MinN.
+func (p *CalibrationProfile) SetBucket(b ThresholdBucket) {
+	i, found := slices.BinarySearchFunc(p.Buckets, b, func(e, t ThresholdBucket) int {
+		return cmp.Or(cmp.Compare(e.MinN, t.MinN), cmp.Compare(e.MaxN, t.MaxN))
+	})
+	if found {
+		p.Buckets[i] = b
+		return
+	}
+	p.Buckets = slices.Insert(p.Buckets, i, b)
+}
+
+// NearestBucket returns the bucket whose range contains n or, if none does,
+// the one whose range is closest to n; on a tie, the bucket of the smaller
+// indices wins.
+//
+// Parameters:
+//   - n: The index of the calculation.
+//
+// Returns:
+//   - ThresholdBucket: The selected bucket.
+//   - bool: false if the profile has no buckets.
+func (p *CalibrationProfile) NearestBucket(n uint64) (ThresholdBucket, bool) {
+	if len(p.Buckets) == 0 {
+		return ThresholdBucket{}, false
+	}
+	best := p.Buckets[0]
+	for _, b := range p.Buckets[1:] {
+		if b.distance(n) < best.distance(n) {
+			best = b
+		}
+	}
+	return best, true
+}
+
+// ThresholdsFor returns the calibrated thresholds to use for index n: those
+// of the nearest bucket (see NearestBucket), or the profile-wide ones if the
+// profile has no buckets.
+//
+// Returns:
+//   - parallel: The parallel threshold in bits.
+//   - fft: The FFT threshold in bits.
+//   - strassen: The Strassen threshold in bits.
+func (p *CalibrationProfile) ThresholdsFor(n uint64) (parallel, fft, strassen int) {
+	if b, ok := p.NearestBucket(n); ok {
+		return b.ParallelThreshold, b.FFTThreshold, b.StrassenThreshold
+	}
+	return p.OptimalParallelThreshold, p.OptimalFFTThreshold, p.OptimalStrassenThreshold
+}
+
 // IsValid checks if the profile is valid for the current hardware.
 // A profile is considered valid if:
 // - The profile version matches
@@ -151,6 +413,12 @@ func (p *CalibrationProfile) IsValid() bool {
 	return true
 }
 
+// IsPartial reports whether the profile only holds the checkpoint of an
+// interrupted first calibration, and no thresholds yet.
+func (p *CalibrationProfile) IsPartial() bool {
+	return p.Checkpoint != nil && p.CalibrationN == 0
+}
+
 // IsStale checks if the profile is older than the given duration.
 // This can be used to trigger re-calibration after a certain period.
 func (p *CalibrationProfile) IsStale(maxAge time.Duration) bool {
@@ -179,6 +447,28 @@ func (p *CalibrationProfile) String() string {
 // LoadOrCreate loads an existing profile or creates a new one if not found.
 // If the existing profile is invalid for the current hardware, returns a new profile.
 func LoadOrCreateProfile(path string) (*CalibrationProfile, bool) {
+	return LoadOrCreateProfileWithWarnings(path, io.Discard)
+}
+
+// LoadOrCreateProfileWithWarnings is LoadOrCreateProfile with checksum
+// warnings written to warn. A profile whose checksum does not match was
+// edited after calibration and is replaced by a new profile, so that the
+// caller recalibrates; a legacy profile without a checksum is accepted. A
+// partial profile (see IsPartial) has no thresholds to use and is replaced
+// as well.
+func LoadOrCreateProfileWithWarnings(path string, warn io.Writer) (*CalibrationProfile, bool) {
+	profile, loaded := loadTrustedProfile(path, warn)
+	if !loaded || profile.IsPartial() {
+		return NewProfile(), false
+	}
+	return profile, true
+}
+
+// loadTrustedProfile loads the profile at path if it is valid for the
+// current hardware and its checksum matches, warning on warn as
+// LoadOrCreateProfileWithWarnings does. Unlike it, partial profiles are
+// returned.
+func loadTrustedProfile(path string, warn io.Writer) (*CalibrationProfile, bool) {
 	profile, err := loadProfile(path)
 	if err != nil {
 		// File doesn't exist or can't be read - create new
@@ -190,6 +480,16 @@ func LoadOrCreateProfile(path string) (*CalibrationProfile, bool) {
 		return NewProfile(), false
 	}
 
+	if path == "" {
+		path = GetDefaultProfilePath()
+	}
+	switch err := profile.VerifyChecksum(); {
+	case errors.Is(err, ErrProfileChecksumMismatch):
+		fmt.Fprintf(warn, "Warning: %v in %s (edited by hand?); ignoring the cached thresholds\n", err, path)
+		return NewProfile(), false
+	case err != nil:
+		fmt.Fprintf(warn, "Warning: %v in %s (legacy format); accepting it, recalibrate to add one\n", err, path)
+	}
+
 	return profile, true
 }
-
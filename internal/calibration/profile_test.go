@@ -1,11 +1,17 @@
 package calibration
 
 import (
+	"bytes"
+	"errors"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
 )
 
 func TestNewProfile(t *testing.T) {
@@ -247,6 +253,220 @@ func TestLoadOrCreateProfile(t *testing.T) {
 	}
 }
 
+// TestLoadOrCreateProfileChecksum verifies that a hand-edited threshold is
+// detected by the checksum and that legacy profiles are accepted with a
+// warning.
+// TestSaveLearnedThresholds verifies that learned thresholds are recorded in
+// an existing profile under its checksum, so that editing them is detected.
+func TestSaveLearnedThresholds(t *testing.T) {
+	t.Parallel()
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+	learned := threshold.LearnedThresholds{FFTThreshold: 750000, ParallelThreshold: 8192, LearnedAt: time.Now()}
+
+	if err := SaveLearnedThresholds(profilePath, learned); err == nil {
+		t.Error("saving learned thresholds without a profile should fail")
+	}
+
+	profile := NewProfile()
+	profile.OptimalParallelThreshold = 4096
+	if err := profile.SaveProfile(profilePath); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	if err := SaveLearnedThresholds(profilePath, learned); err != nil {
+		t.Fatalf("SaveLearnedThresholds failed: %v", err)
+	}
+	var warn bytes.Buffer
+	got, loaded := LoadOrCreateProfileWithWarnings(profilePath, &warn)
+	if !loaded || warn.Len() != 0 || got.LearnedFFTThreshold != 750000 || got.LearnedParallelThreshold != 8192 || got.OptimalParallelThreshold != 4096 {
+		t.Fatalf("loaded=%v, warnings=%q, profile=%+v", loaded, warn.String(), got)
+	}
+
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"learned_fft_threshold": 750000`, `"learned_fft_threshold": 1`, 1)
+	if tampered == string(data) {
+		t.Fatal("test setup: learned threshold not found in saved profile")
+	}
+	if err := os.WriteFile(profilePath, []byte(tampered), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, loaded := LoadOrCreateProfileWithWarnings(profilePath, &warn); loaded {
+		t.Error("a profile with edited learned thresholds should not be trusted")
+	}
+}
+
+func TestLoadOrCreateProfileChecksum(t *testing.T) {
+	t.Parallel()
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+	profile := NewProfile()
+	profile.OptimalParallelThreshold = 4096
+	if err := profile.SaveProfile(profilePath); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	var warn bytes.Buffer
+	if _, loaded := LoadOrCreateProfileWithWarnings(profilePath, &warn); !loaded || warn.Len() != 0 {
+		t.Fatalf("untouched profile: loaded=%v, warnings=%q", loaded, warn.String())
+	}
+
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := strings.Replace(string(data), `"optimal_parallel_threshold": 4096`, `"optimal_parallel_threshold": 1`, 1)
+	if tampered == string(data) {
+		t.Fatal("test setup: threshold not found in saved profile")
+	}
+	if err := os.WriteFile(profilePath, []byte(tampered), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if loaded, err := loadProfile(profilePath); err != nil || !errors.Is(loaded.VerifyChecksum(), ErrProfileChecksumMismatch) {
+		t.Fatalf("VerifyChecksum() on tampered profile = %v (load error %v)", loaded.VerifyChecksum(), err)
+	}
+	warn.Reset()
+	got, loaded := LoadOrCreateProfileWithWarnings(profilePath, &warn)
+	if loaded || got.OptimalParallelThreshold == 1 {
+		t.Error("tampered profile should not be trusted")
+	}
+	if !strings.Contains(warn.String(), "checksum mismatch") {
+		t.Errorf("expected a checksum warning, got %q", warn.String())
+	}
+
+	// A legacy profile without a checksum is accepted with a warning.
+	legacy := strings.Replace(tampered, `"checksum"`, `"unused"`, 1)
+	if err := os.WriteFile(profilePath, []byte(legacy), 0600); err != nil {
+		t.Fatal(err)
+	}
+	warn.Reset()
+	if _, loaded := LoadOrCreateProfileWithWarnings(profilePath, &warn); !loaded {
+		t.Error("legacy profile should be accepted")
+	}
+	if !strings.Contains(warn.String(), "no checksum") {
+		t.Errorf("expected a legacy warning, got %q", warn.String())
+	}
+}
+
+// TestProfileBucketsSaveLoad verifies that per-N buckets survive a save and
+// load, are covered by the checksum, and that profiles without buckets keep
+// their format.
+func TestProfileBucketsSaveLoad(t *testing.T) {
+	t.Parallel()
+	profilePath := filepath.Join(t.TempDir(), "profile.json")
+
+	profile := NewProfile()
+	profile.OptimalParallelThreshold = 4096
+	legacyChecksum := profile.ComputeChecksum()
+	profile.SetBucket(ThresholdBucket{MinN: 1_000_000, MaxN: 5_000_000, ParallelThreshold: 2048, FFTThreshold: 500_000, StrassenThreshold: 128})
+	profile.SetBucket(ThresholdBucket{MinN: 1, MaxN: 999_999, ParallelThreshold: 8192, FFTThreshold: 250_000, StrassenThreshold: 64})
+	if profile.ComputeChecksum() == legacyChecksum {
+		t.Error("buckets should be covered by the checksum")
+	}
+	if err := profile.SaveProfile(profilePath); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+
+	loaded, err := loadProfile(profilePath)
+	if err != nil {
+		t.Fatalf("loadProfile failed: %v", err)
+	}
+	if err := loaded.VerifyChecksum(); err != nil {
+		t.Errorf("VerifyChecksum() = %v", err)
+	}
+	want := []ThresholdBucket{
+		{MinN: 1, MaxN: 999_999, ParallelThreshold: 8192, FFTThreshold: 250_000, StrassenThreshold: 64},
+		{MinN: 1_000_000, MaxN: 5_000_000, ParallelThreshold: 2048, FFTThreshold: 500_000, StrassenThreshold: 128},
+	}
+	if !slices.Equal(loaded.Buckets, want) {
+		t.Errorf("Buckets = %+v, want %+v (sorted by MinN)", loaded.Buckets, want)
+	}
+
+	// Replacing a bucket with the same range does not add one.
+	loaded.SetBucket(ThresholdBucket{MinN: 1, MaxN: 999_999, ParallelThreshold: 1024})
+	if len(loaded.Buckets) != 2 || loaded.Buckets[0].ParallelThreshold != 1024 {
+		t.Errorf("Buckets after replacement = %+v", loaded.Buckets)
+	}
+
+	// A profile without buckets omits the field and keeps its checksum.
+	plain := NewProfile()
+	if err := plain.SaveProfile(profilePath); err != nil {
+		t.Fatalf("SaveProfile failed: %v", err)
+	}
+	data, err := os.ReadFile(profilePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), "buckets") {
+		t.Errorf("profile without buckets should omit the field:\n%s", data)
+	}
+}
+
+func TestProfileNearestBucket(t *testing.T) {
+	t.Parallel()
+	profile := NewProfile()
+	profile.OptimalParallelThreshold = 4096
+	profile.OptimalFFTThreshold = 1_000_000
+	profile.OptimalStrassenThreshold = 256
+
+	if _, ok := profile.NearestBucket(1000); ok {
+		t.Error("NearestBucket() on a profile without buckets should report false")
+	}
+	if p, f, s := profile.ThresholdsFor(1000); p != 4096 || f != 1_000_000 || s != 256 {
+		t.Errorf("ThresholdsFor() without buckets = %d, %d, %d, want the optimal thresholds", p, f, s)
+	}
+
+	profile.SetBucket(ThresholdBucket{MinN: 1000, MaxN: 2000, ParallelThreshold: 1})
+	profile.SetBucket(ThresholdBucket{MinN: 10_000, MaxN: 20_000, ParallelThreshold: 2})
+	profile.SetBucket(ThresholdBucket{MinN: 1_000_000, MaxN: 2_000_000, ParallelThreshold: 3})
+
+	tests := []struct {
+		name string
+		n    uint64
+		want int
+	}{
+		{"below all buckets", 10, 1},
+		{"inside the first bucket", 1500, 1},
+		{"upper bound is inclusive", 2000, 1},
+		{"closer to the first bucket", 5000, 1},
+		{"tie goes to the smaller indices", 6000, 1},
+		{"closer to the second bucket", 6001, 2},
+		{"inside the last bucket", 1_000_000, 3},
+		{"above all buckets", 1 << 40, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			b, ok := profile.NearestBucket(tt.n)
+			if !ok || b.ParallelThreshold != tt.want {
+				t.Errorf("NearestBucket(%d) = %+v, %v, want the bucket with threshold %d", tt.n, b, ok, tt.want)
+			}
+			if p, _, _ := profile.ThresholdsFor(tt.n); p != tt.want {
+				t.Errorf("ThresholdsFor(%d) parallel = %d, want %d", tt.n, p, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalibrationCheckpointRecord(t *testing.T) {
+	t.Parallel()
+	var c CalibrationCheckpoint
+	c.record(CandidateTiming{Threshold: 0, DurationNs: 3})
+	c.record(CandidateTiming{Threshold: 1024, DurationNs: 2})
+	c.record(CandidateTiming{Threshold: 0, DurationNs: 1})
+
+	want := []CandidateTiming{{Threshold: 0, DurationNs: 1}, {Threshold: 1024, DurationNs: 2}}
+	if !slices.Equal(c.Completed, want) {
+		t.Errorf("Completed = %+v, want %+v (re-recording replaces)", c.Completed, want)
+	}
+	if got, ok := c.lookup(1024); !ok || got.DurationNs != 2 {
+		t.Errorf("lookup(1024) = %+v, %v", got, ok)
+	}
+	if _, ok := c.lookup(2048); ok {
+		t.Error("lookup(2048) should report false")
+	}
+}
+
 func TestGetDefaultProfilePath(t *testing.T) {
 	t.Parallel()
 	path := GetDefaultProfilePath()
@@ -0,0 +1,128 @@
+package calibration
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/format"
+)
+
+// CalibrationReport summarizes a calibration run: the time measured for each
+// candidate parallel threshold at one index. It can be exported as a
+// human-readable table or as JSON.
+type CalibrationReport struct {
+	// N is the Fibonacci index computed by the trials.
+	N uint64 `json:"n"`
+	// CalibratedAt is when the report was created.
+	CalibratedAt time.Time `json:"calibrated_at"`
+	// BestThreshold is the fastest candidate.
+	BestThreshold int `json:"best_threshold"`
+	// Candidates lists the trials in the order they were run.
+	Candidates []CandidateTiming `json:"candidates"`
+}
+
+// CandidateTiming is the measurement of one candidate threshold.
+type CandidateTiming struct {
+	// Threshold is the parallel threshold in bits, 0 for sequential.
+	Threshold  int   `json:"threshold"`
+	DurationNs int64 `json:"duration_ns"`
+	// Error is set when the trial failed; DurationNs is then 0.
+	Error string `json:"error,omitempty"`
+}
+
+// newCalibrationReport builds the report of a calibration run.
+func newCalibrationReport(n uint64, results []calibrationResult, bestThreshold int) *CalibrationReport {
+	report := &CalibrationReport{
+		N:             n,
+		CalibratedAt:  time.Now(),
+		BestThreshold: bestThreshold,
+		Candidates:    make([]CandidateTiming, 0, len(results)),
+	}
+	for _, res := range results {
+		report.Candidates = append(report.Candidates, newCandidateTiming(res))
+	}
+	return report
+}
+
+// newCandidateTiming converts the result of a trial.
+func newCandidateTiming(res calibrationResult) CandidateTiming {
+	timing := CandidateTiming{Threshold: res.Threshold, DurationNs: res.Duration.Nanoseconds()}
+	if res.Err != nil {
+		timing.Error = res.Err.Error()
+	}
+	return timing
+}
+
+// result converts the timing back to the result of a trial.
+func (t CandidateTiming) result() calibrationResult {
+	res := calibrationResult{Threshold: t.Threshold, Duration: time.Duration(t.DurationNs)}
+	if t.Error != "" {
+		res.Err = errors.New(t.Error)
+	}
+	return res
+}
+
+// WriteTable writes the report as an aligned, uncolored table.
+//
+// Parameters:
+//   - out: The output writer.
+//
+// Returns:
+//   - error: An error if the table cannot be written.
+func (r *CalibrationReport) WriteTable(out io.Writer) error {
+	fmt.Fprintf(out, "Calibration report for N=%d (%s)\n\n", r.N, r.CalibratedAt.Format(time.RFC3339))
+	tw := tabwriter.NewWriter(out, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, "Threshold\tDuration\tStatus")
+	for _, c := range r.Candidates {
+		label := fmt.Sprintf("%d bits", c.Threshold)
+		if c.Threshold == 0 {
+			label = "Sequential"
+		}
+		duration, status := format.FormatExecutionDuration(time.Duration(c.DurationNs)), "ok"
+		switch {
+		case c.Error != "":
+			duration, status = "N/A", "failed: "+c.Error
+		case c.Threshold == r.BestThreshold:
+			status = "optimal"
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", label, duration, status)
+	}
+	return tw.Flush()
+}
+
+// WriteJSON writes the report as indented JSON.
+//
+// Parameters:
+//   - out: The output writer.
+//
+// Returns:
+//   - error: An error if the document cannot be written.
+func (r *CalibrationReport) WriteJSON(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+// WriteFile writes the report to path: as JSON if the file name ends in
+// ".json", as a table otherwise.
+func (r *CalibrationReport) WriteFile(path string) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	write := r.WriteTable
+	if filepath.Ext(path) == ".json" {
+		write = r.WriteJSON
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return file.Close()
+}
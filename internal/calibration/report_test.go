@@ -0,0 +1,88 @@
+package calibration
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func testCalibrationReport() *CalibrationReport {
+	return newCalibrationReport(1000, []calibrationResult{
+		{Threshold: 0, Duration: 3 * time.Millisecond},
+		{Threshold: 4096, Duration: time.Millisecond},
+		{Threshold: 8192, Err: errors.New("timed out")},
+	}, 4096)
+}
+
+func TestCalibrationReportWriteTable(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := testCalibrationReport().WriteTable(&buf); err != nil {
+		t.Fatalf("WriteTable() error = %v", err)
+	}
+	out := buf.String()
+	for _, want := range []string{"N=1000", "Sequential", "4096 bits", "optimal", "8192 bits", "N/A", "failed: timed out"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table should contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("table should not be colored:\n%s", out)
+	}
+}
+
+func TestCalibrationReportWriteJSON(t *testing.T) {
+	t.Parallel()
+	var buf bytes.Buffer
+	if err := testCalibrationReport().WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON() error = %v", err)
+	}
+	var got CalibrationReport
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	want := []CandidateTiming{
+		{Threshold: 0, DurationNs: int64(3 * time.Millisecond)},
+		{Threshold: 4096, DurationNs: int64(time.Millisecond)},
+		{Threshold: 8192, Error: "timed out"},
+	}
+	if got.N != 1000 || got.BestThreshold != 4096 || len(got.Candidates) != len(want) {
+		t.Fatalf("report = %+v", got)
+	}
+	for i := range want {
+		if got.Candidates[i] != want[i] {
+			t.Errorf("candidate %d = %+v, want %+v", i, got.Candidates[i], want[i])
+		}
+	}
+}
+
+func TestCalibrationReportWriteFile(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	report := testCalibrationReport()
+
+	jsonPath := filepath.Join(dir, "report.json")
+	if err := report.WriteFile(jsonPath); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", jsonPath, err)
+	}
+	if data, _ := os.ReadFile(jsonPath); !json.Valid(data) {
+		t.Errorf("%s should hold JSON:\n%s", jsonPath, data)
+	}
+
+	tablePath := filepath.Join(dir, "report.txt")
+	if err := report.WriteFile(tablePath); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", tablePath, err)
+	}
+	if data, _ := os.ReadFile(tablePath); !strings.HasPrefix(string(data), "Calibration report") {
+		t.Errorf("%s should hold the table:\n%s", tablePath, data)
+	}
+
+	if err := report.WriteFile(filepath.Join(dir, "missing", "report.txt")); err == nil {
+		t.Error("WriteFile() into a missing directory should fail")
+	}
+}
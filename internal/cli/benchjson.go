@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// BenchJSONPackage is the package path reported in --bench-json events.
+const BenchJSONPackage = "github.com/agbru/fibcalc"
+
+// BenchEvent is one record of the `go test -json` stream (see
+// `go doc test2json`). Benchmark results travel as "output" events carrying
+// the standard "BenchmarkName-P  iterations  value ns/op" line, which is what
+// CI dashboards ingesting Go benchmark JSON parse.
+type BenchEvent struct {
+	Time    time.Time `json:",omitempty"`
+	Action  string
+	Package string   `json:",omitempty"`
+	Test    string   `json:",omitempty"`
+	Elapsed *float64 `json:",omitempty"`
+	Output  string   `json:",omitempty"`
+}
+
+// FormatBenchmarkName maps an index and algorithm to a Go benchmark name,
+// e.g. "BenchmarkFibonacci/n=1000000/Fast_Doubling". Spaces are replaced by
+// underscores, as the testing package does for sub-benchmark names.
+//
+// Parameters:
+//   - n: The Fibonacci index.
+//   - algo: The algorithm name.
+//
+// Returns:
+//   - string: The benchmark name, without the GOMAXPROCS suffix.
+func FormatBenchmarkName(n uint64, algo string) string {
+	return fmt.Sprintf("BenchmarkFibonacci/n=%d/%s", n, strings.ReplaceAll(algo, " ", "_"))
+}
+
+// DisplayBenchJSON writes the results as a `go test -json` benchmark event
+// stream: a start event, the goos/goarch/pkg header, one benchmark line per
+// successful calculator (one iteration, the duration as ns/op), a failure
+// report per failed or mismatching calculator, and a final pass or fail event.
+//
+// Parameters:
+//   - out: The output writer.
+//   - results: The calculation results.
+//   - n: The Fibonacci index.
+//   - mismatch: The disagreement between results, or nil.
+//
+// Returns:
+//   - error: An error if an event cannot be written.
+func DisplayBenchJSON(out io.Writer, results []orchestration.CalculationResult, n uint64, mismatch *orchestration.Mismatch) error {
+	enc := json.NewEncoder(out)
+	emit := func(event BenchEvent) error {
+		event.Time = time.Now()
+		event.Package = BenchJSONPackage
+		return enc.Encode(event)
+	}
+	output := func(test, line string) error {
+		return emit(BenchEvent{Action: "output", Test: test, Output: line})
+	}
+
+	if err := emit(BenchEvent{Action: "start"}); err != nil {
+		return err
+	}
+	for _, line := range []string{
+		fmt.Sprintf("goos: %s\n", runtime.GOOS),
+		fmt.Sprintf("goarch: %s\n", runtime.GOARCH),
+		fmt.Sprintf("pkg: %s\n", BenchJSONPackage),
+	} {
+		if err := output("", line); err != nil {
+			return err
+		}
+	}
+
+	// Calculators run concurrently, so the run lasts as long as the slowest.
+	var elapsed time.Duration
+	failed := false
+	procs := runtime.GOMAXPROCS(0)
+	for _, res := range results {
+		name := FormatBenchmarkName(n, res.Name)
+		elapsed = max(elapsed, res.Duration)
+		failure := res.Err
+		if failure == nil {
+			failure = mismatchError(mismatch, res.Name)
+		}
+		if failure != nil {
+			failed = true
+			if err := output(name, fmt.Sprintf("--- FAIL: %s\n    %v\n", name, failure)); err != nil {
+				return err
+			}
+			continue
+		}
+		line := fmt.Sprintf("%s-%d\t%8d\t%12d ns/op\n", name, procs, 1, res.Duration.Nanoseconds())
+		if err := output(name, line); err != nil {
+			return err
+		}
+	}
+
+	action, status := "pass", "PASS\n"
+	if failed {
+		action, status = "fail", "FAIL\n"
+	}
+	if err := output("", status); err != nil {
+		return err
+	}
+	seconds := elapsed.Seconds()
+	return emit(BenchEvent{Action: action, Elapsed: &seconds})
+}
+
+// mismatchError returns the failure to report for algo when it is one of the
+// mismatching algorithms, or nil.
+func mismatchError(mismatch *orchestration.Mismatch, algo string) error {
+	if mismatch == nil || !slices.Contains(mismatch.Algorithms, algo) {
+		return nil
+	}
+	if mismatch.Kind == orchestration.MismatchExpected {
+		return errors.New("result differs from the expected value")
+	}
+	return fmt.Errorf("result differs from %s", mismatch.Reference)
+}
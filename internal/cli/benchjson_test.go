@@ -0,0 +1,120 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// benchLine matches a benchmark result line as printed by `go test -bench`.
+var benchLine = regexp.MustCompile(`^(Benchmark\S+)-\d+\s+(\d+)\s+(\d+) ns/op\n$`)
+
+func decodeBenchEvents(t *testing.T, data []byte) []BenchEvent {
+	t.Helper()
+	var events []BenchEvent
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	for dec.More() {
+		var event BenchEvent
+		if err := dec.Decode(&event); err != nil {
+			t.Fatalf("invalid test2json event: %v", err)
+		}
+		if event.Time.IsZero() || event.Package != BenchJSONPackage {
+			t.Errorf("event missing Time or Package: %+v", event)
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestDisplayBenchJSON(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Fast Doubling", Result: big.NewInt(55), Duration: 1500 * time.Microsecond},
+		{Name: "Matrix Exponentiation", Result: big.NewInt(55), Duration: 3 * time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	if err := DisplayBenchJSON(&buf, results, 10, nil); err != nil {
+		t.Fatalf("DisplayBenchJSON failed: %v", err)
+	}
+	events := decodeBenchEvents(t, buf.Bytes())
+
+	if events[0].Action != "start" {
+		t.Errorf("first event should be start, got %q", events[0].Action)
+	}
+	last := events[len(events)-1]
+	if last.Action != "pass" || last.Elapsed == nil || *last.Elapsed != 0.003 {
+		t.Errorf("last event should be pass with the slowest duration, got %+v", last)
+	}
+
+	got := map[string]string{}
+	for _, event := range events {
+		if m := benchLine.FindStringSubmatch(event.Output); m != nil {
+			if event.Action != "output" || event.Test != m[1] {
+				t.Errorf("benchmark line in unexpected event %+v", event)
+			}
+			got[m[1]] = m[3]
+		}
+	}
+	want := map[string]string{
+		"BenchmarkFibonacci/n=10/Fast_Doubling":         "1500000",
+		"BenchmarkFibonacci/n=10/Matrix_Exponentiation": "3000000",
+	}
+	for name, ns := range want {
+		if got[name] != ns {
+			t.Errorf("%s: got %q ns/op, want %s", name, got[name], ns)
+		}
+	}
+}
+
+func TestDisplayBenchJSON_Failure(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "FFT", Err: errors.New("boom"), Duration: time.Millisecond},
+	}
+
+	var buf bytes.Buffer
+	if err := DisplayBenchJSON(&buf, results, 10, nil); err != nil {
+		t.Fatalf("DisplayBenchJSON failed: %v", err)
+	}
+	events := decodeBenchEvents(t, buf.Bytes())
+	if last := events[len(events)-1]; last.Action != "fail" {
+		t.Errorf("last event should be fail, got %q", last.Action)
+	}
+	if !strings.Contains(buf.String(), "--- FAIL: BenchmarkFibonacci/n=10/FFT") {
+		t.Errorf("expected a failure report, got %s", buf.String())
+	}
+}
+
+func TestDisplayBenchJSON_Mismatch(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Fast", Result: big.NewInt(55), Duration: time.Millisecond},
+		{Name: "Broken", Result: big.NewInt(56), Duration: time.Millisecond},
+	}
+	mismatch := &orchestration.Mismatch{Kind: orchestration.MismatchInconsistent, Reference: "Fast", Algorithms: []string{"Broken"}}
+
+	var buf bytes.Buffer
+	if err := DisplayBenchJSON(&buf, results, 10, mismatch); err != nil {
+		t.Fatalf("DisplayBenchJSON failed: %v", err)
+	}
+	events := decodeBenchEvents(t, buf.Bytes())
+	if last := events[len(events)-1]; last.Action != "fail" {
+		t.Errorf("last event should be fail, got %q", last.Action)
+	}
+	output := buf.String()
+	if !strings.Contains(output, "--- FAIL: BenchmarkFibonacci/n=10/Broken") || !strings.Contains(output, "result differs from Fast") {
+		t.Errorf("expected a mismatch failure for Broken, got %s", output)
+	}
+	if strings.Contains(output, "n=10/Broken-") {
+		t.Errorf("a mismatching algorithm should not report a timing, got %s", output)
+	}
+}
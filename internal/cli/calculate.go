@@ -10,6 +10,21 @@ import (
 	"github.com/agbru/fibcalc/internal/ui"
 )
 
+// SequenceSymbol returns the symbol of the sequence computed by algo: "L" for
+// the Lucas numbers, "F" otherwise.
+//
+// Parameters:
+//   - algo: The --algo value.
+//
+// Returns:
+//   - string: The symbol used in front of the index, as in F(n).
+func SequenceSymbol(algo string) string {
+	if algo == fibonacci.LucasAlgorithm {
+		return "L"
+	}
+	return "F"
+}
+
 // PrintExecutionConfig displays the current execution configuration to the user.
 // It shows the target Fibonacci number, timeout, environment details, and
 // optimization thresholds.
@@ -19,8 +34,8 @@ import (
 //   - out: The writer for standard output.
 func PrintExecutionConfig(cfg config.AppConfig, out io.Writer) {
 	fmt.Fprintf(out, "--- Execution Configuration ---\n")
-	fmt.Fprintf(out, "Calculating %sF(%d)%s with a timeout of %s%s%s.\n",
-		ui.ColorMagenta(), cfg.N, ui.ColorReset(), ui.ColorYellow(), cfg.Timeout, ui.ColorReset())
+	fmt.Fprintf(out, "Calculating %s%s(%d)%s with a timeout of %s%s%s.\n",
+		ui.ColorMagenta(), SequenceSymbol(cfg.Algo), cfg.N, ui.ColorReset(), ui.ColorYellow(), cfg.Timeout, ui.ColorReset())
 	fmt.Fprintf(out, "Environment: %s%d%s logical processors, Go %s%s%s.\n",
 		ui.ColorCyan(), runtime.NumCPU(), ui.ColorReset(), ui.ColorCyan(), runtime.Version(), ui.ColorReset())
 	fmt.Fprintf(out, "Optimization thresholds: Parallelism=%s%d%s bits, FFT=%s%d%s bits.\n",
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"bytes"
+	"strings"
 	"testing"
 
 	"github.com/agbru/fibcalc/internal/config"
@@ -33,6 +34,23 @@ func TestPrintExecutionConfig(t *testing.T) {
 	}
 }
 
+func TestPrintExecutionConfigLucas(t *testing.T) {
+	t.Parallel()
+	for _, tt := range []struct {
+		algo            string
+		want, forbidden string
+	}{
+		{"fast", "F(", "L("},
+		{fibonacci.LucasAlgorithm, "L(", "F("},
+	} {
+		var buf bytes.Buffer
+		PrintExecutionConfig(config.AppConfig{N: 42, Algo: tt.algo}, &buf)
+		if output := buf.String(); !strings.Contains(output, tt.want) || strings.Contains(output, tt.forbidden) {
+			t.Errorf("algo %q: expected %q and no %q, got:\n%s", tt.algo, tt.want, tt.forbidden, output)
+		}
+	}
+}
+
 // TestPrintExecutionMode tests the PrintExecutionMode function.
 func TestPrintExecutionMode(t *testing.T) {
 	t.Parallel()
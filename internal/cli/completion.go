@@ -25,7 +25,7 @@ type FlagCompletion struct {
 var flagRegistry = []FlagCompletion{
 	{Long: "help", Short: "h", Help: "Show help message"},
 	{Long: "version", Short: "V", Help: "Show version information"},
-	{Short: "n", Help: "Fibonacci index to calculate", ValueName: "number"},
+	{Short: "n", Help: "Fibonacci index to calculate", Values: indexSuggestions, ValueName: "number"},
 	{Short: "v", Help: "Display full result value"},
 	{Long: "details", Short: "d", Help: "Show performance details"},
 	{Long: "timeout", Help: "Maximum execution time", Values: []string{"1m", "5m", "10m", "30m", "1h"}, ValueName: "duration"},
@@ -38,9 +38,13 @@ var flagRegistry = []FlagCompletion{
 	{Long: "calibration-profile", Help: "Calibration profile file", IsFile: true, ValueName: "file"},
 	{Long: "output", Short: "o", Help: "Output file path", IsFile: true, ValueName: "file"},
 	{Long: "quiet", Short: "q", Help: "Quiet mode for scripts"},
-	{Long: "completion", Help: "Generate completion script", Values: []string{"bash", "zsh", "fish", "powershell"}, ValueName: "shell"},
+	{Long: "completion", Help: "Generate completion script", Values: []string{"bash", "zsh", "fish", "powershell", "nushell", "elvish"}, ValueName: "shell"},
 }
 
+// indexSuggestions are the Fibonacci indices suggested for -n: powers of ten
+// from 10 to 10^9, which include the usual milestones such as 1000000.
+var indexSuggestions = []string{"10", "100", "1000", "10000", "100000", "1000000", "10000000", "100000000", "1000000000"}
+
 // bashGroupValues defines the completion values used in bash for grouped flags.
 // Flags sharing the same BashGroup use these values in the bash case statement.
 var bashGroupValues = map[string][]string{
@@ -58,7 +62,8 @@ var zshHelpOverrides = map[string]string{
 //
 // Parameters:
 //   - out: The writer to output the completion script.
-//   - shell: The shell type ("bash", "zsh", "fish", "powershell").
+//   - shell: The shell type ("bash", "zsh", "fish", "powershell", "nushell",
+//     "elvish").
 //   - algorithms: List of available algorithm names.
 //
 // Returns:
@@ -73,8 +78,12 @@ func GenerateCompletion(out io.Writer, shell string, algorithms []string) error
 		return generateFishCompletion(out, algorithms)
 	case "powershell", "ps":
 		return generatePowerShellCompletion(out, algorithms)
+	case "nushell", "nu":
+		return generateNushellCompletion(out, algorithms)
+	case "elvish":
+		return generateElvishCompletion(out, algorithms)
 	default:
-		return fmt.Errorf("unsupported shell: %s (accepted values: bash, zsh, fish, powershell)", shell)
+		return fmt.Errorf("unsupported shell: %s (accepted values: bash, zsh, fish, powershell, nushell, elvish)", shell)
 	}
 }
 
@@ -83,6 +92,19 @@ func formatAlgoList(algorithms []string) string {
 	return strings.Join(algorithms, " ")
 }
 
+// flagNames returns the command-line spellings of a flag: "--long" and/or
+// "-short".
+func flagNames(f FlagCompletion) []string {
+	var names []string
+	if f.Long != "" {
+		names = append(names, "--"+f.Long)
+	}
+	if f.Short != "" {
+		names = append(names, "-"+f.Short)
+	}
+	return names
+}
+
 // flagKey returns the identifier used for lookups: Long name if present, else Short.
 func flagKey(f FlagCompletion) string {
 	if f.Long != "" {
@@ -112,7 +134,7 @@ func generateBashCompletion(out io.Writer, algorithms []string) error {
 	}
 	bashCaseEntry := func(f FlagCompletion) caseEntry {
 		return caseEntry{
-			patterns: []string{"--" + f.Long},
+			patterns: flagNames(f),
 			body:     fmt.Sprintf(`COMPREPLY=( $(compgen -W "%s" -- "${cur}") )`, strings.Join(f.Values, " ")),
 		}
 	}
@@ -418,17 +440,23 @@ func generatePowerShellCompletion(out io.Writer, algorithms []string) error {
 	// Order: algo, then non-algo value flags in reverse registry order (completion before timeout).
 	var switchEntries []string
 
+	// psSwitchEntry returns one switch entry per spelling of the flag, so
+	// short-only flags such as -n are matched too.
 	psSwitchEntry := func(f FlagCompletion) string {
 		var quotedVals []string
 		for _, v := range f.Values {
 			quotedVals = append(quotedVals, fmt.Sprintf("'%s'", v))
 		}
-		return fmt.Sprintf(`        '--%s' {
+		var entries []string
+		for _, name := range flagNames(f) {
+			entries = append(entries, fmt.Sprintf(`        '%s' {
             @(%s) | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
                 [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
             }
             return
-        }`, f.Long, strings.Join(quotedVals, ", "))
+        }`, name, strings.Join(quotedVals, ", ")))
+		}
+		return strings.Join(entries, "\n")
 	}
 
 	// Algo flags first
@@ -494,3 +522,132 @@ Register-ArgumentCompleter -CommandName 'fibcalc' -Native -ScriptBlock {
 	_, err := fmt.Fprint(out, script)
 	return err
 }
+
+// generateNushellCompletion generates a Nushell completion script: an extern
+// signature for fibcalc whose value flags point to custom completers.
+func generateNushellCompletion(out io.Writer, algorithms []string) error {
+	var completers, params []string
+	for _, f := range flagRegistry {
+		entry := nushellParam(f)
+		if f.IsAlgo || len(f.Values) > 0 {
+			values := f.Values
+			if f.IsAlgo {
+				values = append(append([]string(nil), algorithms...), "all")
+			}
+			name := "nu-complete fibcalc " + flagKey(f)
+			completers = append(completers, fmt.Sprintf("def \"%s\" [] {\n    [%s]\n}\n", name, nushellList(values)))
+			shape := "string"
+			if f.ValueName == "number" {
+				shape = "int"
+			}
+			entry += fmt.Sprintf(`: %s@"%s"`, shape, name)
+		} else if f.IsFile {
+			entry += ": path"
+		} else if f.ValueName == "number" {
+			entry += ": int"
+		} else if f.ValueName != "" {
+			entry += ": string"
+		}
+		params = append(params, fmt.Sprintf("    %s # %s", entry, f.Help))
+	}
+
+	script := fmt.Sprintf(`# Nushell completion script for fibcalc
+# Save as fibcalc.nu and add 'use fibcalc.nu *' to your config.nu
+
+%s
+export extern "fibcalc" [
+%s
+]
+`, strings.Join(completers, "\n"), strings.Join(params, "\n"))
+
+	_, err := fmt.Fprint(out, script)
+	if err != nil {
+		return fmt.Errorf("completion nushell generation failed: %w", err)
+	}
+	return nil
+}
+
+// nushellParam formats the flag names of a FlagCompletion as a Nushell
+// signature parameter, e.g. "--output(-o)" or "-n".
+func nushellParam(f FlagCompletion) string {
+	if f.Long == "" {
+		return "-" + f.Short
+	}
+	if f.Short != "" {
+		return fmt.Sprintf("--%s(-%s)", f.Long, f.Short)
+	}
+	return "--" + f.Long
+}
+
+// nushellList formats values as a Nushell list body of quoted strings.
+func nushellList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// generateElvishCompletion generates an Elvish completion script: an argument
+// completer that suggests values after value-taking flags and the flags
+// themselves, with their descriptions, otherwise.
+func generateElvishCompletion(out io.Writer, algorithms []string) error {
+	var flags, values, files []string
+	for _, f := range flagRegistry {
+		for _, name := range flagNames(f) {
+			flags = append(flags, fmt.Sprintf("    &%s=%s", name, elvishQuote(f.Help)))
+			switch {
+			case f.IsFile:
+				files = append(files, name)
+			case f.IsAlgo:
+				values = append(values, fmt.Sprintf("    &%s=$fibcalc-algorithms", name))
+			case len(f.Values) > 0:
+				values = append(values, fmt.Sprintf("    &%s=[%s]", name, strings.Join(f.Values, " ")))
+			case f.ValueName != "":
+				// Takes a value but no suggestions (e.g., -n)
+				values = append(values, fmt.Sprintf("    &%s=[]", name))
+			}
+		}
+	}
+
+	script := fmt.Sprintf(`# Elvish completion script for fibcalc
+# Add this to your ~/.config/elvish/rc.elv
+
+var fibcalc-algorithms = [%s all]
+
+var fibcalc-flags = [
+%s
+]
+
+var fibcalc-values = [
+%s
+]
+
+var fibcalc-files = [%s]
+
+set edit:completion:arg-completer[fibcalc] = {|@words|
+    var prev = $words[-2]
+    if (has-key $fibcalc-values $prev) {
+        all $fibcalc-values[$prev]
+    } elif (has-value $fibcalc-files $prev) {
+        edit:complete-filename $words[-1]
+    } else {
+        keys $fibcalc-flags | each {|f|
+            edit:complex-candidate $f &display=$f' ('$fibcalc-flags[$f]')'
+        }
+    }
+}
+`, formatAlgoList(algorithms), strings.Join(flags, "\n"), strings.Join(values, "\n"), strings.Join(files, " "))
+
+	_, err := fmt.Fprint(out, script)
+	if err != nil {
+		return fmt.Errorf("completion elvish generation failed: %w", err)
+	}
+	return nil
+}
+
+// elvishQuote returns s as a single-quoted Elvish string, where a quote is
+// escaped by doubling it.
+func elvishQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
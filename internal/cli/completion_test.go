@@ -90,6 +90,60 @@ func TestGenerateCompletion(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:      "Nushell completion",
+			shell:     "nushell",
+			expectErr: false,
+			checkFunc: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Nushell completion script") {
+					t.Error("Nushell script should contain 'Nushell completion script'")
+				}
+				if !strings.Contains(output, `export extern "fibcalc"`) {
+					t.Error("Nushell script should contain the extern signature")
+				}
+				if !strings.Contains(output, `["fast" "matrix" "fft" "all"]`) {
+					t.Error("Nushell script should contain algorithm list")
+				}
+				if !strings.Contains(output, `--algo: string@"nu-complete fibcalc algo"`) {
+					t.Error("Nushell script should attach the algorithm completer to --algo")
+				}
+				if !strings.Contains(output, "--output(-o): path") {
+					t.Error("Nushell script should complete paths for file flags")
+				}
+			},
+		},
+		{
+			name:      "Nushell short alias",
+			shell:     "nu",
+			expectErr: false,
+			checkFunc: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Nushell completion script") {
+					t.Error("Nushell script should contain 'Nushell completion script'")
+				}
+			},
+		},
+		{
+			name:      "Elvish completion",
+			shell:     "elvish",
+			expectErr: false,
+			checkFunc: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Elvish completion script") {
+					t.Error("Elvish script should contain 'Elvish completion script'")
+				}
+				if !strings.Contains(output, "edit:completion:arg-completer[fibcalc]") {
+					t.Error("Elvish script should register an argument completer")
+				}
+				if !strings.Contains(output, "var fibcalc-algorithms = [fast matrix fft all]") {
+					t.Error("Elvish script should contain algorithm list")
+				}
+				if !strings.Contains(output, "var fibcalc-files = [--calibration-profile --output -o]") {
+					t.Error("Elvish script should list the file flags")
+				}
+				if !strings.Contains(output, "&--timeout=[1m 5m 10m 30m 1h]") {
+					t.Error("Elvish script should contain flag values")
+				}
+			},
+		},
 		{
 			name:      "Unsupported shell",
 			shell:     "unsupported",
@@ -157,3 +211,28 @@ func TestGenerateCompletion_MultipleAlgorithms(t *testing.T) {
 		}
 	}
 }
+
+// TestGenerateCompletion_IndexSuggestions checks that the short-only -n flag
+// gets its suggested indices in every shell, not only long flags.
+func TestGenerateCompletion_IndexSuggestions(t *testing.T) {
+	t.Parallel()
+	values := strings.Join(indexSuggestions, " ")
+	quoted := "'" + strings.Join(indexSuggestions, "', '") + "'"
+	testCases := map[string]string{
+		"bash":       "        -n)\n            COMPREPLY=( $(compgen -W \"" + values + "\" -- \"${cur}\") )",
+		"zsh":        ":number:(" + values + ")'",
+		"fish":       "-s n -d 'Fibonacci index to calculate' -xa '" + values + "'",
+		"powershell": "        '-n' {\n            @(" + quoted + ")",
+		"nushell":    `-n: int@"nu-complete fibcalc n"`,
+		"elvish":     "&-n=[" + values + "]",
+	}
+	for shell, want := range testCases {
+		var buf bytes.Buffer
+		if err := GenerateCompletion(&buf, shell, []string{"fast"}); err != nil {
+			t.Fatalf("%s: unexpected error: %v", shell, err)
+		}
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("%s script should suggest indices for -n with %q", shell, want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"encoding/csv"
+	"io"
+	"math/big"
+	"slices"
+	"strconv"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// CSVHeader is the header row written by --csv.
+var CSVHeader = []string{"name", "duration_ns", "bitlen", "digits", "consistent", "fastest"}
+
+// CSVResultPresenter implements orchestration.ResultPresenter and
+// orchestration.ErrorHandler by collecting the results as CSV rows instead of
+// printing them, one row per algorithm: the out writers passed by the
+// orchestration layer are ignored, and WriteCSV writes the rows once the
+// analysis is done. Use a pointer, as the presenter accumulates state.
+type CSVResultPresenter struct {
+	// Expected, if non-nil, is the known-correct value the results are
+	// checked against.
+	Expected *big.Int
+	// OmitHeader drops the header row, as for --quiet.
+	OmitHeader bool
+
+	results  []orchestration.CalculationResult
+	mismatch *orchestration.Mismatch
+}
+
+// Verify interface compliance.
+var (
+	_ orchestration.ResultPresenter   = (*CSVResultPresenter)(nil)
+	_ orchestration.DurationFormatter = (*CSVResultPresenter)(nil)
+	_ orchestration.ErrorHandler      = (*CSVResultPresenter)(nil)
+)
+
+// PresentComparisonTable records the results, successful ones first by
+// duration, and whether they are consistent.
+func (p *CSVResultPresenter) PresentComparisonTable(results []orchestration.CalculationResult, _ io.Writer) {
+	p.results = results
+	p.mismatch = orchestration.FindMismatch(results, p.Expected)
+}
+
+// PresentResult does nothing: the fastest algorithm is marked in every row.
+func (*CSVResultPresenter) PresentResult(orchestration.CalculationResult, uint64, bool, bool, bool, io.Writer) {
+}
+
+// FormatDuration formats a duration for the human-readable fields of the
+// analysis, which the CSV rows do not include.
+func (*CSVResultPresenter) FormatDuration(d time.Duration) string {
+	return d.String()
+}
+
+// HandleError returns the exit code of a failed comparison without printing
+// anything; the failed algorithms still get a row.
+func (*CSVResultPresenter) HandleError(err error, duration time.Duration, _ io.Writer) int {
+	return apperrors.HandleCalculationError(err, duration, io.Discard, nil)
+}
+
+// Rows returns the CSV rows collected so far, without the header. The size
+// columns are empty for a failed algorithm, which is never consistent.
+func (p *CSVResultPresenter) Rows() [][]string {
+	best := orchestration.FindBestResult(p.results)
+	rows := make([][]string, 0, len(p.results))
+	for i := range p.results {
+		res := &p.results[i]
+		row := []string{res.Name, strconv.FormatInt(res.Duration.Nanoseconds(), 10), "", "", "false", strconv.FormatBool(res == best)}
+		if res.Err == nil {
+			row[2] = strconv.Itoa(res.Result.BitLen())
+			row[3] = strconv.Itoa(format.DigitCount(res.Result))
+			consistent := p.mismatch == nil || !slices.Contains(p.mismatch.Algorithms, res.Name)
+			row[4] = strconv.FormatBool(consistent)
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// WriteCSV writes the collected rows, preceded by CSVHeader unless
+// OmitHeader is set.
+//
+// Parameters:
+//   - out: The output writer.
+//
+// Returns:
+//   - error: An error if the rows cannot be written.
+func (p *CSVResultPresenter) WriteCSV(out io.Writer) error {
+	w := csv.NewWriter(out)
+	if !p.OmitHeader {
+		if err := w.Write(CSVHeader); err != nil {
+			return err
+		}
+	}
+	return w.WriteAll(p.Rows())
+}
@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// analyzeCSV runs the standard analysis through a CSVResultPresenter and
+// reads the emitted records back.
+func analyzeCSV(t *testing.T, p *CSVResultPresenter, results []orchestration.CalculationResult) [][]string {
+	t.Helper()
+	orchestration.AnalyzeComparisonResults(results, orchestration.PresentationOptions{N: 10, Expected: p.Expected}, p, p, io.Discard)
+	var buf bytes.Buffer
+	if err := p.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV %q: %v", buf.String(), err)
+	}
+	return records
+}
+
+func TestCSVResultPresenter(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Slow", Result: big.NewInt(55), Duration: 3 * time.Millisecond},
+		{Name: "Broken", Err: errors.New("boom"), Duration: time.Millisecond},
+		{Name: "Fast, Parallel", Result: big.NewInt(55), Duration: 2 * time.Millisecond},
+	}
+	records := analyzeCSV(t, &CSVResultPresenter{}, results)
+
+	want := [][]string{
+		CSVHeader,
+		{"Fast, Parallel", "2000000", "6", "2", "true", "true"},
+		{"Slow", "3000000", "6", "2", "true", "false"},
+		{"Broken", "1000000", "", "", "false", "false"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d: %q", len(records), len(want), records)
+	}
+	for i := range want {
+		if !slices.Equal(records[i], want[i]) {
+			t.Errorf("record %d = %q, want %q", i, records[i], want[i])
+		}
+	}
+}
+
+func TestCSVResultPresenterOmitHeader(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{{Name: "Fast", Result: big.NewInt(55), Duration: time.Millisecond}}
+	records := analyzeCSV(t, &CSVResultPresenter{OmitHeader: true}, results)
+	if len(records) != 1 || records[0][0] != "Fast" {
+		t.Errorf("expected a single data row, got %q", records)
+	}
+}
+
+func TestCSVResultPresenterMismatch(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Good", Result: big.NewInt(55), Duration: time.Millisecond},
+		{Name: "Bad", Result: big.NewInt(56), Duration: 2 * time.Millisecond},
+	}
+	records := analyzeCSV(t, &CSVResultPresenter{Expected: big.NewInt(55)}, results)
+	if len(records) != 3 || records[1][4] != "true" || records[2][4] != "false" {
+		t.Errorf("expected only Bad to be inconsistent, got %q", records)
+	}
+}
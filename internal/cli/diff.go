@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// ResultDiff describes how two saved results compare.
+type ResultDiff struct {
+	// Equal reports whether both files hold the same index and value.
+	Equal bool
+	// NA and NB are the indices recorded in the two files.
+	NA, NB uint64
+	// FirstDifferingDigit is the 1-based position, counted from the most
+	// significant digit, of the first digit where the decimal values differ.
+	// It is 0 when the values are equal.
+	FirstDifferingDigit int
+	// DigitsA and DigitsB are the decimal lengths of the two values.
+	DigitsA, DigitsB int
+}
+
+// DiffResults compares two result values and their indices.
+//
+// Parameters:
+//   - a, b: The values to compare.
+//   - na, nb: The indices the values were recorded for.
+//
+// Returns:
+//   - ResultDiff: The comparison summary.
+func DiffResults(a *big.Int, na uint64, b *big.Int, nb uint64) ResultDiff {
+	sa, sb := a.String(), b.String()
+	diff := ResultDiff{NA: na, NB: nb, DigitsA: len(sa), DigitsB: len(sb)}
+	if sa != sb {
+		i := 0
+		for i < len(sa) && i < len(sb) && sa[i] == sb[i] {
+			i++
+		}
+		diff.FirstDifferingDigit = i + 1
+	}
+	diff.Equal = na == nb && diff.FirstDifferingDigit == 0
+	return diff
+}
+
+// FormatResultDiff renders a ResultDiff as a human-readable report.
+//
+// Parameters:
+//   - pathA, pathB: The compared file names, used as labels.
+//   - diff: The comparison to describe.
+//
+// Returns:
+//   - string: The report, ending with a newline.
+func FormatResultDiff(pathA, pathB string, diff ResultDiff) string {
+	if diff.Equal {
+		return fmt.Sprintf("Results are identical: F(%d), %d digits.\n", diff.NA, diff.DigitsA)
+	}
+	report := fmt.Sprintf("Results differ:\n  %s: F(%d), %d digits\n  %s: F(%d), %d digits\n",
+		pathA, diff.NA, diff.DigitsA, pathB, diff.NB, diff.DigitsB)
+	if diff.NA != diff.NB {
+		report += "  Indices differ.\n"
+	}
+	if diff.FirstDifferingDigit > 0 {
+		report += fmt.Sprintf("  First differing digit: position %d (from the most significant)\n", diff.FirstDifferingDigit)
+		report += fmt.Sprintf("  Digit-length difference: %+d\n", diff.DigitsB-diff.DigitsA)
+	} else {
+		report += "  Values are identical.\n"
+	}
+	return report
+}
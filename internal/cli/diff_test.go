@@ -0,0 +1,51 @@
+package cli
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestDiffResults(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		a, b      int64
+		na, nb    uint64
+		wantEqual bool
+		wantFirst int
+	}{
+		{"identical", 12586269025, 12586269025, 50, 50, true, 0},
+		{"middle digit", 12586269025, 12586279025, 50, 50, false, 7},
+		{"first digit", 55, 89, 10, 10, false, 1},
+		{"prefix", 1258, 12586, 50, 50, false, 5},
+		{"index only", 55, 55, 10, 11, false, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			diff := DiffResults(big.NewInt(tt.a), tt.na, big.NewInt(tt.b), tt.nb)
+			if diff.Equal != tt.wantEqual {
+				t.Errorf("Equal = %v, want %v", diff.Equal, tt.wantEqual)
+			}
+			if diff.FirstDifferingDigit != tt.wantFirst {
+				t.Errorf("FirstDifferingDigit = %d, want %d", diff.FirstDifferingDigit, tt.wantFirst)
+			}
+		})
+	}
+}
+
+func TestFormatResultDiff(t *testing.T) {
+	t.Parallel()
+	same := FormatResultDiff("a.txt", "b.txt", DiffResults(big.NewInt(55), 10, big.NewInt(55), 10))
+	if !strings.Contains(same, "identical") {
+		t.Errorf("expected identical report, got %q", same)
+	}
+
+	differ := FormatResultDiff("a.txt", "b.txt", DiffResults(big.NewInt(1258), 50, big.NewInt(12586), 50))
+	for _, want := range []string{"Results differ", "a.txt", "position 5", "Digit-length difference: +1"} {
+		if !strings.Contains(differ, want) {
+			t.Errorf("report missing %q:\n%s", want, differ)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"math/big"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// JSONReport is the document written by --json.
+type JSONReport struct {
+	// N is the Fibonacci index.
+	N uint64 `json:"n"`
+	// Results lists every algorithm, successful ones first by duration.
+	Results []JSONAlgorithmResult `json:"results"`
+	// Fastest is the name of the fastest successful algorithm.
+	Fastest string `json:"fastest,omitempty"`
+	// Consistent reports whether the successful results agree (with the
+	// expected value, if any). It is false when no algorithm succeeded.
+	Consistent bool `json:"consistent"`
+	// Mismatch describes the disagreement when Consistent is false.
+	Mismatch *orchestration.Mismatch `json:"mismatch,omitempty"`
+	// Error is the error of the first failed algorithm when none succeeded.
+	Error string `json:"error,omitempty"`
+}
+
+// JSONAlgorithmResult is the outcome of one algorithm in a JSONReport.
+type JSONAlgorithmResult struct {
+	Algorithm  string `json:"algorithm"`
+	DurationNs int64  `json:"duration_ns"`
+	// BitLen and Digits are the binary and decimal size of the result; both
+	// are 0 for a failed algorithm.
+	BitLen int `json:"bitlen"`
+	Digits int `json:"digits"`
+	// Value is the decimal result, included on request.
+	Value string `json:"value,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+// JSONResultPresenter implements orchestration.ResultPresenter and
+// orchestration.ErrorHandler by collecting the results into a JSONReport
+// instead of printing them: the out writers passed by the orchestration
+// layer are ignored, and WriteJSON writes the document once the analysis is
+// done. Use a pointer, as the presenter accumulates state.
+type JSONResultPresenter struct {
+	// N is the Fibonacci index reported in the document.
+	N uint64
+	// IncludeValue adds the decimal value of each result.
+	IncludeValue bool
+	// Expected, if non-nil, is the known-correct F(N) the results are
+	// checked against.
+	Expected *big.Int
+
+	report JSONReport
+}
+
+// Verify interface compliance.
+var (
+	_ orchestration.ResultPresenter   = (*JSONResultPresenter)(nil)
+	_ orchestration.DurationFormatter = (*JSONResultPresenter)(nil)
+	_ orchestration.ErrorHandler      = (*JSONResultPresenter)(nil)
+)
+
+// PresentComparisonTable records every result with its size, and whether
+// the successful results are consistent.
+func (p *JSONResultPresenter) PresentComparisonTable(results []orchestration.CalculationResult, _ io.Writer) {
+	p.report.Results = make([]JSONAlgorithmResult, 0, len(results))
+	successes := 0
+	for _, res := range results {
+		entry := JSONAlgorithmResult{Algorithm: res.Name, DurationNs: res.Duration.Nanoseconds()}
+		if res.Err != nil {
+			entry.Error = res.Err.Error()
+		} else {
+			successes++
+			entry.BitLen = res.Result.BitLen()
+			entry.Digits = format.DigitCount(res.Result)
+			if p.IncludeValue {
+				entry.Value = res.Result.String()
+			}
+		}
+		p.report.Results = append(p.report.Results, entry)
+	}
+	p.report.Mismatch = orchestration.FindMismatch(results, p.Expected)
+	p.report.Consistent = successes > 0 && p.report.Mismatch == nil
+}
+
+// PresentResult records the algorithm chosen by the analysis, the fastest
+// successful one.
+func (p *JSONResultPresenter) PresentResult(result orchestration.CalculationResult, _ uint64, _, _, _ bool, _ io.Writer) {
+	p.report.Fastest = result.Name
+}
+
+// FormatDuration formats a duration for the human-readable fields of the
+// analysis, which the JSON document does not include.
+func (*JSONResultPresenter) FormatDuration(d time.Duration) string {
+	return d.String()
+}
+
+// HandleError records the error of a failed comparison and returns the
+// exit code it maps to, without printing anything.
+func (p *JSONResultPresenter) HandleError(err error, duration time.Duration, _ io.Writer) int {
+	if err != nil {
+		p.report.Error = err.Error()
+	}
+	return apperrors.HandleCalculationError(err, duration, io.Discard, nil)
+}
+
+// Report returns the document collected so far.
+func (p *JSONResultPresenter) Report() JSONReport {
+	report := p.report
+	report.N = p.N
+	if report.Results == nil {
+		report.Results = []JSONAlgorithmResult{}
+	}
+	return report
+}
+
+// WriteJSON writes the collected document as indented JSON.
+//
+// Parameters:
+//   - out: The output writer.
+//
+// Returns:
+//   - error: An error if the document cannot be written.
+func (p *JSONResultPresenter) WriteJSON(out io.Writer) error {
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+	return enc.Encode(p.Report())
+}
@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// analyzeJSON runs the standard analysis through a JSONResultPresenter and
+// decodes the emitted document.
+func analyzeJSON(t *testing.T, p *JSONResultPresenter, results []orchestration.CalculationResult) (JSONReport, int) {
+	t.Helper()
+	opts := orchestration.PresentationOptions{N: p.N, Expected: p.Expected}
+	code := orchestration.AnalyzeComparisonResults(results, opts, p, p, io.Discard)
+	var buf bytes.Buffer
+	if err := p.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var report JSONReport
+	dec := json.NewDecoder(&buf)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&report); err != nil {
+		t.Fatalf("invalid JSON document %q: %v", buf.String(), err)
+	}
+	return report, code
+}
+
+func TestJSONResultPresenter(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Slow", Result: big.NewInt(55), Duration: 3 * time.Millisecond},
+		{Name: "Broken", Err: errors.New("boom"), Duration: time.Millisecond},
+		{Name: "Fast", Result: big.NewInt(55), Duration: 2 * time.Millisecond},
+	}
+	report, code := analyzeJSON(t, &JSONResultPresenter{N: 10, IncludeValue: true}, results)
+
+	if code != apperrors.ExitSuccess {
+		t.Errorf("exit code = %d, want %d", code, apperrors.ExitSuccess)
+	}
+	if report.N != 10 || report.Fastest != "Fast" || !report.Consistent || report.Mismatch != nil || report.Error != "" {
+		t.Errorf("unexpected report %+v", report)
+	}
+	want := []JSONAlgorithmResult{
+		{Algorithm: "Fast", DurationNs: 2_000_000, BitLen: 6, Digits: 2, Value: "55"},
+		{Algorithm: "Slow", DurationNs: 3_000_000, BitLen: 6, Digits: 2, Value: "55"},
+		{Algorithm: "Broken", DurationNs: 1_000_000, Error: "boom"},
+	}
+	if len(report.Results) != len(want) {
+		t.Fatalf("got %d results, want %d", len(report.Results), len(want))
+	}
+	for i := range want {
+		if report.Results[i] != want[i] {
+			t.Errorf("result %d = %+v, want %+v", i, report.Results[i], want[i])
+		}
+	}
+}
+
+func TestJSONResultPresenterValueOmitted(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{{Name: "Fast", Result: big.NewInt(55), Duration: time.Millisecond}}
+	report, _ := analyzeJSON(t, &JSONResultPresenter{N: 10}, results)
+	if len(report.Results) != 1 || report.Results[0].Value != "" || report.Results[0].Digits != 2 {
+		t.Errorf("unexpected results %+v", report.Results)
+	}
+}
+
+func TestJSONResultPresenterMismatch(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Good", Result: big.NewInt(55), Duration: time.Millisecond},
+		{Name: "Bad", Result: big.NewInt(56), Duration: 2 * time.Millisecond},
+	}
+	report, code := analyzeJSON(t, &JSONResultPresenter{N: 10}, results)
+	if code != apperrors.ExitErrorMismatch {
+		t.Errorf("exit code = %d, want %d", code, apperrors.ExitErrorMismatch)
+	}
+	if report.Consistent || report.Mismatch == nil || report.Mismatch.Kind != orchestration.MismatchInconsistent {
+		t.Errorf("expected an inconsistent report, got %+v", report)
+	}
+
+	// A consistent pair that disagrees with the known value is not consistent either.
+	results[0].Result = big.NewInt(56)
+	report, _ = analyzeJSON(t, &JSONResultPresenter{N: 10, Expected: big.NewInt(55)}, results)
+	if report.Consistent || report.Mismatch == nil {
+		t.Errorf("expected a mismatch with the expected value, got %+v", report)
+	}
+}
+
+func TestJSONResultPresenterAllFailed(t *testing.T) {
+	t.Parallel()
+	timeout := apperrors.TimeoutError{Operation: "compute", Limit: time.Second}
+	results := []orchestration.CalculationResult{{Name: "Fast", Err: timeout, Duration: time.Second}}
+	report, code := analyzeJSON(t, &JSONResultPresenter{N: 10}, results)
+	if code != apperrors.ExitErrorTimeout {
+		t.Errorf("exit code = %d, want %d", code, apperrors.ExitErrorTimeout)
+	}
+	if report.Consistent || report.Fastest != "" || report.Error != timeout.Error() {
+		t.Errorf("unexpected report %+v", report)
+	}
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// MarkdownResultPresenter implements orchestration.ResultPresenter and
+// orchestration.ErrorHandler by collecting the results as a Markdown table,
+// for pasting comparisons into issues and documentation: the out writers
+// passed by the orchestration layer are ignored, and WriteMarkdown writes the
+// table once the analysis is done. The fastest algorithm is bolded and no
+// colors are emitted. Use a pointer, as the presenter accumulates state.
+type MarkdownResultPresenter struct {
+	// Expected, if non-nil, is the known-correct value the results are
+	// checked against.
+	Expected *big.Int
+
+	results  []orchestration.CalculationResult
+	mismatch *orchestration.Mismatch
+}
+
+// Verify interface compliance.
+var (
+	_ orchestration.ResultPresenter   = (*MarkdownResultPresenter)(nil)
+	_ orchestration.DurationFormatter = (*MarkdownResultPresenter)(nil)
+	_ orchestration.ErrorHandler      = (*MarkdownResultPresenter)(nil)
+)
+
+// PresentComparisonTable records the results, successful ones first by
+// duration, and whether they are consistent.
+func (p *MarkdownResultPresenter) PresentComparisonTable(results []orchestration.CalculationResult, _ io.Writer) {
+	p.results = results
+	p.mismatch = orchestration.FindMismatch(results, p.Expected)
+}
+
+// PresentResult does nothing: the fastest algorithm is bolded in the table.
+func (*MarkdownResultPresenter) PresentResult(orchestration.CalculationResult, uint64, bool, bool, bool, io.Writer) {
+}
+
+// FormatDuration formats a duration as in the table.
+func (*MarkdownResultPresenter) FormatDuration(d time.Duration) string {
+	return format.FormatExecutionDuration(d)
+}
+
+// HandleError returns the exit code of a failed comparison without printing
+// anything; the failed algorithms still get a row.
+func (*MarkdownResultPresenter) HandleError(err error, duration time.Duration, _ io.Writer) int {
+	return apperrors.HandleCalculationError(err, duration, io.Discard, nil)
+}
+
+// WriteMarkdown writes the collected results as a table with the columns
+// Algorithm, Duration, Digits and Status. The status is OK, Mismatch for an
+// algorithm that disagrees with the others or with Expected, or the error of
+// a failed algorithm.
+//
+// Parameters:
+//   - out: The output writer.
+//
+// Returns:
+//   - error: An error if the table cannot be written.
+func (p *MarkdownResultPresenter) WriteMarkdown(out io.Writer) error {
+	var b strings.Builder
+	b.WriteString("| Algorithm | Duration | Digits | Status |\n")
+	b.WriteString("|---|---:|---:|---|\n")
+	best := orchestration.FindBestResult(p.results)
+	for i := range p.results {
+		res := &p.results[i]
+		name, digits, status := markdownEscape(res.Name), "", "OK"
+		switch {
+		case res.Err != nil:
+			status = "Error: " + markdownEscape(res.Err.Error())
+		case p.mismatch != nil && slices.Contains(p.mismatch.Algorithms, res.Name):
+			status = "Mismatch"
+		}
+		if res.Err == nil {
+			digits = format.FormatNumberString(fmt.Sprint(format.DigitCount(res.Result)))
+		}
+		if res == best {
+			name = "**" + name + "**"
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", name, format.FormatExecutionDuration(res.Duration), digits, status)
+	}
+	_, err := io.WriteString(out, b.String())
+	return err
+}
+
+// markdownEscape escapes the characters that would break a table cell.
+func markdownEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(s)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+func TestMarkdownResultPresenter(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Slow", Result: big.NewInt(55), Duration: 3 * time.Millisecond},
+		{Name: "Broken", Err: errors.New("boom | bust"), Duration: time.Millisecond},
+		{Name: "Fast", Result: big.NewInt(55), Duration: 2 * time.Millisecond},
+	}
+	p := &MarkdownResultPresenter{}
+	orchestration.AnalyzeComparisonResults(results, orchestration.PresentationOptions{N: 10}, p, p, io.Discard)
+	var buf bytes.Buffer
+	if err := p.WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	got := buf.String()
+
+	lines := strings.Split(strings.TrimSuffix(got, "\n"), "\n")
+	if len(lines) != 5 {
+		t.Fatalf("got %d lines, want a header, a separator and 3 rows:\n%s", len(lines), got)
+	}
+	if lines[0] != "| Algorithm | Duration | Digits | Status |" || !strings.HasPrefix(lines[1], "|---|") {
+		t.Errorf("missing header or separator row:\n%s", got)
+	}
+	if !strings.HasPrefix(lines[2], "| **Fast** |") || !strings.HasSuffix(lines[2], "| 2 | OK |") {
+		t.Errorf("the fastest algorithm should come first, bolded: %q", lines[2])
+	}
+	if !strings.HasPrefix(lines[3], "| Slow |") {
+		t.Errorf("only the fastest algorithm should be bolded: %q", lines[3])
+	}
+	if !strings.HasSuffix(lines[4], `| Error: boom \| bust |`) {
+		t.Errorf("the error should be escaped in its cell: %q", lines[4])
+	}
+	if strings.Contains(got, "\x1b") {
+		t.Errorf("output should not contain ANSI escape codes:\n%s", got)
+	}
+}
+
+func TestMarkdownResultPresenterMismatch(t *testing.T) {
+	t.Parallel()
+	results := []orchestration.CalculationResult{
+		{Name: "Fast", Result: big.NewInt(55), Duration: time.Millisecond},
+		{Name: "Wrong", Result: big.NewInt(54), Duration: 2 * time.Millisecond},
+	}
+	p := &MarkdownResultPresenter{Expected: big.NewInt(55)}
+	orchestration.AnalyzeComparisonResults(results, orchestration.PresentationOptions{N: 10, Expected: p.Expected}, p, p, io.Discard)
+	var buf bytes.Buffer
+	if err := p.WriteMarkdown(&buf); err != nil {
+		t.Fatalf("WriteMarkdown() error = %v", err)
+	}
+	if !strings.Contains(buf.String(), "| Wrong |") || !strings.Contains(buf.String(), "| Mismatch |") {
+		t.Errorf("the disagreeing algorithm should be marked:\n%s", buf.String())
+	}
+}
@@ -16,19 +16,27 @@
 //     They handle file creation, directory setup, and error handling.
 //     Examples: [WriteResultToFile].
 //
+//   - Read* functions parse files produced by their Write* counterparts.
+//     Examples: [ReadResultFromFile].
+//
 //   - Print* functions write to stdout as convenience wrappers.
 //     Examples: [PrintExecutionConfig], [PrintExecutionMode].
 
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"io"
 	"math/big"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/metrics"
+	"github.com/agbru/fibcalc/internal/orchestration"
 	"github.com/agbru/fibcalc/internal/ui"
 )
 
@@ -36,14 +44,36 @@ import (
 type OutputConfig struct {
 	// OutputFile is the path to save the result (empty for no file output).
 	OutputFile string
+	// SVGFile is the path of the SVG result card (empty for no card).
+	SVGFile string
 	// Quiet mode suppresses verbose output.
 	Quiet bool
 	// Verbose shows the full result value.
 	Verbose bool
 	// ShowValue enables the calculated value display when true (disabled by default).
 	ShowValue bool
+	// MaxValueBytes caps the size of the displayed value (0 for no limit).
+	// Larger values are truncated with a notice; file output is not affected.
+	MaxValueBytes int
+	// Scientific displays the value in scientific notation with its digit
+	// count; file output is not affected.
+	Scientific bool
+	// Base is the base of the displayed value (0 or 10 for decimal); file
+	// output is not affected.
+	Base int
+	// Edges is the number of leading and trailing digits of a truncated
+	// value (0 for DisplayEdges, or HexDisplayEdges in other bases).
+	Edges int
+	// Memory, if set, is the memory report of the calculation, shown in
+	// details mode.
+	Memory *metrics.MemoryReport
 }
 
+// streamResultBits is the size above which WriteResultToFile streams the
+// decimal value to the file (see format.WriteDecimal) instead of building
+// its string, which would hold about 300,000 digits at this size.
+const streamResultBits = 1 << 20
+
 // WriteResultToFile writes a calculation result to a file.
 //
 // Parameters:
@@ -81,15 +111,125 @@ func WriteResultToFile(result *big.Int, n uint64, duration time.Duration, algo s
 	fmt.Fprintf(file, "# Duration: %s\n", duration)
 	fmt.Fprintf(file, "# N: %d\n", n)
 	fmt.Fprintf(file, "# Bits: %d\n", result.BitLen())
-	fmt.Fprintf(file, "# Digits: %d\n", len(result.String()))
+	fmt.Fprintf(file, "# Digits: %d\n", format.DigitCount(result))
 	fmt.Fprintf(file, "\n")
 
-	// Write result
-	fmt.Fprintf(file, "F(%d) =\n%s\n", n, result.String())
+	// Write result, streaming large values instead of building their string
+	fmt.Fprintf(file, "F(%d) =\n", n)
+	if result.BitLen() > streamResultBits {
+		if err := format.WriteDecimal(file, result); err != nil {
+			return fmt.Errorf("failed to write output file: %w", err)
+		}
+	} else if _, err := io.WriteString(file, result.String()); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	if _, err := io.WriteString(file, "\n"); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
 
 	return nil
 }
 
+// ReadResultFromFile reads back a result file produced by WriteResultToFile.
+// Header comment lines are skipped; the value is taken from the line that
+// follows the "F(n) =" marker.
+//
+// Parameters:
+//   - path: The path of the result file.
+//
+// Returns:
+//   - *big.Int: The parsed Fibonacci number.
+//   - uint64: The index n recorded in the file.
+//   - error: An error if the file cannot be read or its format is not recognized.
+func ReadResultFromFile(path string) (*big.Int, uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to open result file: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	// Values for large n span a single very long line.
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<30)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var n uint64
+		if _, err := fmt.Sscanf(line, "F(%d) =", &n); err != nil {
+			return nil, 0, fmt.Errorf("unrecognized result line %q", line)
+		}
+		if !scanner.Scan() {
+			break
+		}
+		value, ok := new(big.Int).SetString(strings.TrimSpace(scanner.Text()), 10)
+		if !ok {
+			return nil, 0, fmt.Errorf("invalid value for F(%d)", n)
+		}
+		return value, n, nil
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to read result file: %w", err)
+	}
+	return nil, 0, fmt.Errorf("no result found in %s", path)
+}
+
+// FormatLimitedValue guards the value-writing path against runaway output.
+// If value fits in maxBytes it is returned unchanged; otherwise it is cut to
+// maxBytes bytes and followed by a notice on its own line.
+//
+// Parameters:
+//   - value: The formatted value to display.
+//   - maxBytes: The maximum number of value bytes to keep (0 or less for no limit).
+//
+// Returns:
+//   - string: The value, possibly truncated with a notice.
+func FormatLimitedValue(value string, maxBytes int) string {
+	if maxBytes <= 0 || len(value) <= maxBytes {
+		return value
+	}
+	return fmt.Sprintf("%s\n[output truncated: showing %d of %d bytes; raise --limit-output-bytes or use --output to save the full value]",
+		value[:maxBytes], maxBytes, len(value))
+}
+
+// FormatZeckendorf formats a Zeckendorf representation as a sum of
+// Fibonacci terms, e.g. "F(11) + F(6) + F(4)" for 100.
+//
+// Parameters:
+//   - indices: The term indices, as returned by fibonacci.Zeckendorf.
+//
+// Returns:
+//   - string: The formatted sum, or "0" for an empty representation.
+func FormatZeckendorf(indices []uint64) string {
+	if len(indices) == 0 {
+		return "0"
+	}
+	terms := make([]string, len(indices))
+	for i, k := range indices {
+		terms[i] = fmt.Sprintf("F(%d)", k)
+	}
+	return strings.Join(terms, " + ")
+}
+
+// DisplayZeckendorf outputs the Zeckendorf representation of a result. In
+// quiet mode only the formatted sum is printed.
+//
+// Parameters:
+//   - out: The output writer.
+//   - indices: The term indices, as returned by fibonacci.Zeckendorf.
+//   - quiet: Whether to omit the section header and term count.
+func DisplayZeckendorf(out io.Writer, indices []uint64, quiet bool) {
+	if quiet {
+		fmt.Fprintln(out, FormatZeckendorf(indices))
+		return
+	}
+	fmt.Fprintf(out, "\n%s--- Zeckendorf representation ---%s\n", ui.ColorBold(), ui.ColorReset())
+	fmt.Fprintf(out, "Terms : %d\n", len(indices))
+	fmt.Fprintf(out, "Sum   : %s%s%s\n", ui.ColorCyan(), FormatZeckendorf(indices), ui.ColorReset())
+}
+
 // FormatQuietResult formats a result for quiet mode output.
 // Returns a single-line result suitable for scripting.
 //
@@ -112,7 +252,41 @@ func FormatQuietResult(result *big.Int, n uint64, duration time.Duration) string
 //   - n: The index.
 //   - duration: The calculation duration.
 func DisplayQuietResult(out io.Writer, result *big.Int, n uint64, duration time.Duration) {
-	fmt.Fprintln(out, FormatQuietResult(result, n, duration))
+	DisplayQuietResultLimited(out, result, n, duration, 0)
+}
+
+// DisplayQuietResultLimited is like DisplayQuietResult but truncates values
+// longer than maxBytes (see FormatLimitedValue).
+//
+// Parameters:
+//   - out: The output writer.
+//   - result: The calculated Fibonacci number.
+//   - n: The index.
+//   - duration: The calculation duration.
+//   - maxBytes: The maximum number of value bytes to print (0 for no limit).
+func DisplayQuietResultLimited(out io.Writer, result *big.Int, n uint64, duration time.Duration, maxBytes int) {
+	fmt.Fprintln(out, FormatLimitedValue(FormatQuietResult(result, n, duration), maxBytes))
+}
+
+// DisplayQuietValue outputs a result in quiet mode in the notation selected
+// by config: scientific notation, digits in config.Base, or decimal digits,
+// the latter two limited to config.MaxValueBytes. Digits are not grouped.
+//
+// Parameters:
+//   - out: The output writer.
+//   - result: The calculated Fibonacci number.
+//   - n: The index.
+//   - duration: The calculation duration.
+//   - config: Output configuration.
+func DisplayQuietValue(out io.Writer, result *big.Int, n uint64, duration time.Duration, config OutputConfig) {
+	switch {
+	case config.Scientific:
+		fmt.Fprintln(out, format.FormatScientific(result, ScientificSigFigs))
+	case config.Base != 0 && config.Base != 10:
+		fmt.Fprintln(out, FormatLimitedValue(result.Text(config.Base), config.MaxValueBytes))
+	default:
+		DisplayQuietResultLimited(out, result, n, duration, config.MaxValueBytes)
+	}
 }
 
 // DisplayResultWithConfig displays a result with the given output configuration.
@@ -131,10 +305,11 @@ func DisplayQuietResult(out io.Writer, result *big.Int, n uint64, duration time.
 func DisplayResultWithConfig(out io.Writer, result *big.Int, n uint64, duration time.Duration, algo string, config OutputConfig) error {
 	// Handle quiet mode
 	if config.Quiet {
-		DisplayQuietResult(out, result, n, duration)
+		DisplayQuietValue(out, result, n, duration, config)
 	} else {
 		// Use standard display
-		DisplayResult(result, n, duration, config.Verbose, true, config.ShowValue, out)
+		presenter := CLIResultPresenter{MaxValueBytes: config.MaxValueBytes, Scientific: config.Scientific, Base: config.Base}
+		presenter.PresentResult(orchestration.CalculationResult{Result: result, Duration: duration}, n, config.Verbose, true, config.ShowValue, out)
 	}
 
 	// Save to file if requested
@@ -2,12 +2,17 @@ package cli
 
 import (
 	"bytes"
+	"fmt"
+	"io"
 	"math/big"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
+	"github.com/agbru/fibcalc/internal/orchestration"
 )
 
 func TestWriteResultToFile(t *testing.T) {
@@ -83,6 +88,82 @@ func TestWriteResultToFile(t *testing.T) {
 	}
 }
 
+func TestReadResultFromFile(t *testing.T) {
+	t.Parallel()
+	tmpDir := t.TempDir()
+
+	t.Run("Round-trips WriteResultToFile output", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(tmpDir, "roundtrip.txt")
+		want, _ := new(big.Int).SetString("43466557686937456435688527675040625802564660517371780402481729089536555417949051890403879840079255169295922593080322634775209689623239873322471161642996440906533187938298969649928516003704476137795166849228875", 10)
+		if err := WriteResultToFile(want, 1000, time.Second, "fast", OutputConfig{OutputFile: path}); err != nil {
+			t.Fatalf("WriteResultToFile failed: %v", err)
+		}
+
+		got, n, err := ReadResultFromFile(path)
+		if err != nil {
+			t.Fatalf("ReadResultFromFile failed: %v", err)
+		}
+		if n != 1000 {
+			t.Errorf("n = %d, want 1000", n)
+		}
+		if got.Cmp(want) != 0 {
+			t.Errorf("value mismatch after round-trip")
+		}
+	})
+
+	t.Run("Streams large values", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(tmpDir, "streamed.txt")
+		want := new(big.Int).Exp(big.NewInt(3), big.NewInt(700_000), nil)
+		if want.BitLen() <= streamResultBits {
+			t.Fatalf("test value of %d bits is not streamed", want.BitLen())
+		}
+		if err := WriteResultToFile(want, 700_000, time.Second, "fast", OutputConfig{OutputFile: path}); err != nil {
+			t.Fatalf("WriteResultToFile failed: %v", err)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+		digits := want.String()
+		if !strings.HasSuffix(string(content), "F(700000) =\n"+digits+"\n") {
+			t.Error("streamed value differs from result.String()")
+		}
+		if !strings.Contains(string(content), fmt.Sprintf("# Digits: %d\n", len(digits))) {
+			t.Errorf("header should report %d digits", len(digits))
+		}
+	})
+
+	t.Run("Rejects unparseable formats", func(t *testing.T) {
+		t.Parallel()
+		cases := map[string]string{
+			"separator":   "# header\n\nF(10) :\n55\n",
+			"value":       "# header\n\nF(10) =\n55,000\n",
+			"missing":     "# header only\n",
+			"truncated":   "F(10) =\n",
+			"unknown key": "G(10) =\n55\n",
+		}
+		for name, content := range cases {
+			path := filepath.Join(tmpDir, "bad_"+strings.ReplaceAll(name, " ", "_")+".txt")
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				t.Fatalf("WriteFile failed: %v", err)
+			}
+			if _, _, err := ReadResultFromFile(path); err == nil {
+				t.Errorf("%s: expected an error for content %q", name, content)
+			}
+		}
+	})
+
+	t.Run("Missing file", func(t *testing.T) {
+		t.Parallel()
+		if _, _, err := ReadResultFromFile(filepath.Join(tmpDir, "does-not-exist.txt")); err == nil {
+			t.Error("expected an error for a missing file")
+		}
+	})
+}
+
 func TestFormatQuietResult(t *testing.T) {
 	t.Parallel()
 	result := big.NewInt(55)
@@ -121,6 +202,344 @@ func TestDisplayQuietResult(t *testing.T) {
 	})
 }
 
+func TestFormatZeckendorf(t *testing.T) {
+	t.Parallel()
+	if got := FormatZeckendorf([]uint64{11, 6, 4}); got != "F(11) + F(6) + F(4)" {
+		t.Errorf("Expected 'F(11) + F(6) + F(4)', got '%s'", got)
+	}
+	if got := FormatZeckendorf(nil); got != "0" {
+		t.Errorf("Expected '0' for an empty representation, got '%s'", got)
+	}
+
+	var buf bytes.Buffer
+	DisplayZeckendorf(&buf, []uint64{11, 6, 4}, false)
+	if output := buf.String(); !strings.Contains(output, "Zeckendorf") || !strings.Contains(output, "Terms : 3") {
+		t.Errorf("Output should contain the header and term count, got '%s'", output)
+	}
+}
+
+func TestDisplayRepeatRanking(t *testing.T) {
+	t.Parallel()
+	entries := []orchestration.RankedAlgorithm{
+		{Name: "fast", Stats: orchestration.DurationStats{Median: 10 * time.Millisecond, Q1: 9 * time.Millisecond, Q3: 11 * time.Millisecond, Runs: 5}},
+		{Name: "matrix", Stats: orchestration.DurationStats{Median: 30 * time.Millisecond, Q1: 29 * time.Millisecond, Q3: 31 * time.Millisecond, Runs: 4}},
+	}
+	tests := []struct {
+		name    string
+		ranking orchestration.Ranking
+		want    []string
+	}{
+		{"winner", orchestration.Ranking{Entries: entries, Winner: "fast"},
+			[]string{"Repeated Comparison (5 runs)", "9ms - 11ms", "(4/5 successful runs)", "Winner: ", "significantly faster"}},
+		{"tie", orchestration.Ranking{Entries: entries},
+			[]string{"tie within noise between fast and matrix"}},
+		{"empty", orchestration.Ranking{},
+			[]string{"No successful run to rank."}},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		DisplayRepeatRanking(&buf, tt.ranking, 5)
+		for _, want := range tt.want {
+			if !strings.Contains(buf.String(), want) {
+				t.Errorf("%s: output should contain %q, got:\n%s", tt.name, want, buf.String())
+			}
+		}
+	}
+}
+
+func TestDisplayRepeatStats(t *testing.T) {
+	t.Parallel()
+	stats := []RepeatStats{
+		{
+			Name:      "fast",
+			Durations: orchestration.ComputeDurationStats([]time.Duration{10 * time.Millisecond, 12 * time.Millisecond, 14 * time.Millisecond}),
+			Allocs:    1234,
+			Bytes:     2 << 20,
+			Failed:    1,
+		},
+		{Name: "matrix", Failed: 4},
+	}
+	var buf bytes.Buffer
+	DisplayRepeatStats(&buf, stats, 5)
+	output := buf.String()
+	for _, want := range []string{"Repeated runs (4 after warm-up)", "Stddev", "Allocs/op", "12ms", "1,234", "2.0 MB", "1 failed", "no successful run (4 failed)"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output should contain %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestDisplayQuietRepeatStats(t *testing.T) {
+	t.Parallel()
+	stats := []RepeatStats{
+		{
+			Name:      "fast",
+			Durations: orchestration.ComputeDurationStats([]time.Duration{10 * time.Millisecond, 12 * time.Millisecond, 14 * time.Millisecond}),
+			Allocs:    1234,
+			Bytes:     2 << 20,
+			Failed:    1,
+		},
+		{Name: "matrix", Failed: 4},
+	}
+	var buf bytes.Buffer
+	DisplayQuietRepeatStats(&buf, stats)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected one line per algorithm, got:\n%s", buf.String())
+	}
+	for _, want := range []string{"fast ", "median=12ms", "allocs/op=1234", "bytes/op=2097152", "failed=1"} {
+		if !strings.Contains(lines[0], want) {
+			t.Errorf("line %q should contain %q", lines[0], want)
+		}
+	}
+	if lines[1] != "matrix failed=4" {
+		t.Errorf("line = %q, want %q", lines[1], "matrix failed=4")
+	}
+}
+
+func TestDisplayStepTimings(t *testing.T) {
+	t.Parallel()
+	steps := []threshold.IterationMetric{
+		{BitLen: 1, Duration: time.Millisecond},
+		{BitLen: 600000, Duration: 3 * time.Millisecond, UsedFFT: true, UsedParallel: true},
+	}
+	var buf bytes.Buffer
+	DisplayStepTimings(&buf, steps)
+	for _, want := range []string{"Doubling steps (2)", "600,000", "yes", "Total: ", "(slowest step: 75.0%)"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("output should contain %q, got:\n%s", want, buf.String())
+		}
+	}
+
+	buf.Reset()
+	DisplayStepTimings(&buf, nil)
+	if !strings.Contains(buf.String(), "No doubling step was recorded") {
+		t.Errorf("expected a notice without steps, got:\n%s", buf.String())
+	}
+}
+
+// TestLargeOutputGuard checks that above the threshold on a simulated
+// terminal the full value is withheld unless confirmed.
+func TestLargeOutputGuard(t *testing.T) {
+	t.Parallel()
+	// F(1000) has 209 digits, above the truncation limit and the threshold.
+	value, _ := new(big.Int).SetString("43466557686937456435688527675040625802564660517371780402481729089536555417949051890403879840079255169295922593080322634775209689623239873322471161642996440906533187938298969649928516003704476137795166849228875", 10)
+	fullValue := "43,466,557,686,937"
+	terminal := func(io.Writer) bool { return true }
+	tests := []struct {
+		name        string
+		guard       LargeOutputGuard
+		wantFull    bool
+		wantWarning bool
+	}{
+		{"withheld without confirmation", LargeOutputGuard{Threshold: 100, IsTerminal: terminal}, false, true},
+		{"prompt declined", LargeOutputGuard{Threshold: 100, IsTerminal: terminal, Prompt: strings.NewReader("\n")}, false, true},
+		{"prompt accepted", LargeOutputGuard{Threshold: 100, IsTerminal: terminal, Prompt: strings.NewReader("y\n")}, true, true},
+		{"confirmed with --yes", LargeOutputGuard{Threshold: 100, IsTerminal: terminal, Confirmed: true}, true, false},
+		{"below the threshold", LargeOutputGuard{Threshold: 1000, IsTerminal: terminal}, true, false},
+		{"disabled", LargeOutputGuard{IsTerminal: terminal}, true, false},
+		{"not a terminal", LargeOutputGuard{Threshold: 100, IsTerminal: func(io.Writer) bool { return false }}, true, false},
+		{"in-memory buffer", LargeOutputGuard{Threshold: 100}, true, false},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		presenter := CLIResultPresenter{LargeOutput: tt.guard}
+		presenter.PresentResult(orchestration.CalculationResult{Result: value}, 1000, true, false, true, &buf)
+		output := buf.String()
+		if got := strings.Contains(output, fullValue); got != tt.wantFull {
+			t.Errorf("%s: full value printed = %v, want %v. Output:\n%s", tt.name, got, tt.wantFull, output)
+		}
+		if !tt.wantFull && !strings.Contains(output, "(truncated)") {
+			t.Errorf("%s: the withheld value should fall back to the truncated display. Output:\n%s", tt.name, output)
+		}
+		if got := strings.Contains(output, "--warn-large-output threshold of 100"); got != tt.wantWarning {
+			t.Errorf("%s: warning printed = %v, want %v. Output:\n%s", tt.name, got, tt.wantWarning, output)
+		}
+	}
+}
+
+func TestDisplayResultWithConfigScientific(t *testing.T) {
+	t.Parallel()
+	value, _ := new(big.Int).SetString("354224848179261915075", 10) // F(100)
+
+	var buf bytes.Buffer
+	if err := DisplayResultWithConfig(&buf, value, 100, time.Millisecond, "fast", OutputConfig{ShowValue: true, Verbose: true, Scientific: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if output := buf.String(); !strings.Contains(output, "3.54225×10^20 (21 digits)") || strings.Contains(output, "354,224") {
+		t.Errorf("the value should be shown in scientific notation only, got:\n%s", output)
+	}
+
+	buf.Reset()
+	if err := DisplayResultWithConfig(&buf, value, 100, time.Millisecond, "fast", OutputConfig{Quiet: true, Scientific: true}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := buf.String(); got != "3.54225×10^20 (21 digits)\n" {
+		t.Errorf("quiet scientific output = %q", got)
+	}
+}
+
+func TestFormatLimitedValue(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name      string
+		value     string
+		maxBytes  int
+		want      string
+		truncated bool
+	}{
+		{"NoLimit", "123456789", 0, "123456789", false},
+		{"UnderLimit", "12345", 10, "12345", false},
+		{"AtLimit", "1234567890", 10, "1234567890", false},
+		{"OverLimit", "123456789012", 10, "1234567890", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			got := FormatLimitedValue(tc.value, tc.maxBytes)
+			if !strings.HasPrefix(got, tc.want) {
+				t.Errorf("FormatLimitedValue(%q, %d) = %q, want prefix %q", tc.value, tc.maxBytes, got, tc.want)
+			}
+			hasNotice := strings.Contains(got, "output truncated")
+			if hasNotice != tc.truncated {
+				t.Errorf("truncation notice present = %v, want %v (got %q)", hasNotice, tc.truncated, got)
+			}
+			if !tc.truncated && got != tc.value {
+				t.Errorf("value under the limit was altered: %q", got)
+			}
+		})
+	}
+}
+
+func TestDisplayCalculatedValueEdges(t *testing.T) {
+	t.Parallel()
+	// F(500) has 105 digits.
+	digits := "139423224561697880139724382870407283950070256587697307264108962948325571622863290691557658876222521294125"
+	result, _ := new(big.Int).SetString(digits, 10)
+
+	tests := []struct {
+		name      string
+		edges     int
+		truncated string
+	}{
+		{"Default", 0, digits[:DisplayEdges] + "..." + digits[len(digits)-DisplayEdges:]},
+		{"Fewer digits", 5, "13942...94125"},
+		{"More digits", 50, digits[:50] + "..." + digits[len(digits)-50:]},
+		{"Raised limit", 60, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			displayCalculatedValue(&buf, result, "F", 500, false, 0, tt.edges)
+			output := buf.String()
+			if tt.truncated == "" {
+				if strings.Contains(output, "truncated") || !strings.Contains(output, "294,125") {
+					t.Errorf("Expected the full value with %d edges, got:\n%s", tt.edges, output)
+				}
+				return
+			}
+			if !strings.Contains(output, "(truncated)") || !strings.Contains(output, tt.truncated) {
+				t.Errorf("Expected %q, got:\n%s", tt.truncated, output)
+			}
+		})
+	}
+
+	t.Run("Other base", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		displayValueInBase(&buf, result, "F", 500, 2, false, 0, 8)
+		bin := result.Text(2)
+		if want := bin[:8] + "..." + bin[len(bin)-8:]; !strings.Contains(buf.String(), want) {
+			t.Errorf("Expected %q, got:\n%s", want, buf.String())
+		}
+	})
+}
+
+func TestPresentResultConfigHash(t *testing.T) {
+	t.Parallel()
+	result := orchestration.CalculationResult{Result: big.NewInt(55), ConfigHash: "0123456789ab"}
+	for _, tt := range []struct {
+		name             string
+		verbose, details bool
+		want             bool
+	}{
+		{"Default", false, false, false},
+		{"Verbose", true, false, true},
+		{"Details", false, true, true},
+	} {
+		var buf bytes.Buffer
+		CLIResultPresenter{}.PresentResult(result, 10, tt.verbose, tt.details, false, &buf)
+		if got := strings.Contains(buf.String(), "0123456789ab"); got != tt.want {
+			t.Errorf("%s: hash printed = %v, want %v:\n%s", tt.name, got, tt.want, buf.String())
+		}
+	}
+}
+
+func TestPresentResultSymbol(t *testing.T) {
+	t.Parallel()
+	result := orchestration.CalculationResult{Result: big.NewInt(123)}
+	for _, tt := range []struct {
+		name            string
+		presenter       CLIResultPresenter
+		want, forbidden string
+	}{
+		{"Default", CLIResultPresenter{}, "F(", "L("},
+		{"Lucas", CLIResultPresenter{Symbol: "L"}, "L(", "F("},
+		{"Lucas scientific", CLIResultPresenter{Symbol: "L", Scientific: true}, "L(", "F("},
+		{"Lucas base 16", CLIResultPresenter{Symbol: "L", Base: 16}, "L(", "F("},
+	} {
+		var buf bytes.Buffer
+		tt.presenter.PresentResult(result, 10, false, false, true, &buf)
+		if output := buf.String(); !strings.Contains(output, tt.want) || strings.Contains(output, tt.forbidden) {
+			t.Errorf("%s: expected %q and no %q, got:\n%s", tt.name, tt.want, tt.forbidden, output)
+		}
+	}
+}
+
+func TestDisplayResultLimited(t *testing.T) {
+	t.Parallel()
+	// F(500) has 105 digits, 139 bytes once digit-grouped.
+	result := new(big.Int)
+	result.SetString("139423224561697880139724382870407283950070256587697307264108962948325571622863290691557658876222521294125", 10)
+
+	t.Run("Over the limit is truncated with a notice", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		DisplayResultLimited(result, 500, 0, true, false, true, 50, &buf)
+		output := buf.String()
+		if !strings.Contains(output, "[output truncated: showing 50 of 139 bytes") {
+			t.Errorf("Expected truncation notice, got:\n%s", output)
+		}
+		if strings.Contains(output, "294,125") {
+			t.Errorf("Expected the end of the value to be cut, got:\n%s", output)
+		}
+	})
+
+	t.Run("Under the limit is printed fully", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		DisplayResultLimited(result, 500, 0, true, false, true, 1000, &buf)
+		output := buf.String()
+		if strings.Contains(output, "output truncated") {
+			t.Errorf("Unexpected truncation notice:\n%s", output)
+		}
+		if !strings.Contains(output, "294,125") {
+			t.Errorf("Expected the full value, got:\n%s", output)
+		}
+	})
+
+	t.Run("Quiet output", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		DisplayQuietResultLimited(&buf, result, 500, 0, 20)
+		if !strings.HasPrefix(buf.String(), result.String()[:20]+"\n[output truncated") {
+			t.Errorf("Expected truncated quiet output, got %q", buf.String())
+		}
+	})
+}
+
 func TestDisplayResultWithConfig(t *testing.T) {
 	t.Parallel()
 	result := big.NewInt(55)
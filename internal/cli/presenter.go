@@ -1,13 +1,21 @@
 package cli
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci/memory"
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
 	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/metrics"
 	"github.com/agbru/fibcalc/internal/progress"
 	"github.com/agbru/fibcalc/internal/orchestration"
 	"github.com/agbru/fibcalc/internal/ui"
@@ -16,20 +24,111 @@ import (
 // CLIProgressReporter implements orchestration.ProgressReporter for CLI output.
 // It wraps the DisplayProgress function to provide a spinner and progress bar
 // display during calculations.
-type CLIProgressReporter struct{}
+type CLIProgressReporter struct {
+	// Weights, if set, weight each calculator's progress in the average by
+	// its expected cost (see orchestration.ProgressWeights).
+	Weights []float64
+	// SpinnerStyle selects the spinner frames (see SpinnerFrames); empty for
+	// the default dots.
+	SpinnerStyle string
+	// SpinnerSpeed is the delay between spinner frames; zero for
+	// ProgressRefreshRate.
+	SpinnerSpeed time.Duration
+	// Plain, if true, replaces the spinner and bar with newline-terminated
+	// "progress: 42%" lines, as is done automatically when the output is
+	// redirected to a file or a pipe.
+	Plain bool
+}
 
 // Verify that CLIProgressReporter implements orchestration.ProgressReporter.
 var _ orchestration.ProgressReporter = CLIProgressReporter{}
 
 // DisplayProgress displays a spinner and progress bar for ongoing calculations.
-func (CLIProgressReporter) DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUpdate, numCalculators int, out io.Writer) {
-	DisplayProgress(wg, progressChan, numCalculators, out)
+func (r CLIProgressReporter) DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUpdate, numCalculators int, out io.Writer) {
+	displayProgress(wg, progressChan, numCalculators, r, out)
 }
 
 // CLIResultPresenter implements orchestration.ResultPresenter for CLI output.
 // It provides formatted, colorized output for calculation results in the
 // command-line interface.
-type CLIResultPresenter struct{}
+type CLIResultPresenter struct {
+	// MaxValueBytes caps the size of the displayed value (0 for no limit).
+	MaxValueBytes int
+	// Scientific displays the value in scientific notation with its digit
+	// count instead of its digits.
+	Scientific bool
+	// Base is the base of the displayed value (0 or 10 for decimal).
+	Base int
+	// Edges is the number of leading and trailing digits of a truncated
+	// value (0 for DisplayEdges, or HexDisplayEdges in other bases).
+	Edges int
+	// Symbol is the symbol of the computed sequence in the value lines, e.g.
+	// "L" for Lucas numbers (see SequenceSymbol). Empty means "F".
+	Symbol string
+	// Memory, if set, is the memory report of the calculation, shown in
+	// details mode.
+	Memory *metrics.MemoryReport
+	// LargeOutput asks for confirmation before a huge value is printed in
+	// full to a terminal.
+	LargeOutput LargeOutputGuard
+}
+
+// LargeOutputGuard withholds the full display of values with more than
+// Threshold digits when the output is a terminal: a warning is printed and
+// the value falls back to its truncated display, unless the user confirmed
+// with --yes or answers yes to the y/N prompt. Output that is not a terminal
+// is never withheld.
+type LargeOutputGuard struct {
+	// Threshold is the number of digits above which confirmation is
+	// required (0 disables the guard).
+	Threshold int
+	// Confirmed, if true, prints the full value without asking (--yes).
+	Confirmed bool
+	// Prompt supplies the answer to the y/N prompt; nil when no interactive
+	// input is available, in which case the value is withheld.
+	Prompt io.Reader
+	// IsTerminal reports whether out is a terminal; nil uses ui.IsTerminal.
+	IsTerminal func(out io.Writer) bool
+}
+
+// AllowFullValue reports whether value may be printed in full to out,
+// printing the warning and prompt when confirmation is required.
+//
+// Parameters:
+//   - out: The destination of the value.
+//   - value: The value about to be displayed.
+//
+// Returns:
+//   - bool: true to print the full value, false for the truncated display.
+func (g LargeOutputGuard) AllowFullValue(out io.Writer, value *big.Int) bool {
+	if g.Threshold <= 0 || g.Confirmed {
+		return true
+	}
+	digits := int(float64(value.BitLen())*math.Log10(2)) + 1
+	if digits <= g.Threshold {
+		return true
+	}
+	isTerminal := g.IsTerminal
+	if isTerminal == nil {
+		isTerminal = func(w io.Writer) bool { return ui.IsTerminal(w) }
+	}
+	if !isTerminal(out) {
+		return true
+	}
+
+	fmt.Fprintf(out, "\n%sWarning:%s the value has about %s digits, above the --warn-large-output threshold of %s.\n",
+		ui.ColorYellow(), ui.ColorReset(), format.FormatNumberString(strconv.Itoa(digits)), format.FormatNumberString(strconv.Itoa(g.Threshold)))
+	if g.Prompt != nil {
+		fmt.Fprint(out, "Print it in full to the terminal? [y/N] ")
+		answer, _ := bufio.NewReader(g.Prompt).ReadString('\n')
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "y", "yes":
+			return true
+		}
+	}
+	fmt.Fprintln(out, "Showing the truncated value; use --yes to print it in full, or --output to save it to a file.")
+	return false
+}
 
 // Verify interface compliance.
 var (
@@ -94,9 +193,36 @@ func padRight(s string, length int) string {
 }
 
 // PresentResult displays the final calculation result using the CLI's
-// DisplayResult function.
-func (CLIResultPresenter) PresentResult(result orchestration.CalculationResult, n uint64, verbose, details, showValue bool, out io.Writer) {
-	DisplayResult(result.Result, n, result.Duration, verbose, details, showValue, out)
+// DisplayResultLimited function, or in scientific notation or another base
+// when Scientific or Base is set. In verbose or details mode, the
+// configuration hash of the result is printed first; in details mode, the
+// memory report follows the detailed analysis when Memory is set.
+func (p CLIResultPresenter) PresentResult(result orchestration.CalculationResult, n uint64, verbose, details, showValue bool, out io.Writer) {
+	if (verbose || details) && result.ConfigHash != "" {
+		fmt.Fprintf(out, "Configuration hash: %s%s%s\n", ui.ColorCyan(), result.ConfigHash, ui.ColorReset())
+	}
+	if verbose && showValue && !p.Scientific {
+		verbose = p.LargeOutput.AllowFullValue(out, result.Result)
+	}
+	DisplayResultLimited(result.Result, n, result.Duration, verbose, details, false, p.MaxValueBytes, out)
+	if details && p.Memory != nil {
+		displayMemoryReport(out, *p.Memory)
+	}
+	if !showValue {
+		return
+	}
+	symbol := p.Symbol
+	if symbol == "" {
+		symbol = "F"
+	}
+	switch {
+	case p.Scientific:
+		displayScientificValue(out, result.Result, symbol, n)
+	case p.Base != 0 && p.Base != 10:
+		displayValueInBase(out, result.Result, symbol, n, p.Base, verbose, p.MaxValueBytes, p.Edges)
+	default:
+		displayCalculatedValue(out, result.Result, symbol, n, verbose, p.MaxValueBytes, p.Edges)
+	}
 }
 
 // FormatDuration formats a duration for display using the CLI's standard
@@ -123,3 +249,182 @@ func DisplayMemoryStats(heapAlloc, totalAlloc uint64, numGC uint32, pauseTotalNs
 	}
 }
 
+// DisplayMemoryExplanation compares the pre-run memory estimate with the
+// actual peak heap in use observed during the calculation, so users can judge
+// the accuracy of the estimate when choosing a --memory-limit.
+func DisplayMemoryExplanation(est memory.MemoryEstimate, peakHeapInuse uint64, out io.Writer) {
+	fmt.Fprintf(out, "\n%s--- Memory explanation ---%s\n", ui.ColorBold(), ui.ColorReset())
+	fmt.Fprintf(out, "Estimated (pre-run)     : %s%s%s\n",
+		ui.ColorCyan(), format.FormatBytes(est.TotalBytes), ui.ColorReset())
+	fmt.Fprintf(out, "  State                 : %s\n", format.FormatBytes(est.StateBytes))
+	fmt.Fprintf(out, "  FFT buffers           : %s\n", format.FormatBytes(est.FFTBufferBytes))
+	fmt.Fprintf(out, "  Transform cache       : %s\n", format.FormatBytes(est.CacheBytes))
+	fmt.Fprintf(out, "  GC/runtime overhead   : %s\n", format.FormatBytes(est.OverheadBytes))
+	fmt.Fprintf(out, "Actual peak heap in use : %s%s%s\n",
+		ui.ColorGreen(), format.FormatBytes(peakHeapInuse), ui.ColorReset())
+	if peakHeapInuse > 0 {
+		fmt.Fprintf(out, "Estimate / actual       : %s%.2fx%s\n",
+			ui.ColorYellow(), float64(est.TotalBytes)/float64(peakHeapInuse), ui.ColorReset())
+	}
+}
+
+// DisplayRepeatRanking prints the ranking of a --compare-repeat comparison:
+// the median duration of each algorithm with its interquartile range as error
+// bar, then the winner, or "tie within noise" when the fastest median is not
+// significantly faster than the runner-up.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - ranking: The ranking computed by orchestration.RankDurations.
+//   - repeat: The number of runs per algorithm.
+func DisplayRepeatRanking(out io.Writer, ranking orchestration.Ranking, repeat int) {
+	fmt.Fprintf(out, "\n%s--- Repeated Comparison (%d runs) ---%s\n", ui.ColorBold(), repeat, ui.ColorReset())
+	if len(ranking.Entries) == 0 {
+		fmt.Fprintln(out, "No successful run to rank.")
+		return
+	}
+
+	maxNameLen := 9 // "Algorithm" header length
+	for _, entry := range ranking.Entries {
+		maxNameLen = max(maxNameLen, len(entry.Name))
+	}
+	fmt.Fprintf(out, "%-4s %-*s   %-10s   %s\n", "Rank", maxNameLen, "Algorithm", "Median", "IQR (Q1 - Q3)")
+	for i, entry := range ranking.Entries {
+		s := entry.Stats
+		runs := ""
+		if s.Runs < repeat {
+			runs = fmt.Sprintf("  (%d/%d successful runs)", s.Runs, repeat)
+		}
+		fmt.Fprintf(out, "%-4d %s%-*s%s   %s%-10s%s   %s - %s%s\n", i+1,
+			ui.ColorBlue(), maxNameLen, entry.Name, ui.ColorReset(),
+			ui.ColorYellow(), format.FormatExecutionDuration(s.Median), ui.ColorReset(),
+			format.FormatExecutionDuration(s.Q1), format.FormatExecutionDuration(s.Q3), runs)
+	}
+
+	switch {
+	case len(ranking.Entries) == 1:
+		fmt.Fprintf(out, "Winner: %s%s%s (no other algorithm to compare)\n", ui.ColorGreen(), ranking.Winner, ui.ColorReset())
+	case ranking.Winner != "":
+		fmt.Fprintf(out, "Winner: %s%s%s (significantly faster than the runner-up: interquartile ranges do not overlap)\n",
+			ui.ColorGreen(), ranking.Winner, ui.ColorReset())
+	default:
+		fmt.Fprintf(out, "Result: tie within noise between %s and %s (interquartile ranges overlap)\n",
+			ranking.Entries[0].Name, ranking.Entries[1].Name)
+	}
+}
+
+// RepeatStats summarizes the steady-state runs of one algorithm (--repeat).
+type RepeatStats struct {
+	// Name is the name of the algorithm.
+	Name string
+	// Durations summarizes the durations of the successful runs.
+	Durations orchestration.DurationStats
+	// Allocs and Bytes are the mean number and size of the heap allocations
+	// of a run.
+	Allocs uint64
+	Bytes  uint64
+	// Failed is the number of runs that returned an error, e.g. a timeout.
+	Failed int
+}
+
+// DisplayRepeatStats prints the duration statistics and allocations of the
+// runs of --repeat, one line per algorithm. The first run is a warm-up and
+// is not part of the statistics.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - stats: The statistics of each algorithm.
+//   - repeat: The total number of runs, warm-up included.
+func DisplayRepeatStats(out io.Writer, stats []RepeatStats, repeat int) {
+	fmt.Fprintf(out, "\n%s--- Repeated runs (%d after warm-up) ---%s\n", ui.ColorBold(), repeat-1, ui.ColorReset())
+
+	maxNameLen := 9 // "Algorithm" header length
+	for _, st := range stats {
+		maxNameLen = max(maxNameLen, len(st.Name))
+	}
+	fmt.Fprintf(out, "%-*s   %-10s   %-10s   %-10s   %-10s   %10s   %10s\n",
+		maxNameLen, "Algorithm", "Min", "Median", "Mean", "Stddev", "Allocs/op", "Bytes/op")
+	for _, st := range stats {
+		if st.Durations.Runs == 0 {
+			fmt.Fprintf(out, "%s%-*s%s   %sno successful run (%d failed)%s\n",
+				ui.ColorBlue(), maxNameLen, st.Name, ui.ColorReset(), ui.ColorRed(), st.Failed, ui.ColorReset())
+			continue
+		}
+		d := st.Durations
+		fmt.Fprintf(out, "%s%-*s%s   %-10s   %s%-10s%s   %-10s   %-10s   %10s   %10s",
+			ui.ColorBlue(), maxNameLen, st.Name, ui.ColorReset(),
+			format.FormatExecutionDuration(d.Min),
+			ui.ColorYellow(), format.FormatExecutionDuration(d.Median), ui.ColorReset(),
+			format.FormatExecutionDuration(d.Mean), format.FormatExecutionDuration(d.StdDev),
+			format.FormatNumberString(strconv.FormatUint(st.Allocs, 10)), format.FormatBytes(st.Bytes))
+		if st.Failed > 0 {
+			fmt.Fprintf(out, "   (%s%d failed%s)", ui.ColorRed(), st.Failed, ui.ColorReset())
+		}
+		fmt.Fprintln(out)
+	}
+}
+
+// DisplayQuietRepeatStats prints the statistics of the runs of --repeat in
+// quiet mode: one uncolored line per algorithm, without a header, e.g.
+//
+//	fast min=10ms median=12ms mean=12ms stddev=2ms allocs/op=1234 bytes/op=2097152 failed=1
+//
+// An algorithm without a successful run only reports its failures.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - stats: The statistics of each algorithm.
+func DisplayQuietRepeatStats(out io.Writer, stats []RepeatStats) {
+	for _, st := range stats {
+		if st.Durations.Runs == 0 {
+			fmt.Fprintf(out, "%s failed=%d\n", st.Name, st.Failed)
+			continue
+		}
+		d := st.Durations
+		fmt.Fprintf(out, "%s min=%s median=%s mean=%s stddev=%s allocs/op=%d bytes/op=%d failed=%d\n",
+			st.Name, format.FormatExecutionDuration(d.Min), format.FormatExecutionDuration(d.Median),
+			format.FormatExecutionDuration(d.Mean), format.FormatExecutionDuration(d.StdDev),
+			st.Allocs, st.Bytes, st.Failed)
+	}
+}
+
+// DisplayStepTimings prints the duration of each doubling iteration of a
+// calculation (--bench-steps), with the bit length of F(k) at the start of
+// the iteration and whether it used FFT or parallel multiplication, followed
+// by the total and the share of the slowest iteration.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - steps: The iterations, in order, as collected by a
+//     fibonacci.StepCollector.
+func DisplayStepTimings(out io.Writer, steps []threshold.IterationMetric) {
+	fmt.Fprintf(out, "\n%s--- Doubling steps (%d) ---%s\n", ui.ColorBold(), len(steps), ui.ColorReset())
+	if len(steps) == 0 {
+		fmt.Fprintln(out, "No doubling step was recorded (small n are computed directly).")
+		return
+	}
+
+	var total, slowest time.Duration
+	for _, step := range steps {
+		total += step.Duration
+		slowest = max(slowest, step.Duration)
+	}
+	yesNo := func(b bool) string {
+		if b {
+			return "yes"
+		}
+		return "no"
+	}
+	fmt.Fprintf(out, "%4s   %12s   %-10s   %-3s   %s\n", "Step", "Bits", "Duration", "FFT", "Parallel")
+	for i, step := range steps {
+		fmt.Fprintf(out, "%4d   %12s   %s%-10s%s   %-3s   %s\n", i+1,
+			format.FormatNumberString(strconv.Itoa(step.BitLen)),
+			ui.ColorYellow(), format.FormatExecutionDuration(step.Duration), ui.ColorReset(),
+			yesNo(step.UsedFFT), yesNo(step.UsedParallel))
+	}
+	fmt.Fprintf(out, "Total: %s%s%s", ui.ColorGreen(), format.FormatExecutionDuration(total), ui.ColorReset())
+	if total > 0 {
+		fmt.Fprintf(out, " (slowest step: %.1f%%)", 100*float64(slowest)/float64(total))
+	}
+	fmt.Fprintln(out)
+}
@@ -0,0 +1,58 @@
+// This file implements the JSON lines progress output (--progress-format
+// jsonl) for supervising processes.
+
+package cli
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+	"github.com/agbru/fibcalc/internal/progress"
+)
+
+// JSONLProgressReporter implements orchestration.ProgressReporter for
+// machine consumption: each progress update is written as one JSON object
+// per line, e.g.
+//
+//	{"algo_index":0,"progress":0.42,"eta_ms":1234}
+//
+// progress is the progress of the calculator that sent the update, and
+// eta_ms the estimated time remaining for all calculators in milliseconds,
+// 0 while unknown.
+type JSONLProgressReporter struct {
+	// Weights, if set, weight each calculator's progress in the ETA by its
+	// expected cost (see orchestration.ProgressWeights).
+	Weights []float64
+}
+
+// Verify that JSONLProgressReporter implements orchestration.ProgressReporter.
+var _ orchestration.ProgressReporter = JSONLProgressReporter{}
+
+// progressEvent is one line of JSONLProgressReporter output.
+type progressEvent struct {
+	AlgoIndex int     `json:"algo_index"`
+	Progress  float64 `json:"progress"`
+	ETAMs     int64   `json:"eta_ms"`
+}
+
+// DisplayProgress writes one JSON line per progress update until the
+// channel is closed.
+func (r JSONLProgressReporter) DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUpdate, numCalculators int, out io.Writer) {
+	defer wg.Done()
+
+	agg := orchestration.NewProgressAggregator(numCalculators)
+	if agg == nil {
+		orchestration.DrainChannel(progressChan)
+		return
+	}
+	agg.SetWeights(r.Weights)
+
+	enc := json.NewEncoder(out)
+	for update := range progressChan {
+		p := agg.Update(update)
+		// Keep draining after a write error so that calculators never block.
+		_ = enc.Encode(progressEvent{AlgoIndex: p.CalculatorIndex, Progress: p.Value, ETAMs: p.ETA.Milliseconds()})
+	}
+}
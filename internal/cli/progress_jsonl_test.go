@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/agbru/fibcalc/internal/progress"
+)
+
+func TestJSONLProgressReporter(t *testing.T) {
+	t.Parallel()
+	updates := []progress.ProgressUpdate{
+		{CalculatorIndex: 0, Value: 0.25},
+		{CalculatorIndex: 1, Value: 0.5},
+		{CalculatorIndex: 0, Value: 1},
+	}
+	progressChan := make(chan progress.ProgressUpdate)
+	go func() {
+		for _, u := range updates {
+			progressChan <- u
+		}
+		close(progressChan)
+	}()
+
+	var out bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	JSONLProgressReporter{}.DisplayProgress(&wg, progressChan, 2, &out)
+	wg.Wait()
+
+	scanner := bufio.NewScanner(&out)
+	var i int
+	for ; scanner.Scan(); i++ {
+		var event map[string]any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatalf("line %d is not JSON: %v: %s", i+1, err, scanner.Text())
+		}
+		if i >= len(updates) {
+			continue
+		}
+		if event["algo_index"] != float64(updates[i].CalculatorIndex) || event["progress"] != updates[i].Value {
+			t.Errorf("line %d = %s, want algo_index %d and progress %v", i+1, scanner.Text(), updates[i].CalculatorIndex, updates[i].Value)
+		}
+		if eta, ok := event["eta_ms"].(float64); !ok || eta < 0 {
+			t.Errorf("line %d: eta_ms = %v, want a non-negative number", i+1, event["eta_ms"])
+		}
+	}
+	if i != len(updates) {
+		t.Errorf("got %d lines, want one per update (%d)", i, len(updates))
+	}
+}
+
+func TestJSONLProgressReporterZeroCalculators(t *testing.T) {
+	t.Parallel()
+	progressChan := make(chan progress.ProgressUpdate)
+	close(progressChan)
+
+	var out bytes.Buffer
+	var wg sync.WaitGroup
+	wg.Add(1)
+	JSONLProgressReporter{}.DisplayProgress(&wg, progressChan, 0, &out)
+	wg.Wait()
+	if out.Len() != 0 {
+		t.Errorf("expected no output, got %q", out.String())
+	}
+}
@@ -0,0 +1,157 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/format"
+	"github.com/agbru/fibcalc/internal/ui"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// SVGCardColors is the palette of a result card, as CSS hex colors.
+type SVGCardColors struct {
+	Background string
+	Border     string
+	Title      string
+	Text       string
+	Dim        string
+}
+
+// defaultSVGCardColors is the palette used for colors a theme leaves unset,
+// e.g. with --no-color. It matches DarkTUITheme.
+var defaultSVGCardColors = SVGCardColors{
+	Background: "#000000",
+	Border:     "#FF6600",
+	Title:      "#FF8C00",
+	Text:       "#E0E0E0",
+	Dim:        "#666666",
+}
+
+// SVGCardColorsFromTheme derives a card palette from a TUI theme. Colors that
+// are not plain hex values (such as lipgloss.NoColor) fall back to the dark
+// palette, so that the card renders the same in every viewer.
+//
+// Parameters:
+//   - theme: The TUI theme to take the colors from.
+//
+// Returns:
+//   - SVGCardColors: The card palette.
+func SVGCardColorsFromTheme(theme ui.TUITheme) SVGCardColors {
+	pick := func(c lipgloss.TerminalColor, fallback string) string {
+		if hex, ok := c.(lipgloss.Color); ok && len(hex) > 0 && hex[0] == '#' {
+			return string(hex)
+		}
+		return fallback
+	}
+	d := defaultSVGCardColors
+	return SVGCardColors{
+		Background: pick(theme.Bg, d.Background),
+		Border:     pick(theme.Border, d.Border),
+		Title:      pick(theme.Accent, d.Title),
+		Text:       pick(theme.Text, d.Text),
+		Dim:        pick(theme.Dim, d.Dim),
+	}
+}
+
+// SVGCard holds the data summarized by a result card.
+type SVGCard struct {
+	// N is the Fibonacci index.
+	N uint64
+	// Algorithm is the name of the algorithm that produced the result.
+	Algorithm string
+	// Digits is the number of decimal digits of F(N).
+	Digits int
+	// Duration is the calculation time.
+	Duration time.Duration
+	// Colors is the card palette.
+	Colors SVGCardColors
+}
+
+// NewSVGCard builds a card for a result, using the current theme's colors.
+//
+// Parameters:
+//   - result: The calculated Fibonacci number.
+//   - n: The index of the Fibonacci number.
+//   - duration: The calculation duration.
+//   - algo: The algorithm name used.
+//
+// Returns:
+//   - SVGCard: The card data.
+func NewSVGCard(result *big.Int, n uint64, duration time.Duration, algo string) SVGCard {
+	return SVGCard{
+		N:         n,
+		Algorithm: algo,
+		Digits:    len(new(big.Int).Abs(result).String()),
+		Duration:  duration,
+		Colors:    SVGCardColorsFromTheme(ui.GetCurrentTUITheme()),
+	}
+}
+
+// FormatSVGCard renders a card as a standalone SVG document. The output has
+// no external references (fonts, stylesheets or images).
+//
+// Parameters:
+//   - card: The card to render.
+//
+// Returns:
+//   - string: The SVG document.
+func FormatSVGCard(card SVGCard) string {
+	c := card.Colors
+	rows := []struct{ label, value string }{
+		{"Algorithm", card.Algorithm},
+		{"Digits", format.FormatNumberString(fmt.Sprint(card.Digits))},
+		{"Duration", format.FormatExecutionDuration(card.Duration)},
+	}
+
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	b.WriteString(`<svg xmlns="http://www.w3.org/2000/svg" width="400" height="150" viewBox="0 0 400 150">` + "\n")
+	fmt.Fprintf(&b, `  <rect x="1" y="1" width="398" height="148" rx="8" fill="%s" stroke="%s" stroke-width="2"/>`+"\n",
+		escapeSVG(c.Background), escapeSVG(c.Border))
+	fmt.Fprintf(&b, `  <text x="20" y="38" font-family="monospace" font-size="20" font-weight="bold" fill="%s">F(%d)</text>`+"\n",
+		escapeSVG(c.Title), card.N)
+	for i, row := range rows {
+		y := 72 + 26*i
+		fmt.Fprintf(&b, `  <text x="20" y="%d" font-family="monospace" font-size="14" fill="%s">%s</text>`+"\n",
+			y, escapeSVG(c.Dim), escapeSVG(row.label))
+		fmt.Fprintf(&b, `  <text x="130" y="%d" font-family="monospace" font-size="14" fill="%s">%s</text>`+"\n",
+			y, escapeSVG(c.Text), escapeSVG(row.value))
+	}
+	b.WriteString("</svg>\n")
+	return b.String()
+}
+
+// escapeSVG escapes s for use in SVG text and attribute values.
+func escapeSVG(s string) string {
+	var b bytes.Buffer
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+// WriteSVGCard renders a card with FormatSVGCard and writes it to path,
+// creating the parent directory if needed.
+//
+// Parameters:
+//   - path: The destination file path.
+//   - card: The card to render.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func WriteSVGCard(path string, card SVGCard) error {
+	dir := filepath.Dir(path)
+	if dir != "" && dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(FormatSVGCard(card)), 0644); err != nil {
+		return fmt.Errorf("failed to write SVG card: %w", err)
+	}
+	return nil
+}
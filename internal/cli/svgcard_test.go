@@ -0,0 +1,79 @@
+package cli
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/ui"
+)
+
+// checkWellFormedXML fails the test if doc is not well-formed XML.
+func checkWellFormedXML(t *testing.T, doc string) {
+	t.Helper()
+	dec := xml.NewDecoder(strings.NewReader(doc))
+	for {
+		if _, err := dec.Token(); err != nil {
+			if errors.Is(err, io.EOF) {
+				return
+			}
+			t.Fatalf("SVG is not well-formed XML: %v\n%s", err, doc)
+		}
+	}
+}
+
+func TestFormatSVGCard(t *testing.T) {
+	t.Parallel()
+	card := SVGCard{
+		N:         1000000,
+		Algorithm: "Fast <Doubling> & co",
+		Digits:    208988,
+		Duration:  1500 * time.Millisecond,
+		Colors:    defaultSVGCardColors,
+	}
+	svg := FormatSVGCard(card)
+	checkWellFormedXML(t, svg)
+
+	for _, want := range []string{"F(1000000)", "1.5s", "208,988", "Fast &lt;Doubling&gt; &amp; co", `fill="#FF8C00"`} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("SVG missing %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestSVGCardColorsFromTheme(t *testing.T) {
+	t.Parallel()
+	if got := SVGCardColorsFromTheme(ui.DarkTUITheme); got.Border != "#FF6600" || got.Text != "#E0E0E0" {
+		t.Errorf("dark theme colors = %+v", got)
+	}
+	if got := SVGCardColorsFromTheme(ui.NoColorTUITheme); got != defaultSVGCardColors {
+		t.Errorf("no-color theme should fall back to the default palette, got %+v", got)
+	}
+}
+
+func TestWriteSVGCard(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "cards", "f100.svg")
+	f100, _ := new(big.Int).SetString("354224848179261915075", 10)
+	card := NewSVGCard(f100, 100, 2*time.Millisecond, "fast")
+	if card.Digits != 21 {
+		t.Errorf("Digits = %d, want 21", card.Digits)
+	}
+	if err := WriteSVGCard(path, card); err != nil {
+		t.Fatalf("WriteSVGCard failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading card: %v", err)
+	}
+	checkWellFormedXML(t, string(data))
+	if !strings.Contains(string(data), "F(100)") {
+		t.Errorf("card missing F(100):\n%s", data)
+	}
+}
@@ -1,8 +1,11 @@
 package cli
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/agbru/fibcalc/internal/config"
+	"github.com/agbru/fibcalc/internal/ui"
 	"github.com/briandowns/spinner"
 )
 
@@ -13,16 +16,39 @@ const (
 	// DisplayEdges specifies the number of digits to display at the beginning
 	// and end of a truncated number.
 	DisplayEdges = 25
-	// HexDisplayEdges specifies the number of hex characters to display at the
-	// beginning and end of a truncated hexadecimal number.
+	// ScientificSigFigs is the number of significant figures of values
+	// displayed in scientific notation (--scientific).
+	ScientificSigFigs = 6
+	// HexDisplayEdges specifies the number of digits to display at the
+	// beginning and end of a truncated number in a base other than 10, such
+	// as hexadecimal.
 	HexDisplayEdges = 40
 	// ProgressRefreshRate defines the refresh frequency of the progress bar.
 	// Optimized to 200ms to reduce updates and improve performance.
 	ProgressRefreshRate = 200 * time.Millisecond
-	// ProgressBarWidth defines the width in characters of the progress bar.
+	// PlainProgressInterval is the minimum delay between two lines of the
+	// plain progress output used when the output is not a terminal.
+	PlainProgressInterval = time.Second
+	// ProgressBarWidth defines the maximum width in characters of the progress bar.
 	ProgressBarWidth = 40
+	// MinProgressBarWidth is the narrowest progress bar rendered on very small
+	// terminals.
+	MinProgressBarWidth = 10
+	// progressLineOverhead is the number of columns taken by the spinner,
+	// label, percentage and ETA around the bar itself.
+	progressLineOverhead = 40
 )
 
+// progressBarWidth returns the progress bar width that fits a terminal of
+// termWidth columns. Non-positive widths (unknown terminal size) fall back to
+// ui.DefaultTerminalWidth, which yields the standard ProgressBarWidth.
+func progressBarWidth(termWidth int) int {
+	if termWidth <= 0 {
+		termWidth = ui.DefaultTerminalWidth
+	}
+	return max(MinProgressBarWidth, min(ProgressBarWidth, termWidth-progressLineOverhead))
+}
+
 // Spinner is an interface that abstracts the behavior of a terminal spinner.
 // This allows for the decoupling of the `DisplayProgress` function from a
 // specific spinner implementation, facilitating easier testing and maintenance.
@@ -65,6 +91,47 @@ func (rs *realSpinner) UpdateSuffix(suffix string) {
 	rs.s.Suffix = suffix
 }
 
+// spinnerStyles maps each --spinner-style to its frames.
+var spinnerStyles = map[string][]string{
+	config.SpinnerStyleDots:  spinner.CharSets[11],
+	config.SpinnerStyleLine:  spinner.CharSets[3],
+	config.SpinnerStyleArc:   {"◜", "◠", "◝", "◞", "◡", "◟"},
+	config.SpinnerStyleASCII: spinner.CharSets[9],
+}
+
+// SpinnerFrames returns the frames of a spinner style. The ascii style only
+// uses single-byte characters, for terminals without Unicode support.
+//
+// Parameters:
+//   - style: The style name (see config.SpinnerStyleDots and siblings); an
+//     empty name selects dots.
+//
+// Returns:
+//   - []string: The frames, in animation order.
+//   - error: An error if the style is unknown.
+func SpinnerFrames(style string) ([]string, error) {
+	if style == "" {
+		style = config.SpinnerStyleDots
+	}
+	frames, ok := spinnerStyles[style]
+	if !ok {
+		return nil, fmt.Errorf("unknown spinner style %q (valid styles: %s, %s, %s, %s)", style,
+			config.SpinnerStyleDots, config.SpinnerStyleLine, config.SpinnerStyleArc, config.SpinnerStyleASCII)
+	}
+	return frames, nil
+}
+
+// withSpinnerStyle returns a spinner option that sets the frames and the
+// delay between them. A non-positive speed keeps the default delay.
+func withSpinnerStyle(frames []string, speed time.Duration) spinner.Option {
+	return func(s *spinner.Spinner) {
+		s.UpdateCharSet(frames)
+		if speed > 0 {
+			s.UpdateSpeed(speed)
+		}
+	}
+}
+
 var newSpinner = func(options ...spinner.Option) Spinner {
 	// Using the same interval as ProgressRefreshRate to synchronize
 	s := spinner.New(spinner.CharSets[11], ProgressRefreshRate, options...)
@@ -28,12 +28,21 @@ import (
 //   - Periodically refreshing the spinner and progress bar.
 //   - Gracefully shutting down when the progress channel is closed.
 //
+// When out is redirected to a file or a pipe, newline-terminated percentage
+// lines replace the spinner and bar (see CLIProgressReporter.Plain).
+//
 // Parameters:
 //   - wg: A WaitGroup to signal when the display routine is complete.
 //   - progressChan: The channel receiving progress updates.
 //   - numCalculators: The number of calculators contributing to the progress.
 //   - out: The io.Writer to which the progress bar is rendered.
 func DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUpdate, numCalculators int, out io.Writer) {
+	displayProgress(wg, progressChan, numCalculators, CLIProgressReporter{}, out)
+}
+
+// displayProgress implements DisplayProgress with the progress weights and
+// spinner settings of a CLIProgressReporter.
+func displayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUpdate, numCalculators int, opts CLIProgressReporter, out io.Writer) {
 	defer wg.Done()
 
 	agg := orchestration.NewProgressAggregator(numCalculators)
@@ -41,8 +50,19 @@ func DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUp
 		orchestration.DrainChannel(progressChan)
 		return
 	}
+	agg.SetWeights(opts.Weights)
 
-	s := newSpinner(spinner.WithWriter(out))
+	// Carriage returns and escape codes would garble log files.
+	if opts.Plain || ui.IsRedirected(out) {
+		displayPlainProgress(progressChan, agg, out)
+		return
+	}
+
+	spinnerOpts := []spinner.Option{spinner.WithWriter(out)}
+	if frames, err := SpinnerFrames(opts.SpinnerStyle); err == nil {
+		spinnerOpts = append(spinnerOpts, withSpinnerStyle(frames, opts.SpinnerSpeed))
+	}
+	s := newSpinner(spinnerOpts...)
 	s.Start()
 	spinnerStopped := false
 	defer func() {
@@ -51,6 +71,8 @@ func DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUp
 		}
 	}()
 
+	barWidth := progressBarWidth(ui.TerminalWidth(out))
+
 	label := "Progress"
 	if agg.IsMultiCalculator() {
 		label = "Avg progress"
@@ -72,7 +94,7 @@ func DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUp
 				// Display actual final progress (not hardcoded 100%).
 				// Progress may be less than 100% if calculation was canceled or timed out.
 				finalProgress := agg.CalculateAverage()
-				bar := format.ProgressBar(finalProgress, ProgressBarWidth)
+				bar := format.ProgressBar(finalProgress, barWidth)
 				etaStr := "< 1s"
 				if finalProgress < 1.0 {
 					etaStr = "N/A (interrupted)"
@@ -84,13 +106,49 @@ func DisplayProgress(wg *sync.WaitGroup, progressChan <-chan progress.ProgressUp
 		case <-ticker.C:
 			avgProgress := agg.CalculateAverage()
 			eta := agg.GetETA()
-			bar := format.ProgressBar(avgProgress, ProgressBarWidth)
+			bar := format.ProgressBar(avgProgress, barWidth)
 			etaStr := format.FormatETA(eta)
 			s.UpdateSuffix(fmt.Sprintf(" %s: %6.2f%% [%s] ETA: %s", label, avgProgress*100, bar, etaStr))
 		}
 	}
 }
 
+// displayPlainProgress prints the progress as newline-terminated lines
+// without escape codes, e.g. "progress: 42% ETA: 5s", at most every
+// PlainProgressInterval and only when the percentage changes, then a final
+// line when the channel is closed.
+func displayPlainProgress(progressChan <-chan progress.ProgressUpdate, agg *orchestration.ProgressAggregator, out io.Writer) {
+	label := "progress"
+	if agg.IsMultiCalculator() {
+		label = "avg progress"
+	}
+
+	ticker := time.NewTicker(PlainProgressInterval)
+	defer ticker.Stop()
+
+	lastPercent := -1
+	for {
+		select {
+		case update, ok := <-progressChan:
+			if !ok {
+				finalProgress := agg.CalculateAverage()
+				status := "done"
+				if finalProgress < 1.0 {
+					status = "interrupted"
+				}
+				fmt.Fprintf(out, "%s: %d%% (%s)\n", label, int(finalProgress*100), status)
+				return
+			}
+			agg.Update(update)
+		case <-ticker.C:
+			if percent := int(agg.CalculateAverage() * 100); percent != lastPercent {
+				lastPercent = percent
+				fmt.Fprintf(out, "%s: %d%% ETA: %s\n", label, percent, format.FormatETA(agg.GetETA()))
+			}
+		}
+	}
+}
+
 // displayResultHeader prints the binary size of the result.
 //
 // Parameters:
@@ -128,40 +186,130 @@ func displayDetailedAnalysis(out io.Writer, result *big.Int, duration time.Durat
 	}
 }
 
+// displayMemoryReport prints the allocations, garbage collections and peak
+// heap of a calculation (--details).
+//
+// Parameters:
+//   - out: The io.Writer for the output.
+//   - report: The memory report of the calculation.
+func displayMemoryReport(out io.Writer, report metrics.MemoryReport) {
+	fmt.Fprintf(out, "\n%s--- Memory behavior ---%s\n", ui.ColorBold(), ui.ColorReset())
+	fmt.Fprintf(out, "Allocated               : %s%s%s\n", ui.ColorCyan(), format.FormatBytes(report.TotalAlloc), ui.ColorReset())
+	fmt.Fprintf(out, "GC cycles               : %s%d%s  (pause total %s)\n",
+		ui.ColorCyan(), report.NumGC, ui.ColorReset(), format.FormatExecutionDuration(report.GCPause))
+	fmt.Fprintf(out, "Peak heap from the OS   : %s%s%s\n", ui.ColorCyan(), format.FormatBytes(report.PeakHeapSys), ui.ColorReset())
+}
+
+// TruncationEdges returns how a value is truncated for display: the number
+// of digits kept at each end, and the number of digits above which the value
+// is truncated. The limit is TruncationLimit, raised to twice the edges so
+// that the two ends never overlap.
+//
+// Parameters:
+//   - edges: The configured number of edge digits (0 for defaultEdges).
+//   - defaultEdges: The number of edge digits used when edges is 0.
+//
+// Returns:
+//   - int: The number of digits kept at each end.
+//   - int: The digit count above which values are truncated.
+func TruncationEdges(edges, defaultEdges int) (int, int) {
+	if edges <= 0 {
+		edges = defaultEdges
+	}
+	return edges, max(TruncationLimit, 2*edges)
+}
+
 // displayCalculatedValue prints the Fibonacci value, truncating if necessary.
 //
 // Parameters:
 //   - out: The io.Writer for the output.
 //   - result: The calculation result.
+//   - symbol: The symbol of the sequence, "F" or "L" (see SequenceSymbol).
 //   - n: The index of the Fibonacci number calculated.
-//   - verbose: If true, prints the full number regardless of size.
-func displayCalculatedValue(out io.Writer, result *big.Int, n uint64, verbose bool) {
+//   - verbose: If true, prints the full number regardless of its digit count.
+//   - maxBytes: The maximum number of value bytes to print (0 for no limit).
+//   - edges: The number of digits kept at each end of a truncated value (0
+//     for DisplayEdges).
+func displayCalculatedValue(out io.Writer, result *big.Int, symbol string, n uint64, verbose bool, maxBytes, edges int) {
 	resultStr := result.String()
 	numDigits := len(resultStr)
 
 	fmt.Fprintf(out, "\n%s--- Calculated value ---%s\n", ui.ColorBold(), ui.ColorReset())
 
 	if verbose {
-		fmt.Fprintf(out, "F(%s%d%s) =\n%s%s%s\n",
-			ui.ColorMagenta(), n, ui.ColorReset(),
-			ui.ColorGreen(), format.FormatNumberString(resultStr), ui.ColorReset())
+		fmt.Fprintf(out, "%s(%s%d%s) =\n%s%s%s\n",
+			symbol, ui.ColorMagenta(), n, ui.ColorReset(),
+			ui.ColorGreen(), FormatLimitedValue(format.FormatNumberString(resultStr), maxBytes), ui.ColorReset())
 		return
 	}
 
-	if numDigits > TruncationLimit {
-		fmt.Fprintf(out, "F(%s%d%s) (truncated) = %s%s...%s%s\n",
-			ui.ColorMagenta(), n, ui.ColorReset(),
-			ui.ColorGreen(), resultStr[:DisplayEdges], resultStr[numDigits-DisplayEdges:], ui.ColorReset())
+	edges, limit := TruncationEdges(edges, DisplayEdges)
+	if numDigits > limit {
+		fmt.Fprintf(out, "%s(%s%d%s) (truncated) = %s%s...%s%s\n",
+			symbol, ui.ColorMagenta(), n, ui.ColorReset(),
+			ui.ColorGreen(), resultStr[:edges], resultStr[numDigits-edges:], ui.ColorReset())
 		fmt.Fprintf(out, "(Tip: use the %s-v%s or %s--verbose%s option to display the full value)\n",
 			ui.ColorYellow(), ui.ColorReset(), ui.ColorYellow(), ui.ColorReset())
 		return
 	}
 
-	fmt.Fprintf(out, "F(%s%d%s) = %s%s%s\n",
-		ui.ColorMagenta(), n, ui.ColorReset(),
+	fmt.Fprintf(out, "%s(%s%d%s) = %s%s%s\n",
+		symbol, ui.ColorMagenta(), n, ui.ColorReset(),
 		ui.ColorGreen(), format.FormatNumberString(resultStr), ui.ColorReset())
 }
 
+// displayScientificValue prints the Fibonacci value in scientific notation
+// with its digit count (see format.FormatScientific).
+//
+// Parameters:
+//   - out: The io.Writer for the output.
+//   - result: The calculation result.
+//   - symbol: The symbol of the sequence, "F" or "L" (see SequenceSymbol).
+//   - n: The index of the Fibonacci number calculated.
+func displayScientificValue(out io.Writer, result *big.Int, symbol string, n uint64) {
+	fmt.Fprintf(out, "\n%s--- Calculated value ---%s\n", ui.ColorBold(), ui.ColorReset())
+	fmt.Fprintf(out, "%s(%s%d%s) = %s%s%s\n",
+		symbol, ui.ColorMagenta(), n, ui.ColorReset(),
+		ui.ColorGreen(), format.FormatScientific(result, ScientificSigFigs), ui.ColorReset())
+}
+
+// displayValueInBase prints the Fibonacci value in another base than 10,
+// with grouped digits (see format.FormatInBase), truncating it unless verbose.
+//
+// Parameters:
+//   - out: The io.Writer for the output.
+//   - result: The calculation result.
+//   - symbol: The symbol of the sequence, "F" or "L" (see SequenceSymbol).
+//   - n: The index of the Fibonacci number calculated.
+//   - base: The base, from 2 to 36.
+//   - verbose: If true, prints all the digits.
+//   - maxBytes: The maximum number of value bytes to print (0 for no limit).
+//   - edges: The number of digits kept at each end of a truncated value (0
+//     for HexDisplayEdges).
+func displayValueInBase(out io.Writer, result *big.Int, symbol string, n uint64, base int, verbose bool, maxBytes, edges int) {
+	fmt.Fprintf(out, "\n%s--- Calculated value (base %d) ---%s\n", ui.ColorBold(), base, ui.ColorReset())
+
+	digits := result.Text(base)
+	edges, limit := TruncationEdges(edges, HexDisplayEdges)
+	if !verbose && len(digits) > limit {
+		fmt.Fprintf(out, "%s(%s%d%s) (truncated) = %s%s...%s%s\n",
+			symbol, ui.ColorMagenta(), n, ui.ColorReset(),
+			ui.ColorGreen(), digits[:edges], digits[len(digits)-edges:], ui.ColorReset())
+		fmt.Fprintf(out, "(Tip: use the %s-v%s or %s--verbose%s option to display the full value)\n",
+			ui.ColorYellow(), ui.ColorReset(), ui.ColorYellow(), ui.ColorReset())
+		return
+	}
+
+	grouped, err := format.FormatInBase(result, base)
+	if err != nil {
+		fmt.Fprintf(out, "%sError: %v%s\n", ui.ColorRed(), err, ui.ColorReset())
+		return
+	}
+	fmt.Fprintf(out, "%s(%s%d%s) =\n%s%s%s\n",
+		symbol, ui.ColorMagenta(), n, ui.ColorReset(),
+		ui.ColorGreen(), FormatLimitedValue(grouped, maxBytes), ui.ColorReset())
+}
+
 // DisplayResult formats and prints the final calculation result.
 // It provides different levels of detail based on the verbose and details flags,
 // including metadata like binary size, number of digits, and scientific
@@ -177,6 +325,14 @@ func displayCalculatedValue(out io.Writer, result *big.Int, n uint64, verbose bo
 //   - showValue: If true, displays the calculated value section (disabled by default).
 //   - out: The io.Writer for the output.
 func DisplayResult(result *big.Int, n uint64, duration time.Duration, verbose, details, showValue bool, out io.Writer) {
+	DisplayResultLimited(result, n, duration, verbose, details, showValue, 0, out)
+}
+
+// DisplayResultLimited is like DisplayResult but caps the displayed value at
+// maxBytes bytes (0 for no limit), truncating it with a notice when the
+// formatted value would be larger. This protects terminals against runaway
+// output independently of the digit-based truncation.
+func DisplayResultLimited(result *big.Int, n uint64, duration time.Duration, verbose, details, showValue bool, maxBytes int, out io.Writer) {
 	displayResultHeader(out, result.BitLen())
 
 	if details {
@@ -187,7 +343,7 @@ func DisplayResult(result *big.Int, n uint64, duration time.Duration, verbose, d
 	}
 
 	if showValue {
-		displayCalculatedValue(out, result, n, verbose)
+		displayCalculatedValue(out, result, "F", n, verbose, maxBytes, 0)
 	}
 }
 
@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"io"
 	"math/big"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
 	"time"
+	"unicode"
 
+	"github.com/agbru/fibcalc/internal/config"
+	"github.com/agbru/fibcalc/internal/format"
 	"github.com/agbru/fibcalc/internal/progress"
 	"github.com/agbru/fibcalc/internal/ui"
 	"github.com/briandowns/spinner"
@@ -114,6 +119,43 @@ func TestRealSpinner(t *testing.T) {
 	rs.Stop()
 }
 
+// TestSpinnerFrames verifies every configured style has frames, that the
+// ascii style is pure ASCII, and that unknown styles are rejected.
+func TestSpinnerFrames(t *testing.T) {
+	t.Parallel()
+	for _, style := range []string{"", config.SpinnerStyleDots, config.SpinnerStyleLine, config.SpinnerStyleArc, config.SpinnerStyleASCII} {
+		frames, err := SpinnerFrames(style)
+		if err != nil || len(frames) == 0 {
+			t.Errorf("SpinnerFrames(%q) = %v, %v; want frames", style, frames, err)
+		}
+	}
+
+	frames, _ := SpinnerFrames(config.SpinnerStyleASCII)
+	for _, frame := range frames {
+		for _, r := range frame {
+			if r > unicode.MaxASCII {
+				t.Errorf("ascii frame %q contains non-ASCII rune %q", frame, r)
+			}
+		}
+	}
+
+	if _, err := SpinnerFrames("emoji"); err == nil {
+		t.Error("expected an error for an unknown style")
+	}
+}
+
+func TestWithSpinnerStyle(t *testing.T) {
+	t.Parallel()
+	s := spinner.New(spinner.CharSets[11], ProgressRefreshRate, withSpinnerStyle(spinner.CharSets[9], time.Second))
+	if s.Delay != time.Second {
+		t.Errorf("Delay = %v, want 1s", s.Delay)
+	}
+	s = spinner.New(spinner.CharSets[11], ProgressRefreshRate, withSpinnerStyle(spinner.CharSets[9], 0))
+	if s.Delay != ProgressRefreshRate {
+		t.Errorf("Delay = %v, want the default %v", s.Delay, ProgressRefreshRate)
+	}
+}
+
 func TestColors(t *testing.T) {
 	// Initialize with false (colors enabled if terminal supports)
 	ui.InitTheme(false)
@@ -178,3 +220,94 @@ func TestDisplayProgress_ZeroCalculators(t *testing.T) {
 	wg.Wait()
 	// Should return immediately, coverage check
 }
+
+func TestProgressBarWidth_UnknownTerminal(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		termWidth int
+		want      int
+	}{
+		{"Zero width falls back to 80 columns", 0, ProgressBarWidth},
+		{"Negative width falls back to 80 columns", -1, ProgressBarWidth},
+		{"Wide terminal is capped", 200, ProgressBarWidth},
+		{"Narrow terminal shrinks the bar", 60, 20},
+		{"Tiny terminal keeps a minimum bar", 5, MinProgressBarWidth},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := progressBarWidth(tt.termWidth)
+			if got != tt.want {
+				t.Errorf("progressBarWidth(%d) = %d, want %d", tt.termWidth, got, tt.want)
+			}
+			bar := []rune(format.ProgressBar(0.5, got))
+			if len(bar) != got {
+				t.Errorf("bar length = %d, want %d", len(bar), got)
+			}
+		})
+	}
+}
+
+func TestDisplayProgress_NonTerminalWriter(t *testing.T) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+
+	progressChan := make(chan progress.ProgressUpdate, 1)
+	progressChan <- progress.ProgressUpdate{CalculatorIndex: 0, Value: 1.0}
+	close(progressChan)
+
+	var buf bytes.Buffer
+	DisplayProgress(&wg, progressChan, 1, &buf)
+	wg.Wait()
+
+	want := "[" + format.ProgressBar(1.0, ProgressBarWidth) + "]"
+	if !strings.Contains(buf.String(), want) {
+		t.Errorf("expected a %d-column bar in output, got %q", ProgressBarWidth, buf.String())
+	}
+}
+
+// TestDisplayProgress_PlainOutput checks that redirected output and the
+// Plain option get newline-terminated lines without carriage returns or
+// escape codes.
+func TestDisplayProgress_PlainOutput(t *testing.T) {
+	run := func(reporter CLIProgressReporter, out io.Writer) {
+		progressChan := make(chan progress.ProgressUpdate)
+		go func() {
+			for _, v := range []float64{0.25, 0.5, 0.75} {
+				progressChan <- progress.ProgressUpdate{CalculatorIndex: 0, Value: v}
+			}
+			close(progressChan)
+		}()
+		var wg sync.WaitGroup
+		wg.Add(1)
+		reporter.DisplayProgress(&wg, progressChan, 1, out)
+		wg.Wait()
+	}
+	check := func(name, output string) {
+		if strings.ContainsAny(output, "\r\x1b") {
+			t.Errorf("%s: output contains a carriage return or an escape code: %q", name, output)
+		}
+		if !strings.HasSuffix(output, "progress: 75% (interrupted)\n") {
+			t.Errorf("%s: expected a final plain progress line, got %q", name, output)
+		}
+	}
+
+	var buf bytes.Buffer
+	run(CLIProgressReporter{Plain: true}, &buf)
+	check("Plain", buf.String())
+
+	file, err := os.Create(filepath.Join(t.TempDir(), "progress.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	run(CLIProgressReporter{}, file)
+	content, err := os.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	check("redirected", string(content))
+}
@@ -5,10 +5,14 @@ import (
 	"flag"
 	"fmt"
 	"io"
+	"math/big"
+	"strconv"
 	"strings"
 	"time"
 
 	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/fibonacci/memory"
 )
 
 const (
@@ -27,14 +31,64 @@ const (
 	DefaultTimeout = 5 * time.Minute
 	// DefaultAlgo is the default algorithm selection.
 	DefaultAlgo = "all"
+	// DefaultKBonacci is the default order of the k-bonacci sequence
+	// (the Tribonacci numbers).
+	DefaultKBonacci = 3
+	// DefaultWarnLargeOutput is the number of digits above which printing
+	// the full value to a terminal requires confirmation.
+	DefaultWarnLargeOutput = 100_000
+	// DefaultBase is the default base of the displayed result.
+	DefaultBase = 10
 )
 
+// Output limit modes for --limit-output-mode.
+const (
+	// LimitOutputTruncate truncates an oversized value and prints a notice.
+	LimitOutputTruncate = "truncate"
+	// LimitOutputError refuses to display an oversized value and fails.
+	LimitOutputError = "error"
+)
+
+// Spinner styles for --spinner-style.
+const (
+	// SpinnerStyleDots is the default braille dots spinner.
+	SpinnerStyleDots = "dots"
+	// SpinnerStyleLine is a box-drawing line spinner.
+	SpinnerStyleLine = "line"
+	// SpinnerStyleArc is a rotating arc spinner.
+	SpinnerStyleArc = "arc"
+	// SpinnerStyleASCII uses ASCII characters only, for legacy terminals.
+	SpinnerStyleASCII = "ascii"
+)
+
+// Progress formats for --progress-format.
+const (
+	// ProgressFormatAuto selects ProgressFormatText on a terminal and
+	// ProgressFormatPlain otherwise.
+	ProgressFormatAuto = "auto"
+	// ProgressFormatText is the spinner and progress bar, replaced by plain
+	// lines when the output is redirected.
+	ProgressFormatText = "text"
+	// ProgressFormatPlain is newline-terminated "progress: 42%" lines.
+	ProgressFormatPlain = "plain"
+	// ProgressFormatJSONL writes one JSON object per progress update to
+	// stderr.
+	ProgressFormatJSONL = "jsonl"
+)
+
+// DefaultSpinnerSpeed is the default delay between spinner frames.
+const DefaultSpinnerSpeed = 200 * time.Millisecond
+
 // AppConfig aggregates the application's configuration parameters, parsed from
 // command-line flags. It encapsulates all settings that control the execution,
 // from the Fibonacci index to calculate, to performance-tuning parameters.
 type AppConfig struct {
-	// N is the index of the Fibonacci number to be calculated.
+	// N is the index of the Fibonacci number to be calculated. When NegativeN
+	// is set, it holds the magnitude of a negative index.
 	N uint64
+	// NegativeN, if true, requests F(-N) (negafibonacci). Set by passing a
+	// negative value to -n.
+	NegativeN bool
 	// Verbose, if true, instructs the application to display the full calculated number.
 	Verbose bool
 	// Details, if true, provides a detailed report including performance metrics.
@@ -59,27 +113,172 @@ type AppConfig struct {
 	// If set, the application will load/save calibration results from/to this file.
 	// If empty, uses the default path (~/.fibcalc_calibration.json).
 	CalibrationProfile string
+	// DynamicThresholds, if true, adjusts the parallel and FFT thresholds
+	// during the calculation and saves the learned values into the
+	// calibration profile, from which --auto-calibrate starts.
+	DynamicThresholds bool
+	// CalibrateMinN and CalibrateMaxN, when CalibrateMaxN is non-zero,
+	// restrict --calibrate to the indices in [CalibrateMinN, CalibrateMaxN]:
+	// the result is saved as a bucket of the calibration profile instead of
+	// replacing its default thresholds.
+	CalibrateMinN, CalibrateMaxN uint64
+	// CalibrationReport, if set, is the file the report of --calibrate is
+	// written to: as JSON if it ends in ".json", as a table otherwise.
+	CalibrationReport string
+	// CalibrationDiff, if set, holds the two calibration profiles to compare
+	// side by side (--calibration-diff a.json b.json); no calculation is
+	// performed.
+	CalibrationDiff []string
 	// OutputFile, if specified, saves the result to this file path.
 	OutputFile string
+	// EmitSVG, if specified, writes an SVG card summarizing the result
+	// (n, algorithm, digit count, duration) to this file path.
+	EmitSVG string
 	// Quiet mode - minimal output for scripting purposes.
 	// Suppresses progress bars, banners, and informational messages.
 	Quiet bool
 	// Completion, if set, generates shell completion script for the specified shell.
-	// Valid values are: "bash", "zsh", "fish", "powershell".
+	// Valid values are: "bash", "zsh", "fish", "powershell", "nushell", "elvish".
 	Completion string
 	// ShowValue, if true, displays the calculated Fibonacci value. Set with -c/--calculate.
 	ShowValue bool
 	// TUI, if true, launches the interactive TUI dashboard instead of CLI mode.
 	TUI bool
+	// TUIExportOnExit, if set, is the file where the TUI writes its final
+	// dashboard state as JSON on exit. The export key also writes there.
+	TUIExportOnExit string
+	// TUIDemo, if true, launches the TUI dashboard fed with synthetic progress
+	// and timings instead of a real calculation (screenshots, UI testing).
+	TUIDemo bool
 	// LastDigits, if > 0, computes only the last K decimal digits of F(N).
 	// Uses O(K) memory via modular arithmetic.
 	LastDigits int
+	// FibWordLength, if > 0, prints the first K symbols of the Fibonacci word
+	// instead of computing F(N).
+	FibWordLength uint64
+	// FibWordOnes, if > 0, counts the 1s in the first K symbols of the
+	// Fibonacci word instead of computing F(N).
+	FibWordOnes uint64
 	// MemoryLimit, if set, specifies the maximum memory budget for calculation.
 	// Accepts human-readable formats like "8G", "512M", "1024K".
 	// The application warns and exits if the estimated memory exceeds this limit.
 	MemoryLimit string
+	// ExplainMemory, if true, reports the pre-run memory estimate alongside the
+	// actual peak heap in use sampled during the calculation.
+	ExplainMemory bool
+	// BenchSteps, if true, reports the duration of each doubling iteration
+	// of the calculation, with whether it used FFT or parallel multiplication.
+	// It requires an algorithm built on the doubling loop (fast or fft).
+	BenchSteps bool
+	// Expect, if set, is the known-correct decimal value of F(N). Every
+	// algorithm's result is checked against it instead of only against each other.
+	Expect string
+	// LimitOutputBytes, if > 0, caps the size in bytes of the displayed result
+	// value. It guards terminals against runaway output.
+	LimitOutputBytes int
+	// LimitOutputMode selects what happens when the displayed value would
+	// exceed LimitOutputBytes: "truncate" (default) or "error".
+	LimitOutputMode string
+	// MinN, if > 0, rejects indices below MinN, and below the smallest index
+	// meaningful for the selected algorithms (fibonacci.MinMeaningfulN). It
+	// guards benchmark runs against measuring overhead instead of arithmetic.
+	MinN uint64
+	// IgnoreInconsistency, if true, reports algorithms that disagree with each
+	// other without failing the run (--fail-on-inconsistency=false). By
+	// default such a disagreement exits with ExitErrorMismatch in every output
+	// mode. Differences from --expect always fail.
+	IgnoreInconsistency bool
+	// BenchJSON, if true, replaces the standard output with `go test -json`
+	// benchmark events (one ns/op line per algorithm) for CI dashboards.
+	BenchJSON bool
+	// JSON, if true, replaces the standard output with a single JSON
+	// document describing each algorithm's result, the fastest one and
+	// whether the results are consistent.
+	JSON bool
+	// CSV, if true, replaces the standard output with one CSV row per
+	// algorithm; Quiet drops the header row.
+	CSV bool
+	// Markdown, if true, replaces the standard output with a Markdown table
+	// of the results, the fastest algorithm in bold.
+	Markdown bool
+	// Zeckendorf, if true, prints the Zeckendorf representation of the
+	// computed value: its unique sum of non-consecutive Fibonacci numbers.
+	Zeckendorf bool
+	// KBonacci is the order k used by the "kbonacci" algorithm, which sums
+	// the k previous terms (3 for Tribonacci). Must be at least 2; 0 selects
+	// the default order.
+	KBonacci int
+	// BinetPrecision is the mantissa precision in bits of the approximation
+	// computed by the "binet" algorithm.
+	BinetPrecision int
+	// CompareRepeat is the number of times each algorithm runs with --algo
+	// all. Above 1, the algorithms are ranked by median duration and a
+	// winner is declared only if it is significantly faster than the
+	// runner-up. 0 and 1 run each algorithm once.
+	CompareRepeat int
+	// Repeat is the number of times the calculation runs. Above 1, the first
+	// run is a warm-up presented as usual, and the others, one algorithm at
+	// a time and each with its own Timeout, are summarized by duration
+	// statistics and allocations. 0 and 1 run once.
+	Repeat int
+	// WarnLargeOutput is the number of digits above which the full value
+	// (-c -v) is only printed to a terminal after confirmation, either with
+	// Yes or an interactive prompt; otherwise the truncated display is used.
+	// 0 disables the warning.
+	WarnLargeOutput int
+	// Yes, if true, confirms printing values above WarnLargeOutput.
+	Yes bool
+	// Scientific, if true, displays the value in scientific notation with
+	// its digit count, e.g. "3.54225×10^20 (21 digits)". Implies ShowValue.
+	Scientific bool
+	// Base is the base of the displayed result, from 2 to 36 (see
+	// format.FormatInBase). 0 selects DefaultBase. Files written with
+	// --output stay in decimal.
+	Base int
+	// Edges is the number of leading and trailing digits shown when the
+	// value is truncated; values up to max(100, 2·Edges) digits are shown
+	// in full. 0 selects the default, 25 digits (40 in bases other than 10).
+	Edges int
+	// SpinnerStyle selects the CLI progress spinner frames (dots, line, arc
+	// or ascii).
+	SpinnerStyle string
+	// SpinnerSpeed is the delay between spinner frames.
+	SpinnerSpeed time.Duration
+	// ProgressFormat selects the progress output: ProgressFormatText,
+	// ProgressFormatPlain, ProgressFormatJSONL, or ProgressFormatAuto (also for "") to choose
+	// from whether the output is a terminal.
+	ProgressFormat string
+	// Job, if set, is the path of a JSON job spec (see JobSpec) whose values
+	// apply to any flag not given on the command line.
+	Job string
+	// PrintConfig, if set, is the format (PrintConfigJSON or PrintConfigYAML)
+	// in which the resolved configuration is printed; no calculation is
+	// performed.
+	PrintConfig string
+	// ConfigFile, if set, is the path of a TOML or YAML configuration file
+	// (see LoadConfigFile) whose settings apply to any flag not given on the
+	// command line, the job spec or the environment.
+	ConfigFile string
+	// Preset, if set, names a bundle of settings (see ApplyPreset), built in
+	// or from the configuration file, that applies below every other source.
+	Preset string
 	// GCControl sets the GC control mode ("auto", "aggressive", "disabled").
 	GCControl string
+	// RoundTrip, if true, computes F(N), saves it to a result file, reads it
+	// back and verifies the re-parsed value. Hidden flag used to guard the
+	// output file format against regressions.
+	RoundTrip bool
+	// ProfileAllocs, if true, computes F(N) once per selected algorithm under
+	// the memory profiler and prints the top allocating call sites instead of
+	// the standard output. Hidden developer diagnostic.
+	ProfileAllocs bool
+	// ExportFuzzCorpus, if set, is the module root under which the seed
+	// corpus for the fuzz tests is written, in each package's testdata/fuzz
+	// directory (developer aid); no calculation is performed.
+	ExportFuzzCorpus string
+	// NoPooling, if true, disables FFT buffer pooling so that every temporary
+	// buffer is freshly allocated. Hidden flag used to measure pooling benefits.
+	NoPooling bool
 }
 
 // Validate checks the semantic consistency of the configuration parameters.
@@ -91,17 +290,25 @@ type AppConfig struct {
 //     (e.g., ["fast", "matrix"]).
 //
 // Returns:
-//   - error: An error of type ConfigError if the configuration is invalid,
-//     nil otherwise.
+//   - error: An apperrors.ValidationError naming the flag for an invalid
+//     value (timeout, thresholds, displayed edges, algorithm, memory limit,
+//     dump and progress formats), a
+//     ConfigError for other invalid values and incompatible options, or nil.
 func (c AppConfig) Validate(availableAlgos []string) error {
 	if c.Timeout <= 0 {
-		return apperrors.NewConfigError("timeout value must be strictly positive")
+		return apperrors.ValidationError{Field: "timeout", Message: fmt.Sprintf("timeout value must be strictly positive: %s", c.Timeout)}
 	}
 	if c.Threshold < 0 {
-		return apperrors.NewConfigError("parallelism threshold cannot be negative: %d", c.Threshold)
+		return apperrors.ValidationError{Field: "threshold", Message: fmt.Sprintf("parallelism threshold cannot be negative: %d", c.Threshold)}
 	}
 	if c.FFTThreshold < 0 {
-		return apperrors.NewConfigError("FFT threshold cannot be negative: %d", c.FFTThreshold)
+		return apperrors.ValidationError{Field: "fft-threshold", Message: fmt.Sprintf("FFT threshold cannot be negative: %d", c.FFTThreshold)}
+	}
+	if c.StrassenThreshold < 0 {
+		return apperrors.ValidationError{Field: "strassen-threshold", Message: fmt.Sprintf("Strassen threshold cannot be negative: %d", c.StrassenThreshold)}
+	}
+	if c.Edges < 0 {
+		return apperrors.ValidationError{Field: "edges", Message: fmt.Sprintf("the number of displayed edge digits cannot be negative: %d", c.Edges)}
 	}
 	isAlgoAvailable := false
 	for _, a := range availableAlgos {
@@ -111,11 +318,185 @@ func (c AppConfig) Validate(availableAlgos []string) error {
 		}
 	}
 	if c.Algo != "all" && !isAlgoAvailable {
-		return apperrors.NewConfigError("unrecognized algorithm: '%s'. Valid algorithms are: 'all' or [%s]", c.Algo, strings.Join(availableAlgos, ", "))
+		return apperrors.ValidationError{Field: "algo", Message: fmt.Sprintf("unrecognized algorithm: '%s'. Valid algorithms are: 'all' or [%s]", c.Algo, strings.Join(availableAlgos, ", "))}
+	}
+	if c.MemoryLimit != "" {
+		if _, err := memory.ParseMemoryLimit(c.MemoryLimit); err != nil {
+			return apperrors.ValidationError{Field: "memory-limit", Message: fmt.Sprintf("%v (e.g. 8G, 512M)", err)}
+		}
+	}
+	switch c.PrintConfig {
+	case "", PrintConfigJSON, PrintConfigYAML:
+	default:
+		return apperrors.ValidationError{Field: "print-config", Message: fmt.Sprintf("unrecognized format: '%s'. Valid formats are: %s, %s", c.PrintConfig, PrintConfigJSON, PrintConfigYAML)}
+	}
+	if c.NegativeN && (c.TUI || c.TUIDemo || c.LastDigits > 0) {
+		return apperrors.NewConfigError("negative indices are not supported with --tui, --tui-demo or --last-digits")
+	}
+	if (c.Algo == fibonacci.KBonacciAlgorithm || c.Algo == fibonacci.LucasAlgorithm) && (c.NegativeN || c.LastDigits > 0) {
+		return apperrors.NewConfigError("--last-digits and negative indices compute F(n) and cannot be combined with --algo %s", c.Algo)
+	}
+	if c.NegativeN && c.Algo != DefaultAlgo && c.Algo != "fast" {
+		return apperrors.NewConfigError("negative indices are computed with fast doubling and cannot be combined with --algo %s", c.Algo)
+	}
+	if c.NegativeN && (c.Expect != "" || c.OutputFile != "" || c.EmitSVG != "" || c.LimitOutputMode == LimitOutputError || c.machineOutputs() > 0) {
+		return apperrors.NewConfigError("negative indices cannot be combined with --expect, --output, --emit-svg, --limit-output-mode error, --bench-json, --json, --csv or --markdown")
+	}
+	if c.LimitOutputBytes < 0 {
+		return apperrors.NewConfigError("output byte limit cannot be negative: %d", c.LimitOutputBytes)
+	}
+	if c.LimitOutputMode != "" && c.LimitOutputMode != LimitOutputTruncate && c.LimitOutputMode != LimitOutputError {
+		return apperrors.NewConfigError("unrecognized output limit mode: '%s'. Valid modes are: %s, %s", c.LimitOutputMode, LimitOutputTruncate, LimitOutputError)
+	}
+	switch c.SpinnerStyle {
+	case "", SpinnerStyleDots, SpinnerStyleLine, SpinnerStyleArc, SpinnerStyleASCII:
+	default:
+		return apperrors.NewConfigError("unrecognized spinner style: '%s'. Valid styles are: %s, %s, %s, %s",
+			c.SpinnerStyle, SpinnerStyleDots, SpinnerStyleLine, SpinnerStyleArc, SpinnerStyleASCII)
+	}
+	switch c.ProgressFormat {
+	case "", ProgressFormatAuto, ProgressFormatText, ProgressFormatPlain, ProgressFormatJSONL:
+	default:
+		return apperrors.ValidationError{Field: "progress-format", Message: fmt.Sprintf("unrecognized progress format: '%s'. Valid formats are: %s, %s, %s, %s",
+			c.ProgressFormat, ProgressFormatAuto, ProgressFormatText, ProgressFormatPlain, ProgressFormatJSONL)}
+	}
+	if c.SpinnerSpeed < 0 {
+		return apperrors.NewConfigError("spinner speed cannot be negative: %s", c.SpinnerSpeed)
+	}
+	if c.KBonacci != 0 && c.KBonacci < 2 {
+		return apperrors.NewConfigError("k-bonacci order must be at least 2: %d", c.KBonacci)
+	}
+	if c.Base != 0 && (c.Base < 2 || c.Base > 36) {
+		return apperrors.ValidationError{Field: "base", Message: fmt.Sprintf("output base must be between 2 and 36: %d", c.Base)}
+	}
+	if c.OutputBase() != 10 && c.LastDigits > 0 {
+		return apperrors.NewConfigError("--last-digits only supports --base 10")
+	}
+	if c.OutputBase() != 10 && c.Scientific {
+		return apperrors.NewConfigError("--scientific only supports --base 10")
+	}
+	if c.WarnLargeOutput < 0 {
+		return apperrors.NewConfigError("large output warning threshold cannot be negative: %d", c.WarnLargeOutput)
+	}
+	if c.CompareRepeat < 0 {
+		return apperrors.NewConfigError("comparison repeat count cannot be negative: %d", c.CompareRepeat)
+	}
+	if c.CompareRepeat > 1 && c.Algo != "all" {
+		return apperrors.NewConfigError("--compare-repeat requires --algo all")
+	}
+	if c.Repeat < 0 {
+		return apperrors.NewConfigError("repeat count cannot be negative: %d", c.Repeat)
+	}
+	if c.Repeat > 1 && c.CompareRepeat > 1 {
+		return apperrors.NewConfigError("--repeat and --compare-repeat are mutually exclusive")
+	}
+	if c.Repeat > 1 && c.machineOutputs() > 0 {
+		return apperrors.NewConfigError("--repeat cannot be combined with --bench-json, --json, --csv or --markdown")
+	}
+	if c.Algo == fibonacci.BinetAlgorithm && (c.Expect != "" || c.OutputFile != "" || c.machineOutputs() > 0) {
+		return apperrors.NewConfigError("--algo binet is approximate and cannot be combined with --expect, --output, --bench-json, --json, --csv or --markdown")
+	}
+	if c.BenchSteps && c.Algo != "fast" && c.Algo != "fft" {
+		return apperrors.NewConfigError("--bench-steps requires --algo fast or --algo fft")
+	}
+	if c.BenchSteps && c.machineOutputs() > 0 {
+		return apperrors.NewConfigError("--bench-steps cannot be combined with --bench-json, --json, --csv or --markdown")
+	}
+	if c.CompareRepeat > 1 && c.machineOutputs() > 0 {
+		return apperrors.NewConfigError("--compare-repeat cannot be combined with --bench-json, --json, --csv or --markdown")
+	}
+	if c.machineOutputs() > 1 {
+		return apperrors.NewConfigError("--bench-json, --json, --csv and --markdown are mutually exclusive")
+	}
+	if !c.Calibrate && (c.CalibrateMinN > 0 || c.CalibrateMaxN > 0 || c.CalibrationReport != "") {
+		return apperrors.NewConfigError("--calibrate-min-n, --calibrate-max-n and --calibration-report require --calibrate")
+	}
+	if c.CalibrateMinN > 0 && c.CalibrateMaxN == 0 {
+		return apperrors.NewConfigError("--calibrate-min-n requires --calibrate-max-n")
+	}
+	if c.CalibrateMaxN > 0 && c.CalibrateMinN > c.CalibrateMaxN {
+		return apperrors.NewConfigError("calibration range is empty: --calibrate-min-n %d is above --calibrate-max-n %d", c.CalibrateMinN, c.CalibrateMaxN)
+	}
+	if c.CalibrationDiff != nil && len(c.CalibrationDiff) != 2 {
+		return apperrors.NewConfigError("--calibration-diff takes two profiles: --calibration-diff a.json b.json")
+	}
+	if c.Expect != "" && c.ExpectedValue() == nil {
+		return apperrors.NewConfigError("expected value is not a valid non-negative decimal integer: '%s'", c.Expect)
 	}
 	return nil
 }
 
+// machineOutputs returns the number of machine-readable output modes
+// selected.
+func (c AppConfig) machineOutputs() int {
+	count := 0
+	for _, enabled := range []bool{c.BenchJSON, c.JSON, c.CSV, c.Markdown} {
+		if enabled {
+			count++
+		}
+	}
+	return count
+}
+
+// ExpectedValue returns the parsed --expect value.
+//
+// Returns:
+//   - *big.Int: The expected F(N), or nil if Expect is empty or invalid.
+func (c AppConfig) ExpectedValue() *big.Int {
+	if c.Expect == "" {
+		return nil
+	}
+	v, ok := new(big.Int).SetString(c.Expect, 10)
+	if !ok || v.Sign() < 0 {
+		return nil
+	}
+	return v
+}
+
+// OutputBase returns the base of the displayed result.
+//
+// Returns:
+//   - int: Base, or DefaultBase if unset.
+func (c AppConfig) OutputBase() int {
+	if c.Base == 0 {
+		return DefaultBase
+	}
+	return c.Base
+}
+
+// splitCalibrationDiff removes --calibration-diff and the two profile paths
+// that follow it from args, so that the flag may appear anywhere on the
+// command line.
+//
+// Parameters:
+//   - args: The command-line arguments, without the program name.
+//
+// Returns:
+//   - []string: args without the flag and its paths.
+//   - []string: The profile paths, fewer than two if they are missing, or nil
+//     if the flag is absent.
+func splitCalibrationDiff(args []string) ([]string, []string) {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "calibration-diff" {
+			continue
+		}
+		paths := []string{}
+		if hasValue {
+			paths = append(paths, value)
+		}
+		end := i + 1
+		for ; len(paths) < 2 && end < len(args) && !strings.HasPrefix(args[end], "-"); end++ {
+			paths = append(paths, args[end])
+		}
+		return append(args[:i:i], args[end:]...), paths
+	}
+	return args, nil
+}
+
 // ParseConfig parses the command-line arguments and populates an AppConfig
 // struct. It defines all the command-line flags, sets their default values, and
 // handles the parsing process. After parsing, it performs validation on the
@@ -141,7 +522,8 @@ func ParseConfig(programName string, args []string, errorWriter io.Writer, avail
 	algoHelp := fmt.Sprintf("Algorithm to use: 'all' (default) or one of [%s].", strings.Join(availableAlgos, ", "))
 
 	config := AppConfig{}
-	fs.Uint64Var(&config.N, "n", DefaultN, "Index n of the Fibonacci number to calculate.")
+	config.N = DefaultN
+	fs.Var(indexValue{&config}, "n", "Index `n` of the Fibonacci number to calculate (negative values give F(-n) = (-1)^(n+1) F(n)).")
 	fs.BoolVar(&config.Verbose, "v", false, "Display the full value of the result (can be very long).")
 	fs.BoolVar(&config.Verbose, "verbose", false, "Alias for -v.")
 	fs.BoolVar(&config.Details, "d", false, "Display performance details and result metadata.")
@@ -154,25 +536,105 @@ func ParseConfig(programName string, args []string, errorWriter io.Writer, avail
 	fs.BoolVar(&config.Calibrate, "calibrate", false, "Runs calibration mode to determine the optimal parallelism threshold.")
 	fs.BoolVar(&config.AutoCalibrate, "auto-calibrate", false, "Enables quick automatic calibration at startup (may increase loading time).")
 	fs.StringVar(&config.CalibrationProfile, "calibration-profile", "", "Path to calibration profile file (default: ~/.fibcalc_calibration.json).")
+	fs.BoolVar(&config.DynamicThresholds, "dynamic-thresholds", false, "Adjust the parallel and FFT thresholds during the calculation and save the learned values into the calibration profile for --auto-calibrate.")
+	fs.Uint64Var(&config.CalibrateMinN, "calibrate-min-n", 0, "Smallest index of the range calibrated by --calibrate (requires --calibrate-max-n).")
+	fs.Uint64Var(&config.CalibrateMaxN, "calibrate-max-n", 0, "Largest index of the range calibrated by --calibrate; the result is saved as a bucket of the profile (0 for the default thresholds).")
+	fs.StringVar(&config.CalibrationReport, "calibration-report", "", "Write the report of --calibrate to this file (JSON if it ends in .json, a table otherwise).")
+	fs.Func("calibration-diff", "Compare two calibration profiles side by side and exit: --calibration-diff `a.json` b.json.", func(v string) error {
+		config.CalibrationDiff = []string{v}
+		return nil
+	})
 	// New CLI enhancement flags
 	fs.StringVar(&config.OutputFile, "output", "", "Output file path for the result.")
 	fs.StringVar(&config.OutputFile, "o", "", "Output file path (shorthand).")
+	fs.StringVar(&config.EmitSVG, "emit-svg", "", "Write an SVG card summarizing the result (n, algorithm, digits, duration) to this path.")
 	fs.BoolVar(&config.Quiet, "quiet", false, "Quiet mode - minimal output for scripts.")
 	fs.BoolVar(&config.Quiet, "q", false, "Quiet mode (shorthand).")
-	fs.StringVar(&config.Completion, "completion", "", "Generate shell completion script (bash, zsh, fish, powershell).")
+	fs.StringVar(&config.Completion, "completion", "", "Generate shell completion script (bash, zsh, fish, powershell, nushell, elvish).")
 	fs.BoolVar(&config.ShowValue, "calculate", false, "Display the calculated value (disabled by default).")
 	fs.BoolVar(&config.ShowValue, "c", false, "Display the calculated value (shorthand).")
 	fs.BoolVar(&config.TUI, "tui", false, "Launch interactive TUI dashboard.")
+	fs.BoolVar(&config.TUIDemo, "tui-demo", false, "Launch the TUI dashboard with synthetic progress instead of a real calculation.")
+	fs.StringVar(&config.TUIExportOnExit, "tui-export-on-exit", "", "Write the final TUI dashboard state as JSON to this file.")
 	fs.IntVar(&config.LastDigits, "last-digits", 0, "Compute only the last K decimal digits (uses O(K) memory).")
+	fs.Uint64Var(&config.FibWordLength, "fib-word-length", 0, "Print the first K symbols of the Fibonacci word (0 -> 01, 1 -> 0).")
+	fs.Uint64Var(&config.FibWordOnes, "fib-word-ones", 0, "Count the 1s in the first K symbols of the Fibonacci word.")
 	fs.StringVar(&config.MemoryLimit, "memory-limit", "", "Maximum memory budget (e.g., 8G, 512M). Warns if estimate exceeds limit.")
+	fs.BoolVar(&config.ExplainMemory, "explain-memory", false, "Compare the memory estimate with the actual peak heap after the calculation.")
+	fs.BoolVar(&config.BenchSteps, "bench-steps", false, "Report the duration of each doubling iteration, and whether it used FFT or parallel multiplication (--algo fast or fft).")
+	fs.StringVar(&config.Expect, "expect", "", "Known-correct decimal value of F(n); flags any algorithm whose result differs.")
+	fs.IntVar(&config.LimitOutputBytes, "limit-output-bytes", 0, "Maximum size in bytes of the displayed result value (0 for no limit).")
+	fs.StringVar(&config.LimitOutputMode, "limit-output-mode", LimitOutputTruncate, "Action when the value exceeds --limit-output-bytes (truncate, error).")
+	fs.Uint64Var(&config.MinN, "min-n", 0, "Reject -n below this floor or below the smallest n meaningful for the algorithm (benchmark guard).")
+	fs.BoolFunc("fail-on-inconsistency", "Exit with code 3 when algorithms disagree, in every output mode (default true; =false only reports it).", func(v string) error {
+		fail, err := strconv.ParseBool(v)
+		config.IgnoreInconsistency = !fail
+		return err
+	})
+	fs.BoolVar(&config.BenchJSON, "bench-json", false, "Emit timings as go test -json benchmark events instead of the standard output.")
+	fs.BoolVar(&config.JSON, "json", false, "Emit the results as a JSON document (durations, sizes, fastest algorithm, consistency); -c adds the values.")
+	fs.BoolVar(&config.CSV, "csv", false, "Emit one CSV row per algorithm (name, duration_ns, bitlen, digits, consistent, fastest); --quiet omits the header.")
+	fs.BoolVar(&config.Markdown, "markdown", false, "Emit the results as a Markdown table (algorithm, duration, digits, status) with the fastest algorithm in bold, for issues and docs.")
+	fs.IntVar(&config.KBonacci, "kbonacci", DefaultKBonacci, "Order K of the sequence computed by --algo kbonacci (sum of the K previous terms; 3 for Tribonacci).")
+	fs.IntVar(&config.BinetPrecision, "binet-precision", fibonacci.DefaultBinetPrecision, "Mantissa precision in bits of the approximation computed by --algo binet.")
+	fs.IntVar(&config.WarnLargeOutput, "warn-large-output", DefaultWarnLargeOutput, "Digits above which printing the full value to a terminal asks for confirmation (0 to disable).")
+	fs.BoolVar(&config.Yes, "yes", false, "Print values above --warn-large-output in full without asking.")
+	fs.IntVar(&config.Base, "base", DefaultBase, "Base of the displayed result, from 2 to 36 (16 for hexadecimal). Files written with --output stay in decimal.")
+	fs.IntVar(&config.Edges, "edges", 0, "Number of leading and trailing digits shown when the displayed value is truncated (0 for 25, or 40 in bases other than 10).")
+	fs.BoolVar(&config.Scientific, "scientific", false, "Display the calculated value in scientific notation with its digit count.")
+	fs.IntVar(&config.CompareRepeat, "compare-repeat", 0, "With --algo all, run each algorithm N times and rank them by median duration, declaring a winner only if it is significantly faster.")
+	fs.IntVar(&config.Repeat, "repeat", 0, "Run the calculation K times and report the min/median/mean/stddev durations and allocations of the runs after the first (warm-up).")
+	fs.BoolVar(&config.Zeckendorf, "zeckendorf", false, "Print the Zeckendorf representation (sum of non-consecutive Fibonacci numbers) of the result.")
+	fs.StringVar(&config.SpinnerStyle, "spinner-style", SpinnerStyleDots, "Progress spinner style (dots, line, arc, ascii).")
+	fs.DurationVar(&config.SpinnerSpeed, "spinner-speed", DefaultSpinnerSpeed, "Delay between progress spinner frames (e.g. 500ms to slow it for recordings).")
+	fs.StringVar(&config.ProgressFormat, "progress-format", ProgressFormatAuto, "Progress output: text (spinner and bar, plain lines when redirected), plain (newline-terminated percentage lines), jsonl (one JSON object per update on stderr, for supervising processes), or auto (plain when stdout is not a terminal).")
+	fs.StringVar(&config.Job, "job", "", "JSON job spec file ({n, algo, format, timeout, output}); flags and environment take precedence.")
+	fs.Var(printConfigValue{&config.PrintConfig}, "print-config", "Print the resolved configuration (flags, environment, files, calibration) as JSON and exit; --print-config=yaml for YAML.")
+	fs.StringVar(&config.ConfigFile, "config", "", "TOML (.toml) or YAML (.yaml, .yml) configuration file with the keys of the FIBCALC_ variables in lower case; flags, environment and job spec take precedence.")
+	fs.StringVar(&config.Preset, "preset", "", "Apply a bundle of settings: "+strings.Join(PresetNames(), ", ")+", or a preset of the --config file; every other source takes precedence.")
 	fs.StringVar(&config.GCControl, "gc-control", "auto", "GC control during calculation (auto, aggressive, disabled).")
+	fs.BoolVar(&config.NoPooling, "no-pooling", false, "Disable FFT buffer pooling (allocation experiments).")
+	fs.StringVar(&config.ExportFuzzCorpus, "export-fuzz-corpus", "", "Write the fuzz test seed corpus under this module root (e.g. .) and exit.")
+	fs.BoolVar(&config.RoundTrip, "round-trip", false, "Save the result, read it back and verify it (file format self-test).")
+	fs.BoolVar(&config.ProfileAllocs, "profile-allocs", false, "Profile the allocations of one calculation per algorithm and print the top allocating call sites.")
 	setCustomUsage(fs)
 
+	// The flag package takes one value per flag, so the two profiles of
+	// --calibration-diff are taken out of args beforehand.
+	args, config.CalibrationDiff = splitCalibrationDiff(args)
 	if err := fs.Parse(args); err != nil {
 		return AppConfig{}, err
 	}
+	if config.CalibrationDiff != nil {
+		// Extra arguments are reported as extra profiles by Validate.
+		config.CalibrationDiff = append(config.CalibrationDiff, fs.Args()...)
+	}
 
-	// Apply environment variable overrides for flags not explicitly set
+	// Apply the preset, the configuration file, the job spec, then
+	// environment variable overrides, for flags not explicitly set
+	file := &configFile{}
+	if config.ConfigFile != "" {
+		var err error
+		if file, err = readConfigFile(config.ConfigFile); err != nil {
+			fmt.Fprintln(errorWriter, "Configuration error:", err)
+			return AppConfig{}, err
+		}
+	}
+	if config.Preset != "" {
+		if err := applyPreset(&config, fs, config.Preset, file.presets); err != nil {
+			fmt.Fprintln(errorWriter, "Configuration error:", err)
+			return AppConfig{}, err
+		}
+	}
+	applyConfigFile(&config, fs, file.values)
+	if config.Job != "" {
+		job, err := LoadJobSpec(config.Job)
+		if err != nil {
+			fmt.Fprintln(errorWriter, "Configuration error:", err)
+			return AppConfig{}, fmt.Errorf("invalid job spec: %w", err)
+		}
+		applyJobSpec(&config, fs, job)
+	}
 	applyEnvOverrides(&config, fs)
 
 	config.Algo = strings.ToLower(config.Algo)
@@ -2,6 +2,8 @@ package config
 
 import (
 	"bytes"
+	"math"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -174,6 +176,372 @@ func TestValidateAlgorithm(t *testing.T) {
 	}
 }
 
+// TestValidateExpect tests validation and parsing of the --expect value.
+func TestValidateExpect(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		expect      string
+		expectError bool
+	}{
+		{"Empty", "", false},
+		{"Valid", "354224848179261915075", false},
+		{"Zero", "0", false},
+		{"Negative", "-5", true},
+		{"NotANumber", "abc", true},
+		{"Hex", "0x10", true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := AppConfig{Timeout: time.Minute, Algo: "all", Expect: tc.expect}
+			err := cfg.Validate(nil)
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+			if got := cfg.ExpectedValue(); !tc.expectError && tc.expect != "" && got.String() != tc.expect {
+				t.Errorf("ExpectedValue() = %v, want %s", got, tc.expect)
+			}
+		})
+	}
+}
+
+// TestValidateKBonacci tests validation of the k-bonacci order.
+func TestValidateKBonacci(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		k           int
+		expectError bool
+	}{
+		{"Default", 0, false},
+		{"Fibonacci", 2, false},
+		{"Tribonacci", 3, false},
+		{"One", 1, true},
+		{"Negative", -3, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := AppConfig{Timeout: time.Minute, Algo: "kbonacci", KBonacci: tc.k}
+			err := cfg.Validate([]string{"kbonacci"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCompareRepeat(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Default", AppConfig{Algo: "all"}, false},
+		{"Repeat", AppConfig{Algo: "all", CompareRepeat: 5}, false},
+		{"Once with a single algorithm", AppConfig{Algo: "fast", CompareRepeat: 1}, false},
+		{"Negative", AppConfig{Algo: "all", CompareRepeat: -1}, true},
+		{"Single algorithm", AppConfig{Algo: "fast", CompareRepeat: 5}, true},
+		{"Bench JSON", AppConfig{Algo: "all", CompareRepeat: 5, BenchJSON: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"fast", "matrix"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateBenchSteps(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Fast", AppConfig{Algo: "fast", BenchSteps: true}, false},
+		{"FFT", AppConfig{Algo: "fft", BenchSteps: true}, false},
+		{"All algorithms", AppConfig{Algo: "all", BenchSteps: true}, true},
+		{"Matrix", AppConfig{Algo: "matrix", BenchSteps: true}, true},
+		{"JSON", AppConfig{Algo: "fast", BenchSteps: true, JSON: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"fast", "fft", "matrix"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateRepeat(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Single algorithm", AppConfig{Algo: "fast", Repeat: 5}, false},
+		{"All algorithms", AppConfig{Algo: "all", Repeat: 5}, false},
+		{"Negative", AppConfig{Algo: "fast", Repeat: -1}, true},
+		{"Compare repeat", AppConfig{Algo: "all", Repeat: 3, CompareRepeat: 3}, true},
+		{"CSV", AppConfig{Algo: "fast", Repeat: 3, CSV: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"fast", "matrix"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateBinet(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Approximation", AppConfig{Algo: "binet", BinetPrecision: 128}, false},
+		{"Expected value", AppConfig{Algo: "binet", Expect: "55"}, true},
+		{"Output file", AppConfig{Algo: "binet", OutputFile: "f.txt"}, true},
+		{"JSON", AppConfig{Algo: "binet", JSON: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"binet", "fast"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateCalibrationRange(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Range", AppConfig{Calibrate: true, CalibrateMinN: 1000, CalibrateMaxN: 3000}, false},
+		{"Upper bound only", AppConfig{Calibrate: true, CalibrateMaxN: 3000}, false},
+		{"Report", AppConfig{Calibrate: true, CalibrationReport: "report.json"}, false},
+		{"Lower bound only", AppConfig{Calibrate: true, CalibrateMinN: 1000}, true},
+		{"Empty range", AppConfig{Calibrate: true, CalibrateMinN: 3000, CalibrateMaxN: 1000}, true},
+		{"Range without --calibrate", AppConfig{CalibrateMinN: 1000, CalibrateMaxN: 3000}, true},
+		{"Report without --calibrate", AppConfig{CalibrationReport: "report.json"}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			tc.cfg.Algo = "all"
+			err := tc.cfg.Validate([]string{"fast"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+// TestValidateFibonacciOnlyModes verifies that the modes computing F(n)
+// regardless of the algorithm reject the algorithms of other sequences.
+func TestValidateNegativeIndex(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Default algorithm", AppConfig{Algo: "all", NegativeN: true}, false},
+		{"Fast", AppConfig{Algo: "fast", NegativeN: true, Base: 16, Quiet: true}, false},
+		{"Matrix", AppConfig{Algo: "matrix", NegativeN: true}, true},
+		{"Expect", AppConfig{Algo: "fast", NegativeN: true, Expect: "1"}, true},
+		{"Output file", AppConfig{Algo: "fast", NegativeN: true, OutputFile: "f.txt"}, true},
+		{"SVG card", AppConfig{Algo: "fast", NegativeN: true, EmitSVG: "f.svg"}, true},
+		{"Output limit error", AppConfig{Algo: "fast", NegativeN: true, LimitOutputBytes: 10, LimitOutputMode: LimitOutputError}, true},
+		{"Output limit truncate", AppConfig{Algo: "fast", NegativeN: true, LimitOutputBytes: 10, LimitOutputMode: LimitOutputTruncate}, false},
+		{"JSON", AppConfig{Algo: "fast", NegativeN: true, JSON: true}, true},
+		{"Bench JSON", AppConfig{Algo: "fast", NegativeN: true, BenchJSON: true}, true},
+		{"TUI demo", AppConfig{Algo: "fast", NegativeN: true, TUIDemo: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"fast", "matrix"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateFibonacciOnlyModes(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Fast last digits", AppConfig{Algo: "fast", LastDigits: 5}, false},
+		{"Fast negative index", AppConfig{Algo: "fast", NegativeN: true}, false},
+		{"K-bonacci", AppConfig{Algo: "kbonacci"}, false},
+		{"K-bonacci last digits", AppConfig{Algo: "kbonacci", LastDigits: 5}, true},
+		{"K-bonacci negative index", AppConfig{Algo: "kbonacci", NegativeN: true}, true},
+		{"Lucas last digits", AppConfig{Algo: "lucas", LastDigits: 5}, true},
+		{"Lucas negative index", AppConfig{Algo: "lucas", NegativeN: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			err := tc.cfg.Validate([]string{"fast", "kbonacci", "lucas"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
+func TestValidateWarnLargeOutput(t *testing.T) {
+	t.Parallel()
+	for _, threshold := range []int{0, DefaultWarnLargeOutput} {
+		cfg := AppConfig{Timeout: time.Minute, Algo: "fast", WarnLargeOutput: threshold}
+		if err := cfg.Validate([]string{"fast"}); err != nil {
+			t.Errorf("threshold %d: unexpected validation error: %v", threshold, err)
+		}
+	}
+	cfg := AppConfig{Timeout: time.Minute, Algo: "fast", WarnLargeOutput: -1}
+	if err := cfg.Validate([]string{"fast"}); err == nil {
+		t.Error("Expected validation error for a negative threshold but got nil")
+	}
+}
+
+func TestValidateBase(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"Default", AppConfig{}, false},
+		{"Binary", AppConfig{Base: 2}, false},
+		{"Base 36", AppConfig{Base: 36}, false},
+		{"Base 1", AppConfig{Base: 1}, true},
+		{"Base 37", AppConfig{Base: 37}, true},
+		{"Decimal last digits", AppConfig{Base: 10, LastDigits: 5}, false},
+		{"Hex last digits", AppConfig{Base: 16, LastDigits: 5}, true},
+		{"Hex scientific", AppConfig{Base: 16, Scientific: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			tc.cfg.Algo = "fast"
+			err := tc.cfg.Validate([]string{"fast"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+	if got := (AppConfig{}).OutputBase(); got != DefaultBase {
+		t.Errorf("OutputBase() = %d for an unset base, want %d", got, DefaultBase)
+	}
+}
+
+// TestValidateJSON verifies that --json, --csv and --markdown exclude the other
+// machine-readable and repeated modes.
+func TestValidateJSON(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name        string
+		cfg         AppConfig
+		expectError bool
+	}{
+		{"JSON", AppConfig{JSON: true}, false},
+		{"JSON with values", AppConfig{JSON: true, ShowValue: true}, false},
+		{"JSON and bench JSON", AppConfig{JSON: true, BenchJSON: true}, true},
+		{"JSON and compare repeat", AppConfig{JSON: true, CompareRepeat: 3}, true},
+		{"CSV", AppConfig{CSV: true, Quiet: true}, false},
+		{"CSV and JSON", AppConfig{CSV: true, JSON: true}, true},
+		{"CSV and compare repeat", AppConfig{CSV: true, CompareRepeat: 3}, true},
+		{"Markdown", AppConfig{Markdown: true}, false},
+		{"Markdown and CSV", AppConfig{Markdown: true, CSV: true}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			tc.cfg.Timeout = time.Minute
+			tc.cfg.Algo = "all"
+			err := tc.cfg.Validate([]string{"fast", "matrix"})
+			if tc.expectError && err == nil {
+				t.Error("Expected validation error but got nil")
+			}
+			if !tc.expectError && err != nil {
+				t.Errorf("Unexpected validation error: %v", err)
+			}
+		})
+	}
+}
+
 // TestValidateEmptyAvailableAlgos tests validation with empty algo list.
 func TestValidateEmptyAvailableAlgos(t *testing.T) {
 	t.Parallel()
@@ -326,6 +694,61 @@ func TestParseConfigAllFlags(t *testing.T) {
 	}
 }
 
+// TestParseConfigNegativeIndex tests parsing of signed values for -n.
+func TestParseConfigNegativeIndex(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		name     string
+		value    string
+		wantN    uint64
+		wantNeg  bool
+		wantSign int64
+	}{
+		{"Positive", "42", 42, false, 42},
+		{"NegativeZero", "-0", 0, false, 0},
+		{"Negative", "-6", 6, true, -6},
+		{"MinInt64", "-9223372036854775808", 1 << 63, true, math.MinInt64},
+		{"MaxUint64", "18446744073709551615", math.MaxUint64, false, 0}, // SignedN not meaningful
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			cfg, err := ParseConfig("test", []string{"-n", tc.value}, &buf, []string{"fast"})
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cfg.N != tc.wantN || cfg.NegativeN != tc.wantNeg {
+				t.Errorf("N = %d, NegativeN = %v; want %d, %v", cfg.N, cfg.NegativeN, tc.wantN, tc.wantNeg)
+			}
+			if tc.wantN <= math.MaxInt64 || tc.wantNeg {
+				if got := cfg.SignedN(); got != tc.wantSign {
+					t.Errorf("SignedN() = %d, want %d", got, tc.wantSign)
+				}
+			}
+		})
+	}
+
+	t.Run("Invalid", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		for _, value := range []string{"-9223372036854775809", "abc", "-x"} {
+			if _, err := ParseConfig("test", []string{"-n", value}, &buf, []string{"fast"}); err == nil {
+				t.Errorf("Expected error for -n %s", value)
+			}
+		}
+	})
+
+	t.Run("UnsupportedMode", func(t *testing.T) {
+		t.Parallel()
+		var buf bytes.Buffer
+		if _, err := ParseConfig("test", []string{"-n", "-5", "--last-digits", "3"}, &buf, []string{"fast"}); err == nil {
+			t.Error("Expected error for a negative index with --last-digits")
+		}
+	})
+}
+
 // TestParseConfigDetailsAlias tests the -details alias for -d.
 func TestParseConfigDetailsAlias(t *testing.T) {
 	t.Parallel()
@@ -342,6 +765,94 @@ func TestParseConfigDetailsAlias(t *testing.T) {
 	}
 }
 
+// TestParseConfigFailOnInconsistency tests that --fail-on-inconsistency is
+// on by default and can be turned off explicitly.
+func TestParseConfigFailOnInconsistency(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast"}
+	for _, tc := range []struct {
+		args   []string
+		ignore bool
+	}{
+		{nil, false},
+		{[]string{"--fail-on-inconsistency"}, false},
+		{[]string{"--fail-on-inconsistency=false"}, true},
+	} {
+		var buf bytes.Buffer
+		cfg, err := ParseConfig("test", tc.args, &buf, algos)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", tc.args, err)
+		}
+		if cfg.IgnoreInconsistency != tc.ignore {
+			t.Errorf("%v: IgnoreInconsistency = %v, want %v", tc.args, cfg.IgnoreInconsistency, tc.ignore)
+		}
+	}
+}
+
+func TestParseConfigCalibrationDiff(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast"}
+	var buf bytes.Buffer
+	cfg, err := ParseConfig("test", []string{"--calibration-diff", "a.json", "b.json"}, &buf, algos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !slices.Equal(cfg.CalibrationDiff, []string{"a.json", "b.json"}) {
+		t.Errorf("CalibrationDiff = %q, want [a.json b.json]", cfg.CalibrationDiff)
+	}
+
+	// The flag may be followed by other flags, and take its first path after "=".
+	for _, args := range [][]string{
+		{"--calibration-diff", "a.json", "b.json", "-n", "42"},
+		{"-n", "42", "-calibration-diff=a.json", "b.json", "-d"},
+	} {
+		cfg, err := ParseConfig("test", args, &buf, algos)
+		if err != nil {
+			t.Fatalf("%v: unexpected error: %v", args, err)
+		}
+		if !slices.Equal(cfg.CalibrationDiff, []string{"a.json", "b.json"}) || cfg.N != 42 {
+			t.Errorf("%v: CalibrationDiff = %q, N = %d; want [a.json b.json] and 42", args, cfg.CalibrationDiff, cfg.N)
+		}
+	}
+
+	for _, args := range [][]string{
+		{"--calibration-diff", "a.json"},
+		{"--calibration-diff", "a.json", "-n", "42"},
+		{"--calibration-diff", "a.json", "b.json", "c.json"},
+	} {
+		if _, err := ParseConfig("test", args, &buf, algos); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
+func TestParseConfigSpinner(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast"}
+	var buf bytes.Buffer
+	cfg, err := ParseConfig("test", nil, &buf, algos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SpinnerStyle != SpinnerStyleDots || cfg.SpinnerSpeed != DefaultSpinnerSpeed {
+		t.Errorf("defaults = %q, %v; want %q, %v", cfg.SpinnerStyle, cfg.SpinnerSpeed, SpinnerStyleDots, DefaultSpinnerSpeed)
+	}
+
+	cfg, err = ParseConfig("test", []string{"--spinner-style", "ascii", "--spinner-speed", "500ms"}, &buf, algos)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.SpinnerStyle != SpinnerStyleASCII || cfg.SpinnerSpeed != 500*time.Millisecond {
+		t.Errorf("got %q, %v; want ascii, 500ms", cfg.SpinnerStyle, cfg.SpinnerSpeed)
+	}
+
+	for _, args := range [][]string{{"--spinner-style", "emoji"}, {"--spinner-speed", "-1s"}} {
+		if _, err := ParseConfig("test", args, &buf, algos); err == nil {
+			t.Errorf("%v: expected an error", args)
+		}
+	}
+}
+
 // TestParseConfigInvalidFlags tests handling of invalid flags.
 func TestParseConfigInvalidFlags(t *testing.T) {
 	t.Parallel()
@@ -0,0 +1,158 @@
+// This file implements --print-config, which dumps the resolved
+// configuration to debug how flags, environment variables, configuration
+// files and calibration combined.
+
+package config
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// Formats accepted by --print-config.
+const (
+	PrintConfigJSON = "json"
+	PrintConfigYAML = "yaml"
+)
+
+// printConfigValue is the flag.Value of --print-config. It is a boolean flag
+// so that --print-config alone selects JSON, while --print-config=yaml
+// selects another format.
+type printConfigValue struct {
+	format *string
+}
+
+func (v printConfigValue) String() string {
+	if v.format == nil {
+		return ""
+	}
+	return *v.format
+}
+
+func (v printConfigValue) Set(s string) error {
+	switch s = strings.ToLower(s); s {
+	case "true":
+		s = PrintConfigJSON
+	case "false":
+		s = ""
+	}
+	*v.format = s
+	return nil
+}
+
+func (v printConfigValue) IsBoolFlag() bool { return true }
+
+// configEntry is one field of a configuration dump.
+type configEntry struct {
+	key   string
+	value any
+}
+
+// configFileKeys maps the fields read from configuration files (see
+// LoadConfigFile) whose key there is not their snake_case name.
+var configFileKeys = map[string]string{
+	"OutputFile": "output",
+	"ShowValue":  "calculate",
+}
+
+// entries returns the fields of the configuration in declaration order,
+// keyed in snake_case, or by their configuration file key for the fields
+// read by --config. Durations are rendered as Go duration strings.
+func (c AppConfig) entries() []configEntry {
+	v := reflect.ValueOf(c)
+	t := v.Type()
+	entries := make([]configEntry, 0, t.NumField())
+	for i := range t.NumField() {
+		value := v.Field(i).Interface()
+		if d, ok := value.(time.Duration); ok {
+			value = d.String()
+		}
+		key, ok := configFileKeys[t.Field(i).Name]
+		if !ok {
+			key = snakeCase(t.Field(i).Name)
+		}
+		entries = append(entries, configEntry{key: key, value: value})
+	}
+	return entries
+}
+
+// snakeCase converts a Go field name to snake_case, keeping acronyms
+// together (FFTThreshold becomes fft_threshold).
+func snakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prevLower := unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1])
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prevLower || (unicode.IsUpper(runes[i-1]) && nextLower) {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// WriteConfig writes the configuration, one snake_case key per field in
+// declaration order. The fields read by --config use the configuration file
+// keys, so the YAML lines of those keys can be loaded back with
+// LoadConfigFile.
+//
+// Parameters:
+//   - out: The destination writer.
+//   - format: PrintConfigJSON or PrintConfigYAML.
+//
+// Returns:
+//   - error: An error if the format is unknown or writing fails.
+func (c AppConfig) WriteConfig(out io.Writer, format string) error {
+	entries := c.entries()
+	w := bufio.NewWriter(out)
+	switch format {
+	case PrintConfigJSON:
+		w.WriteString("{\n")
+		for i, e := range entries {
+			data, err := json.Marshal(e.value)
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", e.key, err)
+			}
+			sep := ","
+			if i == len(entries)-1 {
+				sep = ""
+			}
+			fmt.Fprintf(w, "  %q: %s%s\n", e.key, data, sep)
+		}
+		w.WriteString("}\n")
+	case PrintConfigYAML:
+		for _, e := range entries {
+			fmt.Fprintf(w, "%s: %s\n", e.key, yamlScalar(e.value))
+		}
+	default:
+		return fmt.Errorf("unknown configuration format %q", format)
+	}
+	return w.Flush()
+}
+
+// yamlScalar renders a value of the configuration in YAML. Strings are
+// always quoted so that values such as "yes" or "8G" keep their type.
+func yamlScalar(value any) string {
+	switch v := value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case []string:
+		quoted := make([]string, len(v))
+		for i, s := range v {
+			quoted[i] = strconv.Quote(s)
+		}
+		return "[" + strings.Join(quoted, ", ") + "]"
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+func TestValidateReturnsValidationErrors(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast", "matrix"}
+	valid := AppConfig{N: 100, Algo: "fast", Timeout: time.Minute}
+
+	tests := []struct {
+		name   string
+		modify func(*AppConfig)
+		field  string
+	}{
+		{"invalid algo", func(c *AppConfig) { c.Algo = "quantum" }, "algo"},
+		{"zero timeout", func(c *AppConfig) { c.Timeout = 0 }, "timeout"},
+		{"negative threshold", func(c *AppConfig) { c.Threshold = -1 }, "threshold"},
+		{"negative Strassen threshold", func(c *AppConfig) { c.StrassenThreshold = -1 }, "strassen-threshold"},
+		{"negative edges", func(c *AppConfig) { c.Edges = -5 }, "edges"},
+		{"invalid memory limit", func(c *AppConfig) { c.MemoryLimit = "8Q" }, "memory-limit"},
+		{"invalid dump format", func(c *AppConfig) { c.PrintConfig = "xml" }, "print-config"},
+		{"invalid progress format", func(c *AppConfig) { c.ProgressFormat = "xml" }, "progress-format"},
+		{"invalid base", func(c *AppConfig) { c.Base = 37 }, "base"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg := valid
+			tt.modify(&cfg)
+			var validationErr apperrors.ValidationError
+			if err := cfg.Validate(algos); !errors.As(err, &validationErr) {
+				t.Fatalf("Validate() error = %v, want a ValidationError", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q", validationErr.Field, tt.field)
+			}
+		})
+	}
+
+	valid.MemoryLimit = "512M"
+	if err := valid.Validate(algos); err != nil {
+		t.Errorf("Validate() on a valid configuration: %v", err)
+	}
+}
+
+func TestWriteConfigJSON(t *testing.T) {
+	t.Parallel()
+	cfg := AppConfig{N: 1000, Algo: "fast", Timeout: 90 * time.Second, FFTThreshold: 500000, TUIExportOnExit: "run.json"}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteConfig(&buf, PrintConfigJSON); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+	want := "{\n  \"n\": 1000,\n  \"negative_n\": false,\n"
+	if !strings.HasPrefix(buf.String(), want) {
+		t.Errorf("JSON should list the fields in declaration order, got:\n%s", buf.String())
+	}
+
+	var dump map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &dump); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buf.String())
+	}
+	for key, value := range map[string]any{
+		"algo":               "fast",
+		"timeout":            "1m30s",
+		"fft_threshold":      float64(500000),
+		"tui_export_on_exit": "run.json",
+		"k_bonacci":          float64(0),
+		"gc_control":         "",
+	} {
+		if dump[key] != value {
+			t.Errorf("%s = %v, want %v", key, dump[key], value)
+		}
+	}
+}
+
+func TestWriteConfigYAML(t *testing.T) {
+	t.Parallel()
+	cfg := AppConfig{N: 42, Algo: "matrix", Timeout: time.Minute, Details: true, CalibrationDiff: []string{"a.json", "b.json"}}
+
+	var buf bytes.Buffer
+	if err := cfg.WriteConfig(&buf, PrintConfigYAML); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+	for _, line := range []string{"n: 42\n", "algo: \"matrix\"\n", "timeout: \"1m0s\"\n", "details: true\n", "calibration_diff: [\"a.json\", \"b.json\"]\n"} {
+		if !strings.Contains(buf.String(), line) {
+			t.Errorf("YAML should contain %q, got:\n%s", line, buf.String())
+		}
+	}
+
+	if err := cfg.WriteConfig(&buf, "xml"); err == nil {
+		t.Error("WriteConfig() should reject an unknown format")
+	}
+}
+
+// TestWriteConfigRoundTrip dumps a configuration setting every key of
+// configuration files, loads the lines of those keys back with
+// LoadConfigFile and compares the result.
+func TestWriteConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+	cfg := AppConfig{
+		N: 1000, Algo: "matrix", Timeout: 90 * time.Second,
+		Threshold: 4096, FFTThreshold: 500000, StrassenThreshold: 256,
+		OutputFile: `out "1".txt`, CalibrationProfile: "profile.json", MemoryLimit: "8G",
+		Verbose: true, Details: true, Quiet: true, Calibrate: true, AutoCalibrate: true,
+		ShowValue: true, TUI: true,
+	}
+	var buf bytes.Buffer
+	if err := cfg.WriteConfig(&buf, PrintConfigYAML); err != nil {
+		t.Fatalf("WriteConfig() error = %v", err)
+	}
+
+	var file strings.Builder
+	keys := map[string]bool{}
+	for _, line := range strings.SplitAfter(buf.String(), "\n") {
+		key, _, _ := strings.Cut(line, ":")
+		if findOverride(key) != nil {
+			keys[key] = true
+			file.WriteString(line)
+		}
+	}
+	for _, o := range envOverrides {
+		if key := strings.ToLower(o.envKey); !keys[key] {
+			t.Errorf("the dump has no %q key, read by configuration files", key)
+		}
+	}
+
+	loaded, err := LoadConfigFile(writeConfigFile(t, "dump.yaml", file.String()))
+	if err != nil {
+		t.Fatalf("LoadConfigFile() on the dump error = %v\n%s", err, file.String())
+	}
+	if !reflect.DeepEqual(loaded, cfg) {
+		t.Errorf("round trip = %+v, want %+v", loaded, cfg)
+	}
+}
+
+func TestParseConfigPrintConfig(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast"}
+	for args, want := range map[string]string{
+		"--print-config":      PrintConfigJSON,
+		"--print-config=YAML": PrintConfigYAML,
+		"-n=10":               "",
+	} {
+		cfg, err := ParseConfig("fibcalc", []string{args}, &bytes.Buffer{}, algos)
+		if err != nil {
+			t.Fatalf("ParseConfig(%s) failed: %v", args, err)
+		}
+		if cfg.PrintConfig != want {
+			t.Errorf("ParseConfig(%s): PrintConfig = %q, want %q", args, cfg.PrintConfig, want)
+		}
+	}
+	if _, err := ParseConfig("fibcalc", []string{"--print-config=xml"}, &bytes.Buffer{}, algos); err == nil {
+		t.Error("ParseConfig should reject an unknown --print-config format")
+	}
+}
+
+func TestSnakeCase(t *testing.T) {
+	t.Parallel()
+	for name, want := range map[string]string{
+		"N":               "n",
+		"FFTThreshold":    "fft_threshold",
+		"TUIExportOnExit": "tui_export_on_exit",
+		"EmitSVG":         "emit_svg",
+		"JSON":            "json",
+		"MinN":            "min_n",
+	} {
+		if got := snakeCase(name); got != want {
+			t.Errorf("snakeCase(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ package config
 
 import (
 	"flag"
+	"fmt"
 	"os"
 	"strconv"
 	"strings"
@@ -99,99 +100,115 @@ func isFlagSetAny(fs *flag.FlagSet, names ...string) bool {
 
 // envOverride declares a single environment variable override.
 // Each entry maps an env key (without the FIBCALC_ prefix) to the CLI flag
-// name(s) it corresponds to and a function that applies the env value.
+// name(s) it corresponds to and a function that applies the env value. The
+// same table maps the keys of configuration files (see LoadConfigFile).
 type envOverride struct {
 	envKey   string
 	flags    []string
-	apply    func(*AppConfig, string)
+	// apply sets the field from v, or returns an error and leaves the
+	// configuration unchanged if v is invalid.
+	apply    func(*AppConfig, string) error
 }
 
 // envOverrides is the declarative table of all environment variable overrides.
 // Order matches the original procedural grouping (numeric, duration, string, bool).
 var envOverrides = []envOverride{
 	// Numeric overrides
-	{"N", []string{"n"}, func(c *AppConfig, v string) {
-		if parsed, err := strconv.ParseUint(v, 10, 64); err == nil {
+	{"N", []string{"n"}, func(c *AppConfig, v string) error {
+		parsed, err := strconv.ParseUint(v, 10, 64)
+		if err == nil {
 			c.N = parsed
 		}
+		return err
 	}},
-	{"THRESHOLD", []string{"threshold"}, func(c *AppConfig, v string) {
-		if parsed, err := strconv.Atoi(v); err == nil {
-			c.Threshold = parsed
-		}
+	{"THRESHOLD", []string{"threshold"}, func(c *AppConfig, v string) error {
+		return setInt(&c.Threshold, v)
 	}},
-	{"FFT_THRESHOLD", []string{"fft-threshold"}, func(c *AppConfig, v string) {
-		if parsed, err := strconv.Atoi(v); err == nil {
-			c.FFTThreshold = parsed
-		}
+	{"FFT_THRESHOLD", []string{"fft-threshold"}, func(c *AppConfig, v string) error {
+		return setInt(&c.FFTThreshold, v)
 	}},
-	{"STRASSEN_THRESHOLD", []string{"strassen-threshold"}, func(c *AppConfig, v string) {
-		if parsed, err := strconv.Atoi(v); err == nil {
-			c.StrassenThreshold = parsed
-		}
+	{"STRASSEN_THRESHOLD", []string{"strassen-threshold"}, func(c *AppConfig, v string) error {
+		return setInt(&c.StrassenThreshold, v)
 	}},
 
 	// Duration overrides
-	{"TIMEOUT", []string{"timeout"}, func(c *AppConfig, v string) {
-		if parsed, err := time.ParseDuration(v); err == nil {
+	{"TIMEOUT", []string{"timeout"}, func(c *AppConfig, v string) error {
+		parsed, err := time.ParseDuration(v)
+		if err == nil {
 			c.Timeout = parsed
 		}
+		return err
 	}},
 
 	// String overrides
-	{"ALGO", []string{"algo"}, func(c *AppConfig, v string) {
+	{"ALGO", []string{"algo"}, func(c *AppConfig, v string) error {
 		c.Algo = v
+		return nil
 	}},
-	{"OUTPUT", []string{"output", "o"}, func(c *AppConfig, v string) {
+	{"OUTPUT", []string{"output", "o"}, func(c *AppConfig, v string) error {
 		c.OutputFile = v
+		return nil
 	}},
-	{"CALIBRATION_PROFILE", []string{"calibration-profile"}, func(c *AppConfig, v string) {
+	{"CALIBRATION_PROFILE", []string{"calibration-profile"}, func(c *AppConfig, v string) error {
 		c.CalibrationProfile = v
+		return nil
 	}},
-	{"MEMORY_LIMIT", []string{"memory-limit"}, func(c *AppConfig, v string) {
+	{"MEMORY_LIMIT", []string{"memory-limit"}, func(c *AppConfig, v string) error {
 		c.MemoryLimit = v
+		return nil
 	}},
 
 	// Boolean overrides
-	{"VERBOSE", []string{"v", "verbose"}, func(c *AppConfig, v string) {
-		c.Verbose = parseBoolEnv(v, c.Verbose)
+	{"VERBOSE", []string{"v", "verbose"}, func(c *AppConfig, v string) error {
+		return setBool(&c.Verbose, v)
 	}},
-	{"DETAILS", []string{"d", "details"}, func(c *AppConfig, v string) {
-		c.Details = parseBoolEnv(v, c.Details)
+	{"DETAILS", []string{"d", "details"}, func(c *AppConfig, v string) error {
+		return setBool(&c.Details, v)
 	}},
-	{"QUIET", []string{"quiet", "q"}, func(c *AppConfig, v string) {
-		c.Quiet = parseBoolEnv(v, c.Quiet)
+	{"QUIET", []string{"quiet", "q"}, func(c *AppConfig, v string) error {
+		return setBool(&c.Quiet, v)
 	}},
-	{"CALIBRATE", []string{"calibrate"}, func(c *AppConfig, v string) {
-		c.Calibrate = parseBoolEnv(v, c.Calibrate)
+	{"CALIBRATE", []string{"calibrate"}, func(c *AppConfig, v string) error {
+		return setBool(&c.Calibrate, v)
 	}},
-	{"AUTO_CALIBRATE", []string{"auto-calibrate"}, func(c *AppConfig, v string) {
-		c.AutoCalibrate = parseBoolEnv(v, c.AutoCalibrate)
+	{"AUTO_CALIBRATE", []string{"auto-calibrate"}, func(c *AppConfig, v string) error {
+		return setBool(&c.AutoCalibrate, v)
 	}},
-	{"CALCULATE", []string{"calculate", "c"}, func(c *AppConfig, v string) {
-		c.ShowValue = parseBoolEnv(v, c.ShowValue)
+	{"CALCULATE", []string{"calculate", "c"}, func(c *AppConfig, v string) error {
+		return setBool(&c.ShowValue, v)
 	}},
-	{"TUI", []string{"tui"}, func(c *AppConfig, v string) {
-		c.TUI = parseBoolEnv(v, c.TUI)
+	{"TUI", []string{"tui"}, func(c *AppConfig, v string) error {
+		return setBool(&c.TUI, v)
 	}},
 }
 
-// parseBoolEnv parses a boolean environment variable value.
+// setInt parses v into *dst, leaving it unchanged if v is invalid.
+func setInt(dst *int, v string) error {
+	parsed, err := strconv.Atoi(v)
+	if err == nil {
+		*dst = parsed
+	}
+	return err
+}
+
+// setBool parses v into *dst, leaving it unchanged if v is not recognized.
 // Accepts "true", "1", "yes" as true; "false", "0", "no" as false (case-insensitive).
-// Returns defaultVal if the value is not recognized.
-func parseBoolEnv(val string, defaultVal bool) bool {
-	switch strings.ToLower(val) {
+func setBool(dst *bool, v string) error {
+	switch strings.ToLower(v) {
 	case "true", "1", "yes":
-		return true
+		*dst = true
 	case "false", "0", "no":
-		return false
+		*dst = false
+	default:
+		return fmt.Errorf("invalid boolean %q (true, false, 1, 0, yes, no)", v)
 	}
-	return defaultVal
+	return nil
 }
 
 // applyEnvOverrides applies environment variable values to the configuration
 // for any flags that were not explicitly set on the command line.
 // This implements the priority: CLI flags > Environment variables > Defaults.
+// Invalid values are ignored.
 //
 // Supported environment variables (all prefixed with FIBCALC_):
 //   - N, ALGO, TIMEOUT, THRESHOLD, FFT_THRESHOLD, STRASSEN_THRESHOLD,
@@ -203,7 +220,7 @@ func applyEnvOverrides(config *AppConfig, fs *flag.FlagSet) {
 			continue
 		}
 		if val := os.Getenv(EnvPrefix + o.envKey); val != "" {
-			o.apply(config, val)
+			_ = o.apply(config, val)
 		}
 	}
 }
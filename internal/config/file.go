@@ -0,0 +1,383 @@
+// This file implements configuration files (--config), a persistent
+// alternative to command-line flags and environment variables.
+
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// configValue is one setting read from a configuration file.
+type configValue struct {
+	override *envOverride
+	value    string
+}
+
+// configFile holds the settings of a configuration file: those at the top
+// level and those of its presets, by preset name.
+type configFile struct {
+	values  []configValue
+	presets map[string][]configValue
+}
+
+// LoadConfigFile reads a configuration file. The format is detected by
+// extension: TOML for ".toml", YAML for ".yaml" and ".yml". The keys are the
+// environment variable names in lower case, without the FIBCALC_ prefix
+// (n, algo, timeout, threshold, fft_threshold, strassen_threshold, verbose,
+// details, quiet, calibrate, auto_calibrate, calculate, output,
+// calibration_profile, memory_limit, tui), all at the top level.
+//
+// Only flat documents of scalar values are supported: TOML tables and
+// arrays, and YAML nested mappings and sequences are rejected, except for
+// the presets selected with --preset ([presets.<name>] tables in TOML, a
+// presets mapping of mappings in YAML), which are not applied here.
+//
+// Example (fibcalc.toml):
+//
+//	n = 1000000
+//	algo = "fast"
+//	timeout = "30s"
+//
+// Parameters:
+//   - path: The path of the configuration file.
+//
+// Returns:
+//   - AppConfig: A configuration holding the file's settings; other fields
+//     are zero.
+//   - error: An error wrapping an apperrors.ConfigError if the file cannot be
+//     read, has an unsupported extension or holds an invalid setting.
+func LoadConfigFile(path string) (AppConfig, error) {
+	file, err := readConfigFile(path)
+	if err != nil {
+		return AppConfig{}, err
+	}
+	var config AppConfig
+	applyConfigFile(&config, nil, file.values)
+	return config, nil
+}
+
+// readConfigFile reads and validates the settings of a configuration file.
+func readConfigFile(path string) (*configFile, error) {
+	var parseLine func(line string) (key, value string, err error)
+	var sections sectionReader
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".toml":
+		parseLine, sections = parseTOMLLine, &tomlSections{}
+	case ".yaml", ".yml":
+		parseLine, sections = parseYAMLLine, &yamlSections{}
+	default:
+		return nil, fmt.Errorf("invalid config file %s: %w", path,
+			apperrors.NewConfigError("unsupported extension %q (use .toml, .yaml or .yml)", filepath.Ext(path)))
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, apperrors.NewConfigError("%v", err))
+	}
+
+	file := &configFile{presets: map[string][]configValue{}}
+	seen := map[string]map[string]bool{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		preset, line, isSetting, err := sections.next(scanner.Text())
+		var key, value string
+		if err == nil && isSetting {
+			key, value, err = parseLine(line)
+		}
+		if err == nil && key != "" {
+			if seen[preset] == nil {
+				seen[preset] = map[string]bool{}
+			}
+			err = checkConfigValue(key, value, seen[preset])
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid config file %s: %w", path, apperrors.NewConfigError("line %d: %v", lineNo, err))
+		}
+		switch {
+		case preset == "" && key != "":
+			file.values = append(file.values, configValue{override: findOverride(key), value: value})
+		case preset != "":
+			// Registers presets without settings too.
+			values := file.presets[preset]
+			if key != "" {
+				values = append(values, configValue{override: findOverride(key), value: value})
+			}
+			file.presets[preset] = values
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, apperrors.NewConfigError("%v", err))
+	}
+	return file, nil
+}
+
+// checkConfigValue rejects unknown and repeated keys and invalid values.
+func checkConfigValue(key, value string, seen map[string]bool) error {
+	o := findOverride(key)
+	if o == nil {
+		return fmt.Errorf("unknown key %q", key)
+	}
+	if seen[key] {
+		return fmt.Errorf("duplicate key %q", key)
+	}
+	seen[key] = true
+	var scratch AppConfig
+	if err := o.apply(&scratch, value); err != nil {
+		return fmt.Errorf("invalid value for %q: %v", key, err)
+	}
+	return nil
+}
+
+// findOverride returns the envOverrides entry of a configuration file key.
+func findOverride(key string) *envOverride {
+	for i := range envOverrides {
+		if strings.ToLower(envOverrides[i].envKey) == key {
+			return &envOverrides[i]
+		}
+	}
+	return nil
+}
+
+// applyConfigFile applies the settings of a configuration file for any flags
+// that were not explicitly set on the command line (all of them if fs is
+// nil). It runs after the preset and before the job spec and the environment
+// overrides, giving the priority: CLI flags > Environment variables > Job
+// spec > Config file > Preset > Defaults.
+func applyConfigFile(config *AppConfig, fs *flag.FlagSet, values []configValue) {
+	for _, v := range values {
+		if fs != nil && isFlagSetAny(fs, v.override.flags...) {
+			continue
+		}
+		_ = v.override.apply(config, v.value) // validated by readConfigFile
+	}
+}
+
+// sectionReader follows the presets section of a configuration file.
+type sectionReader interface {
+	// next returns the preset a line belongs to ("" at the top level) and,
+	// for setting, blank and comment lines, the line for the format's line
+	// parser. Section header lines return isSetting false.
+	next(line string) (preset, setting string, isSetting bool, err error)
+}
+
+// tomlSections accepts [presets.<name>] tables; the keys that follow a table
+// header belong to that preset.
+type tomlSections struct {
+	preset string
+}
+
+func (s *tomlSections) next(line string) (string, string, bool, error) {
+	header := stripComment(strings.TrimSpace(line))
+	if !strings.HasPrefix(header, "[") {
+		return s.preset, line, true, nil
+	}
+	name, ok := strings.CutPrefix(header, "[presets.")
+	name, closed := strings.CutSuffix(name, "]")
+	name = strings.Trim(name, `"`)
+	if !ok || !closed || name == "" {
+		return "", "", false, fmt.Errorf("tables other than [presets.<name>] are not supported; keys must be at the top level")
+	}
+	s.preset = name
+	return name, "", false, nil
+}
+
+// yamlSections accepts a top-level presets mapping whose entries map preset
+// names to settings:
+//
+//	presets:
+//	  batch:
+//	    timeout: 1h
+type yamlSections struct {
+	inPresets bool
+	// indent is the indentation of the preset names, set by the first one.
+	indent int
+	preset string
+}
+
+func (s *yamlSections) next(line string) (string, string, bool, error) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+		return s.preset, line, true, nil
+	}
+	indent := len(line) - len(strings.TrimLeft(line, " \t"))
+	if indent == 0 {
+		*s = yamlSections{}
+		if key, rest, _ := strings.Cut(trimmed, ":"); key == "presets" && stripComment(rest) == "" {
+			s.inPresets = true
+			return "", "", false, nil
+		}
+		return "", line, true, nil
+	}
+	if !s.inPresets {
+		return "", line, true, nil // rejected as a nested value
+	}
+	if s.indent == 0 {
+		s.indent = indent
+	}
+	switch {
+	case indent == s.indent:
+		name, rest, found := strings.Cut(trimmed, ":")
+		name = strings.Trim(strings.TrimSpace(name), `"'`)
+		if !found || name == "" || stripComment(rest) != "" {
+			return "", "", false, fmt.Errorf("expected a preset name followed by its settings")
+		}
+		s.preset = name
+		return name, "", false, nil
+	case indent > s.indent && s.preset != "":
+		return s.preset, trimmed, true, nil
+	default:
+		return "", "", false, fmt.Errorf("inconsistent indentation in presets")
+	}
+}
+
+// parseTOMLLine parses a `key = value` line of a flat TOML document; table
+// headers are handled by tomlSections. It returns an empty key for blank and
+// comment lines. Strings must be quoted, and single-quoted literal strings
+// have no escapes; bare values are booleans and integers, with optional
+// underscores between digits (1_000_000).
+func parseTOMLLine(line string) (key, value string, err error) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", "", nil
+	}
+	key, value, found := strings.Cut(line, "=")
+	if !found {
+		return "", "", fmt.Errorf("expected key = value")
+	}
+	key = strings.Trim(strings.TrimSpace(key), `"`)
+	value = strings.TrimSpace(value)
+	switch {
+	case strings.HasPrefix(value, `"`), strings.HasPrefix(value, "'"):
+		value, err = parseQuoted(value, false)
+	case strings.HasPrefix(value, "["), strings.HasPrefix(value, "{"):
+		err = fmt.Errorf("arrays and inline tables are not supported")
+	default:
+		value = stripComment(value)
+		if value == "" {
+			return "", "", fmt.Errorf("missing value for %q", key)
+		}
+		if value == "true" || value == "false" {
+			break
+		}
+		digits, ok := tomlInteger(value)
+		if !ok {
+			err = fmt.Errorf("string values must be quoted: %s", value)
+		}
+		value = digits
+	}
+	return key, value, err
+}
+
+// tomlInteger validates a bare TOML integer and returns it without its digit
+// separators and plus sign: an underscore must sit between two digits.
+func tomlInteger(value string) (string, bool) {
+	digits := strings.TrimLeft(value, "+-")
+	if len(value)-len(digits) > 1 || digits == "" {
+		return "", false
+	}
+	for i := 0; i < len(digits); i++ {
+		if digits[i] == '_' {
+			if i == 0 || i == len(digits)-1 || !isDigit(digits[i-1]) || !isDigit(digits[i+1]) {
+				return "", false
+			}
+		} else if !isDigit(digits[i]) {
+			return "", false
+		}
+	}
+	value = strings.ReplaceAll(strings.TrimPrefix(value, "+"), "_", "")
+	if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+		return "", false
+	}
+	return value, true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// parseYAMLLine parses a `key: value` line of a flat YAML mapping. It
+// returns an empty key for blank, comment and document marker lines.
+func parseYAMLLine(line string) (key, value string, err error) {
+	trimmed := strings.TrimSpace(line)
+	if trimmed == "" || strings.HasPrefix(trimmed, "#") || trimmed == "---" || trimmed == "..." {
+		return "", "", nil
+	}
+	if line[0] == ' ' || line[0] == '\t' || strings.HasPrefix(trimmed, "- ") {
+		return "", "", fmt.Errorf("nested values and sequences are not supported; keys must be at the top level")
+	}
+	key, value, found := strings.Cut(trimmed, ":")
+	if !found {
+		return "", "", fmt.Errorf("expected key: value")
+	}
+	key = strings.Trim(strings.TrimSpace(key), `"'`)
+	value = strings.TrimSpace(value)
+	switch {
+	case value == "" || strings.HasPrefix(value, "#"):
+		err = fmt.Errorf("missing value for %q (nested values are not supported)", key)
+	case strings.HasPrefix(value, `"`), strings.HasPrefix(value, "'"):
+		value, err = parseQuoted(value, true)
+	case strings.HasPrefix(value, "["), strings.HasPrefix(value, "{"):
+		err = fmt.Errorf("flow sequences and mappings are not supported")
+	default:
+		value = stripComment(value)
+	}
+	return key, value, err
+}
+
+// parseQuoted decodes a quoted string value followed by an optional
+// comment. Double-quoted strings use Go escapes, which cover those of TOML
+// and YAML for the values of the configuration; single-quoted strings are
+// literal. With doubledQuote (YAML), a doubled single quote stands for a
+// quote; TOML literal strings cannot contain one.
+func parseQuoted(value string, doubledQuote bool) (string, error) {
+	quote := value[0]
+	end := 1
+	for end < len(value) {
+		if value[end] == '\\' && quote == '"' {
+			end += 2
+			continue
+		}
+		if value[end] == quote {
+			if quote == '\'' && doubledQuote && end+1 < len(value) && value[end+1] == '\'' {
+				end += 2
+				continue
+			}
+			break
+		}
+		end++
+	}
+	if end >= len(value) {
+		return "", fmt.Errorf("unterminated string %s", value)
+	}
+	if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+		return "", fmt.Errorf("unexpected text after the string: %s", rest)
+	}
+	if quote == '\'' && doubledQuote {
+		return strings.ReplaceAll(value[1:end], "''", "'"), nil
+	}
+	if quote == '\'' {
+		return value[1:end], nil
+	}
+	unquoted, err := strconv.Unquote(value[:end+1])
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", value[:end+1])
+	}
+	return unquoted, nil
+}
+
+// stripComment removes a trailing " # comment" from a bare value.
+func stripComment(value string) string {
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = value[:i]
+	}
+	return strings.TrimSpace(value)
+}
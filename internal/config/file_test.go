@@ -0,0 +1,165 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadConfigFileYAML(t *testing.T) {
+	t.Parallel()
+	path := writeConfigFile(t, "fibcalc.yaml", `---
+# Benchmark setup
+n: 1000000
+algo: fast   # the default is all
+timeout: "90s"
+fft_threshold: 500000
+details: yes
+output: 'f1m.txt'
+`)
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if cfg.N != 1000000 || cfg.Algo != "fast" || cfg.Timeout != 90*time.Second || cfg.FFTThreshold != 500000 || !cfg.Details || cfg.OutputFile != "f1m.txt" {
+		t.Errorf("YAML settings not applied: %+v", cfg)
+	}
+}
+
+func TestLoadConfigFileTOML(t *testing.T) {
+	t.Parallel()
+	path := writeConfigFile(t, "fibcalc.toml", `# Benchmark setup
+n = 1_000_000
+algo = "matrix" # the default is all
+timeout = "2m"
+strassen_threshold = 256
+quiet = true
+memory_limit = '8G'
+`)
+	cfg, err := LoadConfigFile(path)
+	if err != nil {
+		t.Fatalf("LoadConfigFile() error = %v", err)
+	}
+	if cfg.N != 1000000 || cfg.Algo != "matrix" || cfg.Timeout != 2*time.Minute || cfg.StrassenThreshold != 256 || !cfg.Quiet || cfg.MemoryLimit != "8G" {
+		t.Errorf("TOML settings not applied: %+v", cfg)
+	}
+}
+
+// TestLoadConfigFileStrings checks the TOML integer and string rules, which
+// differ from YAML for single-quoted strings.
+func TestLoadConfigFileStrings(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, file, content string
+		wantN               uint64
+		wantOutput          string
+	}{
+		{"TOML integer with underscores", "fibcalc.toml", "n = 1_000_000\n", 1_000_000, ""},
+		{"TOML signed integer", "fibcalc.toml", "n = +1_000\n", 1000, ""},
+		{"TOML literal string", "fibcalc.toml", `output = 'C:\out\f.txt'` + "\n", 0, `C:\out\f.txt`},
+		{"TOML basic string", "fibcalc.toml", `output = "it's \"f\".txt"` + "\n", 0, `it's "f".txt`},
+		{"YAML doubled quote", "fibcalc.yaml", "output: 'it''s.txt'\n", 0, "it's.txt"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			cfg, err := LoadConfigFile(writeConfigFile(t, tt.file, tt.content))
+			if err != nil {
+				t.Fatalf("LoadConfigFile() error = %v", err)
+			}
+			if cfg.N != tt.wantN || cfg.OutputFile != tt.wantOutput {
+				t.Errorf("N = %d, OutputFile = %q, want %d, %q", cfg.N, cfg.OutputFile, tt.wantN, tt.wantOutput)
+			}
+		})
+	}
+}
+
+func TestLoadConfigFileInvalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, file, content string
+	}{
+		{"unsupported extension", "fibcalc.ini", "n = 10\n"},
+		{"unknown key", "fibcalc.yaml", "algorithm: fast\n"},
+		{"duplicate key", "fibcalc.yaml", "n: 1\nn: 2\n"},
+		{"invalid value", "fibcalc.yaml", "timeout: soon\n"},
+		{"nested YAML", "fibcalc.yaml", "tui:\n  theme: dark\n"},
+		{"YAML sequence", "fibcalc.yaml", "algo: [fast, matrix]\n"},
+		{"TOML table", "fibcalc.toml", "[calculation]\nn = 10\n"},
+		{"unquoted TOML string", "fibcalc.toml", "algo = fast\n"},
+		{"unterminated string", "fibcalc.toml", "algo = \"fast\n"},
+		{"missing separator", "fibcalc.toml", "n 10\n"},
+		{"unknown preset key", "fibcalc.yaml", "presets:\n  batch:\n    algorithm: fast\n"},
+		{"preset without name", "fibcalc.yaml", "presets:\n  timeout: 1h\n"},
+		{"duplicate preset key", "fibcalc.toml", "[presets.batch]\nn = 1\nn = 2\n"},
+		{"TOML preset without name", "fibcalc.toml", "[presets.]\nn = 1\n"},
+		{"doubled TOML underscore", "fibcalc.toml", "n = 1__000\n"},
+		{"leading TOML underscore", "fibcalc.toml", "n = _1000\n"},
+		{"trailing TOML underscore", "fibcalc.toml", "n = 1000_\n"},
+		{"quote in TOML literal string", "fibcalc.toml", "output = 'it''s.txt'\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := LoadConfigFile(writeConfigFile(t, tt.file, tt.content))
+			var cfgErr apperrors.ConfigError
+			if !errors.As(err, &cfgErr) {
+				t.Errorf("LoadConfigFile() error = %v, want a wrapped ConfigError", err)
+			}
+		})
+	}
+
+	var cfgErr apperrors.ConfigError
+	if _, err := LoadConfigFile(filepath.Join(t.TempDir(), "missing.toml")); !errors.As(err, &cfgErr) {
+		t.Errorf("LoadConfigFile() on a missing file: error = %v, want a wrapped ConfigError", err)
+	}
+}
+
+// TestParseConfigFilePrecedence checks flags > env > file > defaults. It
+// sets environment variables, so it cannot run in parallel.
+func TestParseConfigFilePrecedence(t *testing.T) {
+	algos := []string{"fast", "matrix"}
+	path := writeConfigFile(t, "fibcalc.yaml", "n: 1234\nalgo: matrix\nthreshold: 2048\n")
+	t.Setenv(EnvPrefix+"THRESHOLD", "4096")
+
+	cfg, err := ParseConfig("fibcalc", []string{"--config", path}, &bytes.Buffer{}, algos)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.N != 1234 || cfg.Algo != "matrix" {
+		t.Errorf("file settings not applied: N=%d, Algo=%q", cfg.N, cfg.Algo)
+	}
+	if cfg.Threshold != 4096 {
+		t.Errorf("Threshold = %d, the environment should override the file", cfg.Threshold)
+	}
+	if cfg.Timeout != DefaultTimeout {
+		t.Errorf("Timeout = %v, want the default for a key absent from the file", cfg.Timeout)
+	}
+
+	cfg, err = ParseConfig("fibcalc", []string{"--config", path, "-n", "55", "--threshold", "8192"}, &bytes.Buffer{}, algos)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.N != 55 || cfg.Threshold != 8192 || cfg.Algo != "matrix" {
+		t.Errorf("flags should override the environment and the file: %+v", cfg)
+	}
+
+	invalid := writeConfigFile(t, "invalid.toml", "n = ten\n")
+	if _, err := ParseConfig("fibcalc", []string{"--config", invalid}, &bytes.Buffer{}, algos); err == nil {
+		t.Error("ParseConfig should reject an invalid configuration file")
+	}
+}
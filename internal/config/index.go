@@ -0,0 +1,57 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// indexValue is the flag.Value behind -n. It accepts the full uint64 range
+// as well as negative indices down to math.MinInt64; a negative index is
+// stored as its magnitude in AppConfig.N with AppConfig.NegativeN set.
+type indexValue struct {
+	config *AppConfig
+}
+
+// String returns the index in its signed decimal form.
+func (v indexValue) String() string {
+	if v.config == nil {
+		return "0"
+	}
+	if v.config.NegativeN {
+		return "-" + strconv.FormatUint(v.config.N, 10)
+	}
+	return strconv.FormatUint(v.config.N, 10)
+}
+
+// Set parses a signed decimal index.
+func (v indexValue) Set(s string) error {
+	if strings.HasPrefix(s, "-") {
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid index %q: %w", s, err)
+		}
+		v.config.N = uint64(-(n + 1)) + 1
+		v.config.NegativeN = n < 0
+		return nil
+	}
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid index %q: %w", s, err)
+	}
+	v.config.N = n
+	v.config.NegativeN = false
+	return nil
+}
+
+// SignedN returns the requested index as a signed integer. It is only
+// meaningful when NegativeN is set or N fits in an int64.
+//
+// Returns:
+//   - int64: The signed index.
+func (c AppConfig) SignedN() int64 {
+	if c.NegativeN {
+		return -int64(c.N-1) - 1
+	}
+	return int64(c.N)
+}
@@ -0,0 +1,139 @@
+// This file implements JSON job specs (--job), a structured alternative to
+// command-line flags for programmatic invocation.
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// Job spec output formats.
+const (
+	JobFormatText    = "text"
+	JobFormatQuiet   = "quiet"
+	JobFormatVerbose = "verbose"
+	JobFormatDetails = "details"
+)
+
+// JobSpec is the content of a --job file. Absent fields keep their default.
+//
+// Example:
+//
+//	{"n": 1000000, "algo": "fast", "format": "quiet", "timeout": "30s", "output": "f1m.txt"}
+type JobSpec struct {
+	// N is the index of the Fibonacci number to calculate.
+	N *uint64 `json:"n"`
+	// Algo is the algorithm name, as for --algo.
+	Algo string `json:"algo"`
+	// Format selects the output style: "text" (default), "quiet", "verbose"
+	// (full value) or "details".
+	Format string `json:"format"`
+	// Timeout is a Go duration string, as for --timeout (e.g. "90s").
+	Timeout string `json:"timeout"`
+	// Output is the result file path, as for --output.
+	Output string `json:"output"`
+}
+
+// LoadJobSpec reads and strictly decodes a job spec file.
+//
+// Parameters:
+//   - path: The path of the JSON job spec.
+//
+// Returns:
+//   - JobSpec: The decoded spec.
+//   - error: An apperrors.ValidationError naming the offending field for
+//     unknown fields, type mismatches and invalid values, or an I/O error.
+func LoadJobSpec(path string) (JobSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return JobSpec{}, fmt.Errorf("failed to read job spec: %w", err)
+	}
+	return parseJobSpec(data)
+}
+
+// parseJobSpec decodes a job spec, rejecting unknown fields and trailing data.
+func parseJobSpec(data []byte) (JobSpec, error) {
+	var job JobSpec
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&job); err != nil {
+		return JobSpec{}, jobDecodeError(err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return JobSpec{}, apperrors.ValidationError{Field: "job", Message: "unexpected data after the JSON object"}
+	}
+
+	if job.Timeout != "" {
+		if d, err := time.ParseDuration(job.Timeout); err != nil || d <= 0 {
+			return JobSpec{}, apperrors.ValidationError{Field: "timeout", Message: fmt.Sprintf("invalid duration %q (e.g. \"30s\", \"5m\")", job.Timeout)}
+		}
+	}
+	switch job.Format {
+	case "", JobFormatText, JobFormatQuiet, JobFormatVerbose, JobFormatDetails:
+	default:
+		return JobSpec{}, apperrors.ValidationError{Field: "format", Message: fmt.Sprintf("unrecognized format %q. Valid formats are: %s, %s, %s, %s",
+			job.Format, JobFormatText, JobFormatQuiet, JobFormatVerbose, JobFormatDetails)}
+	}
+	return job, nil
+}
+
+// jobDecodeError converts a JSON decoding error into a ValidationError.
+func jobDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	var syntaxErr *json.SyntaxError
+	switch {
+	case errors.As(err, &typeErr):
+		return apperrors.ValidationError{Field: typeErr.Field, Message: fmt.Sprintf("expected %s, got JSON %s", typeErr.Type, typeErr.Value)}
+	case errors.As(err, &syntaxErr):
+		return apperrors.ValidationError{Field: "job", Message: fmt.Sprintf("malformed JSON at offset %d: %v", syntaxErr.Offset, err)}
+	case strings.HasPrefix(err.Error(), "json: unknown field "):
+		field := strings.Trim(strings.TrimPrefix(err.Error(), "json: unknown field "), `"`)
+		return apperrors.ValidationError{Field: field, Message: "unknown field (valid fields: n, algo, format, timeout, output)"}
+	default:
+		return apperrors.ValidationError{Field: "job", Message: err.Error()}
+	}
+}
+
+// applyJobSpec applies the job spec to the configuration for any flags that
+// were not explicitly set on the command line. It runs before the environment
+// overrides, giving the priority: CLI flags > Environment variables > Job
+// spec > Defaults.
+func applyJobSpec(config *AppConfig, fs *flag.FlagSet, job JobSpec) {
+	if job.N != nil && !isFlagSet(fs, "n") {
+		config.N = *job.N
+		config.NegativeN = false
+	}
+	if job.Algo != "" && !isFlagSet(fs, "algo") {
+		config.Algo = job.Algo
+	}
+	if job.Timeout != "" && !isFlagSet(fs, "timeout") {
+		config.Timeout, _ = time.ParseDuration(job.Timeout) // validated by parseJobSpec
+	}
+	if job.Output != "" && !isFlagSetAny(fs, "output", "o") {
+		config.OutputFile = job.Output
+	}
+	switch job.Format {
+	case JobFormatQuiet:
+		if !isFlagSetAny(fs, "quiet", "q") {
+			config.Quiet = true
+		}
+	case JobFormatVerbose:
+		if !isFlagSetAny(fs, "v", "verbose") {
+			config.Verbose = true
+		}
+	case JobFormatDetails:
+		if !isFlagSetAny(fs, "d", "details") {
+			config.Details = true
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+func writeJobSpec(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "job.json")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestParseConfigJobSpec(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast", "matrix", "fft"}
+	path := writeJobSpec(t, `{"n": 1234, "algo": "Matrix", "format": "quiet", "timeout": "90s", "output": "out.txt"}`)
+
+	cfg, err := ParseConfig("fibcalc", []string{"--job", path}, &bytes.Buffer{}, algos)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.N != 1234 || cfg.Algo != "matrix" || !cfg.Quiet || cfg.Timeout != 90*time.Second || cfg.OutputFile != "out.txt" {
+		t.Errorf("job spec not applied: %+v", cfg)
+	}
+
+	// Command-line flags take precedence over the job spec.
+	cfg, err = ParseConfig("fibcalc", []string{"--job", path, "-n", "55", "--algo", "fast"}, &bytes.Buffer{}, algos)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.N != 55 || cfg.Algo != "fast" || cfg.OutputFile != "out.txt" {
+		t.Errorf("flags should override the job spec: %+v", cfg)
+	}
+}
+
+func TestParseConfigJobSpecInvalid(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name, content, field string
+	}{
+		{"unknown field", `{"n": 10, "algorithm": "fast"}`, "algorithm"},
+		{"type mismatch", `{"n": "ten"}`, "n"},
+		{"negative n", `{"n": -5}`, "n"},
+		{"bad timeout", `{"timeout": "soon"}`, "timeout"},
+		{"bad format", `{"format": "xml"}`, "format"},
+		{"malformed", `{"n": 10`, "job"},
+		{"trailing data", `{"n": 10} {}`, "job"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var errBuf bytes.Buffer
+			_, err := ParseConfig("fibcalc", []string{"--job", writeJobSpec(t, tt.content)}, &errBuf, []string{"fast"})
+			var validationErr apperrors.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a ValidationError, got %v", err)
+			}
+			if validationErr.Field != tt.field {
+				t.Errorf("Field = %q, want %q (%v)", validationErr.Field, tt.field, validationErr)
+			}
+			if !bytes.Contains(errBuf.Bytes(), []byte("Configuration error")) {
+				t.Errorf("expected the error to be reported, got %q", errBuf.String())
+			}
+		})
+	}
+}
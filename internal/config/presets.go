@@ -0,0 +1,76 @@
+// This file implements presets (--preset), named bundles of settings for
+// recurring setups.
+
+package config
+
+import (
+	"flag"
+	"maps"
+	"slices"
+	"strings"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// builtinPresets are the presets available without a configuration file,
+// as configuration file keys and values.
+var builtinPresets = map[string]map[string]string{
+	// interactive favors a quick answer over a comparison of algorithms.
+	"interactive": {
+		"algo":    "fast",
+		"timeout": "30s",
+	},
+	// throughput parallelizes smaller multiplications and allows long runs.
+	"throughput": {
+		"algo":      "fast",
+		"timeout":   "1h",
+		"threshold": "2048",
+	},
+	// low-memory bounds the estimated footprint and parallelizes only large
+	// multiplications, so fewer temporaries are alive at once.
+	"low-memory": {
+		"algo":         "fast",
+		"memory_limit": "2G",
+		"threshold":    "65536",
+	},
+}
+
+// PresetNames returns the names of the built-in presets, sorted.
+func PresetNames() []string {
+	return slices.Sorted(maps.Keys(builtinPresets))
+}
+
+// ApplyPreset applies the settings of a built-in preset to cfg.
+//
+// Parameters:
+//   - cfg: The configuration to update.
+//   - name: The preset name (see PresetNames).
+//
+// Returns:
+//   - error: A ConfigError if the preset is unknown.
+func ApplyPreset(cfg *AppConfig, name string) error {
+	return applyPreset(cfg, nil, name, nil)
+}
+
+// applyPreset applies a preset of the configuration file, or else a
+// built-in preset, for any flags that were not explicitly set on the
+// command line (all of them if fs is nil). It runs before the configuration
+// file, giving the priority: CLI flags > Environment variables > Job spec >
+// Config file > Preset > Defaults.
+func applyPreset(cfg *AppConfig, fs *flag.FlagSet, name string, filePresets map[string][]configValue) error {
+	values, ok := filePresets[name]
+	if !ok {
+		settings, builtin := builtinPresets[name]
+		if !builtin {
+			names := append(slices.Collect(maps.Keys(filePresets)), PresetNames()...)
+			slices.Sort(names)
+			names = slices.Compact(names)
+			return apperrors.NewConfigError("unknown preset '%s'. Valid presets are: %s", name, strings.Join(names, ", "))
+		}
+		for _, key := range slices.Sorted(maps.Keys(settings)) {
+			values = append(values, configValue{override: findOverride(key), value: settings[key]})
+		}
+	}
+	applyConfigFile(cfg, fs, values)
+	return nil
+}
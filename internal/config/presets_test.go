@@ -0,0 +1,127 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+func TestApplyPreset(t *testing.T) {
+	t.Parallel()
+	var cfg AppConfig
+	if err := ApplyPreset(&cfg, "throughput"); err != nil {
+		t.Fatalf("ApplyPreset() error = %v", err)
+	}
+	if cfg.Algo != "fast" || cfg.Timeout != time.Hour || cfg.Threshold != 2048 {
+		t.Errorf("throughput preset not applied: %+v", cfg)
+	}
+
+	cfg = AppConfig{}
+	if err := ApplyPreset(&cfg, "low-memory"); err != nil {
+		t.Fatalf("ApplyPreset() error = %v", err)
+	}
+	if cfg.MemoryLimit != "2G" || cfg.Threshold != 65536 {
+		t.Errorf("low-memory preset not applied: %+v", cfg)
+	}
+
+	var cfgErr apperrors.ConfigError
+	if err := ApplyPreset(&cfg, "turbo"); !errors.As(err, &cfgErr) {
+		t.Errorf("ApplyPreset() on an unknown preset: error = %v, want a ConfigError", err)
+	}
+}
+
+func TestBuiltinPresetsValid(t *testing.T) {
+	t.Parallel()
+	for _, name := range PresetNames() {
+		seen := map[string]bool{}
+		for key, value := range builtinPresets[name] {
+			if err := checkConfigValue(key, value, seen); err != nil {
+				t.Errorf("preset %s: %v", name, err)
+			}
+		}
+	}
+}
+
+func TestParseConfigPreset(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast", "matrix"}
+
+	cfg, err := ParseConfig("fibcalc", []string{"--preset", "throughput", "--timeout", "5s"}, &bytes.Buffer{}, algos)
+	if err != nil {
+		t.Fatalf("ParseConfig failed: %v", err)
+	}
+	if cfg.Timeout != 5*time.Second {
+		t.Errorf("Timeout = %v, the explicit flag should override the preset", cfg.Timeout)
+	}
+	if cfg.Threshold != 2048 || cfg.Algo != "fast" {
+		t.Errorf("preset settings not applied: Threshold=%d, Algo=%q", cfg.Threshold, cfg.Algo)
+	}
+
+	var errOut bytes.Buffer
+	if _, err := ParseConfig("fibcalc", []string{"--preset", "turbo"}, &errOut, algos); err == nil {
+		t.Error("ParseConfig should reject an unknown preset")
+	}
+	if !bytes.Contains(errOut.Bytes(), []byte("interactive, low-memory, throughput")) {
+		t.Errorf("the error should list the presets, got %q", errOut.String())
+	}
+}
+
+func TestParseConfigFilePresets(t *testing.T) {
+	t.Parallel()
+	algos := []string{"fast", "matrix"}
+	files := map[string]string{
+		"fibcalc.yaml": `n: 1000
+presets:
+  batch:
+    algo: matrix   # compare with fast
+    timeout: 2h
+    threshold: 1024
+  throughput:
+    timeout: 3h
+`,
+		"fibcalc.toml": `n = 1000
+
+[presets.batch]
+algo = "matrix" # compare with fast
+timeout = "2h"
+threshold = 1024
+
+[presets.throughput]
+timeout = "3h"
+`,
+	}
+	for name, content := range files {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := writeConfigFile(t, name, content)
+
+			cfg, err := ParseConfig("fibcalc", []string{"--config", path, "--preset", "batch", "--threshold", "512"}, &bytes.Buffer{}, algos)
+			if err != nil {
+				t.Fatalf("ParseConfig failed: %v", err)
+			}
+			if cfg.N != 1000 || cfg.Algo != "matrix" || cfg.Timeout != 2*time.Hour || cfg.Threshold != 512 {
+				t.Errorf("file preset not applied below the flags: %+v", cfg)
+			}
+
+			// A preset of the file replaces the built-in one of the same name.
+			cfg, err = ParseConfig("fibcalc", []string{"--config", path, "--preset", "throughput"}, &bytes.Buffer{}, algos)
+			if err != nil {
+				t.Fatalf("ParseConfig failed: %v", err)
+			}
+			if cfg.Timeout != 3*time.Hour || cfg.Threshold != 0 {
+				t.Errorf("file preset should replace the built-in one: %+v", cfg)
+			}
+
+			cfg, err = LoadConfigFile(path)
+			if err != nil {
+				t.Fatalf("LoadConfigFile() error = %v", err)
+			}
+			if cfg.N != 1000 || cfg.Algo != "" {
+				t.Errorf("LoadConfigFile() should not apply presets: %+v", cfg)
+			}
+		})
+	}
+}
@@ -8,6 +8,15 @@ import (
 	"github.com/agbru/fibcalc/internal/ui"
 )
 
+// hiddenFlags lists flags that are accepted but omitted from the usage output.
+// They are intended for testing and diagnostics rather than everyday use.
+var hiddenFlags = map[string]bool{
+	"round-trip":         true,
+	"no-pooling":         true,
+	"export-fuzz-corpus": true,
+	"profile-allocs":     true,
+}
+
 // setCustomUsage configures the flag set with a colored usage function.
 func setCustomUsage(fs *flag.FlagSet) {
 	fs.Usage = func() {
@@ -25,6 +34,9 @@ func setCustomUsage(fs *flag.FlagSet) {
 		fmt.Fprintf(out, "%sUsage:%s\n  %s [flags]\n\n%sFlags:%s\n", t.Warning, t.Reset, fs.Name(), t.Warning, t.Reset)
 
 		fs.VisitAll(func(f *flag.Flag) {
+			if hiddenFlags[f.Name] {
+				return
+			}
 			name, usage := flag.UnquoteUsage(f)
 			flagSig := fmt.Sprintf("-%s", f.Name)
 			if len(name) > 0 {
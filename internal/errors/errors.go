@@ -83,6 +83,18 @@ func (e TimeoutError) Error() string {
 	return fmt.Sprintf("operation %q timed out after %s", e.Operation, e.Limit)
 }
 
+// Is reports whether target is context.DeadlineExceeded, so that a
+// TimeoutError is handled like the deadline error it replaces.
+//
+// Parameters:
+//   - target: The error to compare against.
+//
+// Returns:
+//   - bool: true if target is context.DeadlineExceeded.
+func (e TimeoutError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
 // ValidationError represents an input validation failure. It identifies which
 // field failed validation and provides a human-readable explanation.
 type ValidationError struct {
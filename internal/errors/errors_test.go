@@ -142,6 +142,9 @@ func TestTimeoutError(t *testing.T) {
 				if timeoutErr.Limit != tt.err.Limit {
 					t.Errorf("expected Limit %v, got %v", tt.err.Limit, timeoutErr.Limit)
 				}
+				if !errors.Is(err, context.DeadlineExceeded) {
+					t.Error("expected TimeoutError to match context.DeadlineExceeded")
+				}
 			}
 		})
 	}
@@ -22,7 +22,8 @@ func (d DefaultColorProvider) Yellow() string { return "" }
 func (d DefaultColorProvider) Reset() string  { return "" }
 
 // HandleCalculationError formats and prints error messages related to failed calculations.
-// It distinguishes between different error types (timeout, cancellation, generic)
+// It distinguishes between different error types (timeout, cancellation,
+// memory budget, generic)
 // to provide the user with specific feedback.
 //
 // Parameters:
@@ -48,6 +49,11 @@ func HandleCalculationError(err error, duration time.Duration, out io.Writer, co
 		msgSuffix = fmt.Sprintf(" after %s%s%s", colors.Yellow(), duration, colors.Reset())
 	}
 
+	var timeoutErr TimeoutError
+	if errors.As(err, &timeoutErr) && timeoutErr.Operation != "" {
+		fmt.Fprintf(out, "Status: Failure (Timeout). The execution limit was reached during the %s phase%s.\n", timeoutErr.Operation, msgSuffix)
+		return ExitErrorTimeout
+	}
 	if errors.Is(err, context.DeadlineExceeded) {
 		fmt.Fprintf(out, "Status: Failure (Timeout). The execution limit was reached%s.\n", msgSuffix)
 		return ExitErrorTimeout
@@ -56,6 +62,11 @@ func HandleCalculationError(err error, duration time.Duration, out io.Writer, co
 		fmt.Fprintf(out, "%sStatus: Canceled%s.%s\n", colors.Yellow(), msgSuffix, colors.Reset())
 		return ExitErrorCanceled
 	}
+	var memErr MemoryError
+	if errors.As(err, &memErr) {
+		fmt.Fprintf(out, "Status: Failure (Memory). The operation would exceed the memory budget%s: %v\n", msgSuffix, memErr)
+		return ExitErrorGeneric
+	}
 	fmt.Fprintf(out, "Status: Failure. An unexpected error occurred: %v\n", err)
 	return ExitErrorGeneric
 }
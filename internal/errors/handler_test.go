@@ -46,6 +46,20 @@ func TestHandleCalculationError(t *testing.T) {
 			expectedCode: ExitErrorCanceled,
 			expectedMsg:  "[YELLOW]Status: Canceled after [YELLOW]500ms[RESET].[RESET]",
 		},
+		{
+			name:         "Phase Timeout Error",
+			err:          TimeoutError{Operation: "format", Limit: time.Second},
+			duration:     time.Second,
+			colors:       MockColorProvider{},
+			expectedCode: ExitErrorTimeout,
+			expectedMsg:  "Status: Failure (Timeout). The execution limit was reached during the format phase after [YELLOW]1s[RESET].",
+		},
+		{
+			name:         "Memory Error",
+			err:          fmt.Errorf("multiply: %w", MemoryError{Requested: 2048, Available: 1024, Limit: 1024}),
+			expectedCode: ExitErrorGeneric,
+			expectedMsg:  "Status: Failure (Memory). The operation would exceed the memory budget: memory error: requested 2048 bytes, available 1024 bytes (limit: 1024)",
+		},
 		{
 			name:         "Generic Error",
 			err:          fmt.Errorf("random error"),
@@ -0,0 +1,163 @@
+package fibonacci
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// BinetAlgorithm is the registry name of the Binet approximation calculator.
+const BinetAlgorithm = "binet"
+
+const (
+	// DefaultBinetPrecision is the mantissa precision in bits used when
+	// Options.BinetPrecision is 0, about 77 significant decimal digits.
+	DefaultBinetPrecision = 256
+	// MinBinetPrecision and MaxBinetPrecision bound the accepted precision.
+	MinBinetPrecision = 24
+	MaxBinetPrecision = 1 << 20
+	// MaxBinetIndex is the largest index whose approximation fits the
+	// exponent range of big.Float (φ^n has about 0.694·n bits).
+	MaxBinetIndex = 3_000_000_000
+)
+
+// BinetApproximation is an approximation of F(n) computed with Binet's
+// formula.
+type BinetApproximation struct {
+	// Value approximates F(n), rounded to the requested precision.
+	Value *big.Float
+	// RelError is an upper bound on |Value - F(n)| / F(n); 0 for F(0).
+	RelError *big.Float
+}
+
+// BinetApproximate approximates F(n) with Binet's formula, F(n) ≈ φ^n/√5,
+// where φ = (1+√5)/2. The neglected term ψ^n/√5, with ψ = (1-√5)/2, is below
+// 1/2 in absolute value, so the approximation is mostly useful for its
+// leading digits and exponent at indices far beyond what exact algorithms
+// handle quickly.
+//
+// φ^n is computed by square-and-multiply with enough guard bits that the
+// error of φ, amplified n times, stays below the requested precision. The
+// returned bound accounts for the rounding errors, the final rounding to
+// prec bits and the neglected ψ^n term.
+//
+// Parameters:
+//   - n: The index of the Fibonacci number.
+//   - prec: The mantissa precision of the result, in bits, between
+//     MinBinetPrecision and MaxBinetPrecision.
+//
+// Returns:
+//   - BinetApproximation: The approximate value and its relative error bound.
+//   - error: A ValidationError if prec or n is out of range.
+func BinetApproximate(n uint64, prec uint) (BinetApproximation, error) {
+	if prec < MinBinetPrecision || prec > MaxBinetPrecision {
+		return BinetApproximation{}, apperrors.ValidationError{
+			Field:   "binet-precision",
+			Message: fmt.Sprintf("the Binet precision must be between %d and %d bits, got %d", MinBinetPrecision, MaxBinetPrecision, prec),
+		}
+	}
+	if n > MaxBinetIndex {
+		return BinetApproximation{}, apperrors.ValidationError{
+			Field:   "n",
+			Message: fmt.Sprintf("Binet's formula supports indices up to %d, got %d", uint64(MaxBinetIndex), n),
+		}
+	}
+	if n == 0 {
+		return BinetApproximation{Value: new(big.Float).SetPrec(prec), RelError: new(big.Float)}, nil
+	}
+
+	// Working precision: the relative error of φ is multiplied by n in φ^n,
+	// hence bits.Len64(n) guard bits, and as many again with a margin for the
+	// roundings of the up to 2·bits.Len64(n) operations of the exponentiation.
+	numBits := bits.Len64(n)
+	work := prec + 2*uint(numBits) + 16
+	sqrt5 := new(big.Float).SetPrec(work).SetInt64(5)
+	sqrt5.Sqrt(sqrt5)
+	phi := new(big.Float).SetPrec(work).SetInt64(1)
+	phi.Add(phi, sqrt5).Quo(phi, big.NewFloat(2))
+
+	power := new(big.Float).SetPrec(work).SetInt64(1)
+	for i := numBits - 1; i >= 0; i-- {
+		power.Mul(power, power)
+		if (n>>uint(i))&1 == 1 {
+			power.Mul(power, phi)
+		}
+	}
+	power.Quo(power, sqrt5)
+	value := new(big.Float).SetPrec(prec).Set(power)
+
+	// Bound = 2^-prec (final rounding) + (2n + 4·numBits + 8)·2^-work
+	// (working rounding) + φ^(2-2n)/√5 (the ψ^n term relative to F(n)).
+	relErr := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec))
+	rounding := new(big.Float).SetUint64(2*n + 4*uint64(numBits) + 8)
+	relErr.Add(relErr, rounding.SetMantExp(rounding, -int(work)))
+	exp := (2 - 2*float64(n)) * math.Log2(math.Phi)
+	intExp := math.Floor(exp)
+	// The small factor absorbs the float64 rounding of the exponent.
+	psiTerm := big.NewFloat(math.Exp2(exp-intExp) / math.Sqrt(5) * (1 + 1e-9))
+	relErr.Add(relErr, psiTerm.SetMantExp(psiTerm, int(intExp)))
+
+	return BinetApproximation{Value: value, RelError: relErr}, nil
+}
+
+// BinetApproxCalculator approximates F(n) with Binet's formula at the
+// precision of Options.BinetPrecision (see BinetApproximate) and rounds the
+// approximation to the nearest integer. The result is exact only while F(n)
+// fits the precision; beyond that its low-order digits are not those of
+// F(n).
+//
+// Since the result is approximate, this algorithm is left out of
+// "--algo all" comparisons and consistency checks (see ExcludedFromAll).
+type BinetApproxCalculator struct{}
+
+// Name returns the descriptive name of the algorithm.
+//
+// Returns:
+//   - string: The name of the algorithm.
+func (c *BinetApproxCalculator) Name() string {
+	return "Binet's Formula (Approximate)"
+}
+
+// binetPrecision returns the precision configured in opts.
+func binetPrecision(opts Options) uint {
+	if opts.BinetPrecision == 0 {
+		return DefaultBinetPrecision
+	}
+	return uint(max(opts.BinetPrecision, 0))
+}
+
+// calculateSmall returns the approximation for small n, so that the
+// precision limits apply to every index.
+func (c *BinetApproxCalculator) calculateSmall(n uint64, opts Options) (*big.Int, error) {
+	return c.CalculateCore(context.Background(), func(float64) {}, n, opts)
+}
+
+// CalculateCore approximates F(n) with Binet's formula.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - reporter: The function used for reporting progress.
+//   - n: The index of the Fibonacci number to approximate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The approximation rounded to the nearest integer.
+//   - error: An error if the precision or index is out of range, or the
+//     context is canceled.
+func (c *BinetApproxCalculator) CalculateCore(ctx context.Context, reporter ProgressCallback, n uint64, opts Options) (*big.Int, error) {
+	approx, err := BinetApproximate(n, binetPrecision(opts))
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("binet approximation canceled: %w", err)
+	}
+	rounded := new(big.Float).SetPrec(approx.Value.Prec()+2).Add(approx.Value, big.NewFloat(0.5))
+	result, _ := rounded.Int(nil)
+	reporter(1.0)
+	return result, nil
+}
@@ -0,0 +1,104 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+func TestBinetApproximateLeadingDigits(t *testing.T) {
+	t.Parallel()
+	for _, n := range []uint64{1, 2, 10, 93, 100, 1000, 10_000, 100_000} {
+		exact := new(big.Float).SetInt(mustFib(t, n))
+		approx, err := BinetApproximate(n, DefaultBinetPrecision)
+		if err != nil {
+			t.Fatalf("BinetApproximate(%d) error = %v", n, err)
+		}
+
+		// The actual relative error must stay within the reported bound.
+		diff := new(big.Float).Sub(approx.Value, exact)
+		diff.Abs(diff).Quo(diff, exact)
+		if diff.Cmp(approx.RelError) > 0 {
+			t.Errorf("n=%d: relative error %s exceeds the bound %s", n, diff.Text('g', 3), approx.RelError.Text('g', 3))
+		}
+
+		// Past the first indices, where the neglected ψ^n term matters, the
+		// leading 30 digits match.
+		if n >= 100 {
+			if got, want := approx.Value.Text('e', 30), exact.Text('e', 30); got != want {
+				t.Errorf("n=%d: leading digits %s, want %s", n, got, want)
+			}
+		}
+	}
+}
+
+func TestBinetApproxCalculator(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&BinetApproxCalculator{})
+	// F(300) has 208 bits, below the default precision: rounding is exact.
+	for _, n := range []uint64{0, 1, 50, 93, 94, 300} {
+		got, err := calc.Calculate(context.Background(), nil, 0, n, Options{})
+		if err != nil {
+			t.Fatalf("Calculate(%d) error = %v", n, err)
+		}
+		if want := mustFib(t, n); got.Cmp(want) != 0 {
+			t.Errorf("F(%d) = %s, want %s", n, got, want)
+		}
+	}
+
+	// At a low precision only the leading digits of F(1000) are right.
+	got, err := calc.Calculate(context.Background(), nil, 0, 1000, Options{BinetPrecision: 64})
+	if err != nil {
+		t.Fatalf("Calculate(1000) error = %v", err)
+	}
+	want := mustFib(t, 1000)
+	if got.Cmp(want) == 0 || got.String()[:15] != want.String()[:15] {
+		t.Errorf("F(1000) at 64 bits = %s..., want the leading digits of %s... only", got.String()[:20], want.String()[:20])
+	}
+}
+
+func TestBinetApproximateRejectsOutOfRange(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name  string
+		n     uint64
+		prec  uint
+		field string
+	}{
+		{"low precision", 10, MinBinetPrecision - 1, "binet-precision"},
+		{"high precision", 10, MaxBinetPrecision + 1, "binet-precision"},
+		{"index", MaxBinetIndex + 1, DefaultBinetPrecision, "n"},
+	}
+	for _, tt := range tests {
+		_, err := BinetApproximate(tt.n, tt.prec)
+		var validationErr apperrors.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+			t.Errorf("%s: error = %v, want a ValidationError on %q", tt.name, err, tt.field)
+		}
+	}
+
+	approx, err := BinetApproximate(MaxBinetIndex, MinBinetPrecision)
+	if err != nil || approx.Value.IsInf() {
+		t.Errorf("BinetApproximate(MaxBinetIndex) = %v, %v, want a finite value", approx.Value, err)
+	}
+}
+
+func TestBinetExcludedFromAll(t *testing.T) {
+	t.Parallel()
+	if !ExcludedFromAll(BinetAlgorithm) {
+		t.Error("the approximate Binet calculator must not take part in --algo all")
+	}
+}
+
+// mustFib returns the exact F(n).
+func mustFib(t *testing.T, n uint64) *big.Int {
+	t.Helper()
+	f, err := NewCalculator(&OptimizedFastDoubling{}).Calculate(context.Background(), nil, 0, n, Options{})
+	if err != nil {
+		t.Fatalf("exact F(%d): %v", n, err)
+	}
+	return f
+}
@@ -44,6 +44,12 @@ type Calculator interface {
 	Name() string
 }
 
+// smallCalculator is implemented by core calculators that do not compute
+// F(n) and therefore provide their own result for the small-n fast path.
+type smallCalculator interface {
+	calculateSmall(n uint64, opts Options) (*big.Int, error)
+}
+
 // coreCalculator defines the internal interface for a pure calculation
 // algorithm.
 type coreCalculator interface {
@@ -87,6 +93,22 @@ func (c *FibCalculator) Name() string {
 	return c.core.Name()
 }
 
+// RelativeCost returns the cost estimated by the encapsulated coreCalculator
+// if it implements CostEstimator, and 1 otherwise.
+//
+// Parameters:
+//   - n: The Fibonacci index.
+//   - opts: The calculation options.
+//
+// Returns:
+//   - float64: The estimated cost, 1 for fast doubling.
+func (c *FibCalculator) RelativeCost(n uint64, opts Options) float64 {
+	if estimator, ok := c.core.(CostEstimator); ok {
+		return estimator.RelativeCost(n, opts)
+	}
+	return 1
+}
+
 // Calculate orchestrates the calculation process.
 // It first checks for small values of `n` (≤93) which can be computed
 // efficiently using iterative addition without the overhead of the full
@@ -172,16 +194,29 @@ func (c *FibCalculator) CalculateWithObservers(ctx context.Context, subject *Pro
 	}
 
 	if n <= MaxFibUint64 {
+		SetPhase(ctx, PhaseCompute)
 		reporter(1.0)
+		if small, ok := c.core.(smallCalculator); ok {
+			return small.calculateSmall(n, opts)
+		}
 		return calculateSmall(n), nil
 	}
 
 	// Configure FFT cache based on options for optimal performance
+	SetPhase(ctx, PhaseSetup)
 	configureFFTCache(opts)
+	configurePooling(opts)
 
 	// Pre-warm pools once for large calculations (one-time initialization)
-	bigfft.EnsurePoolsWarmed(n)
+	if !opts.DisablePooling {
+		bigfft.EnsurePoolsWarmed(n)
+	}
 
+	// Attribute a deadline reached during setup to the setup phase.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	SetPhase(ctx, PhaseCompute)
 	result, err = c.core.CalculateCore(ctx, reporter, n, opts)
 	if err == nil && result != nil {
 		reporter(1.0)
@@ -28,10 +28,14 @@ import (
 
 // RegisterGMPCalculator registers the GMP calculator in the given factory.
 func RegisterGMPCalculator(f *DefaultFactory) {
-	f.Register("gmp", func() coreCalculator { return &GMPCalculator{} })
+	f.registerCore("gmp", func() coreCalculator { return &GMPCalculator{} })
 }
 
+// init adds the GMP calculator to the package registry, so that every
+// factory created with NewDefaultFactory offers it, and to the global factory,
+// which was built before.
 func init() {
+	registry.mustRegisterCore("gmp", func() coreCalculator { return &GMPCalculator{} })
 	RegisterGMPCalculator(globalFactory)
 }
 
@@ -0,0 +1,198 @@
+package fibonacci
+
+import (
+	"context"
+	"math/big"
+	"sync"
+)
+
+// coalescedProgressBuffer is the buffer size of the progress channel of a
+// shared computation.
+const coalescedProgressBuffer = 16
+
+// coalesceKey identifies calculations that can share a single computation.
+type coalesceKey struct {
+	algorithm string
+	n         uint64
+	values    ValueOptions
+}
+
+// coalescedCall tracks one in-flight shared computation and the callers
+// waiting on it. The waiters map is guarded by CoalescingCalculator.mu.
+type coalescedCall struct {
+	key     coalesceKey
+	done    chan struct{}
+	cancel  context.CancelFunc
+	result  *big.Int
+	err     error
+	waiters map[*coalescedWaiter]struct{}
+}
+
+// coalescedWaiter is a caller attached to a shared computation. Progress of
+// the shared computation is forwarded to its channel under its own index.
+type coalescedWaiter struct {
+	progressChan chan<- ProgressUpdate
+	calcIndex    int
+}
+
+// CoalescingCalculator is a Calculator decorator that deduplicates concurrent
+// identical requests. Calls with the same (n, algorithm, value options) key
+// that overlap in time share a single computation by the inner calculator, and each caller
+// receives its own copy of the result.
+//
+// The shared computation is detached from the callers' contexts: a caller
+// whose context is canceled stops waiting and returns immediately, while the
+// computation continues for the remaining callers. It is canceled only once
+// every caller waiting on it has given up.
+//
+// Only the options that change the value (see ValueOptions) are part of the
+// key; the thresholds and other tuning options of the first caller are used
+// for the shared computation.
+type CoalescingCalculator struct {
+	inner Calculator
+
+	mu    sync.Mutex
+	calls map[coalesceKey]*coalescedCall
+}
+
+// Verify that CoalescingCalculator implements Calculator.
+var _ Calculator = (*CoalescingCalculator)(nil)
+
+// NewCoalescingCalculator wraps inner so that concurrent identical calls are
+// coalesced into a single computation.
+//
+// Parameters:
+//   - inner: The calculator performing the actual computation.
+//
+// Returns:
+//   - *CoalescingCalculator: The coalescing decorator.
+func NewCoalescingCalculator(inner Calculator) *CoalescingCalculator {
+	return &CoalescingCalculator{
+		inner: inner,
+		calls: make(map[coalesceKey]*coalescedCall),
+	}
+}
+
+// Name returns the name of the inner calculator.
+func (c *CoalescingCalculator) Name() string {
+	return c.inner.Name()
+}
+
+// RelativeCost returns the cost estimated by the inner calculator (see
+// RelativeCost).
+func (c *CoalescingCalculator) RelativeCost(n uint64, opts Options) float64 {
+	return RelativeCost(c.inner, n, opts)
+}
+
+// Calculate computes F(n), joining an identical in-flight computation when
+// one exists instead of starting a new one.
+//
+// Parameters:
+//   - ctx: The caller's context. Canceling it only stops this caller's wait.
+//   - progressChan: Channel receiving progress of the shared computation.
+//   - calcIndex: The index reported in progress updates for this caller.
+//   - n: The index of the Fibonacci number to calculate.
+//   - opts: Configuration options, used if a new computation is started.
+//
+// Returns:
+//   - *big.Int: A copy of the calculated Fibonacci number owned by the caller.
+//   - error: The computation error, or the caller's context error.
+func (c *CoalescingCalculator) Calculate(ctx context.Context, progressChan chan<- ProgressUpdate, calcIndex int, n uint64, opts Options) (*big.Int, error) {
+	key := coalesceKey{algorithm: c.inner.Name(), n: n, values: opts.ValueOptions()}
+	w := &coalescedWaiter{progressChan: progressChan, calcIndex: calcIndex}
+
+	c.mu.Lock()
+	call, ok := c.calls[key]
+	if !ok {
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &coalescedCall{
+			key:     key,
+			done:    make(chan struct{}),
+			cancel:  cancel,
+			waiters: make(map[*coalescedWaiter]struct{}),
+		}
+		c.calls[key] = call
+		go c.run(sharedCtx, call, n, opts)
+	}
+	call.waiters[w] = struct{}{}
+	c.mu.Unlock()
+
+	select {
+	case <-call.done:
+		c.leave(call, w)
+		if call.err != nil {
+			return nil, call.err
+		}
+		return new(big.Int).Set(call.result), nil
+	case <-ctx.Done():
+		c.leave(call, w)
+		return nil, ctx.Err()
+	}
+}
+
+// run executes the shared computation and publishes its outcome.
+func (c *CoalescingCalculator) run(ctx context.Context, call *coalescedCall, n uint64, opts Options) {
+	defer call.cancel()
+
+	progressChan := make(chan ProgressUpdate, coalescedProgressBuffer)
+	forwarded := make(chan struct{})
+	go func() {
+		defer close(forwarded)
+		for update := range progressChan {
+			c.forward(call, update.Value)
+		}
+	}()
+
+	result, err := c.inner.Calculate(ctx, progressChan, 0, n, opts)
+	close(progressChan)
+	<-forwarded
+
+	c.mu.Lock()
+	c.detach(call)
+	call.result, call.err = result, err
+	c.mu.Unlock()
+	close(call.done)
+}
+
+// forward relays a progress value to every caller still waiting on call.
+// Sends are non-blocking so a slow consumer cannot stall the computation.
+// Holding mu guarantees a waiter's channel is not used after it has left.
+func (c *CoalescingCalculator) forward(call *coalescedCall, value float64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for w := range call.waiters {
+		if w.progressChan == nil {
+			continue
+		}
+		select {
+		case w.progressChan <- ProgressUpdate{CalculatorIndex: w.calcIndex, Value: value}:
+		default:
+		}
+	}
+}
+
+// leave detaches w from call, canceling the shared computation if it is still
+// running and no caller is waiting on it anymore. An abandoned call is removed
+// from the in-flight set so later callers start a fresh computation.
+func (c *CoalescingCalculator) leave(call *coalescedCall, w *coalescedWaiter) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(call.waiters, w)
+	if len(call.waiters) > 0 {
+		return
+	}
+	select {
+	case <-call.done:
+	default:
+		c.detach(call)
+		call.cancel()
+	}
+}
+
+// detach removes call from the in-flight set if it is still registered.
+// The caller must hold mu.
+func (c *CoalescingCalculator) detach(call *coalescedCall) {
+	if c.calls[call.key] == call {
+		delete(c.calls, call.key)
+	}
+}
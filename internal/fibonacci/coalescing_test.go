@@ -0,0 +1,227 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// blockingCalculator counts invocations and blocks until released.
+type blockingCalculator struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingCalculator() *blockingCalculator {
+	return &blockingCalculator{
+		started: make(chan struct{}, 8),
+		release: make(chan struct{}),
+	}
+}
+
+func (b *blockingCalculator) Name() string { return "blocking" }
+
+func (b *blockingCalculator) Calculate(ctx context.Context, progressChan chan<- ProgressUpdate, calcIndex int, n uint64, opts Options) (*big.Int, error) {
+	b.calls.Add(1)
+	b.started <- struct{}{}
+	select {
+	case <-b.release:
+		return big.NewInt(int64(n)), nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// waitForWaiters polls until the in-flight call for n has the given number of waiters.
+func waitForWaiters(t *testing.T, c *CoalescingCalculator, n uint64, want int) {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		call := c.calls[coalesceKey{algorithm: c.inner.Name(), n: n, values: Options{}.ValueOptions()}]
+		got := 0
+		if call != nil {
+			got = len(call.waiters)
+		}
+		c.mu.Unlock()
+		if got == want {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d waiters on n=%d", want, n)
+}
+
+func TestCoalescingCalculatorSharesComputation(t *testing.T) {
+	t.Parallel()
+	inner := newBlockingCalculator()
+	calc := NewCoalescingCalculator(inner)
+
+	var wg sync.WaitGroup
+	results := make([]*big.Int, 2)
+	errs := make([]error, 2)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], errs[i] = calc.Calculate(context.Background(), nil, i, 42, Options{})
+		}(i)
+	}
+
+	waitForWaiters(t, calc, 42, 2)
+	close(inner.release)
+	wg.Wait()
+
+	if got := inner.calls.Load(); got != 1 {
+		t.Fatalf("inner calculator invoked %d times, want 1", got)
+	}
+	for i := range results {
+		if errs[i] != nil {
+			t.Fatalf("caller %d: unexpected error: %v", i, errs[i])
+		}
+		if results[i].Int64() != 42 {
+			t.Errorf("caller %d: got %s, want 42", i, results[i])
+		}
+	}
+	if results[0] == results[1] {
+		t.Error("callers share the same *big.Int, want defensive copies")
+	}
+}
+
+// TestCoalescingCalculatorValueOptions verifies that calls whose options
+// change the value, the k-bonacci order or the Binet precision, are not
+// coalesced.
+func TestCoalescingCalculatorValueOptions(t *testing.T) {
+	t.Parallel()
+	inner := newBlockingCalculator()
+	calc := NewCoalescingCalculator(inner)
+
+	var wg sync.WaitGroup
+	options := []Options{{KBonacciOrder: 3}, {KBonacciOrder: 4}, {BinetPrecision: 1024}}
+	errs := make([]error, len(options))
+	for i, opts := range options {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, errs[i] = calc.Calculate(context.Background(), nil, i, 200, opts)
+		}()
+	}
+
+	for range errs {
+		select {
+		case <-inner.started:
+		case <-time.After(5 * time.Second):
+			t.Fatal("calls with different value options were coalesced")
+		}
+	}
+	close(inner.release)
+	wg.Wait()
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("caller %d: unexpected error: %v", i, err)
+		}
+	}
+}
+
+func TestCoalescingCalculatorCallerCancellation(t *testing.T) {
+	t.Parallel()
+	inner := newBlockingCalculator()
+	calc := NewCoalescingCalculator(inner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	canceledErr := make(chan error, 1)
+	go func() {
+		_, err := calc.Calculate(ctx, nil, 0, 7, Options{})
+		canceledErr <- err
+	}()
+	<-inner.started
+
+	type outcome struct {
+		res *big.Int
+		err error
+	}
+	survivor := make(chan outcome, 1)
+	go func() {
+		res, err := calc.Calculate(context.Background(), nil, 1, 7, Options{})
+		survivor <- outcome{res, err}
+	}()
+	waitForWaiters(t, calc, 7, 2)
+
+	cancel()
+	if err := <-canceledErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("canceled caller: got %v, want context.Canceled", err)
+	}
+
+	close(inner.release)
+	got := <-survivor
+	if got.err != nil {
+		t.Fatalf("remaining caller: unexpected error: %v", got.err)
+	}
+	if got.res.Int64() != 7 {
+		t.Errorf("remaining caller: got %s, want 7", got.res)
+	}
+	if calls := inner.calls.Load(); calls != 1 {
+		t.Errorf("inner calculator invoked %d times, want 1", calls)
+	}
+}
+
+func TestCoalescingCalculatorAbandonedComputationCanceled(t *testing.T) {
+	t.Parallel()
+	inner := newBlockingCalculator()
+	calc := NewCoalescingCalculator(inner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := calc.Calculate(ctx, nil, 0, 9, Options{})
+		done <- err
+	}()
+	<-inner.started
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+
+	// A new caller must not join the abandoned computation.
+	close(inner.release)
+	res, err := calc.Calculate(context.Background(), nil, 0, 9, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Int64() != 9 {
+		t.Errorf("got %s, want 9", res)
+	}
+	if calls := inner.calls.Load(); calls != 2 {
+		t.Errorf("inner calculator invoked %d times, want 2", calls)
+	}
+}
+
+func TestCoalescingCalculatorForwardsProgress(t *testing.T) {
+	t.Parallel()
+	calc := NewCoalescingCalculator(&MockCalculator{Result: big.NewInt(5)})
+
+	progressChan := make(chan ProgressUpdate, 4)
+	res, err := calc.Calculate(context.Background(), progressChan, 3, 5, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.Int64() != 5 {
+		t.Errorf("got %s, want 5", res)
+	}
+	select {
+	case update := <-progressChan:
+		if update.CalculatorIndex != 3 || update.Value != 1.0 {
+			t.Errorf("got update %+v, want index 3 value 1.0", update)
+		}
+	default:
+		t.Error("expected a forwarded progress update")
+	}
+	if calc.Name() != "mock" {
+		t.Errorf("Name() = %q, want %q", calc.Name(), "mock")
+	}
+}
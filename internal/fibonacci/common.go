@@ -74,6 +74,7 @@ func preSizeBigInt(z *big.Int, words int) {
 var taskLogger = zerolog.Nop()
 
 // SetTaskLogger configures the logger used for parallel task distribution decisions.
+// It is not synchronized and must be called before any calculation starts.
 func SetTaskLogger(l zerolog.Logger) {
 	taskLogger = l
 }
@@ -0,0 +1,100 @@
+package fibonacci
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+// fibByAddition is the reference used by the concurrency tests.
+func fibByAddition(n uint64) *big.Int {
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := uint64(0); i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return a
+}
+
+// TestConcurrentCalculateSharedCalculator runs many concurrent Calculate
+// calls on one shared calculator per algorithm, for varied n, and checks
+// every result. It backs the concurrency guarantee documented in doc.go.
+//
+// Run with: go test -race -run TestConcurrentCalculate ./internal/fibonacci/
+func TestConcurrentCalculateSharedCalculator(t *testing.T) {
+	t.Parallel()
+	ns := []uint64{0, 1, 93, 94, 500, 1000, 4097, 10000, 25000}
+	expected := make(map[uint64]*big.Int, len(ns))
+	for _, n := range ns {
+		expected[n] = fibByAddition(n)
+	}
+	// Low thresholds so that the parallel and FFT paths are exercised too.
+	opts := Options{ParallelThreshold: 1024, FFTThreshold: 8000, GCMode: "aggressive"}
+
+	factory := NewDefaultFactory()
+	for _, name := range []string{"fast", "matrix", "fft"} {
+		calc, err := factory.Get(name)
+		if err != nil {
+			t.Fatalf("Get(%q) failed: %v", name, err)
+		}
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			const rounds = 4
+			var wg sync.WaitGroup
+			errs := make(chan string, rounds*len(ns))
+			for r := 0; r < rounds; r++ {
+				for i, n := range ns {
+					wg.Add(1)
+					go func(idx int, n uint64) {
+						defer wg.Done()
+						progress := make(chan ProgressUpdate, 256)
+						go func() {
+							for range progress {
+							}
+						}()
+						got, err := calc.Calculate(context.Background(), progress, idx, n, opts)
+						close(progress)
+						switch {
+						case err != nil:
+							errs <- err.Error()
+						case got.Cmp(expected[n]) != 0:
+							errs <- fmt.Sprintf("wrong result for n=%d", n)
+						}
+					}(i, n)
+				}
+			}
+			wg.Wait()
+			close(errs)
+			for msg := range errs {
+				t.Error(msg)
+			}
+		})
+	}
+}
+
+// TestConcurrentCalculateCoreDistinctInstances runs CalculateCore
+// concurrently on distinct calculator instances.
+func TestConcurrentCalculateCoreDistinctInstances(t *testing.T) {
+	t.Parallel()
+	const n = 12345
+	want := fibByAddition(n)
+
+	var wg sync.WaitGroup
+	results := make([]*big.Int, 8)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			calcs := allCalculators()
+			results[i], _ = calcF(calcs[i%len(calcs)], n)
+		}(i)
+	}
+	wg.Wait()
+	for i, got := range results {
+		if got == nil || got.Cmp(want) != 0 {
+			t.Errorf("goroutine %d: wrong result", i)
+		}
+	}
+}
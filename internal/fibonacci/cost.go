@@ -0,0 +1,29 @@
+package fibonacci
+
+// CostEstimator is implemented by calculators that can estimate how long
+// they take to compute F(n), relative to fast doubling. Calculators
+// registered by embedders may implement it to weight the aggregated progress
+// of comparisons (see RelativeCost).
+type CostEstimator interface {
+	// RelativeCost returns the estimated cost of computing F(n), 1 for fast
+	// doubling.
+	RelativeCost(n uint64, opts Options) float64
+}
+
+// RelativeCost estimates how long calc takes to compute F(n), relative to
+// fast doubling. Calculators that do not implement CostEstimator are assumed
+// to cost as much as fast doubling.
+//
+// Parameters:
+//   - calc: The calculator.
+//   - n: The Fibonacci index.
+//   - opts: The calculation options.
+//
+// Returns:
+//   - float64: The estimated cost, 1 for fast doubling.
+func RelativeCost(calc Calculator, n uint64, opts Options) float64 {
+	if estimator, ok := calc.(CostEstimator); ok {
+		return estimator.RelativeCost(n, opts)
+	}
+	return 1
+}
@@ -3,4 +3,19 @@
 // algorithm, allowing different strategies (Fast Doubling, Matrix Exponentiation,
 // FFT-based) to be used interchangeably. The package integrates optimizations such
 // as memory pooling, parallel processing, and dynamic threshold adjustment.
+//
+// # Concurrency
+//
+// The calculators returned by the registry and by NewCalculator are
+// stateless: Calculate, CalculateWithObservers and CalculateCore may be
+// called concurrently, both on distinct instances and on a single shared
+// instance. Each call allocates (or takes from a pool) its own
+// CalculationState, which is never shared between calls. Process-wide
+// settings touched by a calculation (GC control, FFT cache and buffer pools)
+// are synchronized internally.
+//
+// DefaultFactory, CoalescingCalculator, IterativeGenerator and
+// ProgressSubject are safe for concurrent use. SetTaskLogger and
+// SetRegistryLogger are configuration hooks that must be called before
+// calculations start.
 package fibonacci
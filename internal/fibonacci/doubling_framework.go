@@ -150,15 +150,17 @@ func (f *DoublingFramework) ExecuteDoublingLoop(ctx context.Context, reporter Pr
 	// Normalize options to ensure consistent default threshold handling
 	currentOpts := normalizeOptions(opts)
 	dtm := f.dynamicThreshold
+	recorder := stepRecorderFrom(ctx)
 
 	for i := numBits - 1; i >= 0; i-- {
 		if err := ctx.Err(); err != nil {
 			return nil, fmt.Errorf("fast doubling calculation canceled at bit %d/%d: %w", i, numBits-1, err)
 		}
 
-		// Track iteration timing for dynamic threshold adjustment
+		// Track iteration timing for dynamic threshold adjustment and the
+		// step recorder
 		var iterStart time.Time
-		if dtm != nil {
+		if dtm != nil || recorder != nil {
 			iterStart = time.Now()
 		}
 
@@ -213,9 +215,16 @@ func (f *DoublingFramework) ExecuteDoublingLoop(ctx context.Context, reporter Pr
 			s.FK, s.FK1, s.T1 = s.FK1, s.T1, s.FK
 		}
 
+		var iterDuration time.Duration
+		if dtm != nil || recorder != nil {
+			iterDuration = time.Since(iterStart)
+		}
+		if recorder != nil {
+			recorder.RecordIteration(bitLen, iterDuration, usedFFT, usedParallel)
+		}
+
 		// Record metrics and check for threshold adjustments
 		if dtm != nil {
-			iterDuration := time.Since(iterStart)
 			dtm.RecordIteration(bitLen, iterDuration, usedFFT, usedParallel)
 
 			// Check if thresholds should be adjusted
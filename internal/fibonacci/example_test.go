@@ -45,7 +45,7 @@ func ExampleDefaultFactory() {
 
 	fmt.Println(result)
 	// Output:
-	// [fast fft matrix]
+	// [binet fast fft iterative kbonacci lucas matrix]
 	// 55
 }
 
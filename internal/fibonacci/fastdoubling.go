@@ -123,13 +123,14 @@ func (fd *OptimizedFastDoubling) CalculateCore(ctx context.Context, reporter Pro
 
 	// Create framework with or without dynamic threshold adjustment
 	var framework *DoublingFramework
+	var dtm *threshold.DynamicThresholdManager
 	if normalizedOpts.EnableDynamicThresholds {
 		// Create dynamic threshold manager
 		interval := normalizedOpts.DynamicAdjustmentInterval
 		if interval <= 0 {
 			interval = threshold.DynamicAdjustmentInterval
 		}
-		dtm := threshold.NewDynamicThresholdManagerFromConfig(threshold.DynamicThresholdConfig{
+		dtm = threshold.NewDynamicThresholdManagerFromConfig(threshold.DynamicThresholdConfig{
 			InitialFFTThreshold:      normalizedOpts.FFTThreshold,
 			InitialParallelThreshold: normalizedOpts.ParallelThreshold,
 			AdjustmentInterval:       interval,
@@ -141,7 +142,11 @@ func (fd *OptimizedFastDoubling) CalculateCore(ctx context.Context, reporter Pro
 	}
 
 	// Execute the doubling loop with parallelization support
-	return framework.ExecuteDoublingLoop(ctx, reporter, n, normalizedOpts, s, useParallel)
+	result, err := framework.ExecuteDoublingLoop(ctx, reporter, n, normalizedOpts, s, useParallel)
+	if err == nil && dtm != nil && normalizedOpts.OnThresholdsLearned != nil {
+		normalizedOpts.OnThresholdsLearned(dtm.Learned())
+	}
+	return result, err
 }
 
 // ShouldParallelizeMultiplication determines whether the multiplication operations
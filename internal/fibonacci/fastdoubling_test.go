@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
 )
 
 func TestShouldParallelizeMultiplication(t *testing.T) {
@@ -156,6 +158,31 @@ func TestFastDoubling_ReducedState_Correctness(t *testing.T) {
 	}
 }
 
+// TestFastDoublingReportsLearnedThresholds verifies that the dynamically
+// adjusted thresholds are passed to OnThresholdsLearned, and only with
+// dynamic thresholds enabled.
+func TestFastDoublingReportsLearnedThresholds(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	var learned []threshold.LearnedThresholds
+	record := func(l threshold.LearnedThresholds) { learned = append(learned, l) }
+
+	if _, err := calc.Calculate(context.Background(), nil, 0, 10_000, Options{OnThresholdsLearned: record}); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if len(learned) != 0 {
+		t.Errorf("static thresholds should not be reported, got %+v", learned)
+	}
+
+	opts := Options{EnableDynamicThresholds: true, OnThresholdsLearned: record}
+	if _, err := calc.Calculate(context.Background(), nil, 0, 10_000, opts); err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	if len(learned) != 1 || learned[0].FFTThreshold <= 0 || learned[0].ParallelThreshold <= 0 {
+		t.Errorf("learned thresholds = %+v, want one positive report", learned)
+	}
+}
+
 // TestFFTBased_ReducedState_Correctness verifies FFT-based calculator
 // produces correct results with the reduced 5-temporary state.
 func TestFFTBased_ReducedState_Correctness(t *testing.T) {
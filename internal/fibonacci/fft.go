@@ -91,6 +91,9 @@ func executeDoublingStepFFT(ctx context.Context, s *CalculationState, opts Optio
 	// FK1 is roughly N iterations * 2.
 	// For GetFFTParams, we need words.
 	fk1Words := len(s.FK1.Bits())
+	if err := bigfft.CheckMemoryBudget(len(s.FK.Bits()), fk1Words); err != nil {
+		return err
+	}
 	targetWords := 2*fk1Words + FFTSafetyMarginWords
 	k, m := bigfft.GetFFTParams(targetWords)
 
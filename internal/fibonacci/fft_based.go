@@ -28,6 +28,23 @@ func (c *FFTBasedCalculator) Name() string {
 	return "FFT-Based Doubling"
 }
 
+// RelativeCost estimates the cost of computing F(n) relative to fast
+// doubling, after the benchmark table in the README: about 10% slower once
+// F(n) exceeds the FFT threshold, but over twice as slow below it.
+//
+// Parameters:
+//   - n: The Fibonacci index.
+//   - opts: The calculation options; a zero FFTThreshold selects the default.
+//
+// Returns:
+//   - float64: The estimated cost, 1 for fast doubling.
+func (c *FFTBasedCalculator) RelativeCost(n uint64, opts Options) float64 {
+	if n < MinMeaningfulN("fft", opts) {
+		return 2.5
+	}
+	return 1.1
+}
+
 // CalculateCore computes F(n) using the Fast Doubling algorithm, with all
 // multiplications performed via FFT.
 //
@@ -2,8 +2,12 @@ package fibonacci
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"testing"
+
+	"github.com/agbru/fibcalc/internal/bigfft"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
 )
 
 func TestExecuteDoublingStepFFT(t *testing.T) {
@@ -136,3 +140,82 @@ func TestSmartSquare_InPlace_BufferReuse(t *testing.T) {
 		t.Errorf("smartSquare = %s, want %s", result.String(), expected.String())
 	}
 }
+
+// TestDoublingStepFFTMemoryBudget verifies that the doubling step with
+// transform reuse, which bypasses bigfft.Mul, still honors the memory budget
+// on the fast and fft calculators. It does not run in parallel: the budget is
+// global.
+func TestDoublingStepFFTMemoryBudget(t *testing.T) {
+	bigfft.SetMemoryBudget(1024)
+	defer bigfft.SetMemoryBudget(0)
+
+	factory := NewDefaultFactory()
+	for _, name := range []string{"fast", "fft"} {
+		calc, err := factory.Build(name)
+		if err != nil {
+			t.Fatalf("Build(%q): %v", name, err)
+		}
+		_, err = calc.Calculate(context.Background(), nil, 0, 200_000, Options{FFTThreshold: 10_000})
+		var memErr apperrors.MemoryError
+		if !errors.As(err, &memErr) {
+			t.Errorf("%s: got %v, want a MemoryError", name, err)
+		}
+	}
+}
+
+// TestMatrixOperationCounts verifies that computing F(n) with FFT forced
+// shows up as FFT squarings in the bigfft operation counters. The matrix
+// algorithm squares through smartSquare. It does not run in parallel: the
+// counters and the bigfft threshold are global.
+func TestMatrixOperationCounts(t *testing.T) {
+	bigfft.SetFFTThreshold(1)
+	defer bigfft.SetFFTThreshold(0)
+	defer bigfft.ResetOperationCounts()
+	bigfft.ResetOperationCounts()
+
+	const n = 20000
+	got, err := NewCalculator(&MatrixExponentiation{}).Calculate(context.Background(), nil, 0, n, Options{FFTThreshold: 1})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := 0; i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	if got.Cmp(a) != 0 {
+		t.Fatal("F(n) computed with forced FFT differs from the iterative reference")
+	}
+
+	counts := bigfft.OperationCounts()
+	if counts.FFTSqr == 0 {
+		t.Errorf("OperationCounts() = %+v, want FFT squarings", counts)
+	}
+}
+
+// TestFastDoublingOperationCounts verifies that the doubling step with
+// transform reuse, which squares transformed polynomials, shows up as FFT
+// squarings in the bigfft operation counters when FFT is forced. It does not
+// run in parallel: the counters are global.
+func TestFastDoublingOperationCounts(t *testing.T) {
+	defer bigfft.ResetOperationCounts()
+	bigfft.ResetOperationCounts()
+
+	const n = 20000
+	got, err := NewCalculator(&OptimizedFastDoubling{}).Calculate(context.Background(), nil, 0, n, Options{FFTThreshold: 1})
+	if err != nil {
+		t.Fatalf("Calculate failed: %v", err)
+	}
+	want, err := NewCalculator(&MatrixExponentiation{}).Calculate(context.Background(), nil, 0, n, Options{})
+	if err != nil {
+		t.Fatalf("reference Calculate failed: %v", err)
+	}
+	if got.Cmp(want) != 0 {
+		t.Fatal("F(n) computed with forced FFT differs from the matrix reference")
+	}
+
+	counts := bigfft.OperationCounts()
+	if counts.FFTSqr == 0 || counts.PolySqr == 0 {
+		t.Errorf("OperationCounts() = %+v, want FFT squarings of transformed polynomials", counts)
+	}
+}
@@ -112,6 +112,47 @@ func FuzzFFTBasedConsistency(f *testing.F) {
 	})
 }
 
+// FuzzIterativeConsistency verifies that the addition-only iterative
+// calculator agrees with the Fast Doubling algorithm.
+func FuzzIterativeConsistency(f *testing.F) {
+	f.Add(uint64(0))
+	f.Add(uint64(1))
+	f.Add(uint64(93))
+	f.Add(uint64(4095))
+	f.Add(uint64(4096))
+	f.Add(uint64(10000))
+
+	f.Fuzz(func(t *testing.T, n uint64) {
+		// The iterative calculator is O(n²)
+		if n > 20000 {
+			return
+		}
+
+		ctx := context.Background()
+		opts := Options{
+			ParallelThreshold: DefaultParallelThreshold,
+			FFTThreshold:      DefaultFFTThreshold,
+		}
+
+		it := &IterativeCalculator{}
+		resultIT, err := it.CalculateCore(ctx, func(float64) {}, n, opts)
+		if err != nil {
+			t.Fatalf("Iterative failed for n=%d: %v", n, err)
+		}
+
+		fd := &OptimizedFastDoubling{}
+		resultFD, err := fd.CalculateCore(ctx, func(float64) {}, n, opts)
+		if err != nil {
+			t.Fatalf("FastDoubling failed for n=%d: %v", n, err)
+		}
+
+		if resultIT.Cmp(resultFD) != 0 {
+			t.Errorf("Inconsistent results for n=%d:\n  Iterative:    %s\n  FastDoubling: %s",
+				n, resultIT.String(), resultFD.String())
+		}
+	})
+}
+
 // FuzzFibonacciIdentities verifies mathematical identities of Fibonacci numbers.
 // These identities provide an independent verification of the implementation.
 func FuzzFibonacciIdentities(f *testing.F) {
@@ -20,12 +20,14 @@ func calcF(calc coreCalculator, n uint64) (*big.Int, error) {
 	return calc.CalculateCore(context.Background(), func(float64) {}, n, defaultTestOpts())
 }
 
-// allCalculators returns the three core calculator implementations.
+// allCalculators returns the three core calculator implementations and the
+// addition-only reference.
 func allCalculators() []coreCalculator {
 	return []coreCalculator{
 		&OptimizedFastDoubling{},
 		&MatrixExponentiation{},
 		&FFTBasedCalculator{},
+		&IterativeCalculator{},
 	}
 }
 
@@ -132,6 +134,53 @@ func TestRecurrenceRelation_PropertyBased(t *testing.T) {
 	properties.TestingRun(t)
 }
 
+// TestMatrixSquaringParallel_PropertyBased verifies that the parallel and
+// serial paths of squareSymmetricMatrix agree, and that squaring the
+// Fibonacci matrix Q^n = [[F(n+1), F(n)], [F(n), F(n-1)]] yields Q^2n.
+// The parallel path is forced directly, independently of the CPU count.
+func TestMatrixSquaringParallel_PropertyBased(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 50
+	properties := gopter.NewProperties(parameters)
+	fib := &OptimizedFastDoubling{}
+	fftThreshold := defaultTestOpts().FFTThreshold
+
+	properties.Property("parallel matrix squaring matches serial squaring", prop.ForAll(
+		func(n uint64) bool {
+			fnPlus1, err1 := calcF(fib, n+1)
+			fn, err2 := calcF(fib, n)
+			fnMinus1, err3 := calcF(fib, n-1)
+			if err1 != nil || err2 != nil || err3 != nil {
+				return false
+			}
+			qn := &matrix{a: fnPlus1, b: fn, c: new(big.Int).Set(fn), d: fnMinus1}
+
+			results := [2]*matrix{newMatrix(), newMatrix()}
+			for i, inParallel := range []bool{false, true} {
+				state := acquireMatrixState()
+				err := squareSymmetricMatrix(results[i], qn, state, inParallel, fftThreshold)
+				releaseMatrixState(state)
+				if err != nil {
+					t.Logf("squaring Q^%d (parallel=%v) failed: %v", n, inParallel, err)
+					return false
+				}
+			}
+
+			f2n, err := calcF(fib, 2*n)
+			if err != nil {
+				return false
+			}
+			serial, par := results[0], results[1]
+			return serial.a.Cmp(par.a) == 0 && serial.b.Cmp(par.b) == 0 &&
+				serial.c.Cmp(par.c) == 0 && serial.d.Cmp(par.d) == 0 &&
+				par.b.Cmp(f2n) == 0 && par.c.Cmp(f2n) == 0
+		},
+		gen.UInt64Range(1, 25000),
+	))
+
+	properties.TestingRun(t)
+}
+
 // TestDoublingIdentity_PropertyBased verifies the doubling identity:
 //
 //	F(2n) = F(n) * (2*F(n+1) - F(n))
@@ -243,3 +292,94 @@ func gcdUint64(a, b uint64) uint64 {
 	}
 	return a
 }
+
+// TestMatrixFibMod_PropertyBased verifies that the modular matrix
+// exponentiation agrees with the modular fast doubling for random indices
+// and moduli, including m = 1, and that the result lies in [0, m).
+func TestMatrixFibMod_PropertyBased(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 200
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("MatrixFibMod(n, m) == FastDoublingMod(n, m)", prop.ForAll(
+		func(n uint64, mod uint64) bool {
+			m := new(big.Int).SetUint64(mod)
+			got, err := MatrixFibMod(n, m)
+			if err != nil {
+				t.Logf("MatrixFibMod(%d, %d): %v", n, mod, err)
+				return false
+			}
+			want, err := FastDoublingMod(n, m)
+			if err != nil {
+				t.Logf("FastDoublingMod(%d, %d): %v", n, mod, err)
+				return false
+			}
+			return got.Cmp(want) == 0 && got.Sign() >= 0 && got.Cmp(m) < 0
+		},
+		gen.UInt64(),
+		gen.UInt64Range(1, 1<<62),
+	))
+
+	properties.Property("MatrixFibMod(n, 1) == FastDoublingMod(n, 1) == 0", prop.ForAll(
+		func(n uint64) bool {
+			one := big.NewInt(1)
+			got, err1 := MatrixFibMod(n, one)
+			want, err2 := FastDoublingMod(n, one)
+			return err1 == nil && err2 == nil && got.Sign() == 0 && want.Sign() == 0
+		},
+		gen.UInt64(),
+	))
+
+	properties.TestingRun(t)
+}
+
+// TestNegafibonacci_KnownValues checks FibonacciSigned against known values
+// of the sequence extended to negative indices.
+func TestNegafibonacci_KnownValues(t *testing.T) {
+	t.Parallel()
+	known := map[int64]int64{
+		0: 0, 1: 1, 2: 1, 6: 8,
+		-1: 1, -2: -1, -3: 2, -4: -3, -5: 5, -6: -8, -10: -55,
+	}
+	for n, want := range known {
+		got, err := FibonacciSigned(context.Background(), n, defaultTestOpts())
+		if err != nil {
+			t.Fatalf("FibonacciSigned(%d) failed: %v", n, err)
+		}
+		if got.Int64() != want {
+			t.Errorf("FibonacciSigned(%d) = %s, want %d", n, got, want)
+		}
+	}
+}
+
+// TestNegafibonacci_PropertyBased verifies the sign rule
+//
+//	F(-n) = (-1)^(n+1) F(n)
+//
+// over a range of random n.
+func TestNegafibonacci_PropertyBased(t *testing.T) {
+	parameters := gopter.DefaultTestParameters()
+	parameters.MinSuccessfulTests = 100
+	properties := gopter.NewProperties(parameters)
+
+	properties.Property("F(-n) = (-1)^(n+1) F(n)", prop.ForAll(
+		func(n int64) bool {
+			ctx := context.Background()
+			negative, err := FibonacciSigned(ctx, -n, defaultTestOpts())
+			if err != nil {
+				return false
+			}
+			positive, err := FibonacciSigned(ctx, n, defaultTestOpts())
+			if err != nil {
+				return false
+			}
+			if n%2 == 0 {
+				positive.Neg(positive)
+			}
+			return negative.Cmp(positive) == 0
+		},
+		gen.Int64Range(0, 25000),
+	))
+
+	properties.TestingRun(t)
+}
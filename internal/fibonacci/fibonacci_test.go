@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"sync"
 	"testing"
@@ -211,6 +212,30 @@ func BenchmarkFibonacci(b *testing.B) {
 	}
 }
 
+// BenchmarkMatrixExponentiationParallel compares the serial and parallel
+// matrix squaring paths at F(5,000,000). A ParallelThreshold above any
+// operand size keeps every step serial.
+func BenchmarkMatrixExponentiationParallel(b *testing.B) {
+	const n = 5_000_000
+	calc := NewCalculator(&MatrixExponentiation{})
+	for _, bm := range []struct {
+		name string
+		opts Options
+	}{
+		{"Serial", Options{ParallelThreshold: math.MaxInt}},
+		{"Parallel", Options{ParallelThreshold: DefaultParallelThreshold}},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			ctx := context.Background()
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				_, _ = calc.Calculate(ctx, nil, 0, n, bm.opts)
+			}
+		})
+	}
+}
+
 // ExampleCalculator_Calculate illustrates the basic use of a Calculator
 // to calculate a Fibonacci number.
 func ExampleCalculator_Calculate() {
@@ -0,0 +1,93 @@
+package fibonacci
+
+import "fmt"
+
+// MaxFibonacciWordPrefix is the largest prefix FibonacciWordPrefix will
+// materialize (one byte per symbol).
+const MaxFibonacciWordPrefix = 1 << 30
+
+// maxFibonacciWordIndex is the largest i such that F(i) fits in a uint64.
+const maxFibonacciWordIndex = 93
+
+// FibonacciWordPrefix returns the first k symbols of the infinite Fibonacci
+// word, the fixed point of the morphism 0 -> 01, 1 -> 0:
+//
+//	0100101001001010010100100101001001...
+//
+// The word is built from its finite approximations S(1) = "0", S(2) = "01"
+// and S(i) = S(i-1) S(i-2), whose lengths are the Fibonacci numbers
+// |S(i)| = F(i+1). Since S(i-2) is a prefix of S(i-1), each step only appends
+// a Fibonacci-sized copy of the beginning of the buffer.
+//
+// Parameters:
+//   - k: The number of symbols to return.
+//
+// Returns:
+//   - []byte: The prefix as ASCII '0'/'1' symbols.
+//   - error: An error if k exceeds MaxFibonacciWordPrefix.
+func FibonacciWordPrefix(k uint64) ([]byte, error) {
+	if k > MaxFibonacciWordPrefix {
+		return nil, fmt.Errorf("fibonacci word prefix length %d exceeds the maximum of %d", k, uint64(MaxFibonacciWordPrefix))
+	}
+	// The last step overshoots k by less than k/φ symbols.
+	word := make([]byte, 0, k+k*5/8+2)
+	word = append(word, '0', '1')
+	prevLen := 1 // |S(i-1)|
+	for uint64(len(word)) < k {
+		curLen := len(word)
+		word = append(word, word[:prevLen]...)
+		prevLen = curLen
+	}
+	return word[:k], nil
+}
+
+// FibonacciWordOnes counts the 1s among the first k symbols of the Fibonacci
+// word without materializing it.
+//
+// The prefix is decomposed over the approximations S(i) = S(i-1) S(i-2): a
+// prefix longer than |S(i-1)| = F(i) contains all of S(i-1), whose number of
+// 1s is F(i-2), followed by a prefix of S(i-2). This takes O(log k) steps.
+//
+// Parameters:
+//   - k: The length of the prefix.
+//
+// Returns:
+//   - uint64: The number of 1s in the prefix.
+//   - error: An error if k exceeds F(93), the largest Fibonacci number that
+//     fits in a uint64.
+func FibonacciWordOnes(k uint64) (uint64, error) {
+	// fib[i] = F(i); |S(i)| = fib[i+1] and S(i) contains fib[i-1] ones.
+	var fib [maxFibonacciWordIndex + 1]uint64
+	fib[1] = 1
+	for i := 2; i <= maxFibonacciWordIndex; i++ {
+		fib[i] = fib[i-1] + fib[i-2]
+	}
+	if k > fib[maxFibonacciWordIndex] {
+		return 0, fmt.Errorf("fibonacci word prefix length %d exceeds the maximum of %d", k, fib[maxFibonacciWordIndex])
+	}
+
+	i := 2
+	for fib[i+1] < k {
+		i++
+	}
+
+	var ones uint64
+	remaining := k
+	for remaining > 0 {
+		if i <= 2 {
+			// S(2) = "01", S(1) = "0": only the second symbol is a 1.
+			if i == 2 && remaining == 2 {
+				ones++
+			}
+			break
+		}
+		if remaining <= fib[i] {
+			i--
+			continue
+		}
+		ones += fib[i-2]
+		remaining -= fib[i]
+		i -= 2
+	}
+	return ones, nil
+}
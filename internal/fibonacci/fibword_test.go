@@ -0,0 +1,76 @@
+package fibonacci
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// fibonacciWordByMorphism applies 0 -> 01, 1 -> 0 until the word has at least k symbols.
+func fibonacciWordByMorphism(k int) string {
+	word := "0"
+	for len(word) < k {
+		var sb strings.Builder
+		for _, c := range word {
+			if c == '0' {
+				sb.WriteString("01")
+			} else {
+				sb.WriteString("0")
+			}
+		}
+		word = sb.String()
+	}
+	return word[:k]
+}
+
+func TestFibonacciWordPrefix(t *testing.T) {
+	t.Parallel()
+	const known = "0100101001001010010100100101001001"
+	got, err := FibonacciWordPrefix(uint64(len(known)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != known {
+		t.Errorf("FibonacciWordPrefix(%d) = %s, want %s", len(known), got, known)
+	}
+
+	for k := 0; k <= 300; k++ {
+		got, err := FibonacciWordPrefix(uint64(k))
+		if err != nil {
+			t.Fatalf("k=%d: unexpected error: %v", k, err)
+		}
+		if want := fibonacciWordByMorphism(k); string(got) != want {
+			t.Fatalf("FibonacciWordPrefix(%d) = %s, want %s", k, got, want)
+		}
+	}
+
+	if _, err := FibonacciWordPrefix(MaxFibonacciWordPrefix + 1); err == nil {
+		t.Error("expected an error above MaxFibonacciWordPrefix")
+	}
+}
+
+func TestFibonacciWordOnes(t *testing.T) {
+	t.Parallel()
+	word := fibonacciWordByMorphism(2000)
+	for k := 0; k <= len(word); k++ {
+		got, err := FibonacciWordOnes(uint64(k))
+		if err != nil {
+			t.Fatalf("k=%d: unexpected error: %v", k, err)
+		}
+		if want := uint64(bytes.Count([]byte(word[:k]), []byte("1"))); got != want {
+			t.Fatalf("FibonacciWordOnes(%d) = %d, want %d", k, got, want)
+		}
+	}
+
+	// S(i) has F(i+1) symbols of which F(i-1) are 1s: F(93) symbols hold F(91) ones.
+	got, err := FibonacciWordOnes(12200160415121876738)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got != 4660046610375530309 {
+		t.Errorf("FibonacciWordOnes(F(93)) = %d, want F(91) = 4660046610375530309", got)
+	}
+	if _, err := FibonacciWordOnes(12200160415121876739); err == nil {
+		t.Error("expected an error above F(93)")
+	}
+}
@@ -0,0 +1,150 @@
+package fibonacci
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Directories, relative to the module root, of the packages holding the
+// fuzz targets seeded by FuzzSeedCorpus.
+const (
+	fibonacciFuzzPackage = "internal/fibonacci"
+	bigfftFuzzPackage    = "internal/bigfft"
+)
+
+// FuzzCorpusEntry is one seed input for a fuzz target of this module.
+type FuzzCorpusEntry struct {
+	// Package is the directory of the package holding the target, relative
+	// to the module root (e.g. "internal/fibonacci").
+	Package string
+	// Target is the name of the fuzz function (e.g. "FuzzFastDoublingConsistency").
+	Target string
+	// Args are the fuzz arguments, in the order of the target's parameters.
+	Args []any
+}
+
+// fuzzEdgeIndices returns indices n at which the calculators change
+// behaviour: the uint64 boundary of F(n), word boundaries, powers of two
+// (longest and shortest doubling paths) and the crossing of the default
+// parallel threshold. Values above limit are dropped.
+func fuzzEdgeIndices(limit uint64) []uint64 {
+	// F(n) has about n*FibonacciGrowthFactor bits.
+	threshold := float64(DefaultParallelThreshold)
+	parallelCrossing := uint64(threshold/FibonacciGrowthFactor) + 1
+	candidates := []uint64{
+		0, 1, 2, 3,
+		92, 93, 94, // F(93) is the largest Fibonacci number that fits in a uint64
+		184, 185, 186, // F(n) crosses two 64-bit words
+		255, 256, 257,
+		1023, 1024, 1025,
+		4095, 4096, 4097,
+		parallelCrossing - 1, parallelCrossing, parallelCrossing + 1,
+		8191, 8192, 8193,
+		limit,
+	}
+	indices := make([]uint64, 0, len(candidates))
+	seen := make(map[uint64]bool, len(candidates))
+	for _, n := range candidates {
+		if n <= limit && !seen[n] {
+			seen[n] = true
+			indices = append(indices, n)
+		}
+	}
+	return indices
+}
+
+// FuzzSeedCorpus returns interesting inputs for the fuzz targets of this
+// package and of bigfft: edge-case indices and sizes known to exercise
+// boundaries of the algorithms, restricted to the input range each target
+// accepts.
+//
+// Returns:
+//   - []FuzzCorpusEntry: The seed inputs, grouped by target.
+func FuzzSeedCorpus() []FuzzCorpusEntry {
+	var entries []FuzzCorpusEntry
+	add := func(pkg, target string, args ...any) {
+		entries = append(entries, FuzzCorpusEntry{Package: pkg, Target: target, Args: args})
+	}
+	single := func(target string, limit uint64) {
+		for _, n := range fuzzEdgeIndices(limit) {
+			add(fibonacciFuzzPackage, target, n)
+		}
+	}
+	single("FuzzFastDoublingConsistency", 50_000)
+	single("FuzzFFTBasedConsistency", 20_000)
+	single("FuzzIterativeConsistency", 20_000)
+	single("FuzzProgressMonotonicity", 20_000)
+
+	for _, n := range fuzzEdgeIndices(10_000) {
+		if n < 2 {
+			continue
+		}
+		for _, m := range []uint64{1, n / 2, n - 1, n} {
+			add(fibonacciFuzzPackage, "FuzzFibonacciIdentities", n, m)
+		}
+	}
+
+	for _, n := range fuzzEdgeIndices(100_000) {
+		for _, mod := range []int64{1, 2, 10, 1_000_000_000} {
+			add(fibonacciFuzzPackage, "FuzzFastDoublingMod", n, mod)
+		}
+	}
+
+	// bigfft.FuzzDivMod takes a random seed (odd seeds negate the dividend),
+	// the divisor and quotient sizes in words and the Newton threshold: sizes
+	// at and just above the threshold select each division path.
+	for _, threshold := range []uint8{4, 16} {
+		for _, ywords := range []uint8{threshold, threshold + 1, 2*threshold + 1} {
+			for _, qwords := range []uint8{threshold, threshold + 1, 2*threshold + 1} {
+				for _, seed := range []int64{1, 2} {
+					add(bigfftFuzzPackage, "FuzzDivMod", seed, ywords, qwords, threshold)
+				}
+			}
+		}
+	}
+	return entries
+}
+
+// WriteFuzzCorpus writes entries in the standard Go fuzzing layout,
+// root/<Package>/testdata/fuzz/<Target>/<hash>, using the "go test fuzz v1"
+// encoding. Pointing root at the module root makes `go test` run these
+// inputs as seeds and `go test -fuzz` start from them.
+//
+// Parameters:
+//   - root: The module root directory.
+//   - entries: The inputs to write.
+//
+// Returns:
+//   - int: The number of corpus files written (duplicate entries are written once).
+//   - error: An error if a directory or file cannot be created.
+func WriteFuzzCorpus(root string, entries []FuzzCorpusEntry) (int, error) {
+	written := 0
+	seen := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		var sb strings.Builder
+		sb.WriteString("go test fuzz v1\n")
+		for _, arg := range entry.Args {
+			fmt.Fprintf(&sb, "%T(%v)\n", arg, arg)
+		}
+		data := []byte(sb.String())
+
+		targetDir := filepath.Join(root, filepath.FromSlash(entry.Package), "testdata", "fuzz", entry.Target)
+		if err := os.MkdirAll(targetDir, 0755); err != nil {
+			return written, fmt.Errorf("failed to create corpus directory: %w", err)
+		}
+		name := fmt.Sprintf("%x", sha256.Sum256(data))[:16]
+		path := filepath.Join(targetDir, name)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		if err := os.WriteFile(path, data, 0644); err != nil {
+			return written, fmt.Errorf("failed to write corpus file: %w", err)
+		}
+		written++
+	}
+	return written, nil
+}
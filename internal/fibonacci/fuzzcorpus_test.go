@@ -0,0 +1,86 @@
+package fibonacci
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestWriteFuzzCorpus(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	entries := FuzzSeedCorpus()
+
+	written, err := WriteFuzzCorpus(dir, entries)
+	if err != nil {
+		t.Fatalf("WriteFuzzCorpus failed: %v", err)
+	}
+
+	unique := make(map[string]bool)
+	targets := make(map[string]bool)
+	for _, e := range entries {
+		unique[fmt.Sprintf("%s/%s%#v", e.Package, e.Target, e.Args)] = true
+		targets[filepath.Join(filepath.FromSlash(e.Package), "testdata", "fuzz", e.Target)] = true
+	}
+	if written != len(unique) {
+		t.Errorf("WriteFuzzCorpus wrote %d files, want %d unique entries", written, len(unique))
+	}
+
+	files := 0
+	for target := range targets {
+		dirEntries, err := os.ReadDir(filepath.Join(dir, target))
+		if err != nil {
+			t.Fatalf("missing corpus directory for %s: %v", target, err)
+		}
+		for _, de := range dirEntries {
+			data, err := os.ReadFile(filepath.Join(dir, target, de.Name()))
+			if err != nil {
+				t.Fatalf("failed to read corpus file: %v", err)
+			}
+			if !strings.HasPrefix(string(data), "go test fuzz v1\n") {
+				t.Errorf("%s/%s: unexpected encoding %q", target, de.Name(), data)
+			}
+			files++
+		}
+	}
+	if files != written {
+		t.Errorf("found %d corpus files on disk, want %d", files, written)
+	}
+	if len(targets) != 7 {
+		t.Errorf("corpus covers %d fuzz targets, want 7", len(targets))
+	}
+}
+
+// TestFuzzSeedCorpusCoversTargets checks that every fuzz target of the
+// fibonacci and bigfft packages is seeded, by scanning their test files.
+func TestFuzzSeedCorpusCoversTargets(t *testing.T) {
+	t.Parallel()
+	seeded := make(map[string]bool)
+	for _, e := range FuzzSeedCorpus() {
+		seeded[e.Package+"/"+e.Target] = true
+	}
+	fuzzFunc := regexp.MustCompile(`(?m)^func (Fuzz\w+)\(f \*testing\.F\)`)
+	for _, pkg := range []struct{ name, dir string }{
+		{fibonacciFuzzPackage, "."},
+		{bigfftFuzzPackage, "../bigfft"},
+	} {
+		files, err := filepath.Glob(filepath.Join(pkg.dir, "*_test.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, m := range fuzzFunc.FindAllStringSubmatch(string(data), -1) {
+				if !seeded[pkg.name+"/"+m[1]] {
+					t.Errorf("fuzz target %s/%s has no seed in FuzzSeedCorpus", pkg.name, m[1])
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"runtime"
+)
+
+// GeneralizedFibonacci computes the n-th term of the sequence with arbitrary
+// seeds G(0) = a, G(1) = b and G(n) = G(n-1) + G(n-2), using the identity
+//
+//	G(n) = b·F(n) + a·F(n-1)
+//
+// A single Fast Doubling pass to n-1 yields both F(n-1) and F(n), so the cost
+// is that of one F(n) calculation plus two multiplications by the seeds.
+// With a = 0, b = 1 this is F(n); with a = 2, b = 1 it is the Lucas number L(n).
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - a: The seed G(0). It may be negative.
+//   - b: The seed G(1). It may be negative.
+//   - n: The index of the term to calculate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The calculated term G(n).
+//   - error: An error if a seed is nil or the calculation fails (e.g.,
+//     context cancellation).
+func GeneralizedFibonacci(ctx context.Context, a, b *big.Int, n uint64, opts Options) (*big.Int, error) {
+	if a == nil || b == nil {
+		return nil, errors.New("generalized fibonacci seeds must not be nil")
+	}
+	if n == 0 {
+		return new(big.Int).Set(a), nil
+	}
+
+	s := AcquireState()
+	defer ReleaseState(s)
+
+	normalizedOpts := normalizeOptions(opts)
+	useParallel := runtime.GOMAXPROCS(0) > 1 && normalizedOpts.ParallelThreshold > 0
+	framework := NewDoublingFramework(&AdaptiveStrategy{})
+	fPrev, err := framework.ExecuteDoublingLoop(ctx, func(float64) {}, n-1, normalizedOpts, s, useParallel)
+	if err != nil {
+		return nil, err
+	}
+	// The loop leaves F(n) in s.FK1.
+	result := new(big.Int).Mul(b, s.FK1)
+	fPrev.Mul(a, fPrev)
+	return result.Add(result, fPrev), nil
+}
@@ -0,0 +1,76 @@
+package fibonacci
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+// generalizedByIteration computes G(n) for seeds a, b by repeated addition.
+func generalizedByIteration(a, b int64, n uint64) *big.Int {
+	x, y := big.NewInt(a), big.NewInt(b)
+	for i := uint64(0); i < n; i++ {
+		x.Add(x, y)
+		x, y = y, x
+	}
+	return x
+}
+
+func TestGeneralizedFibonacciClassicSeeds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	for _, n := range []uint64{0, 1, 2, 10, 93, 94, 1000, 10_000} {
+		got, err := GeneralizedFibonacci(ctx, big.NewInt(0), big.NewInt(1), n, Options{})
+		if err != nil {
+			t.Fatalf("G(%d): unexpected error: %v", n, err)
+		}
+		want, err := calc.Calculate(ctx, nil, 0, n, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Errorf("G(%d) with seeds (0, 1) != F(%d)", n, n)
+		}
+	}
+}
+
+func TestGeneralizedFibonacciLucasSeeds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	lucas := NewCalculator(&LucasCalculator{})
+	for _, n := range []uint64{0, 1, 2, 5, 10, 93, 94, 1000, 10_000} {
+		got, err := GeneralizedFibonacci(ctx, big.NewInt(2), big.NewInt(1), n, Options{})
+		if err != nil {
+			t.Fatalf("G(%d): unexpected error: %v", n, err)
+		}
+		want, err := lucas.Calculate(ctx, nil, 0, n, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Errorf("G(%d) with seeds (2, 1) = %s, want L(%d) = %s", n, got, n, want)
+		}
+	}
+}
+
+func TestGeneralizedFibonacciArbitrarySeeds(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	seeds := [][2]int64{{3, 7}, {-5, 2}, {1, -1}, {0, 0}}
+	for _, seed := range seeds {
+		for n := uint64(0); n <= 200; n += 13 {
+			got, err := GeneralizedFibonacci(ctx, big.NewInt(seed[0]), big.NewInt(seed[1]), n, Options{})
+			if err != nil {
+				t.Fatalf("seeds %v, n=%d: unexpected error: %v", seed, n, err)
+			}
+			if want := generalizedByIteration(seed[0], seed[1], n); got.Cmp(want) != 0 {
+				t.Errorf("seeds %v: G(%d) = %s, want %s", seed, n, got, want)
+			}
+		}
+	}
+
+	if _, err := GeneralizedFibonacci(ctx, nil, big.NewInt(1), 5, Options{}); err == nil {
+		t.Error("expected an error for a nil seed")
+	}
+}
@@ -4,6 +4,7 @@ package fibonacci
 
 import (
 	"context"
+	"iter"
 	"math/big"
 	"sync"
 )
@@ -241,3 +242,31 @@ func (g *IterativeGenerator) Skip(ctx context.Context, n uint64) (*big.Int, erro
 
 // compile-time interface check
 var _ SequenceGenerator = (*IterativeGenerator)(nil)
+
+// SequenceTo returns an iterator over F(0), F(1), ..., F(n), computed by
+// addition with two rolling big.Ints so each term costs O(k) for k digits.
+//
+// The yielded value is a buffer owned by the iterator and is overwritten by
+// the next term; callers that keep a term must copy it. Iteration stops early
+// when ctx is canceled; callers can check ctx.Err() to tell cancellation apart
+// from completion.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation, checked between terms.
+//   - n: The index of the last term to yield.
+//
+// Returns:
+//   - iter.Seq[*big.Int]: The sequence F(0)..F(n).
+func SequenceTo(ctx context.Context, n uint64) iter.Seq[*big.Int] {
+	return func(yield func(*big.Int) bool) {
+		cur, next := new(big.Int), big.NewInt(1)
+		for i := uint64(0); ; i++ {
+			if ctx.Err() != nil || !yield(cur) || i == n {
+				return
+			}
+			// (cur, next) <- (next, cur+next), reusing cur's buffer.
+			cur.Add(cur, next)
+			cur, next = next, cur
+		}
+	}
+}
@@ -331,3 +331,46 @@ func TestIterativeGenerator_Timeout(t *testing.T) {
 		t.Errorf("Next() with expired timeout: got %v, want context.DeadlineExceeded", err)
 	}
 }
+
+func TestSequenceTo(t *testing.T) {
+	t.Parallel()
+
+	want := []int64{0, 1, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 233, 377, 610, 987, 1597, 2584, 4181}
+	var got []int64
+	for v := range SequenceTo(context.Background(), 19) {
+		got = append(got, v.Int64())
+	}
+	if len(got) != len(want) {
+		t.Fatalf("SequenceTo(19) yielded %d terms, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("term %d = %d, want %d", i, got[i], want[i])
+		}
+	}
+
+	count := 0
+	for range SequenceTo(context.Background(), 0) {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("SequenceTo(0) yielded %d terms, want 1", count)
+	}
+}
+
+func TestSequenceTo_Cancellation(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	count := 0
+	for range SequenceTo(ctx, 1000) {
+		count++
+		if count == 5 {
+			cancel()
+		}
+	}
+	if count != 5 {
+		t.Errorf("SequenceTo yielded %d terms after cancellation at 5", count)
+	}
+}
@@ -0,0 +1,93 @@
+package fibonacci
+
+import (
+	"context"
+	"math"
+	"math/big"
+)
+
+// IsFibonacci reports whether x is a Fibonacci number, using the classic
+// characterization: x is a Fibonacci number if and only if 5x²+4 or 5x²−4 is
+// a perfect square. Negative numbers are never Fibonacci numbers here (the
+// negafibonacci values of FibonacciSigned are not considered).
+//
+// Parameters:
+//   - x: The number to test.
+//
+// Returns:
+//   - bool: true if x = F(n) for some n >= 0.
+func IsFibonacci(x *big.Int) bool {
+	if x == nil || x.Sign() < 0 {
+		return false
+	}
+	t := new(big.Int).Mul(x, x)
+	t.Mul(t, big.NewInt(5))
+	four := big.NewInt(4)
+	return isPerfectSquare(new(big.Int).Add(t, four)) ||
+		(t.Cmp(four) >= 0 && isPerfectSquare(t.Sub(t, four)))
+}
+
+// isPerfectSquare reports whether the non-negative v is a perfect square.
+func isPerfectSquare(v *big.Int) bool {
+	r := new(big.Int).Sqrt(v)
+	return r.Mul(r, r).Cmp(v) == 0
+}
+
+// FibonacciIndex returns n such that F(n) = x.
+//
+// The index is estimated with Binet's formula, n ≈ log_φ(x·√5), and confirmed
+// by walking the few Fibonacci numbers around the estimate: F(lo) and F(lo+1)
+// are evaluated once with Fast Doubling, and each following value is derived
+// from the two before it. Since F(1) = F(2) = 1, the smallest index, 1, is
+// returned for x = 1.
+//
+// Parameters:
+//   - x: The number to look up.
+//
+// Returns:
+//   - uint64: The index n of x, if found.
+//   - bool: false if x is not a Fibonacci number (including negative x).
+func FibonacciIndex(x *big.Int) (uint64, bool) {
+	if !IsFibonacci(x) {
+		return 0, false
+	}
+	if x.Cmp(big.NewInt(1)) <= 0 {
+		return x.Uint64(), true
+	}
+
+	// Binet: F(n) ≈ φ^n / √5, so n ≈ (log2(x) + log2(√5)) / log2(φ).
+	estimate := uint64(math.Round((log2Big(x) + math.Log2(math.Sqrt(5))) / FibonacciGrowthFactor))
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	lo := uint64(2)
+	if estimate > lo+2 {
+		lo = estimate - 2
+	}
+	f, err := calc.Calculate(context.Background(), nil, 0, lo, Options{})
+	if err != nil {
+		return 0, false
+	}
+	next, err := calc.Calculate(context.Background(), nil, 0, lo+1, Options{})
+	if err != nil {
+		return 0, false
+	}
+	for n := lo; n <= estimate+2; n++ {
+		switch f.Cmp(x) {
+		case 0:
+			return n, true
+		case 1:
+			return 0, false
+		}
+		f, next = next, f.Add(f, next)
+	}
+	return 0, false
+}
+
+// log2Big returns log2(x) for a positive x of any size.
+func log2Big(x *big.Int) float64 {
+	bitLen := x.BitLen()
+	if bitLen <= 64 {
+		return math.Log2(float64(x.Uint64()))
+	}
+	top := new(big.Int).Rsh(x, uint(bitLen-64))
+	return math.Log2(float64(top.Uint64())) + float64(bitLen-64)
+}
@@ -0,0 +1,61 @@
+package fibonacci
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestIsFibonacci(t *testing.T) {
+	t.Parallel()
+	fib := []int64{0, 1, 2, 3, 5, 8, 13, 21, 34, 55, 89, 144, 7540113804746346429}
+	for _, v := range fib {
+		if !IsFibonacci(big.NewInt(v)) {
+			t.Errorf("IsFibonacci(%d) = false, want true", v)
+		}
+	}
+	notFib := []int64{-1, -8, 4, 6, 7, 9, 10, 12, 14, 54, 56, 7540113804746346428}
+	for _, v := range notFib {
+		if IsFibonacci(big.NewInt(v)) {
+			t.Errorf("IsFibonacci(%d) = true, want false", v)
+		}
+	}
+	if IsFibonacci(nil) {
+		t.Error("IsFibonacci(nil) = true, want false")
+	}
+}
+
+func TestFibonacciIndex(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	for _, n := range []uint64{0, 1, 3, 4, 5, 10, 92, 93, 94, 100, 1000, 4096, 25_000} {
+		f, err := calc.Calculate(context.Background(), nil, 0, n, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, ok := FibonacciIndex(f)
+		if !ok || got != n {
+			t.Errorf("FibonacciIndex(F(%d)) = %d, %v; want %d, true", n, got, ok, n)
+		}
+		if !IsFibonacci(f) {
+			t.Errorf("IsFibonacci(F(%d)) = false", n)
+		}
+		// Neighbours of a large Fibonacci number are not Fibonacci numbers.
+		if n > 5 {
+			for _, d := range []int64{-1, 1} {
+				neighbour := new(big.Int).Add(f, big.NewInt(d))
+				if _, ok := FibonacciIndex(neighbour); ok {
+					t.Errorf("FibonacciIndex(F(%d)%+d) reported a Fibonacci number", n, d)
+				}
+			}
+		}
+	}
+
+	// F(1) = F(2) = 1: the smallest index is returned.
+	if got, ok := FibonacciIndex(big.NewInt(1)); !ok || got != 1 {
+		t.Errorf("FibonacciIndex(1) = %d, %v; want 1, true", got, ok)
+	}
+	if _, ok := FibonacciIndex(big.NewInt(-5)); ok {
+		t.Error("FibonacciIndex(-5) should not find an index")
+	}
+}
@@ -0,0 +1,82 @@
+package fibonacci
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// IterativeAlgorithm is the registry name of the addition-only calculator.
+const IterativeAlgorithm = "iterative"
+
+// MaxIterativeN is the largest index accepted by IterativeCalculator. The
+// addition loop is O(n²) in bit operations, so it is kept to small indices.
+const MaxIterativeN = 100_000
+
+// iterativeCheckInterval is the number of additions between two
+// cancellation checks and progress reports.
+const iterativeCheckInterval = 4096
+
+// IterativeCalculator computes F(n) with the textbook loop
+// F(i+1) = F(i) + F(i-1), using additions only. It is intentionally simple
+// and slow: it serves as an obviously-correct reference to cross-check the
+// doubling algorithms on small indices, where their bugs are most subtle.
+// It only holds two values of at most the size of the result.
+//
+// Indices above MaxIterativeN are rejected, and the algorithm is left out
+// of "--algo all" comparisons (see ExcludedFromAll).
+type IterativeCalculator struct{}
+
+// Name returns the descriptive name of the algorithm.
+//
+// Returns:
+//   - string: The name of the algorithm.
+func (c *IterativeCalculator) Name() string {
+	return "Iterative (O(n), Addition Only)"
+}
+
+// CalculateCore computes F(n) by repeated addition.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - reporter: The function used for reporting progress.
+//   - n: The index of the Fibonacci number to calculate.
+//   - opts: Configuration options for the calculation (unused).
+//
+// Returns:
+//   - *big.Int: The calculated Fibonacci number F(n).
+//   - error: An apperrors.ValidationError if n exceeds MaxIterativeN, or an
+//     error if the context is canceled.
+func (c *IterativeCalculator) CalculateCore(ctx context.Context, reporter ProgressCallback, n uint64, _ Options) (*big.Int, error) {
+	if n > MaxIterativeN {
+		return nil, apperrors.ValidationError{
+			Field:   "n",
+			Message: fmt.Sprintf("the iterative algorithm is limited to n <= %d, got %d", MaxIterativeN, n),
+		}
+	}
+	if n == 0 {
+		return big.NewInt(0), nil
+	}
+
+	// The cost of an addition grows linearly with i, so the work done after
+	// i steps is proportional to i².
+	var lastReported float64
+	a, b := big.NewInt(0), big.NewInt(1)
+	for i := uint64(1); i < n; i++ {
+		if i%iterativeCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, fmt.Errorf("iterative calculation canceled at step %d/%d: %w", i, n, err)
+			}
+			ratio := float64(i) / float64(n)
+			if progress := ratio * ratio; progress-lastReported >= ProgressReportThreshold {
+				reporter(progress)
+				lastReported = progress
+			}
+		}
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return b, nil
+}
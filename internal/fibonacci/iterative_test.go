@@ -0,0 +1,90 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// TestIterativeCalculatorMatchesFastDoubling cross-checks the addition-only
+// reference against fast doubling for every n up to 5000.
+func TestIterativeCalculatorMatchesFastDoubling(t *testing.T) {
+	t.Parallel()
+	iterative := NewCalculator(&IterativeCalculator{})
+	fast := NewCalculator(&OptimizedFastDoubling{})
+	ctx := context.Background()
+
+	for n := uint64(0); n <= 5000; n++ {
+		got, err := iterative.Calculate(ctx, nil, 0, n, Options{})
+		if err != nil {
+			t.Fatalf("F(%d): unexpected error: %v", n, err)
+		}
+		want, err := fast.Calculate(ctx, nil, 0, n, Options{})
+		if err != nil {
+			t.Fatalf("F(%d): unexpected fast doubling error: %v", n, err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Fatalf("F(%d): iterative = %s, fast doubling = %s", n, got, want)
+		}
+	}
+}
+
+func TestIterativeCalculatorRejectsLargeN(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&IterativeCalculator{})
+	if _, err := calc.Calculate(context.Background(), nil, 0, MaxIterativeN, Options{}); err != nil {
+		t.Fatalf("F(%d): unexpected error: %v", MaxIterativeN, err)
+	}
+
+	_, err := calc.Calculate(context.Background(), nil, 0, MaxIterativeN+1, Options{})
+	var validationErr apperrors.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "n" {
+		t.Fatalf("F(%d): expected a ValidationError for n, got %v", MaxIterativeN+1, err)
+	}
+}
+
+// TestIterativeCalculatorProgress checks that progress is reported in
+// increasing order up to completion at the largest accepted index.
+func TestIterativeCalculatorProgress(t *testing.T) {
+	t.Parallel()
+	const n = MaxIterativeN
+	var reports []float64
+	got, err := (&IterativeCalculator{}).CalculateCore(context.Background(), func(p float64) { reports = append(reports, p) }, n, Options{})
+	if err != nil {
+		t.Fatalf("F(%d): unexpected error: %v", n, err)
+	}
+	want, err := NewCalculator(&OptimizedFastDoubling{}).Calculate(context.Background(), nil, 0, n, Options{})
+	if err != nil {
+		t.Fatalf("F(%d): unexpected fast doubling error: %v", n, err)
+	}
+	if got.Cmp(want) != 0 {
+		t.Fatalf("F(%d): iterative and fast doubling results differ", n)
+	}
+
+	if len(reports) == 0 {
+		t.Fatal("expected progress reports")
+	}
+	for i, p := range reports {
+		if p <= 0 || p >= 1 || (i > 0 && p <= reports[i-1]) {
+			t.Fatalf("progress reports are not increasing within (0, 1): %v", reports)
+		}
+	}
+}
+
+func TestIterativeCalculatorCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCalculator(&IterativeCalculator{}).Calculate(ctx, nil, 0, 10_000, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestIterativeExcludedFromAll(t *testing.T) {
+	t.Parallel()
+	if !ExcludedFromAll(IterativeAlgorithm) {
+		t.Error("the iterative reference must not be part of --algo all")
+	}
+}
@@ -0,0 +1,237 @@
+package fibonacci
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// KBonacciAlgorithm is the registry name of the k-bonacci calculator.
+const KBonacciAlgorithm = "kbonacci"
+
+const (
+	// DefaultKBonacciOrder is the order used when Options.KBonacciOrder is 0
+	// (the Tribonacci numbers).
+	DefaultKBonacciOrder = 3
+	// MaxKBonacciOrder is the largest accepted order. Each step costs k³
+	// multiplications, so very large orders are impractical.
+	MaxKBonacciOrder = 64
+)
+
+// KBonacciCalculator computes the k-bonacci numbers T(n), where each term is
+// the sum of the k previous ones:
+//
+//	T(0) = … = T(k-2) = 0, T(k-1) = 1
+//	T(n) = T(n-1) + T(n-2) + … + T(n-k)
+//
+// With k = 2 this is F(n); k = 3 gives the Tribonacci numbers, k = 4 the
+// Tetranacci numbers. The order is taken from Options.KBonacciOrder.
+//
+// The calculator generalizes matrix exponentiation to the k×k companion
+// matrix of the recurrence: the state vector
+// [T(n+k-1), …, T(n)] is the companion matrix raised to the n-th power
+// applied to [1, 0, …, 0]. The powers are built by repeated squaring, and
+// only the state vector is multiplied by the powers matching the set bits
+// of n, which costs k² rather than k³ multiplications.
+//
+// Since T(n) is not F(n) in general, this algorithm is left out of
+// "--algo all" comparisons (see ExcludedFromAll).
+type KBonacciCalculator struct{}
+
+// Name returns the descriptive name of the algorithm.
+//
+// Returns:
+//   - string: The name of the algorithm.
+func (c *KBonacciCalculator) Name() string {
+	return "K-bonacci (k×k Matrix Exponentiation)"
+}
+
+// kbonacciOrder returns the validated order configured in opts.
+func kbonacciOrder(opts Options) (int, error) {
+	k := opts.KBonacciOrder
+	if k == 0 {
+		k = DefaultKBonacciOrder
+	}
+	if k < 2 || k > MaxKBonacciOrder {
+		return 0, apperrors.ValidationError{
+			Field:   "kbonacci",
+			Message: fmt.Sprintf("the k-bonacci order must be between 2 and %d, got %d", MaxKBonacciOrder, k),
+		}
+	}
+	return k, nil
+}
+
+// calculateSmall returns T(n) for small n using iterative addition.
+func (c *KBonacciCalculator) calculateSmall(n uint64, opts Options) (*big.Int, error) {
+	k, err := kbonacciOrder(opts)
+	if err != nil {
+		return nil, err
+	}
+	if n < uint64(k-1) {
+		return big.NewInt(0), nil
+	}
+	// window holds the last k terms as a ring buffer (T(i) at index i mod k)
+	// and sum is their total.
+	window := make([]*big.Int, k)
+	for i := range window {
+		window[i] = new(big.Int)
+	}
+	window[k-1].SetInt64(1)
+	sum := big.NewInt(1)
+	for i := uint64(k); i <= n; i++ {
+		// The oldest term T(i-k) leaves the window and T(i) = sum enters.
+		slot := window[i%uint64(k)]
+		sum.Sub(sum, slot)
+		slot.Add(sum, slot)
+		sum.Add(sum, slot)
+	}
+	return new(big.Int).Set(window[n%uint64(k)]), nil
+}
+
+// CalculateCore computes T(n) by k×k matrix exponentiation.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - reporter: The function used for reporting progress.
+//   - n: The index of the k-bonacci number to calculate.
+//   - opts: Configuration options for the calculation, including the order.
+//
+// Returns:
+//   - *big.Int: The calculated k-bonacci number T(n).
+//   - error: An apperrors.ValidationError if the order is out of range, or an
+//     error if one occurred (e.g., context cancellation).
+func (c *KBonacciCalculator) CalculateCore(ctx context.Context, reporter ProgressCallback, n uint64, opts Options) (*big.Int, error) {
+	k, err := kbonacciOrder(opts)
+	if err != nil {
+		return nil, err
+	}
+	if n < uint64(k-1) {
+		return big.NewInt(0), nil
+	}
+
+	fftThreshold := normalizeOptions(opts).FFTThreshold
+	numBits := bits.Len64(n)
+	totalWork := CalcTotalWork(numBits)
+	powers := PrecomputePowers4(numBits)
+	workDone := 0.0
+	lastReportedProgress := -1.0
+
+	p := newCompanionMatrix(k)
+	tmp := newKMatrix(k)
+	vec := make([]*big.Int, k)
+	tmpVec := make([]*big.Int, k)
+	for i := range vec {
+		vec[i] = new(big.Int)
+		tmpVec[i] = new(big.Int)
+	}
+	vec[0].SetInt64(1)
+	prod := new(big.Int)
+
+	for i := 0; i < numBits; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("k-bonacci calculation canceled at bit %d/%d: %w", i, numBits-1, err)
+		}
+
+		if (n>>uint(i))&1 == 1 {
+			if err := p.mulVec(tmpVec, vec, prod, fftThreshold); err != nil {
+				return nil, fmt.Errorf("k-bonacci vector product failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			vec, tmpVec = tmpVec, vec
+		}
+
+		if i < numBits-1 {
+			if err := tmp.mul(p, p, prod, fftThreshold); err != nil {
+				return nil, fmt.Errorf("k-bonacci matrix squaring failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			p, tmp = tmp, p
+		}
+
+		// Bits are processed from LSB to MSB, so the step index is inverted
+		// as in the 2×2 matrix framework.
+		workDone = ReportStepProgress(reporter, &lastReportedProgress, totalWork, workDone, numBits-1-i, numBits, powers)
+	}
+	return vec[k-1], nil
+}
+
+// kMatrix is a k×k matrix of *big.Int values stored in row-major order.
+type kMatrix struct {
+	k int
+	e []*big.Int
+}
+
+// newKMatrix allocates a zero k×k matrix.
+func newKMatrix(k int) *kMatrix {
+	m := &kMatrix{k: k, e: make([]*big.Int, k*k)}
+	for i := range m.e {
+		m.e[i] = new(big.Int)
+	}
+	return m
+}
+
+// newCompanionMatrix returns the companion matrix of the k-bonacci
+// recurrence: a first row of ones and ones on the subdiagonal.
+//
+//	[ 1 1 … 1 1 ]
+//	[ 1 0 … 0 0 ]
+//	[ 0 1 … 0 0 ]
+//	[ …         ]
+//	[ 0 0 … 1 0 ]
+func newCompanionMatrix(k int) *kMatrix {
+	m := newKMatrix(k)
+	for j := 0; j < k; j++ {
+		m.at(0, j).SetInt64(1)
+	}
+	for i := 1; i < k; i++ {
+		m.at(i, i-1).SetInt64(1)
+	}
+	return m
+}
+
+// at returns the element at row i, column j.
+func (m *kMatrix) at(i, j int) *big.Int {
+	return m.e[i*m.k+j]
+}
+
+// mul sets m = a·b. The receiver must not alias a or b; prod is a scratch
+// value for the partial products.
+func (m *kMatrix) mul(a, b *kMatrix, prod *big.Int, fftThreshold int) error {
+	for i := 0; i < m.k; i++ {
+		for j := 0; j < m.k; j++ {
+			dst := m.at(i, j)
+			dst.SetInt64(0)
+			for l := 0; l < m.k; l++ {
+				x, y := a.at(i, l), b.at(l, j)
+				if x.Sign() == 0 || y.Sign() == 0 {
+					continue
+				}
+				if _, err := smartMultiply(prod, x, y, fftThreshold); err != nil {
+					return err
+				}
+				dst.Add(dst, prod)
+			}
+		}
+	}
+	return nil
+}
+
+// mulVec sets dst = m·v. dst must not alias v; prod is a scratch value for
+// the partial products.
+func (m *kMatrix) mulVec(dst, v []*big.Int, prod *big.Int, fftThreshold int) error {
+	for i := 0; i < m.k; i++ {
+		dst[i].SetInt64(0)
+		for l := 0; l < m.k; l++ {
+			x, y := m.at(i, l), v[l]
+			if x.Sign() == 0 || y.Sign() == 0 {
+				continue
+			}
+			if _, err := smartMultiply(prod, x, y, fftThreshold); err != nil {
+				return err
+			}
+			dst[i].Add(dst[i], prod)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// naiveKBonacci computes T(n) by summing the k previous terms at every step.
+func naiveKBonacci(k int, n uint64) *big.Int {
+	terms := make([]*big.Int, 0, n+1)
+	for i := uint64(0); i <= n; i++ {
+		switch {
+		case i+1 < uint64(k):
+			terms = append(terms, big.NewInt(0))
+		case i+1 == uint64(k):
+			terms = append(terms, big.NewInt(1))
+		default:
+			sum := new(big.Int)
+			for _, term := range terms[i-uint64(k) : i] {
+				sum.Add(sum, term)
+			}
+			terms = append(terms, sum)
+		}
+	}
+	return terms[n]
+}
+
+// TestTribonacciMatchesNaiveLoop cross-checks k = 3 against the naive loop,
+// through both the small-n path and the matrix exponentiation.
+func TestTribonacciMatchesNaiveLoop(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&KBonacciCalculator{})
+	core := &KBonacciCalculator{}
+	ctx := context.Background()
+	opts := Options{KBonacciOrder: 3}
+
+	for n := uint64(0); n <= 300; n++ {
+		want := naiveKBonacci(3, n)
+		got, err := calc.Calculate(ctx, nil, 0, n, opts)
+		if err != nil {
+			t.Fatalf("T(%d): unexpected error: %v", n, err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Fatalf("T(%d) = %s, want %s", n, got, want)
+		}
+		got, err = core.CalculateCore(ctx, func(float64) {}, n, opts)
+		if err != nil {
+			t.Fatalf("T(%d): unexpected core error: %v", n, err)
+		}
+		if got.Cmp(want) != 0 {
+			t.Fatalf("T(%d) by matrix exponentiation = %s, want %s", n, got, want)
+		}
+	}
+}
+
+func TestKBonacciKnownValues(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&KBonacciCalculator{})
+	tests := []struct {
+		k    int
+		n    uint64
+		want string
+	}{
+		{0, 20, "35890"}, // default order: Tribonacci
+		{3, 20, "35890"},
+		{4, 20, "39648"},
+		{5, 10, "31"},
+	}
+	for _, tt := range tests {
+		got, err := calc.Calculate(context.Background(), nil, 0, tt.n, Options{KBonacciOrder: tt.k})
+		if err != nil {
+			t.Fatalf("k=%d, n=%d: unexpected error: %v", tt.k, tt.n, err)
+		}
+		if got.String() != tt.want {
+			t.Errorf("k=%d, n=%d: got %s, want %s", tt.k, tt.n, got, tt.want)
+		}
+	}
+}
+
+// TestKBonacciOrderTwoIsFibonacci checks that k = 2 reproduces F(n) above the
+// small-n path.
+func TestKBonacciOrderTwoIsFibonacci(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&KBonacciCalculator{})
+	fast := NewCalculator(&OptimizedFastDoubling{})
+	for _, n := range []uint64{94, 1000, 12345} {
+		got, err := calc.Calculate(context.Background(), nil, 0, n, Options{KBonacciOrder: 2})
+		if err != nil {
+			t.Fatalf("n=%d: unexpected error: %v", n, err)
+		}
+		want, _ := fast.Calculate(context.Background(), nil, 0, n, Options{})
+		if got.Cmp(want) != 0 {
+			t.Errorf("n=%d: k-bonacci(2) differs from F(n)", n)
+		}
+	}
+}
+
+func TestKBonacciRejectsInvalidOrder(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&KBonacciCalculator{})
+	for _, k := range []int{-1, 1, MaxKBonacciOrder + 1} {
+		for _, n := range []uint64{10, 1000} {
+			_, err := calc.Calculate(context.Background(), nil, 0, n, Options{KBonacciOrder: k})
+			var validationErr apperrors.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "kbonacci" {
+				t.Errorf("k=%d, n=%d: expected a ValidationError for kbonacci, got %v", k, n, err)
+			}
+		}
+	}
+}
+
+func TestKBonacciCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCalculator(&KBonacciCalculator{}).Calculate(ctx, nil, 0, 10_000, Options{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestKBonacciExcludedFromAll(t *testing.T) {
+	t.Parallel()
+	if !ExcludedFromAll(KBonacciAlgorithm) {
+		t.Error("the k-bonacci calculator must not be part of --algo all")
+	}
+}
@@ -0,0 +1,120 @@
+package fibonacci
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/bits"
+)
+
+// LucasAlgorithm is the registry name of the Lucas number calculator.
+const LucasAlgorithm = "lucas"
+
+// LucasCalculator computes the Lucas numbers L(n), the companion sequence of
+// the Fibonacci numbers defined by L(0) = 2, L(1) = 1 and
+// L(n) = L(n-1) + L(n-2). They are related to F(n) by L(n) = F(n-1) + F(n+1).
+//
+// The calculator uses the Lucas fast doubling identities:
+//
+//	L(2k)   = L(k)² - 2(-1)^k
+//	L(2k+1) = L(k)L(k+1) - (-1)^k
+//	L(2k+2) = L(k+1)² + 2(-1)^k
+//
+// Each step needs only two multiplications: the pair (L(2k), L(2k+1)) or
+// (L(2k+1), L(2k+2)) is produced depending on the current bit of n.
+//
+// Since L(n) is not F(n), this algorithm is left out of "--algo all"
+// comparisons (see ExcludedFromAll).
+type LucasCalculator struct{}
+
+// Name returns the descriptive name of the algorithm.
+//
+// Returns:
+//   - string: The name of the algorithm.
+func (c *LucasCalculator) Name() string {
+	return "Lucas Numbers (Fast Doubling)"
+}
+
+// calculateSmall returns L(n) for small n using iterative addition.
+func (c *LucasCalculator) calculateSmall(n uint64, _ Options) (*big.Int, error) {
+	a := big.NewInt(2)
+	b := big.NewInt(1)
+	for i := uint64(0); i < n; i++ {
+		a.Add(a, b)
+		a, b = b, a
+	}
+	return a, nil
+}
+
+// CalculateCore computes L(n) using the Lucas fast doubling identities.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - reporter: The function used for reporting progress.
+//   - n: The index of the Lucas number to calculate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The calculated Lucas number L(n).
+//   - error: An error if one occurred (e.g., context cancellation).
+func (c *LucasCalculator) CalculateCore(ctx context.Context, reporter ProgressCallback, n uint64, opts Options) (*big.Int, error) {
+	s := AcquireState()
+	defer ReleaseState(s)
+	// FK = L(k), FK1 = L(k+1), starting from k = 0.
+	s.FK.SetInt64(2)
+	s.FK1.SetInt64(1)
+	kOdd := false
+
+	numBits := bits.Len64(n)
+	totalWork := CalcTotalWork(numBits)
+	powers := PrecomputePowers4(numBits)
+	workDone := 0.0
+	lastReportedProgress := -1.0
+	fftThreshold := normalizeOptions(opts).FFTThreshold
+
+	// sign is (-1)^k.
+	sign := big.NewInt(1)
+	two := big.NewInt(2)
+	var err error
+	for i := numBits - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("lucas calculation canceled at bit %d/%d: %w", i, numBits-1, err)
+		}
+		if kOdd {
+			sign.SetInt64(-1)
+		} else {
+			sign.SetInt64(1)
+		}
+		two.Lsh(sign, 1)
+
+		// T1 = L(k)L(k+1) - (-1)^k = L(2k+1)
+		if s.T1, err = smartMultiply(s.T1, s.FK, s.FK1, fftThreshold); err != nil {
+			return nil, fmt.Errorf("lucas doubling step failed at bit %d/%d: %w", i, numBits-1, err)
+		}
+		s.T1.Sub(s.T1, sign)
+
+		if (n>>uint(i))&1 == 1 {
+			// T2 = L(k+1)² + 2(-1)^k = L(2k+2)
+			if s.T2, err = smartSquare(s.T2, s.FK1, fftThreshold); err != nil {
+				return nil, fmt.Errorf("lucas doubling step failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			s.T2.Add(s.T2, two)
+			s.FK, s.FK1, s.T1, s.T2 = s.T1, s.T2, s.FK, s.FK1
+			kOdd = true
+		} else {
+			// T2 = L(k)² - 2(-1)^k = L(2k)
+			if s.T2, err = smartSquare(s.T2, s.FK, fftThreshold); err != nil {
+				return nil, fmt.Errorf("lucas doubling step failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			s.T2.Sub(s.T2, two)
+			s.FK, s.FK1, s.T1, s.T2 = s.T2, s.T1, s.FK, s.FK1
+			kOdd = false
+		}
+
+		workDone = ReportStepProgress(reporter, &lastReportedProgress, totalWork, workDone, i, numBits, powers)
+	}
+	// Steal FK from the state, as in ExecuteDoublingLoop.
+	result := s.FK
+	s.FK = new(big.Int)
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package fibonacci
+
+import (
+	"context"
+	"math/big"
+	"testing"
+)
+
+func TestLucasCalculatorKnownValues(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&LucasCalculator{})
+	known := map[uint64]int64{0: 2, 1: 1, 2: 3, 3: 4, 5: 11, 10: 123, 20: 15127}
+	for n, want := range known {
+		got, err := calc.Calculate(context.Background(), nil, 0, n, Options{})
+		if err != nil {
+			t.Fatalf("L(%d): unexpected error: %v", n, err)
+		}
+		if got.Int64() != want {
+			t.Errorf("L(%d) = %s, want %d", n, got, want)
+		}
+	}
+}
+
+// TestLucasCalculatorMatchesFibonacci cross-checks L(n) = F(n-1) + F(n+1) on
+// both the small-n path and the doubling loop, including FFT-sized operands.
+func TestLucasCalculatorMatchesFibonacci(t *testing.T) {
+	t.Parallel()
+	lucas := NewCalculator(&LucasCalculator{})
+	fib := NewCalculator(&OptimizedFastDoubling{})
+	ctx := context.Background()
+
+	indices := []uint64{1, 2, 92, 93, 94, 95, 127, 128, 129, 1000, 4097, 25_000}
+	for n := uint64(150); n < 400; n += 7 {
+		indices = append(indices, n)
+	}
+	for _, opts := range []Options{{}, {FFTThreshold: 1000}} {
+		for _, n := range indices {
+			got, err := lucas.Calculate(ctx, nil, 0, n, opts)
+			if err != nil {
+				t.Fatalf("L(%d): unexpected error: %v", n, err)
+			}
+			prev, err := fib.Calculate(ctx, nil, 0, n-1, opts)
+			if err != nil {
+				t.Fatalf("F(%d): unexpected error: %v", n-1, err)
+			}
+			next, err := fib.Calculate(ctx, nil, 0, n+1, opts)
+			if err != nil {
+				t.Fatalf("F(%d): unexpected error: %v", n+1, err)
+			}
+			want := new(big.Int).Add(prev, next)
+			if got.Cmp(want) != 0 {
+				t.Errorf("L(%d) != F(%d) + F(%d) (FFTThreshold=%d)", n, n-1, n+1, opts.FFTThreshold)
+			}
+		}
+	}
+}
+
+func TestLucasCalculatorCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := NewCalculator(&LucasCalculator{}).Calculate(ctx, nil, 0, 10_000, Options{}); err == nil {
+		t.Error("expected an error for a canceled context")
+	}
+}
+
+func TestLucasRegistered(t *testing.T) {
+	t.Parallel()
+	calc, err := NewDefaultFactory().Build(LucasAlgorithm)
+	if err != nil {
+		t.Fatalf("Build(%q) failed: %v", LucasAlgorithm, err)
+	}
+	if calc.Name() != (&LucasCalculator{}).Name() {
+		t.Errorf("Build(%q) returned %q", LucasAlgorithm, calc.Name())
+	}
+	if !ExcludedFromAll(LucasAlgorithm) || ExcludedFromAll("fast") {
+		t.Error("only lucas should be excluded from --algo all")
+	}
+}
@@ -53,6 +53,20 @@ func (c *MatrixExponentiation) Name() string {
 	return "Matrix Exponentiation (O(log n), Parallel, Zero-Alloc)"
 }
 
+// RelativeCost estimates the cost of computing F(n) relative to fast
+// doubling: matrix exponentiation is about 40% slower, after the benchmark
+// table in the README.
+//
+// Parameters:
+//   - n: The Fibonacci index.
+//   - opts: The calculation options.
+//
+// Returns:
+//   - float64: The estimated cost, 1 for fast doubling.
+func (c *MatrixExponentiation) RelativeCost(n uint64, opts Options) float64 {
+	return 1.4
+}
+
 // CalculateCore computes F(n) using the matrix exponentiation method.
 //
 // This function implements the binary exponentiation algorithm to efficiently
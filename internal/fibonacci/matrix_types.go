@@ -42,6 +42,17 @@ func (m *matrix) Set(other *matrix) {
 	m.d.Set(other.d)
 }
 
+// mod reduces every element of the matrix modulo m, in [0, m).
+//
+// Parameters:
+//   - modulus: The modulus; it must be positive.
+func (m *matrix) mod(modulus *big.Int) {
+	m.a.Mod(m.a, modulus)
+	m.b.Mod(m.b, modulus)
+	m.c.Mod(m.c, modulus)
+	m.d.Mod(m.d, modulus)
+}
+
 // SetIdentity configures the matrix as an identity matrix.
 // The identity matrix is the multiplicative identity for matrix multiplication,
 // and is defined as:
@@ -36,6 +36,19 @@ func EstimateMemoryUsage(n uint64) MemoryEstimate {
 	}
 }
 
+// EstimateIterativeMemoryUsage estimates the memory needed to compute F(n)
+// by repeated addition, which only holds two values of at most the size of
+// F(n) and uses neither FFT buffers nor the transform cache.
+func EstimateIterativeMemoryUsage(n uint64) MemoryEstimate {
+	est := EstimateMemoryUsage(n)
+	stateBytes := est.StateBytes / 5 * 2 // the two last terms
+	return MemoryEstimate{
+		StateBytes:    stateBytes,
+		OverheadBytes: stateBytes, // GC + runtime ~1x
+		TotalBytes:    stateBytes * 2,
+	}
+}
+
 // ParseMemoryLimit parses a human-readable memory limit (e.g., "8G", "512M").
 func ParseMemoryLimit(s string) (uint64, error) {
 	s = strings.TrimSpace(s)
@@ -33,6 +33,23 @@ func TestEstimateMemoryUsage(t *testing.T) {
 	}
 }
 
+func TestEstimateIterativeMemoryUsage(t *testing.T) {
+	t.Parallel()
+	for _, n := range []uint64{1_000_000, 1_000_000_000} {
+		iterative, doubling := EstimateIterativeMemoryUsage(n), EstimateMemoryUsage(n)
+		if iterative.TotalBytes >= doubling.TotalBytes {
+			t.Errorf("n=%d: iterative estimate %d should be below the fast doubling one %d", n, iterative.TotalBytes, doubling.TotalBytes)
+		}
+		// Two values of F(n), about n*0.694 bits each.
+		if minBytes := n * 694 / 1000 / 8 * 2; iterative.TotalBytes < minBytes {
+			t.Errorf("n=%d: estimate %d too low, want >= %d", n, iterative.TotalBytes, minBytes)
+		}
+		if iterative.FFTBufferBytes != 0 || iterative.CacheBytes != 0 {
+			t.Errorf("n=%d: iterative estimate %+v should have no FFT buffers or cache", n, iterative)
+		}
+	}
+}
+
 func TestParseMemoryLimit(t *testing.T) {
 	t.Parallel()
 
@@ -76,3 +93,14 @@ func TestParseMemoryLimit_Errors(t *testing.T) {
 		})
 	}
 }
+
+func TestAvailableSystemMemory(t *testing.T) {
+	t.Parallel()
+	available, err := AvailableSystemMemory()
+	if err != nil {
+		t.Skipf("system memory unavailable: %v", err)
+	}
+	if available == 0 {
+		t.Error("AvailableSystemMemory() = 0 without an error")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"math"
 	"runtime"
 	"runtime/debug"
+	"sync"
 
 	"github.com/rs/zerolog"
 )
@@ -20,9 +21,23 @@ const (
 // GCAutoThreshold is the minimum N for auto GC control to activate.
 const GCAutoThreshold uint64 = 1_000_000
 
+// The GC percent and memory limit are process-wide, so overlapping
+// controllers (concurrent calculations) share them: the first Begin saves the
+// settings and disables GC, and the last End restores them. Without this, a
+// controller that began while another one was active would "restore" GC to
+// the disabled state.
+var (
+	gcMu             sync.Mutex
+	gcActiveCount    int
+	gcSavedGCPercent int
+)
+
 // GCController manages Go's garbage collector during intensive calculations.
 // It disables GC during computation and restores it afterward, reducing
 // pause times and memory overhead for large calculations.
+//
+// A controller is used by a single calculation and is not safe for
+// concurrent use, but distinct controllers may be active concurrently.
 type GCController struct {
 	mode              GCMode
 	originalGCPercent int
@@ -65,29 +80,48 @@ func (gc *GCController) Begin() {
 		return
 	}
 	runtime.ReadMemStats(&gc.startStats)
-	gc.originalGCPercent = debug.SetGCPercent(-1)
-	// Set soft memory limit as OOM safety net.
-	if gc.startStats.Sys > 0 {
-		limit := int64(float64(gc.startStats.Sys) * 3)
-		if limit > 0 {
-			debug.SetMemoryLimit(limit)
+
+	gcMu.Lock()
+	if gcActiveCount == 0 {
+		gcSavedGCPercent = debug.SetGCPercent(-1)
+		// Set soft memory limit as OOM safety net.
+		if gc.startStats.Sys > 0 {
+			limit := int64(float64(gc.startStats.Sys) * 3)
+			if limit > 0 {
+				debug.SetMemoryLimit(limit)
+			}
 		}
 	}
+	gcActiveCount++
+	gc.originalGCPercent = gcSavedGCPercent
+	gcMu.Unlock()
+
 	gc.logger.Debug().
 		Str("mode", string(gc.mode)).
 		Uint64("heap_alloc_bytes", gc.startStats.HeapAlloc).
 		Msg("gc disabled")
 }
 
-// End restores original GC settings and triggers a collection.
+// End restores original GC settings and triggers a collection once no other
+// controller is active.
 func (gc *GCController) End() {
 	if !gc.active {
 		return
 	}
 	runtime.ReadMemStats(&gc.endStats)
-	debug.SetGCPercent(gc.originalGCPercent)
-	debug.SetMemoryLimit(math.MaxInt64)
-	runtime.GC()
+
+	gcMu.Lock()
+	gcActiveCount--
+	last := gcActiveCount == 0
+	if last {
+		debug.SetGCPercent(gcSavedGCPercent)
+		debug.SetMemoryLimit(math.MaxInt64)
+	}
+	gcMu.Unlock()
+
+	if last {
+		runtime.GC()
+	}
 	gc.logger.Debug().
 		Str("mode", string(gc.mode)).
 		Uint64("heap_alloc_bytes", gc.endStats.HeapAlloc).
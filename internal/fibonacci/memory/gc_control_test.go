@@ -1,6 +1,7 @@
 package memory
 
 import (
+	"runtime/debug"
 	"testing"
 )
 
@@ -74,3 +75,26 @@ func TestGCController_Stats_AfterBeginEnd(t *testing.T) {
 	// (we can't assert exact values due to runtime variability)
 	_ = stats
 }
+
+// TestGCController_Overlapping verifies that interleaved controllers restore
+// the original GC percent only when the last one ends. It does not run in
+// parallel: the GC settings are process-wide.
+func TestGCController_Overlapping(t *testing.T) {
+	original := debug.SetGCPercent(100)
+	defer debug.SetGCPercent(original)
+
+	first := NewGCController("aggressive", 100)
+	second := NewGCController("aggressive", 100)
+	first.Begin()
+	second.Begin()
+
+	first.End()
+	if got := debug.SetGCPercent(-1); got != -1 {
+		t.Errorf("GC percent after first End = %d, want -1 (second still active)", got)
+	}
+
+	second.End()
+	if got := debug.SetGCPercent(100); got != 100 {
+		t.Errorf("GC percent after last End = %d, want 100", got)
+	}
+}
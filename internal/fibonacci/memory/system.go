@@ -0,0 +1,25 @@
+package memory
+
+import (
+	"fmt"
+
+	"github.com/shirou/gopsutil/v4/mem"
+)
+
+// AvailableSystemMemory returns the memory the system reports as available
+// for new allocations without swapping. It reads /proc/meminfo on Linux,
+// sysctl on Darwin and GlobalMemoryStatusEx on Windows.
+//
+// Returns:
+//   - uint64: The available memory in bytes.
+//   - error: An error if the system memory cannot be read.
+func AvailableSystemMemory() (uint64, error) {
+	vm, err := mem.VirtualMemory()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read system memory: %w", err)
+	}
+	if vm.Available == 0 {
+		return 0, fmt.Errorf("the system reports no available memory")
+	}
+	return vm.Available, nil
+}
@@ -0,0 +1,27 @@
+package fibonacci
+
+import "math"
+
+// MinMeaningfulN returns the smallest index worth benchmarking with the
+// algorithm registered under algo. Below MaxFibUint64 every calculator takes
+// the small-n fast path, so timings measure call overhead rather than the
+// algorithm. The "fft" calculator is additionally only meaningful once F(n)
+// exceeds the FFT threshold, the size from which FFT multiplication pays off.
+//
+// Parameters:
+//   - algo: The registry name of the algorithm (e.g. "fast", "fft").
+//   - opts: The calculation options; a zero FFTThreshold selects the default.
+//
+// Returns:
+//   - uint64: The smallest meaningful n.
+func MinMeaningfulN(algo string, opts Options) uint64 {
+	floor := uint64(MaxFibUint64 + 1)
+	if algo == "fft" {
+		// F(n) has about n*FibonacciGrowthFactor bits.
+		threshold := float64(normalizeOptions(opts).FFTThreshold)
+		if fftFloor := uint64(math.Ceil(threshold / FibonacciGrowthFactor)); fftFloor > floor {
+			floor = fftFloor
+		}
+	}
+	return floor
+}
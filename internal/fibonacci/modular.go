@@ -1,7 +1,9 @@
 package fibonacci
 
 import (
+	"context"
 	"fmt"
+	"math"
 	"math/big"
 	"math/bits"
 )
@@ -15,6 +17,24 @@ import (
 //	F(2k)   = F(k) * (2*F(k+1) - F(k))  mod m
 //	F(2k+1) = F(k+1)² + F(k)²            mod m
 func FastDoublingMod(n uint64, m *big.Int) (*big.Int, error) {
+	return FastDoublingModContext(context.Background(), n, m)
+}
+
+// FastDoublingModContext is like FastDoublingMod but stops when ctx is done.
+// The context is checked before each of the at most 64 doubling steps; with
+// a large modulus a single step can take a while, so this bounds the delay
+// of a cancellation or a deadline to one step.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - n: The index of the Fibonacci number.
+//   - m: The modulus, which must be positive.
+//
+// Returns:
+//   - *big.Int: F(n) mod m.
+//   - error: An error if m is not positive, or one wrapping ctx.Err() if the
+//     context is done before the calculation completes.
+func FastDoublingModContext(ctx context.Context, n uint64, m *big.Int) (*big.Int, error) {
 	if m == nil || m.Sign() <= 0 {
 		return nil, fmt.Errorf("modulus must be positive")
 	}
@@ -31,6 +51,10 @@ func FastDoublingMod(n uint64, m *big.Int) (*big.Int, error) {
 	numBits := bits.Len64(n)
 
 	for i := numBits - 1; i >= 0; i-- {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("modular calculation canceled at step %d/%d: %w", numBits-i, numBits, err)
+		}
+
 		// F(2k) = F(k) * (2*F(k+1) - F(k)) mod m
 		t1.Lsh(fk1, 1)
 		t1.Sub(t1, fk)
@@ -63,3 +87,92 @@ func FastDoublingMod(n uint64, m *big.Int) (*big.Int, error) {
 
 	return fk, nil
 }
+
+// MatrixFibMod computes F(n) mod m by raising the Fibonacci matrix Q to the
+// (n-1)-th power, reducing every element mod m after each product. It reuses
+// the symmetric squaring and 2×2 multiplication of MatrixExponentiation, so
+// it is an independent cross-check of FastDoublingMod. Memory usage is
+// O(log(m)) regardless of n.
+//
+// Parameters:
+//   - n: The index of the Fibonacci number.
+//   - m: The modulus; it must be positive.
+//
+// Returns:
+//   - *big.Int: F(n) mod m, in [0, m).
+//   - error: An error if m is not positive or a multiplication fails.
+func MatrixFibMod(n uint64, m *big.Int) (*big.Int, error) {
+	if m == nil || m.Sign() <= 0 {
+		return nil, fmt.Errorf("modulus must be positive")
+	}
+	if n == 0 {
+		return big.NewInt(0), nil
+	}
+
+	state := acquireMatrixState()
+	defer releaseMatrixState(state)
+	fftThreshold := normalizeOptions(Options{}).FFTThreshold
+
+	// As in MatrixFramework.ExecuteMatrixLoop, res = Q^(n-1) accumulates
+	// the powers p = Q^(2^i) for the set bits of n-1, and F(n) is res.a.
+	// Powers of Q are symmetric, so p can be squared as such.
+	exponent := n - 1
+	numBits := bits.Len64(exponent)
+	for i := 0; i < numBits; i++ {
+		if (exponent>>uint(i))&1 == 1 {
+			if err := multiplyMatrix2x2(state.tempMatrix, state.res, state.p, state, false, fftThreshold); err != nil {
+				return nil, fmt.Errorf("modular matrix multiplication failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			state.res, state.tempMatrix = state.tempMatrix, state.res
+			state.res.mod(m)
+		}
+		if i < numBits-1 {
+			if err := squareSymmetricMatrix(state.tempMatrix, state.p, state, false, fftThreshold); err != nil {
+				return nil, fmt.Errorf("modular matrix squaring failed at bit %d/%d: %w", i, numBits-1, err)
+			}
+			state.p, state.tempMatrix = state.tempMatrix, state.p
+			state.p.mod(m)
+		}
+	}
+	return new(big.Int).Mod(state.res.a, m), nil
+}
+
+// PisanoPeriod returns π(m), the period of the sequence F(n) mod m. For
+// example π(10) = 60: the last decimal digit of F(n) repeats every 60 terms,
+// so F(n) mod m = F(n mod π(m)) mod m.
+//
+// The residues are iterated until the pair (0, 1) reappears, which takes
+// O(π(m)) additions. Since π(m) <= 6m for every m, the iteration is bounded
+// by 6m.
+//
+// Parameters:
+//   - m: The modulus; it must be positive and at most math.MaxUint64/6.
+//
+// Returns:
+//   - uint64: The Pisano period of m.
+//   - error: An error if m is not positive or too large.
+func PisanoPeriod(m *big.Int) (uint64, error) {
+	if m == nil || m.Sign() <= 0 {
+		return 0, fmt.Errorf("modulus must be positive")
+	}
+	if !m.IsUint64() || m.Uint64() > math.MaxUint64/6 {
+		return 0, fmt.Errorf("modulus %s is too large for a Pisano period search", m)
+	}
+	mod := m.Uint64()
+	if mod == 1 {
+		return 1, nil
+	}
+
+	// a, b = F(i) mod m, F(i+1) mod m; a + b cannot overflow since m <= MaxUint64/6.
+	var a, b uint64 = 0, 1
+	for i := uint64(1); i <= 6*mod; i++ {
+		a, b = b, a+b
+		if b >= mod {
+			b -= mod
+		}
+		if a == 0 && b == 1 {
+			return i, nil
+		}
+	}
+	return 0, fmt.Errorf("no Pisano period found for modulus %d within 6m terms", mod)
+}
@@ -2,9 +2,12 @@ package fibonacci
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"testing"
+	"time"
 )
 
 func TestFastDoublingMod_KnownValues(t *testing.T) {
@@ -82,3 +85,85 @@ func TestFastDoublingMod_InvalidModulus(t *testing.T) {
 		t.Error("expected error for negative modulus")
 	}
 }
+
+// TestFastDoublingModContext_Deadline checks that a deadline stops the
+// calculation within a doubling step. The full calculation takes seconds
+// with this modulus.
+func TestFastDoublingModContext_Deadline(t *testing.T) {
+	t.Parallel()
+	m := new(big.Int).Exp(big.NewInt(10), big.NewInt(300_000), nil)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := FastDoublingModContext(ctx, math.MaxUint64, m)
+	elapsed := time.Since(start)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected an error wrapping context.DeadlineExceeded, got %v", err)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("the calculation stopped %v after the call, want it within one doubling step", elapsed)
+	}
+}
+
+func TestMatrixFibMod_KnownValues(t *testing.T) {
+	t.Parallel()
+	m := big.NewInt(1000000)
+	for n, want := range map[uint64]int64{0: 0, 1: 1, 2: 1, 10: 55, 1000: 228875} {
+		got, err := MatrixFibMod(n, m)
+		if err != nil {
+			t.Fatalf("MatrixFibMod(%d) error: %v", n, err)
+		}
+		if got.Int64() != want {
+			t.Errorf("MatrixFibMod(%d, %s) = %s, want %d", n, m, got, want)
+		}
+	}
+
+	for _, m := range []*big.Int{nil, big.NewInt(0), big.NewInt(-5)} {
+		if _, err := MatrixFibMod(10, m); err == nil {
+			t.Errorf("expected an error for modulus %v", m)
+		}
+	}
+}
+
+func TestPisanoPeriod_KnownValues(t *testing.T) {
+	t.Parallel()
+
+	known := map[int64]uint64{1: 1, 2: 3, 3: 8, 5: 20, 7: 16, 10: 60, 100: 300, 1000: 1500}
+	for m, want := range known {
+		got, err := PisanoPeriod(big.NewInt(m))
+		if err != nil {
+			t.Fatalf("PisanoPeriod(%d): unexpected error: %v", m, err)
+		}
+		if got != want {
+			t.Errorf("PisanoPeriod(%d) = %d, want %d", m, got, want)
+		}
+	}
+}
+
+func TestPisanoPeriod_ConsistentWithFastDoublingMod(t *testing.T) {
+	t.Parallel()
+
+	m := big.NewInt(1000)
+	period, err := PisanoPeriod(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, n := range []uint64{7, 123, 999, 12345} {
+		a, _ := FastDoublingMod(n, m)
+		b, _ := FastDoublingMod(n+period, m)
+		if a.Cmp(b) != 0 {
+			t.Errorf("F(%d) mod 1000 = %s, F(%d+π) mod 1000 = %s", n, a, n, b)
+		}
+	}
+}
+
+func TestPisanoPeriod_InvalidModulus(t *testing.T) {
+	t.Parallel()
+
+	for _, m := range []*big.Int{nil, big.NewInt(0), big.NewInt(-3), new(big.Int).Lsh(big.NewInt(1), 64)} {
+		if _, err := PisanoPeriod(m); err == nil {
+			t.Errorf("PisanoPeriod(%v): expected an error", m)
+		}
+	}
+}
@@ -2,7 +2,10 @@
 
 package fibonacci
 
-import "github.com/agbru/fibcalc/internal/bigfft"
+import (
+	"github.com/agbru/fibcalc/internal/bigfft"
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
+)
 
 // Options configures the Fibonacci calculation.
 type Options struct {
@@ -33,9 +36,52 @@ type Options struct {
 	// DynamicAdjustmentInterval is the number of iterations between threshold checks.
 	// If 0, uses the default (5 iterations). Only used when EnableDynamicThresholds is true.
 	DynamicAdjustmentInterval int
+	// OnThresholdsLearned, if set, receives the dynamically adjusted
+	// thresholds after a successful calculation, e.g. to persist them in the
+	// calibration profile. Only used when EnableDynamicThresholds is true.
+	OnThresholdsLearned func(threshold.LearnedThresholds)
 	// GCMode controls the garbage collector during calculation.
 	// Valid values: "auto" (default), "aggressive", "disabled".
 	GCMode string
+	// DisablePooling makes the FFT layer allocate fresh buffers for every
+	// operation instead of recycling them through sync.Pool. Intended for
+	// measuring the benefit of pooling; default is false (pooling enabled).
+	DisablePooling bool
+	// KBonacciOrder is the order k of the sequence computed by the k-bonacci
+	// calculator, where each term is the sum of the k previous ones. If 0,
+	// uses DefaultKBonacciOrder. Other calculators ignore it.
+	KBonacciOrder int
+	// BinetPrecision is the mantissa precision in bits of the Binet
+	// approximation calculator. If 0, uses DefaultBinetPrecision. Other
+	// calculators ignore it.
+	BinetPrecision int
+}
+
+// ValueOptions holds the options that change the value computed by a
+// calculator, as opposed to how fast it is computed, with defaults filled in.
+// Calculations of the same algorithm and index with equal ValueOptions
+// return the same value, so it is the part of the options that caches and
+// coalesced calls must key on.
+type ValueOptions struct {
+	// KBonacciOrder is the order of the k-bonacci sequence.
+	KBonacciOrder int
+	// BinetPrecision is the precision of the Binet approximation.
+	BinetPrecision int
+}
+
+// ValueOptions returns the value-affecting options of o (see ValueOptions).
+//
+// Returns:
+//   - ValueOptions: The normalized value-affecting options.
+func (o Options) ValueOptions() ValueOptions {
+	v := ValueOptions{KBonacciOrder: o.KBonacciOrder, BinetPrecision: o.BinetPrecision}
+	if v.KBonacciOrder == 0 {
+		v.KBonacciOrder = DefaultKBonacciOrder
+	}
+	if v.BinetPrecision == 0 {
+		v.BinetPrecision = DefaultBinetPrecision
+	}
+	return v
 }
 
 // normalizeOptions returns a copy of opts with default values filled in for zero values.
@@ -86,3 +132,9 @@ func configureFFTCache(opts Options) {
 	// Apply configuration to global cache
 	bigfft.SetTransformCacheConfig(config)
 }
+
+// configurePooling enables or disables FFT buffer pooling based on the
+// provided options.
+func configurePooling(opts Options) {
+	bigfft.SetPoolingEnabled(!opts.DisablePooling)
+}
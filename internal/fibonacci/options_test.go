@@ -116,3 +116,57 @@ func TestOptionsFFTCacheEnabled(t *testing.T) {
 		}
 	})
 }
+
+// ─────────────────────────────────────────────────────────────────────────────
+// MinMeaningfulN Tests
+// ─────────────────────────────────────────────────────────────────────────────
+
+func TestMinMeaningfulN(t *testing.T) {
+	t.Parallel()
+	if got := MinMeaningfulN("fast", Options{}); got != MaxFibUint64+1 {
+		t.Errorf("MinMeaningfulN(fast) = %d, want %d", got, MaxFibUint64+1)
+	}
+	got := MinMeaningfulN("fft", Options{FFTThreshold: 100_000})
+	if bits := float64(got) * FibonacciGrowthFactor; bits < 100_000 || bits > 100_001 {
+		t.Errorf("MinMeaningfulN(fft) = %d (%.0f bits), want F(n) just above the 100000-bit threshold", got, bits)
+	}
+	if MinMeaningfulN("fft", Options{}) <= MinMeaningfulN("fft", Options{FFTThreshold: 100_000}) {
+		t.Error("a zero FFTThreshold should select the larger default")
+	}
+}
+
+// costlyCalculator is a custom calculator estimating its own cost.
+type costlyCalculator struct{ MockCalculator }
+
+func (c *costlyCalculator) RelativeCost(n uint64, opts Options) float64 { return 3 }
+
+func TestRelativeCost(t *testing.T) {
+	t.Parallel()
+	opts := Options{FFTThreshold: 100_000}
+	large := MinMeaningfulN("fft", opts)
+	factory := NewDefaultFactory()
+	cost := func(name string, n uint64) float64 {
+		calc, err := factory.Build(name)
+		if err != nil {
+			t.Fatalf("Build(%q): %v", name, err)
+		}
+		// Decorators forward the estimate of the calculator they wrap.
+		return RelativeCost(NewPhaseTimeoutCalculator(NewCoalescingCalculator(calc), 0), n, opts)
+	}
+
+	if got := cost("fast", large); got != 1 {
+		t.Errorf("RelativeCost(fast) = %v, want 1", got)
+	}
+	if got := RelativeCost(&MockCalculator{}, large, opts); got != 1 {
+		t.Errorf("RelativeCost of a calculator without an estimate = %v, want 1", got)
+	}
+	if got := RelativeCost(&costlyCalculator{}, large, opts); got != 3 {
+		t.Errorf("RelativeCost of a CostEstimator = %v, want its estimate 3", got)
+	}
+	if cost("matrix", large) <= 1 {
+		t.Error("matrix should cost more than fast doubling")
+	}
+	if below, above := cost("fft", large-1), cost("fft", large); below <= above {
+		t.Errorf("fft below the threshold (%v) should cost more than above it (%v)", below, above)
+	}
+}
@@ -0,0 +1,179 @@
+// This file tracks which phase of a calculation is running, so that a
+// timeout can be attributed to the phase that exceeded it.
+
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// Phases of a calculation, reported as the Operation of an
+// apperrors.TimeoutError by PhaseTimeoutCalculator.
+const (
+	// PhaseSetup covers the preparation before the algorithm runs (GC
+	// control, FFT cache and pool configuration).
+	PhaseSetup = "setup"
+	// PhaseCompute covers the algorithm itself, e.g. the doubling loop.
+	PhaseCompute = "compute"
+	// PhaseFormat covers the conversion of the result to decimal.
+	PhaseFormat = "format"
+)
+
+// phaseKey is the context key of the phase tracker.
+type phaseKey struct{}
+
+// phaseTracker holds the current phase of one calculation. It is shared by
+// the goroutines working on the calculation, hence the atomic value.
+type phaseTracker struct {
+	phase atomic.Value // string
+}
+
+// withPhaseTracker returns a copy of ctx carrying a new phase tracker, set to
+// PhaseSetup.
+func withPhaseTracker(ctx context.Context) (context.Context, *phaseTracker) {
+	t := &phaseTracker{}
+	t.phase.Store(PhaseSetup)
+	return context.WithValue(ctx, phaseKey{}, t), t
+}
+
+// current returns the phase that is running.
+func (t *phaseTracker) current() string {
+	return t.phase.Load().(string)
+}
+
+// SetPhase records phase as the running phase of the calculation tracked by
+// ctx. It does nothing when ctx carries no tracker, so calculators may call
+// it unconditionally.
+//
+// Parameters:
+//   - ctx: The calculation context.
+//   - phase: The phase that starts, e.g. PhaseCompute.
+func SetPhase(ctx context.Context, phase string) {
+	if t, ok := ctx.Value(phaseKey{}).(*phaseTracker); ok {
+		t.phase.Store(phase)
+	}
+}
+
+// CurrentPhase returns the running phase of the calculation tracked by ctx.
+//
+// Parameters:
+//   - ctx: The calculation context.
+//
+// Returns:
+//   - string: The phase, or "" if ctx carries no tracker.
+func CurrentPhase(ctx context.Context) string {
+	if t, ok := ctx.Value(phaseKey{}).(*phaseTracker); ok {
+		return t.current()
+	}
+	return ""
+}
+
+// PhaseTimeoutCalculator wraps a Calculator with a time limit and reports
+// which phase was running when the limit was reached: calculation errors
+// caused by an expired deadline are replaced by an apperrors.TimeoutError
+// whose Operation is PhaseSetup, PhaseCompute or PhaseFormat. The error
+// still matches context.DeadlineExceeded with errors.Is.
+type PhaseTimeoutCalculator struct {
+	Calculator
+	limit time.Duration
+	// format converts the result to decimal; replaced in tests.
+	format func(*big.Int) string
+}
+
+// NewPhaseTimeoutCalculator wraps calc with a time limit.
+//
+// Parameters:
+//   - calc: The calculator to wrap.
+//   - limit: The time limit of each calculation, or 0 to rely on the
+//     deadline of the caller's context only.
+//
+// Returns:
+//   - *PhaseTimeoutCalculator: The wrapped calculator.
+func NewPhaseTimeoutCalculator(calc Calculator, limit time.Duration) *PhaseTimeoutCalculator {
+	return &PhaseTimeoutCalculator{Calculator: calc, limit: limit, format: (*big.Int).String}
+}
+
+// RelativeCost returns the cost estimated by the wrapped calculator (see
+// RelativeCost).
+func (c *PhaseTimeoutCalculator) RelativeCost(n uint64, opts Options) float64 {
+	return RelativeCost(c.Calculator, n, opts)
+}
+
+// Calculate computes F(n) within the time limit.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - progressChan: The channel for sending progress updates.
+//   - calcIndex: A unique index for the calculator instance.
+//   - n: The index of the Fibonacci number to calculate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The calculated Fibonacci number.
+//   - error: An apperrors.TimeoutError naming the phase if the limit was
+//     reached, or the error of the wrapped calculator.
+func (c *PhaseTimeoutCalculator) Calculate(ctx context.Context, progressChan chan<- ProgressUpdate, calcIndex int, n uint64, opts Options) (*big.Int, error) {
+	ctx, cancel, tracker := c.start(ctx)
+	defer cancel()
+	result, err := c.Calculator.Calculate(ctx, progressChan, calcIndex, n, opts)
+	return result, c.timeoutError(err, tracker)
+}
+
+// CalculateAndFormat computes F(n) and converts it to decimal, both within
+// the time limit. If the limit is reached while formatting, the conversion
+// is abandoned in the background and a TimeoutError for PhaseFormat is
+// returned.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - n: The index of the Fibonacci number to calculate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The calculated Fibonacci number.
+//   - string: Its decimal representation.
+//   - error: An apperrors.TimeoutError naming the phase if the limit was
+//     reached, or the error of the wrapped calculator.
+func (c *PhaseTimeoutCalculator) CalculateAndFormat(ctx context.Context, n uint64, opts Options) (*big.Int, string, error) {
+	ctx, cancel, tracker := c.start(ctx)
+	defer cancel()
+	result, err := c.Calculator.Calculate(ctx, nil, 0, n, opts)
+	if err != nil {
+		return nil, "", c.timeoutError(err, tracker)
+	}
+
+	tracker.phase.Store(PhaseFormat)
+	done := make(chan string, 1)
+	go func() { done <- c.format(result) }()
+	select {
+	case s := <-done:
+		return result, s, nil
+	case <-ctx.Done():
+		return nil, "", c.timeoutError(ctx.Err(), tracker)
+	}
+}
+
+// start applies the time limit to ctx and attaches a phase tracker.
+func (c *PhaseTimeoutCalculator) start(ctx context.Context) (context.Context, context.CancelFunc, *phaseTracker) {
+	cancel := context.CancelFunc(func() {})
+	if c.limit > 0 {
+		ctx, cancel = context.WithTimeout(ctx, c.limit)
+	}
+	ctx, tracker := withPhaseTracker(ctx)
+	return ctx, cancel, tracker
+}
+
+// timeoutError converts a deadline error into a TimeoutError for the running
+// phase. Other errors, including cancellations, are returned unchanged.
+func (c *PhaseTimeoutCalculator) timeoutError(err error, tracker *phaseTracker) error {
+	if err == nil || !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return apperrors.TimeoutError{Operation: tracker.current(), Limit: c.limit}
+}
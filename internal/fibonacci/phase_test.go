@@ -0,0 +1,122 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// blockingCore is a core calculator that runs until its context is done.
+type blockingCore struct{}
+
+func (blockingCore) Name() string { return "blocking" }
+
+func (blockingCore) CalculateCore(ctx context.Context, _ ProgressCallback, _ uint64, _ Options) (*big.Int, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+// requirePhaseTimeout fails unless err is a TimeoutError for phase that still
+// matches context.DeadlineExceeded.
+func requirePhaseTimeout(t *testing.T, err error, phase string, limit time.Duration) {
+	t.Helper()
+	var timeoutErr apperrors.TimeoutError
+	if !errors.As(err, &timeoutErr) {
+		t.Fatalf("expected a TimeoutError, got %v", err)
+	}
+	if timeoutErr.Operation != phase || timeoutErr.Limit != limit {
+		t.Errorf("got %+v, want Operation=%q and Limit=%s", timeoutErr, phase, limit)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Error("a phase timeout should match context.DeadlineExceeded")
+	}
+}
+
+func TestPhaseTimeoutDuringCompute(t *testing.T) {
+	t.Parallel()
+	limit := 10 * time.Millisecond
+	calc := NewPhaseTimeoutCalculator(NewCalculator(blockingCore{}), limit)
+
+	_, err := calc.Calculate(context.Background(), nil, 0, 1000, Options{})
+	requirePhaseTimeout(t, err, PhaseCompute, limit)
+
+	_, _, err = calc.CalculateAndFormat(context.Background(), 1000, Options{})
+	requirePhaseTimeout(t, err, PhaseCompute, limit)
+}
+
+func TestPhaseTimeoutDuringFormat(t *testing.T) {
+	t.Parallel()
+	limit := 20 * time.Millisecond
+	calc := NewPhaseTimeoutCalculator(NewCalculator(&OptimizedFastDoubling{}), limit)
+	release := make(chan struct{})
+	defer close(release)
+	calc.format = func(x *big.Int) string {
+		<-release
+		return x.String()
+	}
+
+	_, _, err := calc.CalculateAndFormat(context.Background(), 100, Options{})
+	requirePhaseTimeout(t, err, PhaseFormat, limit)
+}
+
+func TestPhaseTimeoutSetupDeadline(t *testing.T) {
+	t.Parallel()
+	// A deadline that has already passed is detected at the end of setup.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	calc := NewPhaseTimeoutCalculator(NewCalculator(blockingCore{}), 0)
+
+	_, err := calc.Calculate(ctx, nil, 0, 1000, Options{})
+	requirePhaseTimeout(t, err, PhaseSetup, 0)
+}
+
+func TestPhaseTimeoutSuccess(t *testing.T) {
+	t.Parallel()
+	calc := NewPhaseTimeoutCalculator(NewCalculator(&OptimizedFastDoubling{}), time.Minute)
+
+	result, text, err := calc.CalculateAndFormat(context.Background(), 1000, Options{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if text != result.String() || len(text) != 209 {
+		t.Errorf("F(1000) formatted as %d digits, want 209", len(text))
+	}
+	if calc.Name() != (&OptimizedFastDoubling{}).Name() {
+		t.Errorf("Name() = %q, want the wrapped calculator's name", calc.Name())
+	}
+}
+
+func TestPhaseTimeoutKeepsCancellation(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calc := NewPhaseTimeoutCalculator(NewCalculator(blockingCore{}), time.Minute)
+
+	_, err := calc.Calculate(ctx, nil, 0, 1000, Options{})
+	var timeoutErr apperrors.TimeoutError
+	if !errors.Is(err, context.Canceled) || errors.As(err, &timeoutErr) {
+		t.Errorf("expected the cancellation to pass through, got %v", err)
+	}
+}
+
+func TestSetPhaseWithoutTracker(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	SetPhase(ctx, PhaseCompute)
+	if got := CurrentPhase(ctx); got != "" {
+		t.Errorf("CurrentPhase() = %q without a tracker, want empty", got)
+	}
+
+	ctx, _ = withPhaseTracker(ctx)
+	if got := CurrentPhase(ctx); got != PhaseSetup {
+		t.Errorf("CurrentPhase() = %q, want %q", got, PhaseSetup)
+	}
+	SetPhase(ctx, PhaseFormat)
+	if got := CurrentPhase(ctx); got != PhaseFormat {
+		t.Errorf("CurrentPhase() = %q, want %q", got, PhaseFormat)
+	}
+}
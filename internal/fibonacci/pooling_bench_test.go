@@ -0,0 +1,67 @@
+package fibonacci
+
+import (
+	"context"
+	"testing"
+
+	"github.com/agbru/fibcalc/internal/bigfft"
+)
+
+// BenchmarkFFTPooling compares a full F(1M) computation with the FFT
+// calculator when buffers are recycled through sync.Pool versus freshly
+// allocated for every operation. Run with -benchmem to see the allocation
+// delta; B/op and allocs/op quantify the value of pooling.
+func BenchmarkFFTPooling(b *testing.B) {
+	const n = 1_000_000
+	calc := NewCalculator(&FFTBasedCalculator{})
+	ctx := context.Background()
+	defer bigfft.SetPoolingEnabled(true)
+
+	for _, bc := range []struct {
+		name           string
+		disablePooling bool
+	}{
+		{"Pooled", false},
+		{"Unpooled", true},
+	} {
+		b.Run(bc.name, func(b *testing.B) {
+			opts := Options{DisablePooling: bc.disablePooling}
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := calc.Calculate(ctx, nil, 0, n, opts); err != nil {
+					b.Fatalf("Calculation failed: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestFFTPoolingPathsAgree verifies that the pooled and unpooled allocation
+// paths produce identical results.
+func TestFFTPoolingPathsAgree(t *testing.T) {
+	// Not parallel: pooling is a process-wide setting.
+	defer bigfft.SetPoolingEnabled(true)
+
+	n := uint64(100_000)
+	if testing.Short() {
+		n = 20_000
+	}
+	calc := NewCalculator(&FFTBasedCalculator{})
+	ctx := context.Background()
+
+	pooled, err := calc.Calculate(ctx, nil, 0, n, Options{})
+	if err != nil {
+		t.Fatalf("pooled calculation failed: %v", err)
+	}
+	unpooled, err := calc.Calculate(ctx, nil, 0, n, Options{DisablePooling: true})
+	if err != nil {
+		t.Fatalf("unpooled calculation failed: %v", err)
+	}
+	if bigfft.PoolingEnabled() {
+		t.Error("pooling should be disabled after an unpooled calculation")
+	}
+	if pooled.Cmp(unpooled) != 0 {
+		t.Fatalf("F(%d) differs between pooled and unpooled paths", n)
+	}
+}
@@ -31,6 +31,10 @@ type (
 	NoOpObserver = progress.NoOpObserver
 )
 
+// ProgressReportThreshold is the minimum progress change between two reports
+// (see progress.ProgressReportThreshold).
+const ProgressReportThreshold = progress.ProgressReportThreshold
+
 // Re-exported constructors and functions from internal/progress.
 var (
 	// NewProgressSubject creates a new progress subject.
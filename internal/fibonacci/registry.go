@@ -1,8 +1,5 @@
 package fibonacci
 
-// Note: CalculatorFactory interface is not mockable with mockgen because Register()
-// uses the unexported coreCalculator type. Use DefaultFactory or manual mocks instead.
-
 import (
 	"fmt"
 	"sort"
@@ -11,10 +8,29 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// Factory is the extension point for custom algorithms. Calculators
+// registered on the application's factory take part in --algo all,
+// comparison runs, --algo validation and shell completion like the built-in
+// ones.
+type Factory interface {
+	// Register adds a calculator constructor under name, replacing any
+	// calculator previously registered with that name.
+	Register(name string, ctor func() Calculator) error
+
+	// Build returns the Calculator registered under name.
+	// Returns an error if no calculator is registered with that name.
+	Build(name string) (Calculator, error)
+
+	// List returns a sorted list of registered calculator names.
+	List() []string
+}
+
 // CalculatorFactory is an interface for creating Calculator instances.
-// It allows for flexible calculator instantiation and registration,
-// enabling dependency injection and easier testing.
+// It extends Factory with instance management, enabling dependency
+// injection and easier testing.
 type CalculatorFactory interface {
+	Factory
+
 	// Create creates a new Calculator instance by name.
 	// Returns an error if the calculator type is not registered.
 	Create(name string) (Calculator, error)
@@ -23,12 +39,6 @@ type CalculatorFactory interface {
 	// Returns an error if the calculator type is not registered.
 	Get(name string) (Calculator, error)
 
-	// List returns a sorted list of registered calculator names.
-	List() []string
-
-	// Register adds a new calculator type to the factory.
-	Register(name string, creator func() coreCalculator) error
-
 	// GetAll returns a map of all registered calculators.
 	GetAll() map[string]Calculator
 }
@@ -38,6 +48,8 @@ type CalculatorFactory interface {
 var registryLogger = zerolog.Nop()
 
 // SetRegistryLogger configures the logger used by the calculator registry.
+// It is not synchronized and must be called before the registry is used
+// concurrently.
 func SetRegistryLogger(l zerolog.Logger) {
 	registryLogger = l
 }
@@ -47,51 +59,145 @@ func SetRegistryLogger(l zerolog.Logger) {
 // caches Calculator instances for reuse.
 type DefaultFactory struct {
 	mu          sync.RWMutex
-	creators    map[string]func() coreCalculator
+	creators    map[string]func() Calculator
 	calculators map[string]Calculator
 }
 
-// NewDefaultFactory creates a new DefaultFactory with the standard
-// Fibonacci calculator implementations pre-registered.
+// Verify that DefaultFactory implements CalculatorFactory.
+var _ CalculatorFactory = (*DefaultFactory)(nil)
+
+// calculatorRegistry is the package-level set of calculator constructors
+// every DefaultFactory starts with: the built-in algorithms, registered when
+// the package is initialized, and those added with RegisterCalculator.
+type calculatorRegistry struct {
+	mu       sync.RWMutex
+	creators map[string]func() Calculator
+}
+
+// registry holds the built-in and externally registered calculators. It is
+// initialized before globalFactory, which is built from it.
+var registry = newBuiltinRegistry()
+
+// newBuiltinRegistry returns a registry holding the built-in algorithms.
 //
-// Pre-registered calculators:
+// Built-in calculators:
 //   - "fast": OptimizedFastDoubling (O(log n), Parallel, Zero-Alloc)
 //   - "matrix": MatrixExponentiation (O(log n), Parallel, Zero-Alloc)
 //   - "fft": FFTBasedCalculator (O(log n), FFT-accelerated)
+//   - "lucas": LucasCalculator (Lucas numbers L(n), not part of "all")
+//   - "iterative": IterativeCalculator (O(n) additions, small-n reference,
+//     not part of "all")
+//   - "kbonacci": KBonacciCalculator (k-bonacci numbers, not part of "all")
+//   - "binet": BinetApproxCalculator (approximate, not part of "all")
+func newBuiltinRegistry() *calculatorRegistry {
+	r := &calculatorRegistry{creators: make(map[string]func() Calculator)}
+	r.mustRegisterCore("fast", func() coreCalculator { return &OptimizedFastDoubling{} })
+	r.mustRegisterCore("matrix", func() coreCalculator { return &MatrixExponentiation{} })
+	r.mustRegisterCore("fft", func() coreCalculator { return &FFTBasedCalculator{} })
+	r.mustRegisterCore(LucasAlgorithm, func() coreCalculator { return &LucasCalculator{} })
+	r.mustRegisterCore(IterativeAlgorithm, func() coreCalculator { return &IterativeCalculator{} })
+	r.mustRegisterCore(KBonacciAlgorithm, func() coreCalculator { return &KBonacciCalculator{} })
+	r.mustRegisterCore(BinetAlgorithm, func() coreCalculator { return &BinetApproxCalculator{} })
+	return r
+}
+
+// add registers ctor under name.
+//
+// Parameters:
+//   - name: The unique identifier for the calculator type.
+//   - ctor: A function that creates a new Calculator instance.
 //
 // Returns:
-//   - *DefaultFactory: A new factory with default calculators registered.
-func NewDefaultFactory() *DefaultFactory {
-	f := &DefaultFactory{
-		creators:    make(map[string]func() coreCalculator),
-		calculators: make(map[string]Calculator),
+//   - error: An error if name is empty, ctor is nil or a calculator is
+//     already registered under name.
+func (r *calculatorRegistry) add(name string, ctor func() Calculator) error {
+	if name == "" || ctor == nil {
+		return fmt.Errorf("invalid calculator registration: name and constructor are required")
 	}
 
-	// Register the default calculators
-	_ = f.Register("fast", func() coreCalculator { return &OptimizedFastDoubling{} })
-	_ = f.Register("matrix", func() coreCalculator { return &MatrixExponentiation{} })
-	_ = f.Register("fft", func() coreCalculator { return &FFTBasedCalculator{} })
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.creators[name]; exists {
+		return fmt.Errorf("calculator %q is already registered", name)
+	}
+	r.creators[name] = ctor
+	return nil
+}
+
+// mustRegisterCore registers a built-in algorithm, wrapping it with the
+// FibCalculator decorator. Built-in names are fixed, so a collision is a
+// programming error.
+func (r *calculatorRegistry) mustRegisterCore(name string, creator func() coreCalculator) {
+	if err := r.add(name, func() Calculator { return NewCalculator(creator()) }); err != nil {
+		panic(fmt.Sprintf("fibonacci: %v", err))
+	}
+}
+
+// snapshot returns a copy of the registered constructors.
+func (r *calculatorRegistry) snapshot() map[string]func() Calculator {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	creators := make(map[string]func() Calculator, len(r.creators))
+	for name, ctor := range r.creators {
+		creators[name] = ctor
+	}
+	return creators
+}
 
-	return f
+// NewDefaultFactory creates a new DefaultFactory with every calculator of the
+// package registry pre-registered: the built-in algorithms (see
+// newBuiltinRegistry) and those added with RegisterCalculator before the
+// call.
+//
+// Returns:
+//   - *DefaultFactory: A new factory with the registered calculators.
+func NewDefaultFactory() *DefaultFactory {
+	return &DefaultFactory{
+		creators:    registry.snapshot(),
+		calculators: make(map[string]Calculator),
+	}
 }
 
 // Register adds a new calculator type to the factory.
-// The creator function is called lazily when the calculator is first requested.
+// The constructor is called lazily when the calculator is first requested.
 // If a calculator with the same name already exists, it will be replaced.
 //
 // Parameters:
 //   - name: The unique identifier for the calculator type.
-//   - creator: A function that creates a new coreCalculator instance.
-func (f *DefaultFactory) Register(name string, creator func() coreCalculator) error {
+//   - ctor: A function that creates a new Calculator instance.
+//
+// Returns:
+//   - error: An error if name is empty or ctor is nil.
+func (f *DefaultFactory) Register(name string, ctor func() Calculator) error {
+	if name == "" || ctor == nil {
+		return fmt.Errorf("invalid calculator registration: name and constructor are required")
+	}
+
 	f.mu.Lock()
 	defer f.mu.Unlock()
 
-	f.creators[name] = creator
+	f.creators[name] = ctor
 	// Clear cached calculator if it exists, so it will be recreated with the new creator
 	delete(f.calculators, name)
 	return nil
 }
 
+// ExcludedFromAll reports whether the algorithm registered under name must
+// be left out of "--algo all" comparisons, either because it computes a
+// sequence other than F(n), because it is a small-n reference only or
+// because its result is approximate.
+func ExcludedFromAll(name string) bool {
+	return name == LucasAlgorithm || name == IterativeAlgorithm || name == KBonacciAlgorithm || name == BinetAlgorithm
+}
+
+// registerCore registers a built-in algorithm, wrapping it with the
+// FibCalculator decorator.
+func (f *DefaultFactory) registerCore(name string, creator func() coreCalculator) {
+	_ = f.Register(name, func() Calculator { return NewCalculator(creator()) })
+}
+
 // Create creates a new Calculator instance by name.
 // Unlike Get(), this always creates a fresh instance without caching.
 //
@@ -110,7 +216,7 @@ func (f *DefaultFactory) Create(name string) (Calculator, error) {
 		registryLogger.Debug().Str("calculator", name).Msg("calculator not found")
 		return nil, fmt.Errorf("unknown calculator: %s", name)
 	}
-	calc := NewCalculator(creator())
+	calc := creator()
 	registryLogger.Debug().Str("calculator", name).Msg("calculator created")
 	return calc, nil
 }
@@ -149,12 +255,25 @@ func (f *DefaultFactory) Get(name string) (Calculator, error) {
 		return nil, fmt.Errorf("unknown calculator: %s", name)
 	}
 
-	calc := NewCalculator(creator())
+	calc := creator()
 	f.calculators[name] = calc
 	registryLogger.Debug().Str("calculator", name).Msg("calculator created and cached")
 	return calc, nil
 }
 
+// Build returns the Calculator registered under name. It is equivalent to
+// Get and is provided to implement Factory.
+//
+// Parameters:
+//   - name: The name of the calculator to build.
+//
+// Returns:
+//   - Calculator: The Calculator instance.
+//   - error: An error if the calculator type is not registered.
+func (f *DefaultFactory) Build(name string) (Calculator, error) {
+	return f.Get(name)
+}
+
 // List returns a sorted list of all registered calculator names.
 // The list is sorted alphabetically for consistent ordering.
 //
@@ -185,7 +304,7 @@ func (f *DefaultFactory) GetAll() map[string]Calculator {
 	// Ensure all calculators are initialized
 	for name, creator := range f.creators {
 		if _, exists := f.calculators[name]; !exists {
-			f.calculators[name] = NewCalculator(creator())
+			f.calculators[name] = creator()
 		}
 	}
 
@@ -244,12 +363,47 @@ func GlobalFactory() *DefaultFactory {
 	return globalFactory
 }
 
-// RegisterCalculator registers a calculator in the global factory.
-// This is a convenience function for adding custom calculators.
+// RegisterCalculator adds c to the package registry under name, so that it
+// is part of every factory created afterwards with NewDefaultFactory, and to
+// the global factory. Registered calculators take part in --algo, "--algo
+// all" comparisons and shell completion like the built-in ones; embedders
+// should register them before creating the application, typically from an
+// init function.
 //
 // Parameters:
-//   - name: The unique identifier for the calculator type.
-//   - creator: A function that creates a new coreCalculator instance.
-func RegisterCalculator(name string, creator func() coreCalculator) error {
-	return globalFactory.Register(name, creator)
+//   - name: The unique identifier for the calculator.
+//   - c: The calculator, shared by every factory.
+//
+// Returns:
+//   - error: An error if name is empty, c is nil or a calculator, built-in
+//     or not, is already registered under name.
+func RegisterCalculator(name string, c Calculator) error {
+	if c == nil {
+		return fmt.Errorf("invalid calculator registration: name and calculator are required")
+	}
+	return register(name, func() Calculator { return c })
+}
+
+// register adds ctor to the package registry and the global factory.
+func register(name string, ctor func() Calculator) error {
+	if err := registry.add(name, ctor); err != nil {
+		return err
+	}
+	return globalFactory.Register(name, ctor)
+}
+
+// RegisteredCalculators returns the calculators of the package registry,
+// built-in ones included, by name. Calculators added with
+// RegisterCalculator are returned as registered; built-in ones are new
+// instances.
+//
+// Returns:
+//   - map[string]Calculator: A map of calculator names to Calculator instances.
+func RegisteredCalculators() map[string]Calculator {
+	creators := registry.snapshot()
+	calculators := make(map[string]Calculator, len(creators))
+	for name, ctor := range creators {
+		calculators[name] = ctor()
+	}
+	return calculators
 }
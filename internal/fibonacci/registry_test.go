@@ -21,7 +21,7 @@ func TestDefaultFactory(t *testing.T) {
 
 	// Test Register and Has
 	t.Run("RegisterAndHas", func(t *testing.T) {
-		factory.Register("test", func() coreCalculator { return &mockCoreCalculator{} })
+		factory.Register("test", func() Calculator { return NewCalculator(&mockCoreCalculator{}) })
 		if !factory.Has("test") {
 			t.Error("Factory should have 'test' calculator")
 		}
@@ -152,8 +152,83 @@ func TestGlobalFactory(t *testing.T) {
 	}
 
 	// Ensure RegisterCalculator works
-	RegisterCalculator("global_test", func() coreCalculator { return &mockCoreCalculator{} })
+	unregisterOnCleanup(t, "global_test")
+	if err := RegisterCalculator("global_test", NewCalculator(&mockCoreCalculator{})); err != nil {
+		t.Fatalf("RegisterCalculator failed: %v", err)
+	}
 	if !f.Has("global_test") {
 		t.Error("Global factory should have 'global_test' calculator")
 	}
 }
+
+// TestRegisterCalculator verifies that registered calculators reach new
+// factories and RegisteredCalculators, and that names are unique.
+func TestRegisterCalculator(t *testing.T) {
+	t.Parallel()
+	stub := &MockCalculator{}
+	unregisterOnCleanup(t, "registry_stub")
+	if err := RegisterCalculator("registry_stub", stub); err != nil {
+		t.Fatalf("RegisterCalculator failed: %v", err)
+	}
+
+	registered := RegisteredCalculators()
+	if registered["registry_stub"] != stub {
+		t.Errorf("RegisteredCalculators()[registry_stub] = %v, want the stub", registered["registry_stub"])
+	}
+	for _, name := range []string{"fast", "matrix", "fft", LucasAlgorithm, IterativeAlgorithm, KBonacciAlgorithm, BinetAlgorithm} {
+		if registered[name] == nil {
+			t.Errorf("built-in calculator %q is not registered", name)
+		}
+	}
+
+	if calc, err := NewDefaultFactory().Get("registry_stub"); err != nil || calc != stub {
+		t.Errorf("NewDefaultFactory().Get(registry_stub) = %v, %v, want the stub", calc, err)
+	}
+
+	for _, name := range []string{"registry_stub", "fast"} {
+		if err := RegisterCalculator(name, &MockCalculator{}); err == nil {
+			t.Errorf("RegisterCalculator(%q) should fail: the name is already registered", name)
+		}
+	}
+	if err := RegisterCalculator("", stub); err == nil {
+		t.Error("RegisterCalculator should reject an empty name")
+	}
+	if err := RegisterCalculator("registry_nil", nil); err == nil {
+		t.Error("RegisterCalculator should reject a nil calculator")
+	}
+}
+
+// unregisterOnCleanup removes the calculators registered under names from the
+// package registry and the global factory when the test ends, so that they do
+// not show up in the factories of other tests and examples.
+func unregisterOnCleanup(t *testing.T, names ...string) {
+	t.Helper()
+	t.Cleanup(func() {
+		for _, name := range names {
+			UnregisterCalculator(name)
+		}
+	})
+}
+
+func TestDefaultFactoryRegisterValidation(t *testing.T) {
+	t.Parallel()
+	factory := NewDefaultFactory()
+	if err := factory.Register("", func() Calculator { return &MockCalculator{} }); err == nil {
+		t.Error("Register should reject an empty name")
+	}
+	if err := factory.Register("nil", nil); err == nil {
+		t.Error("Register should reject a nil constructor")
+	}
+
+	custom := &MockCalculator{}
+	if err := factory.Register("custom", func() Calculator { return custom }); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	calc, err := factory.Build("custom")
+	if err != nil || calc != custom {
+		t.Errorf("Build(custom) = %v, %v; want the registered calculator", calc, err)
+	}
+	if _, err := factory.Build("unknown"); err == nil {
+		t.Error("Build should fail for an unknown calculator")
+	}
+}
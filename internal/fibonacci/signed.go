@@ -0,0 +1,41 @@
+package fibonacci
+
+import (
+	"context"
+	"math/big"
+)
+
+// FibonacciSigned computes F(n) for any signed index n, extending the sequence
+// to negative indices ("negafibonacci") with the identity
+//
+//	F(-n) = (-1)^(n+1) F(n)
+//
+// so that F(-1) = 1, F(-2) = -1, F(-3) = 2, F(-4) = -3, ... The magnitude is
+// computed with the optimized Fast Doubling calculator.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - n: The signed index of the Fibonacci number to calculate.
+//   - opts: Configuration options for the calculation.
+//
+// Returns:
+//   - *big.Int: The calculated Fibonacci number (negative for even negative n).
+//   - error: An error if one occurred (e.g., context cancellation).
+func FibonacciSigned(ctx context.Context, n int64, opts Options) (*big.Int, error) {
+	var abs uint64
+	if n < 0 {
+		// Written this way to stay correct for math.MinInt64.
+		abs = uint64(-(n + 1)) + 1
+	} else {
+		abs = uint64(n)
+	}
+
+	result, err := NewCalculator(&OptimizedFastDoubling{}).Calculate(ctx, nil, 0, abs, opts)
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 && abs%2 == 0 {
+		result.Neg(result)
+	}
+	return result, nil
+}
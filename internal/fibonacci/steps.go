@@ -0,0 +1,73 @@
+// This file lets callers collect the timing of each doubling step of a
+// calculation, e.g. to see where the time goes in fast doubling.
+
+package fibonacci
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci/threshold"
+)
+
+// StepRecorder receives the metrics of each doubling iteration. Its method
+// matches threshold.DynamicThresholdManager.RecordIteration, so a manager can
+// be used as a recorder.
+type StepRecorder interface {
+	// RecordIteration is called once per iteration of the doubling loop,
+	// from the goroutine running the calculation.
+	RecordIteration(bitLen int, duration time.Duration, usedFFT, usedParallel bool)
+}
+
+// stepRecorderKey is the context key of the step recorder.
+type stepRecorderKey struct{}
+
+// WithStepRecorder returns a copy of ctx whose calculations report each
+// doubling iteration to r. Only the calculators built on the doubling
+// framework, such as fast and fft, report steps; the others ignore r.
+//
+// Parameters:
+//   - ctx: The parent context.
+//   - r: The recorder receiving the iterations.
+//
+// Returns:
+//   - context.Context: The context to pass to Calculate.
+func WithStepRecorder(ctx context.Context, r StepRecorder) context.Context {
+	return context.WithValue(ctx, stepRecorderKey{}, r)
+}
+
+// stepRecorderFrom returns the step recorder carried by ctx, or nil.
+func stepRecorderFrom(ctx context.Context) StepRecorder {
+	r, _ := ctx.Value(stepRecorderKey{}).(StepRecorder)
+	return r
+}
+
+// StepCollector is a StepRecorder keeping every iteration in order. It is
+// safe for concurrent use.
+type StepCollector struct {
+	mu    sync.Mutex
+	steps []threshold.IterationMetric
+}
+
+// RecordIteration appends the metrics of one iteration.
+func (c *StepCollector) RecordIteration(bitLen int, duration time.Duration, usedFFT, usedParallel bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.steps = append(c.steps, threshold.IterationMetric{
+		BitLen:       bitLen,
+		Duration:     duration,
+		UsedFFT:      usedFFT,
+		UsedParallel: usedParallel,
+	})
+}
+
+// Steps returns a copy of the recorded iterations, in order.
+//
+// Returns:
+//   - []threshold.IterationMetric: One metric per doubling iteration.
+func (c *StepCollector) Steps() []threshold.IterationMetric {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]threshold.IterationMetric(nil), c.steps...)
+}
@@ -0,0 +1,39 @@
+package fibonacci
+
+import (
+	"context"
+	"math/bits"
+	"testing"
+)
+
+func TestStepCollectorRecordsEachDoublingStep(t *testing.T) {
+	t.Parallel()
+	for _, core := range []coreCalculator{&OptimizedFastDoubling{}, &FFTBasedCalculator{}} {
+		t.Run(core.Name(), func(t *testing.T) {
+			t.Parallel()
+			const n = 100_000
+			var collector StepCollector
+			ctx := WithStepRecorder(context.Background(), &collector)
+			if _, err := NewCalculator(core).Calculate(ctx, nil, 0, n, Options{}); err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+
+			steps := collector.Steps()
+			if len(steps) != bits.Len64(n) {
+				t.Fatalf("got %d steps, want bits.Len64(%d) = %d", len(steps), n, bits.Len64(n))
+			}
+			for i := 1; i < len(steps); i++ {
+				if steps[i].BitLen < steps[i-1].BitLen {
+					t.Errorf("step %d: bit length %d decreased from %d", i, steps[i].BitLen, steps[i-1].BitLen)
+				}
+			}
+		})
+	}
+}
+
+func TestStepRecorderAbsentIsIgnored(t *testing.T) {
+	t.Parallel()
+	if r := stepRecorderFrom(context.Background()); r != nil {
+		t.Errorf("stepRecorderFrom() = %v, want nil without a recorder", r)
+	}
+}
@@ -73,12 +73,17 @@ func (f *TestFactory) List() []string {
 	return names
 }
 
-// Register is a no-op for TestFactory as calculators are provided at construction.
-func (f *TestFactory) Register(name string, creator func() coreCalculator) error {
-	// No-op: calculators are set at construction time
+// Register adds a calculator to the factory by calling ctor once.
+func (f *TestFactory) Register(name string, ctor func() Calculator) error {
+	f.calculators[name] = ctor()
 	return nil
 }
 
+// Build returns the calculator by name.
+func (f *TestFactory) Build(name string) (Calculator, error) {
+	return f.Get(name)
+}
+
 // GetAll returns all calculators.
 func (f *TestFactory) GetAll() map[string]Calculator {
 	result := make(map[string]Calculator, len(f.calculators))
@@ -96,3 +101,20 @@ type UnknownCalculatorError struct {
 func (e *UnknownCalculatorError) Error() string {
 	return "unknown calculator: " + e.Name
 }
+
+// UnregisterCalculator removes the calculator registered under name from the
+// package registry and the global factory. It is intended for tests that
+// call RegisterCalculator, so that their calculators do not show up in the
+// factories of other tests; unknown names are ignored.
+//
+// Parameters:
+//   - name: The name the calculator was registered under.
+func UnregisterCalculator(name string) {
+	registry.mu.Lock()
+	globalFactory.mu.Lock()
+	defer registry.mu.Unlock()
+	defer globalFactory.mu.Unlock()
+	delete(registry.creators, name)
+	delete(globalFactory.creators, name)
+	delete(globalFactory.calculators, name)
+}
@@ -108,3 +108,22 @@ func TestMockCalculator_Calculate(t *testing.T) {
 		}
 	})
 }
+
+func TestUnregisterCalculator(t *testing.T) {
+	t.Parallel()
+
+	if err := RegisterCalculator("unregister_stub", &MockCalculator{}); err != nil {
+		t.Fatalf("RegisterCalculator failed: %v", err)
+	}
+	UnregisterCalculator("unregister_stub")
+	if _, ok := RegisteredCalculators()["unregister_stub"]; ok {
+		t.Error("unregister_stub is still registered")
+	}
+	if GlobalFactory().Has("unregister_stub") {
+		t.Error("the global factory still has unregister_stub")
+	}
+	if err := RegisterCalculator("unregister_stub", &MockCalculator{}); err != nil {
+		t.Errorf("RegisterCalculator after UnregisterCalculator failed: %v", err)
+	}
+	UnregisterCalculator("unregister_stub")
+}
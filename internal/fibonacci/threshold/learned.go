@@ -0,0 +1,130 @@
+// This file implements persistence of dynamically learned thresholds.
+
+package threshold
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// saveMu serializes the read-modify-write of SaveLearned within the process.
+var saveMu sync.Mutex
+
+// Learned returns the current (adjusted) thresholds in their persisted form,
+// stamped with the current time.
+//
+// Returns:
+//   - LearnedThresholds: The current thresholds.
+func (m *DynamicThresholdManager) Learned() LearnedThresholds {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return LearnedThresholds{
+		FFTThreshold:      m.currentFFTThreshold,
+		ParallelThreshold: m.currentParallelThreshold,
+		LearnedAt:         time.Now(),
+	}
+}
+
+// SaveLearned writes the current (adjusted) thresholds to path as JSON, so
+// that a later run can start from them (see LoadLearned).
+//
+// The keys are those of the calibration profile's learned fields. If path
+// already holds a JSON object, the learned keys are merged into it and its
+// other fields are preserved; calibration profiles should be updated with
+// calibration.SaveLearnedThresholds instead, which also updates their
+// checksum. Concurrent saves within the process are serialized, and the file
+// is replaced atomically.
+//
+// Parameters:
+//   - path: The destination file.
+//
+// Returns:
+//   - error: An error if the existing file is not a JSON object or the file
+//     cannot be written.
+func (m *DynamicThresholdManager) SaveLearned(path string) error {
+	learned := m.Learned()
+
+	saveMu.Lock()
+	defer saveMu.Unlock()
+	fields := map[string]json.RawMessage{}
+	if data, err := os.ReadFile(path); err == nil {
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return fmt.Errorf("failed to merge learned thresholds into %s: %w", path, err)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	encoded, err := json.Marshal(learned)
+	if err != nil {
+		return fmt.Errorf("failed to marshal learned thresholds: %w", err)
+	}
+	if err := json.Unmarshal(encoded, &fields); err != nil {
+		return fmt.Errorf("failed to marshal learned thresholds: %w", err)
+	}
+	data, err := json.MarshalIndent(fields, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal learned thresholds: %w", err)
+	}
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("failed to write learned thresholds: %w", err)
+	}
+	return nil
+}
+
+// writeFileAtomic writes data to a temporary file next to path and renames
+// it over path, so that readers never see a partially written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// LoadLearned reads thresholds saved by SaveLearned, from a learned
+// thresholds file or a calibration profile, and installs them as both the
+// current and the initial thresholds: Reset returns to them, and adjustments
+// are measured against them.
+//
+// Parameters:
+//   - path: The file to read.
+//
+// Returns:
+//   - error: An error if the file cannot be read or parsed, or holds no
+//     positive learned thresholds.
+func (m *DynamicThresholdManager) LoadLearned(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read learned thresholds: %w", err)
+	}
+	var learned LearnedThresholds
+	if err := json.Unmarshal(data, &learned); err != nil {
+		return fmt.Errorf("failed to parse learned thresholds: %w", err)
+	}
+	if learned.FFTThreshold <= 0 || learned.ParallelThreshold <= 0 {
+		return fmt.Errorf("no learned thresholds in %s", path)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.currentFFTThreshold = learned.FFTThreshold
+	m.currentParallelThreshold = learned.ParallelThreshold
+	m.originalFFTThreshold = learned.FFTThreshold
+	m.originalParallelThreshold = learned.ParallelThreshold
+	return nil
+}
@@ -0,0 +1,93 @@
+package threshold
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSaveLoadLearned round-trips adjusted thresholds through a temp file and
+// checks that they become the initial thresholds of the next manager.
+func TestSaveLoadLearned(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "learned.json")
+
+	mgr := NewDynamicThresholdManager(500000, 4096)
+	mgr.currentFFTThreshold = 350000 // as if adjusted by ShouldAdjust
+	mgr.currentParallelThreshold = 8192
+	if err := mgr.SaveLearned(path); err != nil {
+		t.Fatalf("SaveLearned failed: %v", err)
+	}
+
+	next := NewDynamicThresholdManager(500000, 4096)
+	if err := next.LoadLearned(path); err != nil {
+		t.Fatalf("LoadLearned failed: %v", err)
+	}
+	stats := next.GetStats()
+	if stats.CurrentFFT != 350000 || stats.CurrentParallel != 8192 {
+		t.Errorf("current thresholds = (%d, %d), want (350000, 8192)", stats.CurrentFFT, stats.CurrentParallel)
+	}
+	if stats.OriginalFFT != 350000 || stats.OriginalParallel != 8192 {
+		t.Errorf("initial thresholds = (%d, %d), want (350000, 8192)", stats.OriginalFFT, stats.OriginalParallel)
+	}
+	next.Reset()
+	if fft, par := next.GetThresholds(); fft != 350000 || par != 8192 {
+		t.Errorf("Reset restored (%d, %d), want the learned thresholds", fft, par)
+	}
+}
+
+// TestSaveLearnedMergesProfile verifies that saving into an existing JSON
+// object, such as a calibration profile, keeps its other fields.
+func TestSaveLearnedMergesProfile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := os.WriteFile(path, []byte(`{"optimal_fft_threshold": 500000, "checksum": "abc"}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewDynamicThresholdManager(250000, 2048).SaveLearned(path); err != nil {
+		t.Fatalf("SaveLearned failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	if fields["optimal_fft_threshold"] != 500000.0 || fields["checksum"] != "abc" {
+		t.Errorf("existing fields were not preserved: %v", fields)
+	}
+	if fields["learned_fft_threshold"] != 250000.0 || fields["learned_parallel_threshold"] != 2048.0 {
+		t.Errorf("learned fields missing: %v", fields)
+	}
+
+	if err := os.WriteFile(path, []byte(`[1, 2]`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewDynamicThresholdManager(1, 1).SaveLearned(path); err == nil {
+		t.Error("expected an error when the file is not a JSON object")
+	}
+}
+
+func TestLoadLearnedErrors(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	mgr := NewDynamicThresholdManager(500000, 4096)
+
+	if err := mgr.LoadLearned(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+	empty := filepath.Join(dir, "profile.json")
+	if err := os.WriteFile(empty, []byte(`{"optimal_fft_threshold": 500000}`), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := mgr.LoadLearned(empty); err == nil {
+		t.Error("expected an error for a file without learned thresholds")
+	}
+	if fft, par := mgr.GetThresholds(); fft != 500000 || par != 4096 {
+		t.Errorf("failed load changed the thresholds to (%d, %d)", fft, par)
+	}
+}
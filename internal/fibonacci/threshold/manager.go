@@ -38,8 +38,9 @@ const (
 // DynamicThresholdManager adjusts FFT and parallel thresholds during calculation
 // based on observed performance metrics.
 type DynamicThresholdManager struct {
-	mu     sync.RWMutex
-	logger zerolog.Logger
+	mu       sync.RWMutex
+	logger   zerolog.Logger
+	strategy AdjustmentStrategy
 
 	// Current thresholds (can be adjusted during calculation)
 	currentFFTThreshold      int
@@ -54,18 +55,16 @@ type DynamicThresholdManager struct {
 	metricsCount int // Total metrics collected (ever)
 	metricsHead  int // Index of the next slot to write to
 
-	// Running sums for fast average calculation (O(1))
-	// We track separate sums for different categories to avoid iterating.
-	// Note: These sums are approximate as they cover the window, but simplifying updates is key.
-	// Actually, accurate running sums require removing the overwritten value.
-	// Given MaxMetricsHistory is small (20), iterating is fast enough, but let's optimize slightly.
-	// We will stick to iterating the small ring buffer for simplicity in categorization (FFT vs Non-FFT),
-	// but the structure itself is now a fixed array to avoid allocation.
+	// Exact running sums over the metrics in the ring buffer, indexed by
+	// [UsedFFT][UsedParallel], so that analysis is O(1) in the history size.
+	// Overwritten metrics are subtracted as the buffer wraps around.
+	sums [2][2]metricSums
 
 	// Adjustment state
 	iterationCount     int
 	adjustmentInterval int
 	lastAdjustment     time.Time
+	adjustments        []AdjustmentEvent
 }
 
 // ─────────────────────────────────────────────────────────────────────────────
@@ -76,6 +75,7 @@ type DynamicThresholdManager struct {
 func NewDynamicThresholdManager(fftThreshold, parallelThreshold int) *DynamicThresholdManager {
 	return &DynamicThresholdManager{
 		logger:                    zerolog.Nop(),
+		strategy:                  DefaultStrategy{},
 		currentFFTThreshold:       fftThreshold,
 		currentParallelThreshold:  parallelThreshold,
 		originalFFTThreshold:      fftThreshold,
@@ -86,9 +86,26 @@ func NewDynamicThresholdManager(fftThreshold, parallelThreshold int) *DynamicThr
 
 // NewDynamicThresholdManagerFromConfig creates a manager from configuration.
 func NewDynamicThresholdManagerFromConfig(cfg DynamicThresholdConfig) *DynamicThresholdManager {
+	return NewDynamicThresholdManagerWithStrategy(cfg, DefaultStrategy{})
+}
+
+// NewDynamicThresholdManagerWithStrategy creates a manager from configuration
+// that moves its thresholds with the given strategy, e.g. a more aggressive or
+// conservative variant of DefaultStrategy.
+//
+// Parameters:
+//   - cfg: The dynamic threshold configuration.
+//   - strategy: The adjustment policy; nil selects DefaultStrategy.
+//
+// Returns:
+//   - *DynamicThresholdManager: The manager, or nil if cfg is not enabled.
+func NewDynamicThresholdManagerWithStrategy(cfg DynamicThresholdConfig, strategy AdjustmentStrategy) *DynamicThresholdManager {
 	if !cfg.Enabled {
 		return nil
 	}
+	if strategy == nil {
+		strategy = DefaultStrategy{}
+	}
 
 	interval := cfg.AdjustmentInterval
 	if interval <= 0 {
@@ -97,6 +114,7 @@ func NewDynamicThresholdManagerFromConfig(cfg DynamicThresholdConfig) *DynamicTh
 
 	return &DynamicThresholdManager{
 		logger:                    zerolog.Nop(),
+		strategy:                  strategy,
 		currentFFTThreshold:       cfg.InitialFFTThreshold,
 		currentParallelThreshold:  cfg.InitialParallelThreshold,
 		originalFFTThreshold:      cfg.InitialFFTThreshold,
@@ -125,6 +143,10 @@ func (m *DynamicThresholdManager) RecordIteration(bitLen int, duration time.Dura
 	}
 
 	// Write to ring buffer (no mutex needed: called from single goroutine in the doubling loop)
+	if m.metricsCount >= MaxMetricsHistory {
+		m.sumsFor(m.metrics[m.metricsHead]).sub(m.metrics[m.metricsHead])
+	}
+	m.sumsFor(metric).add(metric)
 	m.metrics[m.metricsHead] = metric
 	m.metricsHead = (m.metricsHead + 1) % MaxMetricsHistory
 	m.metricsCount++
@@ -184,13 +206,15 @@ func (m *DynamicThresholdManager) ShouldAdjust() (newFFT, newParallel int, adjus
 	if fftChanged || parallelChanged {
 		oldFFT := m.currentFFTThreshold
 		oldParallel := m.currentParallelThreshold
+		m.lastAdjustment = time.Now()
 		if fftChanged {
 			m.currentFFTThreshold = newFFT
+			m.recordAdjustment(FFTThresholdKind, oldFFT, newFFT)
 		}
 		if parallelChanged {
 			m.currentParallelThreshold = newParallel
+			m.recordAdjustment(ParallelThresholdKind, oldParallel, newParallel)
 		}
-		m.lastAdjustment = time.Now()
 		m.logger.Debug().
 			Int("iteration", m.iterationCount).
 			Bool("fft_changed", fftChanged).
@@ -206,65 +230,29 @@ func (m *DynamicThresholdManager) ShouldAdjust() (newFFT, newParallel int, adjus
 	return m.currentFFTThreshold, m.currentParallelThreshold, false
 }
 
-// getActiveMetrics returns a slice of valid metrics from the ring buffer.
+// getActiveMetrics returns the valid metrics of the ring buffer, oldest first.
+// Since MaxMetricsHistory is small (20), this copy is cheap and simplifies logic.
 func (m *DynamicThresholdManager) getActiveMetrics() []IterationMetric {
-	count := m.metricsCount
-	if count > MaxMetricsHistory {
-		count = MaxMetricsHistory
-	}
-
-	// Create a temporary slice to make analysis easier without complex ring buffer arithmetic
-	// Since MaxMetricsHistory is small (20), this copy is cheap and simplifies logic.
-	result := make([]IterationMetric, count)
-
 	if m.metricsCount <= MaxMetricsHistory {
-		copy(result, m.metrics[:count])
-	} else {
-		// Buffer wrapped around. Order: [Head...End] + [0...Head-1]
-		// Actually, since we just need the values and not strict temporal order for averages,
-		// we can just copy the whole array.
-		// However, to be strictly correct if we needed order:
-		// tailLen := MaxMetricsHistory - m.metricsHead
-		// copy(result, m.metrics[m.metricsHead:])
-		// copy(result[tailLen:], m.metrics[:m.metricsHead])
-		// For averages, order doesn't matter.
-		copy(result, m.metrics[:])
+		return append([]IterationMetric(nil), m.metrics[:m.metricsCount]...)
 	}
-	return result
+
+	// Buffer wrapped around: the oldest metric is at metricsHead.
+	result := make([]IterationMetric, 0, MaxMetricsHistory)
+	result = append(result, m.metrics[m.metricsHead:]...)
+	return append(result, m.metrics[:m.metricsHead]...)
 }
 
 // thresholdAnalysisParams captures the per-threshold configuration differences
 // used by analyzeThreshold to avoid duplicated analysis logic.
 type thresholdAnalysisParams struct {
 	// predicate selects which metrics belong to the "optimized" mode (FFT or parallel).
+	// It must only depend on the UsedFFT and UsedParallel flags.
 	predicate func(IterationMetric) bool
-	// speedupThreshold is the minimum ratio to consider the optimized mode faster.
-	speedupThreshold float64
-	// lowerNumerator/raiseNumerator over 10: adjustment factors when lowering/raising.
-	lowerNumerator int
-	raiseNumerator int
-	// minThreshold is the floor for the threshold value.
-	minThreshold int
-	// maxCapMultiplier is the multiplier against the original threshold for the ceiling.
-	maxCapMultiplier int
-	// currentThreshold and originalThreshold are the values being analyzed.
-	currentThreshold  int
-	originalThreshold int
-}
-
-// filterMetricsByMode partitions metrics into two groups based on the predicate.
-// Returns (matching, non-matching) slices.
-func filterMetricsByMode(metrics []IterationMetric, predicate func(IterationMetric) bool) (matching, nonMatching []IterationMetric) {
-	matching = make([]IterationMetric, 0, len(metrics))
-	nonMatching = make([]IterationMetric, 0, len(metrics))
-	for _, metric := range metrics {
-		if predicate(metric) {
-			matching = append(matching, metric)
-		} else {
-			nonMatching = append(nonMatching, metric)
-		}
-	}
-	return matching, nonMatching
+	// bounds are the limits handed to the adjustment strategy.
+	bounds Bounds
+	// currentThreshold is the value being analyzed.
+	currentThreshold int
 }
 
 // calculateSpeedupRatio returns the speedup ratio of baseline over optimized.
@@ -277,19 +265,15 @@ func calculateSpeedupRatio(avgOptimized, avgBaseline float64) float64 {
 }
 
 // analyzeThreshold is the common analysis logic for both FFT and parallel thresholds.
-// It partitions metrics, computes a speedup ratio, and returns an adjusted threshold.
+// It partitions the running sums, computes a speedup ratio, and returns an
+// adjusted threshold.
 func (m *DynamicThresholdManager) analyzeThreshold(params thresholdAnalysisParams) int {
-	metrics := m.getActiveMetrics()
-	if len(metrics) == 0 {
-		return params.currentThreshold
-	}
-
-	optimized, baseline := filterMetricsByMode(metrics, params.predicate)
-	if len(optimized) == 0 || len(baseline) == 0 {
+	optimized, baseline := m.partitionSums(params.predicate)
+	if optimized.count == 0 || baseline.count == 0 {
 		return params.currentThreshold
 	}
 
-	ratio := calculateSpeedupRatio(m.avgTimePerBit(optimized), m.avgTimePerBit(baseline))
+	ratio := calculateSpeedupRatio(optimized.timePerBit(), baseline.timePerBit())
 	if ratio == 0 {
 		return params.currentThreshold
 	}
@@ -297,74 +281,109 @@ func (m *DynamicThresholdManager) analyzeThreshold(params thresholdAnalysisParam
 	return m.applyThresholdAdjustment(ratio, params)
 }
 
-// applyThresholdAdjustment applies the lower/raise logic based on the speedup ratio.
+// applyThresholdAdjustment delegates the new threshold to the strategy.
 func (m *DynamicThresholdManager) applyThresholdAdjustment(ratio float64, params thresholdAnalysisParams) int {
-	if ratio > params.speedupThreshold {
-		// Optimized mode is faster, lower threshold
-		newThreshold := params.currentThreshold * params.lowerNumerator / 10
-		if newThreshold < params.minThreshold {
-			newThreshold = params.minThreshold
-		}
-		return newThreshold
+	strategy := m.strategy
+	if strategy == nil {
+		strategy = DefaultStrategy{}
 	}
-	if ratio < 1.0/params.speedupThreshold {
-		// Optimized mode is slower, raise threshold
-		newThreshold := params.currentThreshold * params.raiseNumerator / 10
-		maxCap := params.originalThreshold * params.maxCapMultiplier
-		if newThreshold > maxCap {
-			newThreshold = maxCap
-		}
-		return newThreshold
-	}
-	return params.currentThreshold
+	return strategy.Adjust(params.currentThreshold, ratio, params.bounds)
 }
 
 // analyzeFFTThreshold analyzes metrics to determine optimal FFT threshold.
 func (m *DynamicThresholdManager) analyzeFFTThreshold() int {
 	return m.analyzeThreshold(thresholdAnalysisParams{
-		predicate:         func(metric IterationMetric) bool { return metric.UsedFFT },
-		speedupThreshold:  FFTSpeedupThreshold,
-		lowerNumerator:    9,
-		raiseNumerator:    11,
-		minThreshold:      100000,
-		maxCapMultiplier:  2,
-		currentThreshold:  m.currentFFTThreshold,
-		originalThreshold: m.originalFFTThreshold,
+		predicate: func(metric IterationMetric) bool { return metric.UsedFFT },
+		bounds: Bounds{
+			Kind:             FFTThresholdKind,
+			Min:              100000,
+			Max:              m.originalFFTThreshold * 2,
+			Original:         m.originalFFTThreshold,
+			SpeedupThreshold: FFTSpeedupThreshold,
+		},
+		currentThreshold: m.currentFFTThreshold,
 	})
 }
 
 // analyzeParallelThreshold analyzes metrics to determine optimal parallel threshold.
 func (m *DynamicThresholdManager) analyzeParallelThreshold() int {
 	return m.analyzeThreshold(thresholdAnalysisParams{
-		predicate:         func(metric IterationMetric) bool { return metric.UsedParallel },
-		speedupThreshold:  ParallelSpeedupThreshold,
-		lowerNumerator:    8,
-		raiseNumerator:    12,
-		minThreshold:      1024,
-		maxCapMultiplier:  4,
-		currentThreshold:  m.currentParallelThreshold,
-		originalThreshold: m.originalParallelThreshold,
+		predicate: func(metric IterationMetric) bool { return metric.UsedParallel },
+		bounds: Bounds{
+			Kind:             ParallelThresholdKind,
+			Min:              1024,
+			Max:              m.originalParallelThreshold * 4,
+			Original:         m.originalParallelThreshold,
+			SpeedupThreshold: ParallelSpeedupThreshold,
+		},
+		currentThreshold: m.currentParallelThreshold,
 	})
 }
 
-// avgTimePerBit calculates average time per bit across metrics.
-func (m *DynamicThresholdManager) avgTimePerBit(metrics []IterationMetric) float64 {
-	if len(metrics) == 0 {
+// metricSums accumulates the totals of a group of metrics.
+type metricSums struct {
+	duration time.Duration
+	bits     int64
+	count    int
+}
+
+// add includes a metric in the totals.
+func (s *metricSums) add(metric IterationMetric) {
+	s.duration += metric.Duration
+	s.bits += int64(metric.BitLen)
+	s.count++
+}
+
+// sub removes a previously added metric from the totals.
+func (s *metricSums) sub(metric IterationMetric) {
+	s.duration -= metric.Duration
+	s.bits -= int64(metric.BitLen)
+	s.count--
+}
+
+// merge adds the totals of another group.
+func (s *metricSums) merge(other metricSums) {
+	s.duration += other.duration
+	s.bits += other.bits
+	s.count += other.count
+}
+
+// timePerBit returns the average time per bit in nanoseconds, or 0 if the
+// group has no bits.
+func (s metricSums) timePerBit() float64 {
+	if s.bits == 0 {
 		return 0
 	}
+	return float64(s.duration.Nanoseconds()) / float64(s.bits)
+}
 
-	var totalTime time.Duration
-	var totalBits int64
-	for _, metric := range metrics {
-		totalTime += metric.Duration
-		totalBits += int64(metric.BitLen)
-	}
+// sumsFor returns the running sums of the category of metric.
+func (m *DynamicThresholdManager) sumsFor(metric IterationMetric) *metricSums {
+	return &m.sums[b2i(metric.UsedFFT)][b2i(metric.UsedParallel)]
+}
 
-	if totalBits == 0 {
-		return 0
+// partitionSums splits the running sums into the categories matching the
+// predicate (optimized) and the others (baseline).
+func (m *DynamicThresholdManager) partitionSums(predicate func(IterationMetric) bool) (optimized, baseline metricSums) {
+	for _, usedFFT := range []bool{false, true} {
+		for _, usedParallel := range []bool{false, true} {
+			category := IterationMetric{UsedFFT: usedFFT, UsedParallel: usedParallel}
+			if predicate(category) {
+				optimized.merge(*m.sumsFor(category))
+			} else {
+				baseline.merge(*m.sumsFor(category))
+			}
+		}
 	}
+	return optimized, baseline
+}
 
-	return float64(totalTime.Nanoseconds()) / float64(totalBits)
+// b2i converts a bool to an array index.
+func b2i(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
 }
 
 // significantChange checks if a threshold change is significant enough to apply.
@@ -413,5 +432,7 @@ func (m *DynamicThresholdManager) Reset() {
 	// Ring buffer reset is simple
 	m.metricsCount = 0
 	m.metricsHead = 0
+	m.sums = [2][2]metricSums{}
 	m.iterationCount = 0
+	m.adjustments = nil
 }
@@ -141,6 +141,31 @@ func TestRecordIterationHistoryLimit(t *testing.T) {
 	}
 }
 
+// TestRunningSumsAfterWraparound verifies that the running sums always match
+// a recomputation over the metrics retained in the ring buffer, including
+// after overwritten metrics have been subtracted.
+func TestRunningSumsAfterWraparound(t *testing.T) {
+	t.Parallel()
+	mgr := NewDynamicThresholdManager(500000, 10000)
+
+	for i := 0; i < 3*MaxMetricsHistory+7; i++ {
+		mgr.RecordIteration(100+i*13, time.Duration(i%7+1)*time.Microsecond, i%3 == 0, i%2 == 0)
+
+		var want [2][2]metricSums
+		for _, metric := range mgr.getActiveMetrics() {
+			want[b2i(metric.UsedFFT)][b2i(metric.UsedParallel)].add(metric)
+		}
+		if mgr.sums != want {
+			t.Fatalf("after %d iterations: sums = %+v, want %+v", i+1, mgr.sums, want)
+		}
+	}
+
+	mgr.Reset()
+	if mgr.sums != ([2][2]metricSums{}) {
+		t.Errorf("expected zero sums after Reset, got %+v", mgr.sums)
+	}
+}
+
 // TestGetFFTThreshold tests individual threshold getter.
 func TestGetFFTThreshold(t *testing.T) {
 	t.Parallel()
@@ -468,32 +493,34 @@ func TestAnalyzeParallelThreshold(t *testing.T) {
 	})
 }
 
-// TestAvgTimePerBit tests the time-per-bit calculation.
-func TestAvgTimePerBit(t *testing.T) {
-	mgr := NewDynamicThresholdManager(500000, 10000)
+// TestMetricSumsTimePerBit tests the time-per-bit calculation.
+func TestMetricSumsTimePerBit(t *testing.T) {
+	sumsOf := func(metrics ...IterationMetric) metricSums {
+		var sums metricSums
+		for _, metric := range metrics {
+			sums.add(metric)
+		}
+		return sums
+	}
 
 	t.Run("empty metrics returns zero", func(t *testing.T) {
-		result := mgr.avgTimePerBit(nil)
-		if result != 0 {
+		if result := sumsOf().timePerBit(); result != 0 {
 			t.Errorf("expected 0, got %f", result)
 		}
 	})
 
 	t.Run("zero bits returns zero", func(t *testing.T) {
-		metrics := []IterationMetric{{BitLen: 0, Duration: time.Millisecond}}
-		result := mgr.avgTimePerBit(metrics)
-		if result != 0 {
+		if result := sumsOf(IterationMetric{BitLen: 0, Duration: time.Millisecond}).timePerBit(); result != 0 {
 			t.Errorf("expected 0 for zero bits, got %f", result)
 		}
 	})
 
 	t.Run("calculates correctly", func(t *testing.T) {
-		metrics := []IterationMetric{
-			{BitLen: 1000, Duration: time.Millisecond},
-			{BitLen: 2000, Duration: 2 * time.Millisecond},
-		}
 		// Total: 3ms for 3000 bits = 1ms/1000 bits = 1000ns/bit
-		result := mgr.avgTimePerBit(metrics)
+		result := sumsOf(
+			IterationMetric{BitLen: 1000, Duration: time.Millisecond},
+			IterationMetric{BitLen: 2000, Duration: 2 * time.Millisecond},
+		).timePerBit()
 		expected := float64(3*time.Millisecond) / 3000.0
 		if result != expected {
 			t.Errorf("expected %f, got %f", expected, result)
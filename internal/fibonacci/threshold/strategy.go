@@ -0,0 +1,85 @@
+// This file defines the pluggable policy that turns an observed speedup ratio
+// into a new threshold value.
+
+package threshold
+
+// ThresholdKind identifies the threshold being adjusted.
+type ThresholdKind int
+
+const (
+	// FFTThresholdKind is the operand size (in bits) above which FFT
+	// multiplication is used.
+	FFTThresholdKind ThresholdKind = iota
+	// ParallelThresholdKind is the operand size (in bits) above which
+	// multiplications run in parallel.
+	ParallelThresholdKind
+)
+
+// String returns the threshold name, e.g. "fft".
+func (k ThresholdKind) String() string {
+	switch k {
+	case FFTThresholdKind:
+		return "fft"
+	case ParallelThresholdKind:
+		return "parallel"
+	default:
+		return "unknown"
+	}
+}
+
+// Bounds describes the threshold handed to an AdjustmentStrategy.
+type Bounds struct {
+	// Kind is the threshold being adjusted
+	Kind ThresholdKind
+	// Min is the floor for the threshold value
+	Min int
+	// Max is the ceiling for the threshold value
+	Max int
+	// Original is the threshold the manager started with
+	Original int
+	// SpeedupThreshold is the minimum ratio considered a real speedup
+	// (FFTSpeedupThreshold or ParallelSpeedupThreshold)
+	SpeedupThreshold float64
+}
+
+// AdjustmentStrategy decides how a DynamicThresholdManager moves a threshold.
+// The manager still applies HysteresisMargin to the returned value, so small
+// moves are ignored.
+type AdjustmentStrategy interface {
+	// Adjust returns the new threshold.
+	//
+	// Parameters:
+	//   - current: The current threshold.
+	//   - ratio: The time per bit without the optimization divided by the time
+	//     per bit with it; above 1 the optimized mode (FFT or parallel) is faster.
+	//   - bounds: The limits and defaults of the threshold.
+	//
+	// Returns:
+	//   - int: The new threshold, which should lie within bounds.
+	Adjust(current int, ratio float64, bounds Bounds) int
+}
+
+// DefaultStrategy is the built-in adjustment policy. When the optimized mode
+// beats the speedup threshold, the threshold is lowered (by 10% for FFT, 20%
+// for parallelism); when it loses by the same margin, the threshold is raised
+// (by 10% and 20% respectively). Results are clamped to bounds.
+type DefaultStrategy struct{}
+
+// Adjust implements AdjustmentStrategy.
+func (DefaultStrategy) Adjust(current int, ratio float64, bounds Bounds) int {
+	// Adjustment factors, in tenths.
+	lower, raise := 9, 11
+	if bounds.Kind == ParallelThresholdKind {
+		lower, raise = 8, 12
+	}
+
+	if ratio > bounds.SpeedupThreshold {
+		// Optimized mode is faster, lower threshold
+		return max(current*lower/10, bounds.Min)
+	}
+	if ratio < 1.0/bounds.SpeedupThreshold {
+		// Optimized mode is slower, raise threshold
+		return min(current*raise/10, bounds.Max)
+	}
+	return current
+}
@@ -0,0 +1,115 @@
+package threshold
+
+import (
+	"testing"
+	"time"
+)
+
+// TestDefaultStrategyAdjust pins the built-in adjustment factors and bounds.
+func TestDefaultStrategyAdjust(t *testing.T) {
+	t.Parallel()
+	fftBounds := Bounds{Kind: FFTThresholdKind, Min: 100000, Max: 1000000, Original: 500000, SpeedupThreshold: FFTSpeedupThreshold}
+	parBounds := Bounds{Kind: ParallelThresholdKind, Min: 1024, Max: 40000, Original: 10000, SpeedupThreshold: ParallelSpeedupThreshold}
+
+	tests := []struct {
+		name    string
+		current int
+		ratio   float64
+		bounds  Bounds
+		want    int
+	}{
+		{"fft faster lowers by 10%", 500000, 2, fftBounds, 450000},
+		{"fft slower raises by 10%", 500000, 0.5, fftBounds, 550000},
+		{"fft within margin unchanged", 500000, 1, fftBounds, 500000},
+		{"fft clamped to min", 100001, 2, fftBounds, 100000},
+		{"fft clamped to max", 990000, 0.5, fftBounds, 1000000},
+		{"parallel faster lowers by 20%", 10000, 2, parBounds, 8000},
+		{"parallel slower raises by 20%", 10000, 0.5, parBounds, 12000},
+		{"parallel within margin unchanged", 10000, 1.05, parBounds, 10000},
+		{"parallel clamped to min", 1100, 2, parBounds, 1024},
+		{"parallel clamped to max", 39000, 0.5, parBounds, 40000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := (DefaultStrategy{}).Adjust(tt.current, tt.ratio, tt.bounds); got != tt.want {
+				t.Errorf("Adjust(%d, %v) = %d, want %d", tt.current, tt.ratio, got, tt.want)
+			}
+		})
+	}
+}
+
+// recordingStrategy halves every threshold and records the bounds it saw.
+type recordingStrategy struct {
+	bounds []Bounds
+}
+
+func (s *recordingStrategy) Adjust(current int, _ float64, bounds Bounds) int {
+	s.bounds = append(s.bounds, bounds)
+	return current / 2
+}
+
+// TestNewDynamicThresholdManagerWithStrategy verifies that the manager
+// delegates adjustments to a custom strategy.
+func TestNewDynamicThresholdManagerWithStrategy(t *testing.T) {
+	t.Parallel()
+	cfg := DynamicThresholdConfig{
+		InitialFFTThreshold:      500000,
+		InitialParallelThreshold: 10000,
+		AdjustmentInterval:       5,
+		Enabled:                  true,
+	}
+
+	t.Run("disabled returns nil", func(t *testing.T) {
+		t.Parallel()
+		if mgr := NewDynamicThresholdManagerWithStrategy(DynamicThresholdConfig{}, &recordingStrategy{}); mgr != nil {
+			t.Error("expected nil manager when disabled")
+		}
+	})
+
+	t.Run("custom strategy is used", func(t *testing.T) {
+		t.Parallel()
+		strategy := &recordingStrategy{}
+		mgr := NewDynamicThresholdManagerWithStrategy(cfg, strategy)
+		// FFT and parallel both faster than the baseline.
+		for i := 0; i < 3; i++ {
+			mgr.RecordIteration(10000, time.Millisecond, true, true)
+		}
+		for i := 0; i < 2; i++ {
+			mgr.RecordIteration(10000, 100*time.Millisecond, false, false)
+		}
+
+		fft, par, adjusted := mgr.ShouldAdjust()
+		if !adjusted || fft != 250000 || par != 5000 {
+			t.Errorf("ShouldAdjust() = (%d, %d, %v), want (250000, 5000, true)", fft, par, adjusted)
+		}
+		if len(strategy.bounds) != 2 {
+			t.Fatalf("strategy called %d times, want 2", len(strategy.bounds))
+		}
+		if b := strategy.bounds[0]; b.Kind != FFTThresholdKind || b.Min != 100000 || b.Max != 1000000 || b.Original != 500000 {
+			t.Errorf("FFT bounds = %+v", b)
+		}
+		if b := strategy.bounds[1]; b.Kind != ParallelThresholdKind || b.Min != 1024 || b.Max != 40000 || b.Original != 10000 {
+			t.Errorf("parallel bounds = %+v", b)
+		}
+	})
+
+	t.Run("nil strategy matches the default", func(t *testing.T) {
+		t.Parallel()
+		withNil := NewDynamicThresholdManagerWithStrategy(cfg, nil)
+		withDefault := NewDynamicThresholdManagerFromConfig(cfg)
+		for _, mgr := range []*DynamicThresholdManager{withNil, withDefault} {
+			for i := 0; i < 3; i++ {
+				mgr.RecordIteration(10000, time.Millisecond, true, true)
+			}
+			for i := 0; i < 2; i++ {
+				mgr.RecordIteration(10000, 100*time.Millisecond, false, false)
+			}
+		}
+		fft1, par1, adj1 := withNil.ShouldAdjust()
+		fft2, par2, adj2 := withDefault.ShouldAdjust()
+		if fft1 != fft2 || par1 != par2 || adj1 != adj2 {
+			t.Errorf("nil strategy = (%d, %d, %v), default = (%d, %d, %v)", fft1, par1, adj1, fft2, par2, adj2)
+		}
+	})
+}
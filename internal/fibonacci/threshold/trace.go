@@ -0,0 +1,72 @@
+// This file exposes the metric history and adjustment events of a
+// DynamicThresholdManager for offline analysis.
+
+package threshold
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// recordAdjustment appends a threshold change to the adjustment history.
+func (m *DynamicThresholdManager) recordAdjustment(kind ThresholdKind, oldVal, newVal int) {
+	m.adjustments = append(m.adjustments, AdjustmentEvent{
+		Iteration: m.iterationCount,
+		Kind:      kind,
+		Old:       oldVal,
+		New:       newVal,
+		At:        m.lastAdjustment,
+	})
+}
+
+// DumpMetrics returns a copy of the retained metric history (the last
+// MaxMetricsHistory iterations), oldest first.
+//
+// Returns:
+//   - []IterationMetric: The metrics in temporal order.
+func (m *DynamicThresholdManager) DumpMetrics() []IterationMetric {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.getActiveMetrics()
+}
+
+// Adjustments returns a copy of the threshold changes made since the manager
+// was created or last reset, oldest first.
+//
+// Returns:
+//   - []AdjustmentEvent: The adjustment events.
+func (m *DynamicThresholdManager) Adjustments() []AdjustmentEvent {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return append([]AdjustmentEvent(nil), m.adjustments...)
+}
+
+// WriteCSV writes the retained metric history as CSV, oldest first, with a
+// header row: bitlen, duration_ns, used_fft, used_parallel.
+//
+// Parameters:
+//   - w: The destination writer.
+//
+// Returns:
+//   - error: An error if a row cannot be written.
+func (m *DynamicThresholdManager) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"bitlen", "duration_ns", "used_fft", "used_parallel"}); err != nil {
+		return fmt.Errorf("failed to write metrics header: %w", err)
+	}
+	for _, metric := range m.DumpMetrics() {
+		row := []string{
+			strconv.Itoa(metric.BitLen),
+			strconv.FormatInt(metric.Duration.Nanoseconds(), 10),
+			strconv.FormatBool(metric.UsedFFT),
+			strconv.FormatBool(metric.UsedParallel),
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("failed to write metrics row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
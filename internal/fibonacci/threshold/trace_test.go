@@ -0,0 +1,110 @@
+package threshold
+
+import (
+	"bytes"
+	"encoding/csv"
+	"testing"
+	"time"
+)
+
+// TestDumpMetricsTemporalOrder verifies the history is returned oldest first,
+// before and after the ring buffer wraps around.
+func TestDumpMetricsTemporalOrder(t *testing.T) {
+	t.Parallel()
+	mgr := NewDynamicThresholdManager(500000, 10000)
+	if got := mgr.DumpMetrics(); len(got) != 0 {
+		t.Fatalf("expected no metrics, got %d", len(got))
+	}
+
+	for i := 1; i <= 5; i++ {
+		mgr.RecordIteration(i, time.Millisecond, false, false)
+	}
+	got := mgr.DumpMetrics()
+	if len(got) != 5 || got[0].BitLen != 1 || got[4].BitLen != 5 {
+		t.Fatalf("unexpected history before wrap: %+v", got)
+	}
+
+	total := MaxMetricsHistory + 7
+	for i := 6; i <= total; i++ {
+		mgr.RecordIteration(i, time.Millisecond, false, false)
+	}
+	got = mgr.DumpMetrics()
+	if len(got) != MaxMetricsHistory {
+		t.Fatalf("expected %d metrics, got %d", MaxMetricsHistory, len(got))
+	}
+	for i, metric := range got {
+		if want := total - MaxMetricsHistory + 1 + i; metric.BitLen != want {
+			t.Errorf("metric %d: BitLen = %d, want %d", i, metric.BitLen, want)
+		}
+	}
+
+	// The dump is a copy.
+	got[0].BitLen = -1
+	if mgr.DumpMetrics()[0].BitLen == -1 {
+		t.Error("DumpMetrics should return a copy")
+	}
+}
+
+// TestAdjustments verifies that threshold changes are recorded and cleared
+// by Reset.
+func TestAdjustments(t *testing.T) {
+	t.Parallel()
+	mgr := NewDynamicThresholdManager(500000, 10000)
+	// Parallel faster than sequential: the parallel threshold is lowered by
+	// 20%, the FFT one by 10% (below the hysteresis margin).
+	for i := 0; i < 3; i++ {
+		mgr.RecordIteration(10000, time.Millisecond, true, true)
+	}
+	for i := 0; i < 2; i++ {
+		mgr.RecordIteration(10000, 100*time.Millisecond, false, false)
+	}
+	if _, _, adjusted := mgr.ShouldAdjust(); !adjusted {
+		t.Fatal("expected an adjustment")
+	}
+
+	events := mgr.Adjustments()
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %+v", events)
+	}
+	want := AdjustmentEvent{Iteration: 5, Kind: ParallelThresholdKind, Old: 10000, New: 8000}
+	if e := events[0]; e.Iteration != want.Iteration || e.Kind != want.Kind || e.Old != want.Old || e.New != want.New || e.At.IsZero() {
+		t.Errorf("event = %+v, want %+v with a timestamp", e, want)
+	}
+
+	mgr.Reset()
+	if got := mgr.Adjustments(); len(got) != 0 {
+		t.Errorf("expected no events after Reset, got %+v", got)
+	}
+}
+
+// TestWriteCSV verifies the CSV header and rows.
+func TestWriteCSV(t *testing.T) {
+	t.Parallel()
+	mgr := NewDynamicThresholdManager(500000, 10000)
+	mgr.RecordIteration(1024, 3*time.Microsecond, false, true)
+	mgr.RecordIteration(2048, 5*time.Microsecond, true, false)
+
+	var buf bytes.Buffer
+	if err := mgr.WriteCSV(&buf); err != nil {
+		t.Fatalf("WriteCSV failed: %v", err)
+	}
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("invalid CSV: %v", err)
+	}
+	want := [][]string{
+		{"bitlen", "duration_ns", "used_fft", "used_parallel"},
+		{"1024", "3000", "false", "true"},
+		{"2048", "5000", "true", "false"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("got %d records, want %d", len(records), len(want))
+	}
+	for i := range want {
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Errorf("record %d field %d = %q, want %q", i, j, records[i][j], want[i][j])
+			}
+		}
+	}
+}
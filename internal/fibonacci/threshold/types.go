@@ -41,3 +41,29 @@ type DynamicThresholdConfig struct {
 	// Enabled controls whether dynamic adjustment is active
 	Enabled bool
 }
+
+// LearnedThresholds is the persisted form of the thresholds reached by a
+// DynamicThresholdManager (see SaveLearned and LoadLearned). The JSON keys
+// match the learned fields of the calibration profile.
+type LearnedThresholds struct {
+	// FFTThreshold is the learned FFT threshold
+	FFTThreshold int `json:"learned_fft_threshold"`
+	// ParallelThreshold is the learned parallel threshold
+	ParallelThreshold int `json:"learned_parallel_threshold"`
+	// LearnedAt is when the thresholds were saved
+	LearnedAt time.Time `json:"learned_at"`
+}
+
+// AdjustmentEvent records one threshold change made by a DynamicThresholdManager.
+type AdjustmentEvent struct {
+	// Iteration is the iteration count at which the change was made
+	Iteration int
+	// Kind is the threshold that changed
+	Kind ThresholdKind
+	// Old is the threshold before the change
+	Old int
+	// New is the threshold after the change
+	New int
+	// At is when the change was made
+	At time.Time
+}
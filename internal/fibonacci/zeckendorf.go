@@ -0,0 +1,73 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+)
+
+// Zeckendorf returns the Zeckendorf representation of x: the unique set of
+// non-consecutive Fibonacci numbers F(k), k >= 2, whose sum is x.
+//
+// The greedy algorithm repeatedly takes the largest F(k) <= x. The top pair
+// F(k), F(k+1) is found from a Binet estimate and Fast Doubling; the smaller
+// terms are then walked down by subtraction, F(k-1) = F(k+1) - F(k), so only
+// one pair is computed from scratch.
+//
+// Parameters:
+//   - ctx: The context for cancellation, checked at each step of the walk.
+//   - x: The non-negative number to decompose.
+//
+// Returns:
+//   - []uint64: The indices k of the terms, largest first and pairwise
+//     non-consecutive. Empty for x = 0.
+//   - error: An error if x is nil or negative, or if the context is canceled.
+func Zeckendorf(ctx context.Context, x *big.Int) ([]uint64, error) {
+	if x == nil || x.Sign() < 0 {
+		return nil, errors.New("zeckendorf representation requires a non-negative number")
+	}
+	if x.Sign() == 0 {
+		return []uint64{}, nil
+	}
+
+	// Binet: F(k) ≈ φ^k / √5, so the largest k with F(k) <= x is about
+	// (log2(x) + log2(√5)) / log2(φ). Start just below and step up.
+	estimate := (log2Big(x) + math.Log2(math.Sqrt(5))) / FibonacciGrowthFactor
+	k := uint64(2)
+	if estimate > 4 {
+		k = uint64(estimate) - 2
+	}
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	lo, err := calc.Calculate(ctx, nil, 0, k, Options{})
+	if err != nil {
+		return nil, err
+	}
+	hi, err := calc.Calculate(ctx, nil, 0, k+1, Options{})
+	if err != nil {
+		return nil, err
+	}
+	for hi.Cmp(x) <= 0 {
+		lo.Add(lo, hi)
+		lo, hi = hi, lo
+		k++
+	}
+
+	// Invariant: lo = F(k), hi = F(k+1).
+	remaining := new(big.Int).Set(x)
+	indices := make([]uint64, 0, 8)
+	for remaining.Sign() > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("zeckendorf decomposition canceled at index %d: %w", k, err)
+		}
+		if lo.Cmp(remaining) <= 0 {
+			indices = append(indices, k)
+			remaining.Sub(remaining, lo)
+		}
+		hi.Sub(hi, lo)
+		lo, hi = hi, lo
+		k--
+	}
+	return indices, nil
+}
@@ -0,0 +1,95 @@
+package fibonacci
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+// checkZeckendorf verifies that indices are largest-first, non-consecutive,
+// at least 2, and sum to x.
+func checkZeckendorf(t *testing.T, x *big.Int, indices []uint64) {
+	t.Helper()
+	calc := NewCalculator(&OptimizedFastDoubling{})
+	sum := new(big.Int)
+	for i, k := range indices {
+		if k < 2 {
+			t.Fatalf("Zeckendorf(%s): index %d < 2", x, k)
+		}
+		if i > 0 && indices[i-1] < k+2 {
+			t.Fatalf("Zeckendorf(%s): indices %d and %d are not decreasing and non-consecutive", x, indices[i-1], k)
+		}
+		f, err := calc.Calculate(context.Background(), nil, 0, k, Options{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sum.Add(sum, f)
+	}
+	if sum.Cmp(x) != 0 {
+		t.Fatalf("Zeckendorf(%s) = %v sums to %s", x, indices, sum)
+	}
+}
+
+func TestZeckendorf(t *testing.T) {
+	t.Parallel()
+
+	got, err := Zeckendorf(context.Background(), big.NewInt(100)) // 100 = 89 + 8 + 3
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []uint64{11, 6, 4}; len(got) != len(want) || got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Errorf("Zeckendorf(100) = %v, want %v", got, want)
+	}
+
+	for x := int64(0); x <= 500; x++ {
+		v := big.NewInt(x)
+		indices, err := Zeckendorf(context.Background(), v)
+		if err != nil {
+			t.Fatalf("Zeckendorf(%d): unexpected error: %v", x, err)
+		}
+		checkZeckendorf(t, v, indices)
+	}
+}
+
+func TestZeckendorfLarge(t *testing.T) {
+	t.Parallel()
+	calc := NewCalculator(&OptimizedFastDoubling{})
+
+	f, err := calc.Calculate(context.Background(), nil, 0, 1000, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if indices, err := Zeckendorf(context.Background(), f); err != nil || len(indices) != 1 || indices[0] != 1000 {
+		t.Errorf("Zeckendorf(F(1000)) = %v, %v; want [1000]", indices, err)
+	}
+
+	// F(1000) - 1 decomposes into many terms.
+	x := new(big.Int).Sub(f, big.NewInt(1))
+	indices, err := Zeckendorf(context.Background(), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkZeckendorf(t, x, indices)
+
+	x = new(big.Int).Exp(big.NewInt(10), big.NewInt(150), nil)
+	indices, err = Zeckendorf(context.Background(), x)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkZeckendorf(t, x, indices)
+
+	if _, err := Zeckendorf(context.Background(), big.NewInt(-1)); err == nil {
+		t.Error("expected an error for a negative number")
+	}
+}
+
+func TestZeckendorfCanceled(t *testing.T) {
+	t.Parallel()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	x := new(big.Int).Exp(big.NewInt(10), big.NewInt(150), nil)
+	if _, err := Zeckendorf(ctx, x); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,73 @@
+// Streaming decimal conversion for values too large to hold as a string.
+
+package format
+
+import (
+	"bufio"
+	"io"
+	"math/big"
+	"strings"
+)
+
+// decimalChunkDigits is the number of digits below which WriteDecimal
+// converts a part of the value with big.Int.Text.
+const decimalChunkDigits = 4096
+
+// decimalWriter holds the state of a WriteDecimal conversion.
+type decimalWriter struct {
+	w *bufio.Writer
+	// pows[i] is 10^(decimalChunkDigits * 2^i).
+	pows []*big.Int
+}
+
+// WriteDecimal writes the decimal representation of x to w, as x.String()
+// would, without building the whole string. The value is split recursively
+// by powers of ten, and only parts of decimalChunkDigits digits are
+// converted to text, so the extra memory stays close to the size of x in
+// binary instead of its decimal string, which is about 2.4 times larger.
+//
+// Parameters:
+//   - w: The destination writer.
+//   - x: The value to write; not modified.
+//
+// Returns:
+//   - error: An error if writing fails.
+func WriteDecimal(w io.Writer, x *big.Int) error {
+	d := decimalWriter{w: bufio.NewWriterSize(w, 64<<10)}
+	if x.Sign() < 0 {
+		d.w.WriteByte('-')
+	}
+	abs := new(big.Int).Abs(x)
+
+	// Stop at the first power whose square exceeds x.
+	last := new(big.Int).Exp(big.NewInt(10), big.NewInt(decimalChunkDigits), nil)
+	d.pows = append(d.pows, last)
+	for 2*last.BitLen()-1 <= abs.BitLen() {
+		last = new(big.Int).Mul(last, last)
+		d.pows = append(d.pows, last)
+	}
+
+	d.write(abs, len(d.pows)-1, 0)
+	return d.w.Flush()
+}
+
+// write writes x, left-padded with zeros to width digits. x must be below
+// pows[level] squared, and below 10^width when width is not 0.
+func (d *decimalWriter) write(x *big.Int, level, width int) {
+	// The leading part is not padded: skip the powers above it.
+	for width == 0 && level >= 0 && x.Cmp(d.pows[level]) < 0 {
+		level--
+	}
+	if level < 0 {
+		s := x.Text(10)
+		if pad := width - len(s); pad > 0 {
+			d.w.WriteString(strings.Repeat("0", pad))
+		}
+		d.w.WriteString(s)
+		return
+	}
+	q, r := new(big.Int).QuoRem(x, d.pows[level], new(big.Int))
+	low := decimalChunkDigits << level
+	d.write(q, level-1, max(width-low, 0))
+	d.write(r, level-1, low)
+}
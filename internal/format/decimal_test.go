@@ -0,0 +1,58 @@
+package format
+
+import (
+	"bytes"
+	"math/big"
+	"strings"
+	"testing"
+)
+
+// TestWriteDecimal compares the streamed conversion with big.Int.String,
+// including values whose parts are runs of zeros at the chunk boundaries.
+func TestWriteDecimal(t *testing.T) {
+	t.Parallel()
+	ten := big.NewInt(10)
+	chunk := new(big.Int).Exp(ten, big.NewInt(decimalChunkDigits), nil)
+	values := map[string]*big.Int{
+		"zero":           big.NewInt(0),
+		"small":          big.NewInt(55),
+		"negative":       big.NewInt(-1234567890),
+		"chunk":          chunk,
+		"chunk minus 1":  new(big.Int).Sub(chunk, big.NewInt(1)),
+		"chunk squared":  new(big.Int).Mul(chunk, chunk),
+		"zero low parts": new(big.Int).Add(new(big.Int).Exp(chunk, big.NewInt(5), nil), big.NewInt(7)),
+		"large":          new(big.Int).Exp(big.NewInt(3), big.NewInt(200_001), nil),
+		"large negative": new(big.Int).Neg(new(big.Int).Exp(big.NewInt(7), big.NewInt(50_000), nil)),
+	}
+	for name, x := range values {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			if err := WriteDecimal(&buf, x); err != nil {
+				t.Fatalf("WriteDecimal() error = %v", err)
+			}
+			if want := x.String(); buf.String() != want {
+				t.Errorf("WriteDecimal() wrote %d digits differing from String() (%d digits)", buf.Len(), len(want))
+			}
+		})
+	}
+}
+
+// TestDigitCountPowersOfTen checks the digit count on both sides of powers
+// of ten, where the estimate from the bit length is closest.
+func TestDigitCountPowersOfTen(t *testing.T) {
+	t.Parallel()
+	for _, exp := range []int64{1, 19, 20, 300, 1233, 12345} {
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(exp), nil)
+		if got := DigitCount(pow); got != int(exp)+1 {
+			t.Errorf("DigitCount(10^%d) = %d, want %d", exp, got, exp+1)
+		}
+		below := new(big.Int).Sub(pow, big.NewInt(1))
+		if got := DigitCount(below); got != int(exp) {
+			t.Errorf("DigitCount(10^%d-1) = %d, want %d", exp, got, exp)
+		}
+		if got, want := DigitCount(below.Neg(below)), len(strings.TrimPrefix(below.String(), "-")); got != want {
+			t.Errorf("DigitCount(-(10^%d-1)) = %d, want %d", exp, got, want)
+		}
+	}
+}
@@ -4,11 +4,22 @@ package format
 
 import (
 	"fmt"
+	"math"
+	"math/big"
+	"math/bits"
 	"strings"
+	"unicode/utf8"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// Bases supported by FormatInBase, as for big.Int.Text.
+const (
+	MinBase = 2
+	MaxBase = 36
 )
 
 // FormatNumberString inserts thousand separators into a numeric string.
-// Optimized to reduce memory allocations
 //
 // Parameters:
 //   - s: The numeric string to format.
@@ -16,6 +27,27 @@ import (
 // Returns:
 //   - string: The formatted string with comma separators.
 func FormatNumberString(s string) string {
+	return FormatNumberStringGrouped(s, ',', westernGrouping)
+}
+
+// westernGrouping groups digits by thousands.
+var westernGrouping = []int{3}
+
+// FormatNumberStringGrouped inserts sep between the digit groups of a
+// numeric string. grouping gives the group sizes from the right, the last
+// size repeating for the remaining digits, as in locale conventions: [3] for
+// Western thousands ("1,000,000") and [3 2] for the Indian system
+// ("10,00,000"). A non-positive size stops grouping, and an empty grouping
+// leaves s unchanged. A leading "-" sign is kept in front.
+//
+// Parameters:
+//   - s: The numeric string to format.
+//   - sep: The separator, e.g. ',', '.', ' ' or '_'.
+//   - grouping: The group sizes from the right.
+//
+// Returns:
+//   - string: The formatted string.
+func FormatNumberStringGrouped(s string, sep rune, grouping []int) string {
 	if s == "" {
 		return ""
 	}
@@ -24,30 +56,228 @@ func FormatNumberString(s string) string {
 		prefix = "-"
 		s = s[1:]
 	}
-	n := len(s)
-	if n <= 3 {
+
+	// Count the groups that precede the leftmost one, which takes the
+	// remaining digits.
+	groups, first := 0, len(s)
+	for {
+		size := groupSize(grouping, groups)
+		if size <= 0 || size >= first {
+			break
+		}
+		first -= size
+		groups++
+	}
+	if groups == 0 {
 		return prefix + s
 	}
 
 	// Precise calculation of the required capacity to avoid reallocations
-	numSeparators := (n - 1) / 3
-	capacity := len(prefix) + n + numSeparators
 	var builder strings.Builder
-	builder.Grow(capacity)
+	builder.Grow(len(prefix) + len(s) + groups*utf8.RuneLen(sep))
 	builder.WriteString(prefix)
+	builder.WriteString(s[:first])
+	for i, pos := groups-1, first; i >= 0; i-- {
+		size := groupSize(grouping, i)
+		builder.WriteRune(sep)
+		builder.WriteString(s[pos : pos+size])
+		pos += size
+	}
+	return builder.String()
+}
 
-	firstGroupLen := n % 3
-	if firstGroupLen == 0 {
-		firstGroupLen = 3
+// groupSize returns the size of the i-th digit group from the right, the
+// last size of grouping repeating; 0 if grouping is empty.
+func groupSize(grouping []int, i int) int {
+	if len(grouping) == 0 {
+		return 0
 	}
-	builder.WriteString(s[:firstGroupLen])
+	return grouping[min(i, len(grouping)-1)]
+}
 
-	// Optimized loop with fewer function calls
-	for i := firstGroupLen; i < n; i += 3 {
-		builder.WriteByte(',')
-		builder.WriteString(s[i : i+3])
+// DigitCount returns the number of decimal digits of x, ignoring its sign.
+// Zero has one digit.
+//
+// Parameters:
+//   - x: The number to measure.
+//
+// Returns:
+//   - int: The number of decimal digits of |x|.
+func DigitCount(x *big.Int) int {
+	bits := x.BitLen()
+	if bits == 0 {
+		return 1
+	}
+	// 2^(bits-1) <= |x|, so |x| has more than (bits-1)*log10(2) digits. Start
+	// one below that estimate to absorb rounding, then count up to the first
+	// power of ten above |x|, without converting x to a string.
+	digits := max(int(float64(bits-1)*math.Log10(2))-1, 0)
+	pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(digits)), nil)
+	abs := new(big.Int).Abs(x)
+	ten := big.NewInt(10)
+	for abs.Cmp(pow) >= 0 {
+		pow.Mul(pow, ten)
+		digits++
+	}
+	return digits
+}
+
+// FormatScientific formats x compactly as a mantissa with sigFigs
+// significant figures and a power of ten, followed by its digit count, e.g.
+// "3.54×10^20 (21 digits)". The mantissa is rounded half up from the leading
+// decimal digits, so x is never converted to a float and any size is exact.
+//
+// Parameters:
+//   - x: The number to format.
+//   - sigFigs: The number of significant figures (at least 1).
+//
+// Returns:
+//   - string: The scientific notation of x and its number of digits.
+func FormatScientific(x *big.Int, sigFigs int) string {
+	sigFigs = max(sigFigs, 1)
+	digits := x.String()
+	sign := ""
+	if digits[0] == '-' {
+		sign, digits = "-", digits[1:]
+	}
+	numDigits := len(digits)
+	exponent := numDigits - 1
+
+	mantissa := []byte(digits[:min(sigFigs, numDigits)])
+	for len(mantissa) < sigFigs {
+		mantissa = append(mantissa, '0')
+	}
+	if sigFigs < numDigits && digits[sigFigs] >= '5' {
+		// Round half up, propagating the carry; 9.99 rounds to 1.00×10^(e+1).
+		i := sigFigs - 1
+		for ; i >= 0 && mantissa[i] == '9'; i-- {
+			mantissa[i] = '0'
+		}
+		if i >= 0 {
+			mantissa[i]++
+		} else {
+			mantissa = append([]byte{'1'}, mantissa[:sigFigs-1]...)
+			exponent++
+		}
+	}
+
+	text := string(mantissa[:1])
+	if sigFigs > 1 {
+		text += "." + string(mantissa[1:])
+	}
+	unit := "digits"
+	if numDigits == 1 {
+		unit = "digit"
+	}
+	return fmt.Sprintf("%s%s×10^%d (%s %s)", sign, text, exponent, FormatNumberString(fmt.Sprint(numDigits)), unit)
+}
+
+// FormatScientificFloat formats x compactly as a mantissa with sigFigs
+// significant figures and a power of ten, as FormatScientific does but
+// without the digit count, e.g. "3.54×10^20". Unlike big.Float.Text, whose
+// cost grows with the binary exponent of x, it divides x by a power of ten
+// computed at the precision of x, so values with huge exponents format
+// quickly.
+//
+// Parameters:
+//   - x: The number to format.
+//   - sigFigs: The number of significant figures (at least 1).
+//
+// Returns:
+//   - string: The scientific notation of x.
+func FormatScientificFloat(x *big.Float, sigFigs int) string {
+	sigFigs = max(sigFigs, 1)
+	if x.Sign() == 0 {
+		return new(big.Float).Text('f', sigFigs-1) + "×10^0"
+	}
+	sign := ""
+	if x.Sign() < 0 {
+		sign = "-"
+	}
+	prec := max(x.Prec(), 64) + 64
+	abs := new(big.Float).SetPrec(prec).Abs(x)
+
+	// Estimate the decimal exponent from the binary one, then correct it.
+	mant := new(big.Float)
+	exp2 := abs.MantExp(mant)
+	m, _ := mant.Float64()
+	exponent := int(math.Floor(math.Log10(m) + float64(exp2)*math.Log10(2)))
+	scaled := new(big.Float).SetPrec(prec).Quo(abs, pow10Float(exponent, prec))
+	ten, one := big.NewFloat(10), big.NewFloat(1)
+	for scaled.Cmp(ten) >= 0 {
+		scaled.Quo(scaled, ten)
+		exponent++
+	}
+	for scaled.Cmp(one) < 0 {
+		scaled.Mul(scaled, ten)
+		exponent--
+	}
+
+	text := scaled.Text('f', sigFigs-1)
+	if strings.HasPrefix(text, "10") {
+		// 9.99 rounds to 1.00×10^(e+1).
+		scaled.Quo(scaled, ten)
+		exponent++
+		text = scaled.Text('f', sigFigs-1)
+	}
+	return fmt.Sprintf("%s%s×10^%d", sign, text, exponent)
+}
+
+// pow10Float returns 10^k at precision prec, with guard bits for the
+// roundings of the exponentiation.
+func pow10Float(k int, prec uint) *big.Float {
+	abs := uint64(k)
+	if k < 0 {
+		abs = uint64(-k)
+	}
+	work := prec + 2*uint(bits.Len64(abs)) + 16
+	ten := new(big.Float).SetPrec(work).SetInt64(10)
+	pow := new(big.Float).SetPrec(work).SetInt64(1)
+	for i := bits.Len64(abs) - 1; i >= 0; i-- {
+		pow.Mul(pow, pow)
+		if (abs>>uint(i))&1 == 1 {
+			pow.Mul(pow, ten)
+		}
+	}
+	if k < 0 {
+		pow.Quo(new(big.Float).SetPrec(work).SetInt64(1), pow)
+	}
+	return pow
+}
+
+// FormatInBase formats x in the given base with its digits grouped for
+// readability: decimal values get thousand separators (see
+// FormatNumberString), binary and hexadecimal values groups of four digits,
+// and octal values groups of three, separated by underscores as in Go
+// literals. Other bases are not grouped. Digits above 9 are lower-case
+// letters.
+//
+// Parameters:
+//   - x: The number to format.
+//   - base: The base, from MinBase to MaxBase.
+//
+// Returns:
+//   - string: The grouped digits of x, with a leading "-" if negative.
+//   - error: An apperrors.ValidationError for field "base" if the base is
+//     out of range.
+func FormatInBase(x *big.Int, base int) (string, error) {
+	if base < MinBase || base > MaxBase {
+		return "", apperrors.ValidationError{
+			Field:   "base",
+			Message: fmt.Sprintf("base must be between %d and %d, got %d", MinBase, MaxBase, base),
+		}
+	}
+	digits := x.Text(base)
+	switch base {
+	case 10:
+		return FormatNumberString(digits), nil
+	case 2, 16:
+		return FormatNumberStringGrouped(digits, '_', []int{4}), nil
+	case 8:
+		return FormatNumberStringGrouped(digits, '_', []int{3}), nil
+	default:
+		return digits, nil
 	}
-	return builder.String()
 }
 
 // FormatBytes formats a byte count as a human-readable string.
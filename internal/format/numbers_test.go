@@ -0,0 +1,165 @@
+package format
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+)
+
+// TestFormatNumberStringGrouped covers Western, European and Indian
+// grouping conventions.
+func TestFormatNumberStringGrouped(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		sep      rune
+		grouping []int
+		want     string
+	}{
+		{"10000000", ',', []int{3, 2}, "1,00,00,000"}, // Indian crore
+		{"100000", ',', []int{3, 2}, "1,00,000"},      // Indian lakh
+		{"999", ',', []int{3, 2}, "999"},
+		{"-123456789", ',', []int{3, 2}, "-12,34,56,789"},
+		{"1234567", ' ', []int{3}, "1 234 567"},
+		{"1234567", '.', []int{3}, "1.234.567"},
+		{"1234567", '\u202f', []int{3}, "1\u202f234\u202f567"}, // narrow no-break space
+		{"-1234", ' ', []int{3}, "-1 234"},
+		{"123456789", ',', []int{3, 0}, "123456,789"}, // grouping stops
+		{"123456789", ',', nil, "123456789"},
+		{"", ',', []int{3}, ""},
+	}
+	for _, tt := range tests {
+		if got := FormatNumberStringGrouped(tt.input, tt.sep, tt.grouping); got != tt.want {
+			t.Errorf("FormatNumberStringGrouped(%q, %q, %v) = %q, want %q", tt.input, tt.sep, tt.grouping, got, tt.want)
+		}
+	}
+}
+
+// TestDigitCount verifies the digit count of zero, signed and large values.
+func TestDigitCount(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value string
+		want  int
+	}{
+		{"0", 1},
+		{"7", 1},
+		{"-7", 1},
+		{"10", 2},
+		{"999", 3},
+		{"1000", 4},
+		{"1" + strings.Repeat("0", 500), 501},
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.value, 10)
+		if got := DigitCount(x); got != tt.want {
+			t.Errorf("DigitCount(%s) = %d, want %d", tt.value, got, tt.want)
+		}
+	}
+}
+
+// TestFormatScientific covers exact powers of ten and rounding at the
+// significant-figure boundary.
+func TestFormatScientific(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value   string
+		sigFigs int
+		want    string
+	}{
+		{"0", 3, "0.00×10^0 (1 digit)"},
+		{"5", 1, "5×10^0 (1 digit)"},
+		{"1000", 3, "1.00×10^3 (4 digits)"},
+		{"1" + strings.Repeat("0", 1000), 2, "1.0×10^1000 (1,001 digits)"},
+		{"354224848179261915075", 3, "3.54×10^20 (21 digits)"}, // F(100)
+		{"12345", 3, "1.23×10^4 (5 digits)"},                   // below the boundary
+		{"12350", 3, "1.24×10^4 (5 digits)"},                   // half rounds up
+		{"12349999", 4, "1.235×10^7 (8 digits)"},
+		{"99950", 3, "1.00×10^5 (5 digits)"}, // carry into a new power of ten
+		{"99949", 3, "9.99×10^4 (5 digits)"},
+		{"-354224848179261915075", 3, "-3.54×10^20 (21 digits)"},
+		{"42", 5, "4.2000×10^1 (2 digits)"}, // fewer digits than sigFigs
+		{"42", 0, "4×10^1 (2 digits)"},      // at least one figure
+	}
+	for _, tt := range tests {
+		x, _ := new(big.Int).SetString(tt.value, 10)
+		if got := FormatScientific(x, tt.sigFigs); got != tt.want {
+			t.Errorf("FormatScientific(%.20s, %d) = %q, want %q", tt.value, tt.sigFigs, got, tt.want)
+		}
+	}
+}
+
+func TestFormatScientificFloat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		value   string
+		sigFigs int
+		want    string
+	}{
+		{"0", 3, "0.00×10^0"},
+		{"354224848179261915075", 3, "3.54×10^20"},
+		{"99960", 3, "1.00×10^5"}, // carry into a new power of ten
+		{"99949", 3, "9.99×10^4"},
+		{"0.000123456", 2, "1.2×10^-4"},
+		{"-12350", 3, "-1.24×10^4"},
+		{"1e1000", 2, "1.0×10^1000"},
+	}
+	for _, tt := range tests {
+		x, _, err := big.ParseFloat(tt.value, 10, 256, big.ToNearestEven)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := FormatScientificFloat(x, tt.sigFigs); got != tt.want {
+			t.Errorf("FormatScientificFloat(%s, %d) = %q, want %q", tt.value, tt.sigFigs, got, tt.want)
+		}
+	}
+
+	// A binary exponent near the limit of big.Float formats quickly.
+	huge := new(big.Float).SetMantExp(big.NewFloat(0.75), 2_000_000_000)
+	if got := FormatScientificFloat(huge, 3); !strings.HasSuffix(got, "×10^602059991") {
+		t.Errorf("FormatScientificFloat(0.75×2^2e9) = %q, want an exponent of 602059991", got)
+	}
+}
+
+// TestFormatInBase covers the grouped bases and the base range.
+func TestFormatInBase(t *testing.T) {
+	t.Parallel()
+	f100, _ := new(big.Int).SetString("354224848179261915075", 10)
+	tests := []struct {
+		value *big.Int
+		base  int
+		want  string
+	}{
+		{big.NewInt(0), 2, "0"},
+		{big.NewInt(10), 2, "1010"},
+		{big.NewInt(55), 2, "11_0111"},
+		{big.NewInt(-255), 2, "-1111_1111"},
+		{big.NewInt(4096), 16, "1000"},
+		{f100, 16, "13_33db_76a7_c594_bfc3"},
+		{big.NewInt(511), 8, "777"},
+		{big.NewInt(4095), 8, "7_777"},
+		{f100, 10, "354,224,848,179,261,915,075"},
+		{big.NewInt(35), 36, "z"},
+		{big.NewInt(1295), 36, "zz"},
+	}
+	for _, tt := range tests {
+		got, err := FormatInBase(tt.value, tt.base)
+		if err != nil {
+			t.Fatalf("FormatInBase(%s, %d): unexpected error: %v", tt.value, tt.base, err)
+		}
+		if got != tt.want {
+			t.Errorf("FormatInBase(%s, %d) = %q, want %q", tt.value, tt.base, got, tt.want)
+		}
+	}
+
+	for _, base := range []int{0, 1, 37} {
+		_, err := FormatInBase(big.NewInt(10), base)
+		var validationErr apperrors.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Field != "base" {
+			t.Errorf("base %d: expected a ValidationError for base, got %v", base, err)
+		}
+	}
+}
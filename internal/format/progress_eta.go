@@ -12,6 +12,7 @@ import (
 // multiple algorithms are running in parallel.
 type ProgressState struct {
 	progresses     []float64
+	weights        []float64 // nil for a plain average
 	numCalculators int
 }
 
@@ -44,23 +45,57 @@ func (ps *ProgressState) Update(index int, value float64) {
 	}
 }
 
+// SetWeights weights each calculator's progress in the average by its
+// expected share of the total time, so that a slow calculator moves the
+// consolidated bar less per percent than a fast one. Weights that do not
+// match the number of calculators, or that contain a non-positive value,
+// are ignored and every calculator counts equally.
+//
+// Parameters:
+//   - weights: The relative cost of each calculator, or nil for equal weights.
+func (ps *ProgressState) SetWeights(weights []float64) {
+	ps.weights = nil
+	if len(weights) != len(ps.progresses) {
+		return
+	}
+	for _, w := range weights {
+		if !(w > 0) {
+			return
+		}
+	}
+	ps.weights = append([]float64(nil), weights...)
+}
+
 // CalculateAverage computes the average progress across all tracked calculators.
 // This is used to display a single, consolidated progress bar to the user,
-// representing the overall progress of the application.
+// representing the overall progress of the application. When weights are set
+// (see SetWeights), the average is weighted.
 //
 // Returns:
 //   - float64: The average progress (0.0 to 1.0).
 func (ps *ProgressState) CalculateAverage() float64 {
+	if ps.numCalculators == 0 {
+		return 0.0
+	}
+	if ps.weights != nil {
+		var weighted, total float64
+		for i, p := range ps.progresses {
+			weighted += p * ps.weights[i]
+			total += ps.weights[i]
+		}
+		return weighted / total
+	}
 	var totalProgress float64
 	for _, p := range ps.progresses {
 		totalProgress += p
 	}
-	if ps.numCalculators == 0 {
-		return 0.0
-	}
 	return totalProgress / float64(ps.numCalculators)
 }
 
+// DefaultETASmoothingAlpha is the default weight of the latest measured
+// progress rate in the smoothed rate of ProgressWithETA.
+const DefaultETASmoothingAlpha = 0.3
+
 // ProgressWithETA extends ProgressState with time estimation capabilities.
 // It tracks progress updates and calculates the estimated time remaining
 // based on the rate of progress.
@@ -70,6 +105,8 @@ type ProgressWithETA struct {
 	lastUpdate   time.Time
 	lastProgress float64
 	progressRate float64 // smoothed progress rate (progress per second)
+	alpha        float64 // weight of the latest rate (see SetSmoothingAlpha)
+	now          func() time.Time
 }
 
 // NewProgressWithETA creates a new progress tracker with ETA calculation.
@@ -87,12 +124,30 @@ func NewProgressWithETA(numCalculators int) *ProgressWithETA {
 		lastUpdate:    now,
 		lastProgress:  0,
 		progressRate:  0,
+		alpha:         DefaultETASmoothingAlpha,
+		now:           time.Now,
 	}
 }
 
+// SetSmoothingAlpha sets the weight of the latest measured progress rate in
+// the exponential moving average the ETA is extrapolated from. Lower values
+// give a steadier ETA that reacts more slowly to real changes of pace; 1
+// disables smoothing. Values outside (0, 1] restore
+// DefaultETASmoothingAlpha.
+//
+// Parameters:
+//   - a: The smoothing factor, in (0, 1].
+func (p *ProgressWithETA) SetSmoothingAlpha(a float64) {
+	if !(a > 0 && a <= 1) {
+		a = DefaultETASmoothingAlpha
+	}
+	p.alpha = a
+}
+
 // UpdateWithETA updates progress for a specific calculator and calculates ETA.
-// It uses exponential smoothing for the progress rate to provide stable
-// estimates even with variable progress updates.
+// It uses exponential smoothing for the progress rate (see
+// SetSmoothingAlpha) to provide stable estimates even with bursty progress
+// updates.
 //
 // Parameters:
 //   - index: The index of the calculator (0 to numCalculators-1).
@@ -105,7 +160,7 @@ func (p *ProgressWithETA) UpdateWithETA(index int, value float64) (progress floa
 	p.Update(index, value)
 	progress = p.CalculateAverage()
 
-	now := time.Now()
+	now := p.now()
 	elapsed := now.Sub(p.startTime)
 
 	// Need some elapsed time and progress to make meaningful estimates
@@ -122,9 +177,9 @@ func (p *ProgressWithETA) UpdateWithETA(index int, value float64) (progress floa
 		if progressDelta > 0 {
 			instantRate := progressDelta / timeSinceUpdate
 
-			// Exponential smoothing: 70% old rate, 30% new rate
+			// Exponential moving average of the rate
 			if p.progressRate > 0 {
-				p.progressRate = 0.7*p.progressRate + 0.3*instantRate
+				p.progressRate = (1-p.alpha)*p.progressRate + p.alpha*instantRate
 			} else {
 				// First meaningful rate calculation - use simple estimation
 				p.progressRate = progress / elapsed.Seconds()
@@ -217,7 +272,8 @@ func FormatETA(eta time.Duration) string {
 //   - length: The total character width of the progress bar.
 //
 // Returns:
-//   - string: A string representation of the progress bar.
+//   - string: A string representation of the progress bar, or an empty string
+//     if length is not positive.
 func ProgressBar(progress float64, length int) string {
 	if progress > 1.0 {
 		progress = 1.0
@@ -225,6 +281,9 @@ func ProgressBar(progress float64, length int) string {
 	if progress < 0.0 {
 		progress = 0.0
 	}
+	if length <= 0 {
+		return ""
+	}
 	count := int(progress * float64(length))
 	var builder strings.Builder
 	builder.Grow(length)
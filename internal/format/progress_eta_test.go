@@ -158,6 +158,10 @@ func TestFormatProgressBarWithETA(t *testing.T) {
 			if !contains(result, "[") || !contains(result, "]") {
 				t.Errorf("FormatProgressBarWithETA result should contain progress bar brackets, got %q", result)
 			}
+			// Safe for redirected output: no carriage returns or escape codes
+			if contains(result, "\r") || contains(result, "\x1b") {
+				t.Errorf("FormatProgressBarWithETA result should not contain control characters, got %q", result)
+			}
 		})
 	}
 }
@@ -336,3 +340,104 @@ func containsHelper(s, substr string) bool {
 	}
 	return false
 }
+
+// TestProgressBarNonPositiveLength verifies that a zero or negative length
+// yields an empty bar instead of panicking.
+func TestProgressBarNonPositiveLength(t *testing.T) {
+	t.Parallel()
+	for _, length := range []int{0, -1, -40} {
+		if got := ProgressBar(0.5, length); got != "" {
+			t.Errorf("ProgressBar(0.5, %d) = %q, want empty string", length, got)
+		}
+	}
+}
+
+// TestProgressStateSetWeights verifies the weighted average and the fallback
+// to equal weights for invalid weights.
+func TestProgressStateSetWeights(t *testing.T) {
+	t.Parallel()
+	ps := NewProgressState(2)
+	ps.Update(0, 1)
+	ps.Update(1, 0.5)
+
+	ps.SetWeights([]float64{1, 3})
+	if got := ps.CalculateAverage(); got != 0.625 { // (1*1 + 3*0.5) / 4
+		t.Errorf("weighted average = %f, want 0.625", got)
+	}
+
+	for _, weights := range [][]float64{nil, {1}, {1, 0}, {1, -2}} {
+		ps.SetWeights(weights)
+		if got := ps.CalculateAverage(); got != 0.75 {
+			t.Errorf("SetWeights(%v): average = %f, want the plain average 0.75", weights, got)
+		}
+	}
+}
+
+// noisyETAs feeds bursty progress, every 100ms on a fake clock, to a tracker
+// with the given smoothing factor and returns the ETAs in seconds.
+func noisyETAs(alpha float64) []float64 {
+	p := NewProgressWithETA(1)
+	p.SetSmoothingAlpha(alpha)
+	clock := time.Unix(0, 0)
+	p.startTime, p.lastUpdate = clock, clock
+	p.now = func() time.Time { return clock }
+
+	var etas []float64
+	progress := 0.0
+	for i := range 60 {
+		clock = clock.Add(100 * time.Millisecond)
+		// Alternate slow and fast steps averaging 1% per update.
+		if i%2 == 0 {
+			progress += 0.002
+		} else {
+			progress += 0.018
+		}
+		if _, eta := p.UpdateWithETA(0, progress); i >= 10 {
+			etas = append(etas, eta.Seconds())
+		}
+	}
+	return etas
+}
+
+func variance(values []float64) float64 {
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var sum float64
+	for _, v := range values {
+		sum += (v - mean) * (v - mean)
+	}
+	return sum / float64(len(values))
+}
+
+// TestSmoothingAlphaStabilizesETA checks that the default smoothing gives a
+// steadier ETA on bursty progress than extrapolating the latest rate alone.
+func TestSmoothingAlphaStabilizesETA(t *testing.T) {
+	t.Parallel()
+	smoothed := variance(noisyETAs(DefaultETASmoothingAlpha))
+	raw := variance(noisyETAs(1))
+	if smoothed >= raw {
+		t.Errorf("ETA variance with smoothing = %.1f, want below %.1f without", smoothed, raw)
+	}
+}
+
+// TestSetSmoothingAlpha checks that invalid factors restore the default.
+func TestSetSmoothingAlpha(t *testing.T) {
+	t.Parallel()
+	p := NewProgressWithETA(1)
+	if p.alpha != DefaultETASmoothingAlpha {
+		t.Errorf("default alpha = %v, want %v", p.alpha, DefaultETASmoothingAlpha)
+	}
+	p.SetSmoothingAlpha(0.5)
+	if p.alpha != 0.5 {
+		t.Errorf("alpha = %v, want 0.5", p.alpha)
+	}
+	for _, invalid := range []float64{0, -0.1, 1.5} {
+		p.SetSmoothingAlpha(invalid)
+		if p.alpha != DefaultETASmoothingAlpha {
+			t.Errorf("SetSmoothingAlpha(%v): alpha = %v, want the default", invalid, p.alpha)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package metrics
 
-import "runtime"
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
 
 // MemorySnapshot holds a point-in-time memory reading.
 type MemorySnapshot struct {
@@ -10,6 +15,8 @@ type MemorySnapshot struct {
 	NumGC        uint32 // number of completed GC cycles
 	PauseTotalNs uint64 // cumulative GC pause time
 	HeapObjects  uint64 // number of allocated heap objects
+	HeapInuse    uint64 // bytes in in-use heap spans
+	TotalAlloc   uint64 // cumulative bytes allocated for heap objects
 }
 
 // MemoryCollector reads runtime memory statistics.
@@ -31,5 +38,107 @@ func (mc *MemoryCollector) Snapshot() MemorySnapshot {
 		NumGC:        m.NumGC,
 		PauseTotalNs: m.PauseTotalNs,
 		HeapObjects:  m.HeapObjects,
+		HeapInuse:    m.HeapInuse,
+		TotalAlloc:   m.TotalAlloc,
 	}
 }
+
+// MemoryReport summarizes the memory behavior of a computation from the
+// snapshots taken before and after it.
+type MemoryReport struct {
+	TotalAlloc  uint64        // bytes allocated during the computation
+	NumGC       uint32        // GC cycles completed during the computation
+	GCPause     time.Duration // GC pause time during the computation
+	PeakHeapSys uint64        // highest heap memory obtained from the OS
+}
+
+// NewMemoryReport computes the memory report of a computation. The heap
+// obtained from the OS (HeapSys) never shrinks, as released memory stays
+// accounted for, so its peak is the larger of the two readings.
+//
+// Parameters:
+//   - before: The snapshot taken before the computation.
+//   - after: The snapshot taken after the computation.
+//
+// Returns:
+//   - MemoryReport: The allocations, GC cycles and peak heap in between.
+func NewMemoryReport(before, after MemorySnapshot) MemoryReport {
+	return MemoryReport{
+		TotalAlloc:  after.TotalAlloc - before.TotalAlloc,
+		NumGC:       after.NumGC - before.NumGC,
+		GCPause:     time.Duration(after.PauseTotalNs - before.PauseTotalNs),
+		PeakHeapSys: max(before.HeapSys, after.HeapSys),
+	}
+}
+
+// DefaultPeakSampleInterval is the default interval between heap samples taken
+// by a PeakHeapSampler.
+const DefaultPeakSampleInterval = 10 * time.Millisecond
+
+// PeakHeapSampler records the high-water mark of runtime HeapInuse while a
+// computation runs. Sampling happens in a background goroutine started by
+// StartPeakHeapSampler and ends with Stop.
+type PeakHeapSampler struct {
+	peak     atomic.Uint64
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// StartPeakHeapSampler starts sampling HeapInuse every interval.
+// A non-positive interval uses DefaultPeakSampleInterval.
+func StartPeakHeapSampler(interval time.Duration) *PeakHeapSampler {
+	if interval <= 0 {
+		interval = DefaultPeakSampleInterval
+	}
+	s := &PeakHeapSampler{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	s.sample()
+	go s.run(interval)
+	return s
+}
+
+// run samples until Stop is called.
+func (s *PeakHeapSampler) run(interval time.Duration) {
+	defer close(s.done)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.sample()
+		}
+	}
+}
+
+// sample reads HeapInuse and raises the recorded peak if needed.
+func (s *PeakHeapSampler) sample() {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	for {
+		cur := s.peak.Load()
+		if m.HeapInuse <= cur || s.peak.CompareAndSwap(cur, m.HeapInuse) {
+			return
+		}
+	}
+}
+
+// Stop ends sampling, takes a final sample and returns the peak HeapInuse in
+// bytes. It is safe to call more than once.
+func (s *PeakHeapSampler) Stop() uint64 {
+	s.stopOnce.Do(func() {
+		close(s.stop)
+		<-s.done
+		s.sample()
+	})
+	return s.peak.Load()
+}
+
+// Peak returns the highest HeapInuse observed so far, in bytes.
+func (s *PeakHeapSampler) Peak() uint64 {
+	return s.peak.Load()
+}
@@ -1,6 +1,9 @@
 package metrics
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestMemoryCollector_Snapshot(t *testing.T) {
 	t.Parallel()
@@ -32,3 +35,44 @@ func TestMemoryCollector_Delta(t *testing.T) {
 		t.Error("Sys should not decrease between snapshots")
 	}
 }
+
+var sink []byte
+
+func TestNewMemoryReport(t *testing.T) {
+	t.Parallel()
+
+	mc := NewMemoryCollector()
+	before := mc.Snapshot()
+	sink = make([]byte, 4<<20)
+	report := NewMemoryReport(before, mc.Snapshot())
+
+	if report.TotalAlloc < 4<<20 {
+		t.Errorf("TotalAlloc = %d, want at least the 4 MB allocated", report.TotalAlloc)
+	}
+	if report.PeakHeapSys < before.HeapSys {
+		t.Errorf("PeakHeapSys = %d, want at least HeapSys before (%d)", report.PeakHeapSys, before.HeapSys)
+	}
+}
+
+func TestPeakHeapSampler(t *testing.T) {
+	t.Parallel()
+
+	s := StartPeakHeapSampler(time.Millisecond)
+	buf := make([]byte, 4<<20) // 4 MB
+	for i := range buf {
+		buf[i] = byte(i)
+	}
+	time.Sleep(5 * time.Millisecond)
+	peak := s.Stop()
+
+	if peak == 0 {
+		t.Fatal("peak HeapInuse should be > 0")
+	}
+	if peak < s.Peak() {
+		t.Errorf("Stop() = %d, lower than Peak() = %d", peak, s.Peak())
+	}
+	if again := s.Stop(); again != peak {
+		t.Errorf("second Stop() = %d, want %d", again, peak)
+	}
+	_ = buf[len(buf)-1]
+}
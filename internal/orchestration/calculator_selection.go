@@ -6,27 +6,52 @@ import (
 
 // GetCalculatorsToRun determines which calculators should be executed based on
 // the algorithm name. Returns calculators in alphabetically sorted order for
-// consistent, reproducible behavior.
+// consistent, reproducible behavior. Algorithms that do not compute F(n),
+// such as "lucas", are not part of "all".
 //
 // Parameters:
 //   - algo: The algorithm name ("fast", "matrix", "fft", "all").
-//   - factory: The calculator factory to retrieve implementations from.
+//   - factory: The factory to build calculators from, including any custom
+//     algorithms registered by embedders.
 //
 // Returns:
 //   - []fibonacci.Calculator: A slice of calculators to execute.
-func GetCalculatorsToRun(algo string, factory fibonacci.CalculatorFactory) []fibonacci.Calculator {
+func GetCalculatorsToRun(algo string, factory fibonacci.Factory) []fibonacci.Calculator {
 	if algo == "all" {
 		keys := factory.List() // List() returns sorted keys
 		calculators := make([]fibonacci.Calculator, 0, len(keys))
 		for _, k := range keys {
-			if calc, err := factory.Get(k); err == nil {
+			if fibonacci.ExcludedFromAll(k) {
+				continue
+			}
+			if calc, err := factory.Build(k); err == nil {
 				calculators = append(calculators, calc)
 			}
 		}
 		return calculators
 	}
-	if calc, err := factory.Get(algo); err == nil {
+	if calc, err := factory.Build(algo); err == nil {
 		return []fibonacci.Calculator{calc}
 	}
 	return nil
 }
+
+// ProgressWeights returns the progress weight of each calculator: its
+// estimated cost for F(n) (see fibonacci.RelativeCost). Weighting the
+// aggregated progress by cost keeps the combined bar and ETA steady in mixed
+// comparisons.
+//
+// Parameters:
+//   - calculators: The calculators to run, e.g. from GetCalculatorsToRun.
+//   - n: The Fibonacci index.
+//   - opts: The calculation options.
+//
+// Returns:
+//   - []float64: One weight per calculator, in the same order.
+func ProgressWeights(calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options) []float64 {
+	weights := make([]float64, len(calculators))
+	for i, calc := range calculators {
+		weights[i] = fibonacci.RelativeCost(calc, n, opts)
+	}
+	return weights
+}
@@ -31,6 +31,11 @@ func TestGetCalculatorsToRun(t *testing.T) {
 		if len(calculators) < 2 {
 			t.Errorf("Expected at least 2 calculators for 'all', got %d", len(calculators))
 		}
+		for _, calc := range calculators {
+			if calc.Name() == (&fibonacci.LucasCalculator{}).Name() {
+				t.Error("'all' must not include the Lucas calculator")
+			}
+		}
 	})
 
 	t.Run("Matrix algorithm", func(t *testing.T) {
@@ -42,3 +47,78 @@ func TestGetCalculatorsToRun(t *testing.T) {
 		}
 	})
 }
+
+// TestGetCalculatorsToRunCustomCalculator verifies that a calculator
+// registered through the Factory interface can be selected by name and takes
+// part in "all".
+func TestGetCalculatorsToRunCustomCalculator(t *testing.T) {
+	t.Parallel()
+	var factory fibonacci.Factory = fibonacci.NewDefaultFactory()
+	custom := &MockCalculator{NameFunc: func() string { return "Custom" }}
+	if err := factory.Register("custom", func() fibonacci.Calculator { return custom }); err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	calculators := GetCalculatorsToRun("custom", factory)
+	if len(calculators) != 1 || calculators[0] != custom {
+		t.Fatalf("Expected the custom calculator, got %v", calculators)
+	}
+
+	found := false
+	for _, calc := range GetCalculatorsToRun("all", factory) {
+		if calc == custom {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Custom calculator should be included in 'all'")
+	}
+}
+
+// TestGetCalculatorsToRunRegisteredCalculator verifies that a calculator
+// added with fibonacci.RegisterCalculator is part of the run set of the
+// factories created afterwards. It registers in the package-level registry,
+// so it does not run in parallel with the tests listing "all".
+func TestGetCalculatorsToRunRegisteredCalculator(t *testing.T) {
+	stub := &MockCalculator{NameFunc: func() string { return "Stub" }}
+	if err := fibonacci.RegisterCalculator("selection_stub", stub); err != nil {
+		t.Fatalf("RegisterCalculator failed: %v", err)
+	}
+	t.Cleanup(func() { fibonacci.UnregisterCalculator("selection_stub") })
+	factory := fibonacci.NewDefaultFactory()
+
+	if calculators := GetCalculatorsToRun("selection_stub", factory); len(calculators) != 1 || calculators[0] != stub {
+		t.Fatalf("Expected the stub calculator, got %v", calculators)
+	}
+	found := false
+	for _, calc := range GetCalculatorsToRun("all", factory) {
+		if calc == stub {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("Registered calculator should be included in 'all'")
+	}
+}
+
+// TestProgressWeights verifies that weights line up with the calculators
+// and follow fibonacci.RelativeCost.
+func TestProgressWeights(t *testing.T) {
+	t.Parallel()
+	opts := fibonacci.Options{}
+
+	calculators := GetCalculatorsToRun("all", fibonacci.GlobalFactory())
+	weights := ProgressWeights(calculators, 1000, opts)
+	if len(weights) != len(calculators) {
+		t.Fatalf("got %d weights for %d calculators", len(weights), len(calculators))
+	}
+	for i, calc := range calculators {
+		if want := fibonacci.RelativeCost(calc, 1000, opts); weights[i] != want {
+			t.Errorf("weight of %s = %v, want %v", calc.Name(), weights[i], want)
+		}
+	}
+
+	if got := ProgressWeights(nil, 1000, opts); len(got) != 0 {
+		t.Errorf("no calculators: got %v, want no weights", got)
+	}
+}
@@ -0,0 +1,68 @@
+package orchestration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"runtime/debug"
+	"sync"
+
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+// configHashLen is the number of hexadecimal digits of a ConfigHash.
+const configHashLen = 12
+
+// buildVersion returns the version of the main module and, when the binary
+// was built from a VCS checkout, its revision, as recorded by the Go
+// toolchain.
+var buildVersion = sync.OnceValue(func() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	version := info.Main.Version
+	for _, setting := range info.Settings {
+		if setting.Key == "vcs.revision" {
+			version += "+" + setting.Value
+		}
+	}
+	return version
+})
+
+// ConfigHash returns a short fingerprint of the settings of a calculation:
+// the algorithm name, every field of opts and the build version. The fields
+// of opts are enumerated by reflection, so that a new option is hashed
+// without changes here; pointer fields are hashed by the value they point
+// to, or as "default" when nil, and callbacks, which are not settings, are
+// skipped. Two
+// calculations with the same fingerprint ran the same code with the same
+// options, which helps correlating the logs of different runs.
+//
+// Parameters:
+//   - algo: The calculator name.
+//   - opts: The calculation options.
+//
+// Returns:
+//   - string: The first 12 hexadecimal digits of a SHA-256 digest.
+func ConfigHash(algo string, opts fibonacci.Options) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "algo=%q version=%q", algo, buildVersion())
+	v := reflect.ValueOf(opts)
+	for i := range v.NumField() {
+		field := v.Field(i)
+		if field.Kind() == reflect.Func {
+			continue
+		}
+		if field.Kind() == reflect.Pointer {
+			if field.IsNil() {
+				fmt.Fprintf(h, " %s=default", v.Type().Field(i).Name)
+				continue
+			}
+			field = field.Elem()
+		}
+		fmt.Fprintf(h, " %s=%#v", v.Type().Field(i).Name, field.Interface())
+	}
+	return hex.EncodeToString(h.Sum(nil))[:configHashLen]
+}
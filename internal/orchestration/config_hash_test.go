@@ -0,0 +1,81 @@
+package orchestration
+
+import (
+	"context"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+// TestExecuteCalculationsConfigHash verifies that results carry a
+// fingerprint that only depends on the algorithm and the options.
+func TestExecuteCalculationsConfigHash(t *testing.T) {
+	t.Parallel()
+	calculators := []fibonacci.Calculator{
+		&MockCalculator{NameFunc: func() string { return "a" }},
+		&MockCalculator{NameFunc: func() string { return "b" }},
+	}
+	run := func(opts fibonacci.Options) []CalculationResult {
+		return ExecuteCalculations(context.Background(), calculators, 10, opts, NullProgressReporter{}, io.Discard)
+	}
+
+	opts := fibonacci.Options{ParallelThreshold: 4096, FFTThreshold: 500000}
+	first, second := run(opts), run(opts)
+	for i := range first {
+		if len(first[i].ConfigHash) != configHashLen {
+			t.Fatalf("results[%d].ConfigHash = %q, want %d hexadecimal digits", i, first[i].ConfigHash, configHashLen)
+		}
+		if first[i].ConfigHash != second[i].ConfigHash {
+			t.Errorf("results[%d]: identical options gave %q and %q", i, first[i].ConfigHash, second[i].ConfigHash)
+		}
+	}
+	if first[0].ConfigHash == first[1].ConfigHash {
+		t.Errorf("algorithms a and b share the hash %q", first[0].ConfigHash)
+	}
+
+	opts.FFTThreshold = 250000
+	if other := run(opts); other[0].ConfigHash == first[0].ConfigHash {
+		t.Errorf("different FFT thresholds gave the same hash %q", other[0].ConfigHash)
+	}
+
+	enabled, disabled := true, false
+	withCache := ConfigHash("a", fibonacci.Options{FFTCacheEnabled: &enabled})
+	if withCache != ConfigHash("a", fibonacci.Options{FFTCacheEnabled: &enabled}) {
+		t.Error("the hash should depend on the FFT cache setting, not its address")
+	}
+	if withCache == ConfigHash("a", fibonacci.Options{FFTCacheEnabled: &disabled}) {
+		t.Error("enabling and disabling the FFT cache gave the same hash")
+	}
+}
+
+// TestConfigHashCoversEveryOption verifies that changing any field of
+// fibonacci.Options changes the hash, including fields added later; callbacks
+// are not settings and are left out.
+func TestConfigHashCoversEveryOption(t *testing.T) {
+	t.Parallel()
+	base := ConfigHash("a", fibonacci.Options{})
+	typ := reflect.TypeFor[fibonacci.Options]()
+	for i := range typ.NumField() {
+		var opts fibonacci.Options
+		field := reflect.ValueOf(&opts).Elem().Field(i)
+		switch field.Kind() {
+		case reflect.Int:
+			field.SetInt(1)
+		case reflect.Bool:
+			field.SetBool(true)
+		case reflect.String:
+			field.SetString("x")
+		case reflect.Pointer:
+			field.Set(reflect.New(field.Type().Elem()))
+		case reflect.Func:
+			continue
+		default:
+			t.Fatalf("%s: unhandled kind %s", typ.Field(i).Name, field.Kind())
+		}
+		if ConfigHash("a", opts) == base {
+			t.Errorf("setting %s does not change the hash", typ.Field(i).Name)
+		}
+	}
+}
@@ -20,6 +20,40 @@ type CalculationResult struct {
 	Duration time.Duration
 	// Err contains any error that occurred during the calculation.
 	Err error
+	// Index is the position of the calculator in the slice passed to
+	// ExecuteCalculations or ExecuteCalculationsStream. It matches the
+	// CalculatorIndex of the progress updates sent by that calculator.
+	Index int
+	// ConfigHash is the fingerprint of the algorithm, options and build
+	// version of the calculation (see ConfigHash). It is empty for results
+	// served from a ResultCache, whose options are unknown.
+	ConfigHash string
+}
+
+// ExecOptions configures how calculations are executed. The zero value runs
+// every calculator to completion (full comparison).
+type ExecOptions struct {
+	// CancelOnFirstSuccess cancels the remaining calculators as soon as one of
+	// them returns a valid result. Cancelled calculators report an error
+	// wrapping both ErrCanceledByWinner and context.Canceled.
+	CancelOnFirstSuccess bool
+	// Hooks are invoked around each calculator's execution.
+	Hooks ExecHooks
+}
+
+// ExecHooks are optional callbacks invoked when each calculator starts and
+// finishes, e.g. to feed custom telemetry. Nil fields are skipped.
+//
+// Hooks run synchronously on the calculator's goroutine, so they should be
+// fast: a slow hook delays the calculation (OnStart) or the delivery of its
+// result (OnFinish). Invocations are serialized by the orchestrator, so hooks
+// may update shared state without additional locking.
+type ExecHooks struct {
+	// OnStart is called just before a calculator starts.
+	OnStart func(name string)
+	// OnFinish is called once a calculator has returned, with its duration
+	// and error (nil on success).
+	OnFinish func(name string, d time.Duration, err error)
 }
 
 // PresentationOptions configures how results are presented to the user.
@@ -28,6 +62,14 @@ type PresentationOptions struct {
 	Verbose   bool
 	Details   bool
 	ShowValue bool
+	// Expected, if non-nil, is the known-correct F(N). Each result is checked
+	// against it instead of only checking the results agree with each other.
+	Expected *big.Int
+	// IgnoreInconsistency, if true, reports results that disagree with each
+	// other as a warning and still presents the reference result, instead of
+	// failing (--fail-on-inconsistency=false). It has no effect on a
+	// mismatch with Expected.
+	IgnoreInconsistency bool
 }
 
 // ProgressReporter defines the interface for displaying calculation progress.
@@ -2,14 +2,16 @@ package orchestration
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"math/big"
 	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-
 	apperrors "github.com/agbru/fibcalc/internal/errors"
 	"github.com/agbru/fibcalc/internal/fibonacci"
 	"github.com/agbru/fibcalc/internal/progress"
@@ -20,12 +22,18 @@ import (
 // goroutines when the UI is slow to consume updates.
 const ProgressBufferMultiplier = 5
 
+// ErrCanceledByWinner marks results of calculators that were stopped because
+// another calculator had already produced a valid result (see
+// ExecOptions.CancelOnFirstSuccess).
+var ErrCanceledByWinner = errors.New("canceled: another algorithm finished first")
+
 // ExecuteCalculations orchestrates the concurrent execution of one or more
 // Fibonacci calculations.
 //
 // It manages the lifecycle of calculation goroutines, collects their results,
 // and coordinates the display of progress updates. This function is the core of
-// the application's concurrency model.
+// the application's concurrency model. It is built on ExecuteCalculationsStream
+// and blocks until every calculator has returned.
 //
 // Parameters:
 //   - ctx: The context for managing cancellation and deadlines.
@@ -36,55 +44,139 @@ const ProgressBufferMultiplier = 5
 //   - out: The io.Writer for displaying progress updates.
 //
 // Returns:
-//   - []CalculationResult: A slice containing the results of each calculation.
+//   - []CalculationResult: A slice containing the results of each calculation,
+//     in the same order as calculators.
 func ExecuteCalculations(ctx context.Context, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, progressReporter ProgressReporter, out io.Writer) []CalculationResult {
+	return ExecuteCalculationsWithOptions(ctx, calculators, n, opts, ExecOptions{}, progressReporter, out)
+}
+
+// ExecuteCalculationsWithOptions is like ExecuteCalculations but accepts
+// execution options. With ExecOptions.CancelOnFirstSuccess set, the first
+// valid result cancels the shared context so that slower calculators stop
+// instead of burning CPU; their results carry an error wrapping
+// ErrCanceledByWinner and context.Canceled.
+//
+// Returns:
+//   - []CalculationResult: A slice containing the results of each calculation,
+//     in the same order as calculators.
+func ExecuteCalculationsWithOptions(ctx context.Context, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, execOpts ExecOptions, progressReporter ProgressReporter, out io.Writer) []CalculationResult {
 	results := make([]CalculationResult, len(calculators))
+	for res := range executeStream(ctx, calculators, n, opts, execOpts, progressReporter, out) {
+		results[res.Index] = res
+	}
+	return results
+}
+
+// ExecuteCalculationsWithHooks is like ExecuteCalculations but invokes hooks
+// when each calculator starts and finishes. See ExecHooks for the threading
+// guarantees.
+//
+// Returns:
+//   - []CalculationResult: A slice containing the results of each calculation,
+//     in the same order as calculators.
+func ExecuteCalculationsWithHooks(ctx context.Context, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, hooks ExecHooks, progressReporter ProgressReporter, out io.Writer) []CalculationResult {
+	return ExecuteCalculationsWithOptions(ctx, calculators, n, opts, ExecOptions{Hooks: hooks}, progressReporter, out)
+}
+
+// ExecuteCalculationsStream runs the calculators concurrently like
+// ExecuteCalculations, but emits each result on the returned channel as soon
+// as its calculator returns, so that the fastest algorithm's result can be
+// shown before the slowest one finishes.
+//
+// The channel is buffered for all results, so calculators never block on a
+// slow consumer. It is closed once every calculator has returned and the
+// progress display has finished. Calculators observe ctx, so cancelling it
+// makes them return promptly with a context error and the channel is closed
+// right after those (failed) results are delivered.
+//
+// Parameters:
+//   - ctx: The context for managing cancellation and deadlines.
+//   - calculators: A slice of calculators to execute.
+//   - n: The Fibonacci index to compute.
+//   - opts: Calculation options (thresholds, etc.).
+//   - progressReporter: The progress reporter for displaying updates (use NullProgressReporter for quiet mode).
+//   - out: The io.Writer for displaying progress updates.
+//
+// Returns:
+//   - <-chan CalculationResult: Results in completion order; use Index to map
+//     them back to calculators.
+func ExecuteCalculationsStream(ctx context.Context, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, progressReporter ProgressReporter, out io.Writer) <-chan CalculationResult {
+	return executeStream(ctx, calculators, n, opts, ExecOptions{}, progressReporter, out)
+}
+
+// executeStream implements ExecuteCalculationsStream and
+// ExecuteCalculationsWithOptions.
+func executeStream(ctx context.Context, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, execOpts ExecOptions, progressReporter ProgressReporter, out io.Writer) <-chan CalculationResult {
+	parentCtx := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	var winnerFound atomic.Bool
+
+	resultsChan := make(chan CalculationResult, len(calculators))
 	progressChan := make(chan progress.ProgressUpdate, len(calculators)*ProgressBufferMultiplier)
 
 	var displayWg sync.WaitGroup
 	displayWg.Add(1)
 	go progressReporter.DisplayProgress(&displayWg, progressChan, len(calculators), out)
 
-	// Fast path: single calculator doesn't need errgroup overhead
-	if len(calculators) == 1 {
-		startTime := time.Now()
-		res, err := calculators[0].Calculate(ctx, progressChan, 0, n, opts)
-		results[0] = CalculationResult{
-			Name: calculators[0].Name(), Result: res, Duration: time.Since(startTime), Err: err,
-		}
-	} else {
-		g, ctx := errgroup.WithContext(ctx)
-		for i, calc := range calculators {
-			idx, calculator := i, calc
-			g.Go(func() error {
-				startTime := time.Now()
-				res, err := calculator.Calculate(ctx, progressChan, idx, n, opts)
-				results[idx] = CalculationResult{
-					Name: calculator.Name(), Result: res, Duration: time.Since(startTime), Err: err,
-				}
-				return nil
-			})
-		}
-		g.Wait()
+	// hooksMu serializes hook invocations across calculator goroutines.
+	var hooksMu sync.Mutex
+	hooks := execOpts.Hooks
+
+	var calcWg sync.WaitGroup
+	calcWg.Add(len(calculators))
+	for i, calc := range calculators {
+		go func(idx int, calculator fibonacci.Calculator) {
+			defer calcWg.Done()
+			name := calculator.Name()
+			if hooks.OnStart != nil {
+				hooksMu.Lock()
+				hooks.OnStart(name)
+				hooksMu.Unlock()
+			}
+			startTime := time.Now()
+			res, err := calculator.Calculate(ctx, progressChan, idx, n, opts)
+			duration := time.Since(startTime)
+			if err == nil && execOpts.CancelOnFirstSuccess && winnerFound.CompareAndSwap(false, true) {
+				cancel()
+			} else if err != nil && winnerFound.Load() && parentCtx.Err() == nil && errors.Is(err, context.Canceled) {
+				err = fmt.Errorf("%w: %w", ErrCanceledByWinner, err)
+			}
+			if hooks.OnFinish != nil {
+				hooksMu.Lock()
+				hooks.OnFinish(name, duration, err)
+				hooksMu.Unlock()
+			}
+			resultsChan <- CalculationResult{
+				Name: name, Result: res, Duration: duration, Err: err, Index: idx,
+				ConfigHash: ConfigHash(name, opts),
+			}
+		}(i, calc)
 	}
 
-	close(progressChan)
-	displayWg.Wait()
+	go func() {
+		calcWg.Wait()
+		cancel()
+		close(progressChan)
+		displayWg.Wait()
+		close(resultsChan)
+	}()
 
-	return results
+	return resultsChan
 }
 
 // AnalyzeComparisonResults processes the results from multiple algorithms and
 // generates a summary report.
 //
 // It sorts the results by execution time, validates consistency across
-// successful calculations, and displays a comparative table. It handles the
+// successful calculations (or, when presOpts.Expected is set, checks each of
+// them against the expected value), and displays a comparative table. It handles the
 // logic for determining global success or failure based on the individual
-// outcomes.
+// outcomes. With presOpts.IgnoreInconsistency, results that disagree with
+// each other only produce a warning, and the reference result is presented.
 //
 // Parameters:
 //   - results: The slice of calculation results to analyze.
-//   - presOpts: Presentation options (N, verbose, details, showValue).
+//   - presOpts: Presentation options (N, verbose, details, showValue, expected).
 //   - presenter: The result presenter for display formatting.
 //   - out: The io.Writer for the summary report.
 //
@@ -123,19 +215,119 @@ func AnalyzeComparisonResults(results []CalculationResult, presOpts Presentation
 		return errHandler.HandleError(firstError, 0, out)
 	}
 
-	mismatch := false
-	for _, res := range results {
-		if res.Err == nil && res.Result.Cmp(firstValidResult.Result) != 0 {
-			mismatch = true
-			break
+	if mismatch := FindMismatch(results, presOpts.Expected); mismatch != nil {
+		switch {
+		case mismatch.Kind == MismatchExpected:
+			fmt.Fprintf(out, "\nGlobal Status: CRITICAL ERROR! Result differs from the expected value for: %s\n", strings.Join(mismatch.Algorithms, ", "))
+		case presOpts.IgnoreInconsistency:
+			fmt.Fprintf(out, "\nGlobal Status: Warning. An inconsistency was detected between the results of the algorithms (%s differ from %s). Showing the result of %s.\n",
+				strings.Join(mismatch.Algorithms, ", "), mismatch.Reference, mismatch.Reference)
+			presenter.PresentResult(*firstValidResult, presOpts.N, presOpts.Verbose, presOpts.Details, presOpts.ShowValue, out)
+			return apperrors.ExitSuccess
+		default:
+			fmt.Fprintf(out, "\nGlobal Status: CRITICAL ERROR! An inconsistency was detected between the results of the algorithms.\n")
 		}
-	}
-	if mismatch {
-		fmt.Fprintf(out, "\nGlobal Status: CRITICAL ERROR! An inconsistency was detected between the results of the algorithms.")
 		return apperrors.ExitErrorMismatch
 	}
 
+	if presOpts.Expected != nil {
+		fmt.Fprintf(out, "\nGlobal Status: Success. All valid results match the expected value.\n")
+		presenter.PresentResult(*firstValidResult, presOpts.N, presOpts.Verbose, presOpts.Details, presOpts.ShowValue, out)
+		return apperrors.ExitSuccess
+	}
+
 	fmt.Fprintf(out, "\nGlobal Status: Success. All valid results are consistent.\n")
 	presenter.PresentResult(*firstValidResult, presOpts.N, presOpts.Verbose, presOpts.Details, presOpts.ShowValue, out)
 	return apperrors.ExitSuccess
 }
+
+// FindBestResult returns the fastest successful result.
+//
+// Parameters:
+//   - results: The calculation results, in any order.
+//
+// Returns:
+//   - *CalculationResult: A pointer into results, or nil if none succeeded.
+func FindBestResult(results []CalculationResult) *CalculationResult {
+	var bestResult *CalculationResult
+	for i := range results {
+		if results[i].Err == nil {
+			if bestResult == nil || results[i].Duration < bestResult.Duration {
+				bestResult = &results[i]
+			}
+		}
+	}
+	return bestResult
+}
+
+// Mismatch kinds reported in a Mismatch.
+const (
+	// MismatchInconsistent means the successful results disagree with each other.
+	MismatchInconsistent = "inconsistency"
+	// MismatchExpected means results differ from the expected value (--expect).
+	MismatchExpected = "expected"
+)
+
+// Mismatch is a machine-readable description of results that do not agree.
+type Mismatch struct {
+	// Kind is MismatchInconsistent or MismatchExpected.
+	Kind string `json:"kind"`
+	// Reference is the algorithm the others were compared with. It is empty
+	// for MismatchExpected.
+	Reference string `json:"reference,omitempty"`
+	// Algorithms are the names of the algorithms whose result differs.
+	Algorithms []string `json:"algorithms"`
+}
+
+// FindMismatch checks the successful results against expected, or, when
+// expected is nil, against the first successful result.
+//
+// Parameters:
+//   - results: The calculation results to check. Failed results are ignored.
+//   - expected: The known-correct value, or nil.
+//
+// Returns:
+//   - *Mismatch: The disagreement, or nil if all successful results agree.
+func FindMismatch(results []CalculationResult, expected *big.Int) *Mismatch {
+	if expected != nil {
+		if deviating := DeviatingResults(results, expected); len(deviating) > 0 {
+			return &Mismatch{Kind: MismatchExpected, Algorithms: deviating}
+		}
+		return nil
+	}
+
+	var reference *CalculationResult
+	var deviating []string
+	for i := range results {
+		switch {
+		case results[i].Err != nil:
+		case reference == nil:
+			reference = &results[i]
+		case results[i].Result.Cmp(reference.Result) != 0:
+			deviating = append(deviating, results[i].Name)
+		}
+	}
+	if len(deviating) == 0 {
+		return nil
+	}
+	return &Mismatch{Kind: MismatchInconsistent, Reference: reference.Name, Algorithms: deviating}
+}
+
+// DeviatingResults returns the names of the successful results whose value
+// differs from expected, in the order they appear in results.
+//
+// Parameters:
+//   - results: The calculation results to check. Failed results are ignored.
+//   - expected: The known-correct value.
+//
+// Returns:
+//   - []string: The names of the deviating algorithms (empty if all match).
+func DeviatingResults(results []CalculationResult, expected *big.Int) []string {
+	var deviating []string
+	for _, res := range results {
+		if res.Err == nil && res.Result.Cmp(expected) != 0 {
+			deviating = append(deviating, res.Name)
+		}
+	}
+	return deviating
+}
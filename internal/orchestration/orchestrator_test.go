@@ -1,10 +1,12 @@
 package orchestration
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"io"
 	"math/big"
+	"strings"
 	"testing"
 	"time"
 
@@ -164,9 +166,393 @@ func TestAnalyzeComparisonResults(t *testing.T) {
 	}
 }
 
+// TestAnalyzeComparisonResultsExpected verifies that results are checked
+// against PresentationOptions.Expected when it is set.
+func TestAnalyzeComparisonResultsExpected(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name           string
+		results        []CalculationResult
+		expected       *big.Int
+		expectedStatus int
+		wantInOutput   string
+	}{
+		{
+			name: "All match expected",
+			results: []CalculationResult{
+				{Name: "A", Result: big.NewInt(5), Duration: time.Millisecond},
+				{Name: "B", Result: big.NewInt(5), Duration: 2 * time.Millisecond},
+			},
+			expected:       big.NewInt(5),
+			expectedStatus: apperrors.ExitSuccess,
+			wantInOutput:   "match the expected value",
+		},
+		{
+			name: "Consistent but wrong",
+			results: []CalculationResult{
+				{Name: "A", Result: big.NewInt(6), Duration: time.Millisecond},
+				{Name: "B", Result: big.NewInt(6), Duration: 2 * time.Millisecond},
+			},
+			expected:       big.NewInt(5),
+			expectedStatus: apperrors.ExitErrorMismatch,
+			wantInOutput:   "expected value for: A, B",
+		},
+		{
+			name: "One deviates",
+			results: []CalculationResult{
+				{Name: "A", Result: big.NewInt(5), Duration: time.Millisecond},
+				{Name: "B", Result: big.NewInt(6), Duration: 2 * time.Millisecond},
+				{Name: "C", Err: errors.New("fail"), Duration: time.Millisecond},
+			},
+			expected:       big.NewInt(5),
+			expectedStatus: apperrors.ExitErrorMismatch,
+			wantInOutput:   "expected value for: B\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			var buf bytes.Buffer
+			status := AnalyzeComparisonResults(tt.results, PresentationOptions{Expected: tt.expected}, MockResultPresenter{}, MockResultPresenter{}, &buf)
+			if status != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, status)
+			}
+			if !strings.Contains(buf.String(), tt.wantInOutput) {
+				t.Errorf("output %q does not contain %q", buf.String(), tt.wantInOutput)
+			}
+		})
+	}
+}
+
+// TestAnalyzeComparisonResultsIgnoreInconsistency verifies that, with
+// IgnoreInconsistency, disagreeing results produce a warning and the
+// reference result is presented with a success status.
+func TestAnalyzeComparisonResultsIgnoreInconsistency(t *testing.T) {
+	t.Parallel()
+	results := []CalculationResult{
+		{Name: "A", Result: big.NewInt(5), Duration: time.Millisecond},
+		{Name: "B", Result: big.NewInt(6), Duration: 2 * time.Millisecond},
+	}
+	var buf bytes.Buffer
+	presenter := &recordingPresenter{}
+	status := AnalyzeComparisonResults(results, PresentationOptions{IgnoreInconsistency: true}, presenter, MockResultPresenter{}, &buf)
+	if status != apperrors.ExitSuccess {
+		t.Errorf("expected status %d, got %d", apperrors.ExitSuccess, status)
+	}
+	if out := buf.String(); !strings.Contains(out, "Warning") || strings.Contains(out, "CRITICAL") {
+		t.Errorf("expected a warning without a critical error, got %q", out)
+	}
+	if presenter.presented != "A" {
+		t.Errorf("expected the reference result A to be presented, got %q", presenter.presented)
+	}
+
+	// A mismatch with the expected value still fails.
+	status = AnalyzeComparisonResults(results, PresentationOptions{IgnoreInconsistency: true, Expected: big.NewInt(5)}, MockResultPresenter{}, MockResultPresenter{}, &buf)
+	if status != apperrors.ExitErrorMismatch {
+		t.Errorf("expected status %d with an expected value, got %d", apperrors.ExitErrorMismatch, status)
+	}
+}
+
+// recordingPresenter records the name of the result passed to PresentResult.
+type recordingPresenter struct {
+	MockResultPresenter
+	presented string
+}
+
+func (p *recordingPresenter) PresentResult(result CalculationResult, n uint64, verbose, details, showValue bool, out io.Writer) {
+	p.presented = result.Name
+}
+
+func TestFindMismatch(t *testing.T) {
+	t.Parallel()
+	results := []CalculationResult{
+		{Name: "A", Result: big.NewInt(5)},
+		{Name: "B", Err: errors.New("fail")},
+		{Name: "C", Result: big.NewInt(6)},
+		{Name: "D", Result: big.NewInt(5)},
+	}
+
+	m := FindMismatch(results, nil)
+	if m == nil || m.Kind != MismatchInconsistent || m.Reference != "A" || strings.Join(m.Algorithms, ",") != "C" {
+		t.Errorf("unexpected inconsistency %+v", m)
+	}
+	m = FindMismatch(results, big.NewInt(5))
+	if m == nil || m.Kind != MismatchExpected || m.Reference != "" || strings.Join(m.Algorithms, ",") != "C" {
+		t.Errorf("unexpected expected-value mismatch %+v", m)
+	}
+	if m := FindMismatch(results[:2], nil); m != nil {
+		t.Errorf("expected no mismatch for consistent results, got %+v", m)
+	}
+}
+
 // DiscardWriter is a helper that implements io.Writer and discards all data.
 type DiscardWriter struct{}
 
 func (d *DiscardWriter) Write(p []byte) (n int, err error) {
 	return len(p), nil
 }
+
+// TestExecuteCalculationsStream verifies that results are emitted as soon as
+// each calculator finishes, before slower calculators complete.
+func TestExecuteCalculationsStream(t *testing.T) {
+	t.Parallel()
+	release := make(chan struct{})
+	calculators := []fibonacci.Calculator{
+		&MockCalculator{
+			NameFunc: func() string { return "slow" },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				<-release
+				return big.NewInt(2), nil
+			},
+		},
+		&MockCalculator{
+			NameFunc: func() string { return "fast" },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				return big.NewInt(1), nil
+			},
+		},
+	}
+
+	stream := ExecuteCalculationsStream(context.Background(), calculators, 10, fibonacci.Options{}, NullProgressReporter{}, io.Discard)
+
+	select {
+	case first := <-stream:
+		if first.Name != "fast" || first.Index != 1 {
+			t.Errorf("first result = %q (index %d), want fast (index 1)", first.Name, first.Index)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("fast result was not streamed while the slow calculator was running")
+	}
+
+	close(release)
+	second, ok := <-stream
+	if !ok || second.Name != "slow" || second.Index != 0 {
+		t.Errorf("second result = %+v (ok=%v), want slow (index 0)", second, ok)
+	}
+	if _, ok := <-stream; ok {
+		t.Error("stream should be closed after all calculators return")
+	}
+}
+
+// TestExecuteCalculationsStream_Cancellation verifies that cancelling the
+// context closes the stream promptly once calculators observe it.
+func TestExecuteCalculationsStream_Cancellation(t *testing.T) {
+	t.Parallel()
+	blocking := func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	calculators := []fibonacci.Calculator{
+		&MockCalculator{CalculateFunc: blocking},
+		&MockCalculator{CalculateFunc: blocking},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := ExecuteCalculationsStream(ctx, calculators, 10, fibonacci.Options{}, NullProgressReporter{}, io.Discard)
+	cancel()
+
+	count := 0
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case res, ok := <-stream:
+			if !ok {
+				if count != len(calculators) {
+					t.Errorf("received %d results, want %d", count, len(calculators))
+				}
+				return
+			}
+			count++
+			if !errors.Is(res.Err, context.Canceled) {
+				t.Errorf("result error = %v, want context.Canceled", res.Err)
+			}
+		case <-timeout:
+			t.Fatal("stream was not closed after cancellation")
+		}
+	}
+}
+
+// TestExecuteCalculations_PreservesOrder verifies that the blocking API keeps
+// results in calculator order even though they complete out of order.
+func TestExecuteCalculations_PreservesOrder(t *testing.T) {
+	t.Parallel()
+	names := []string{"a", "b", "c"}
+	calculators := make([]fibonacci.Calculator, len(names))
+	for i, name := range names {
+		delay := time.Duration(len(names)-i) * 5 * time.Millisecond
+		calculators[i] = &MockCalculator{
+			NameFunc: func() string { return name },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				time.Sleep(delay)
+				return big.NewInt(1), nil
+			},
+		}
+	}
+
+	results := ExecuteCalculations(context.Background(), calculators, 10, fibonacci.Options{}, NullProgressReporter{}, io.Discard)
+	for i, res := range results {
+		if res.Name != names[i] || res.Index != i {
+			t.Errorf("results[%d] = %q (index %d), want %q (index %d)", i, res.Name, res.Index, names[i], i)
+		}
+	}
+}
+
+// TestExecuteCalculationsWithOptions_CancelOnFirstSuccess verifies that the
+// first valid result cancels the remaining calculators and that their
+// results are marked with ErrCanceledByWinner.
+func TestExecuteCalculationsWithOptions_CancelOnFirstSuccess(t *testing.T) {
+	t.Parallel()
+	calculators := []fibonacci.Calculator{
+		&MockCalculator{
+			NameFunc: func() string { return "winner" },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				return big.NewInt(55), nil
+			},
+		},
+		&MockCalculator{
+			NameFunc: func() string { return "loser" },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				<-ctx.Done()
+				return nil, ctx.Err()
+			},
+		},
+	}
+
+	done := make(chan []CalculationResult, 1)
+	go func() {
+		done <- ExecuteCalculationsWithOptions(context.Background(), calculators, 10, fibonacci.Options{},
+			ExecOptions{CancelOnFirstSuccess: true}, NullProgressReporter{}, io.Discard)
+	}()
+
+	var results []CalculationResult
+	select {
+	case results = <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("losing calculator was not cancelled")
+	}
+
+	if results[0].Err != nil || results[0].Result.Int64() != 55 {
+		t.Errorf("winner result = %v, err = %v", results[0].Result, results[0].Err)
+	}
+	if !errors.Is(results[1].Err, ErrCanceledByWinner) {
+		t.Errorf("loser error = %v, want ErrCanceledByWinner", results[1].Err)
+	}
+	if !errors.Is(results[1].Err, context.Canceled) {
+		t.Errorf("loser error = %v, want it to wrap context.Canceled", results[1].Err)
+	}
+}
+
+// TestExecuteCalculations_DefaultRunsAll verifies that without options every
+// calculator runs to completion.
+func TestExecuteCalculations_DefaultRunsAll(t *testing.T) {
+	t.Parallel()
+	calculators := []fibonacci.Calculator{
+		&MockCalculator{
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				return big.NewInt(55), nil
+			},
+		},
+		&MockCalculator{
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				time.Sleep(20 * time.Millisecond)
+				if err := ctx.Err(); err != nil {
+					return nil, err
+				}
+				return big.NewInt(55), nil
+			},
+		},
+	}
+
+	results := ExecuteCalculations(context.Background(), calculators, 10, fibonacci.Options{}, NullProgressReporter{}, io.Discard)
+	for i, res := range results {
+		if res.Err != nil {
+			t.Errorf("results[%d].Err = %v, want nil", i, res.Err)
+		}
+	}
+}
+
+// TestExecuteCalculationsWithHooks verifies that start and finish hooks are
+// invoked once per calculator. The hooks mutate plain maps without locking,
+// so the race detector also checks that invocations are serialized.
+func TestExecuteCalculationsWithHooks(t *testing.T) {
+	t.Parallel()
+	names := []string{"a", "b", "c"}
+	calculators := make([]fibonacci.Calculator, len(names))
+	for i, name := range names {
+		calculators[i] = &MockCalculator{
+			NameFunc: func() string { return name },
+			CalculateFunc: func(ctx context.Context, reporter progress.ProgressCallback, index int, n uint64, opts fibonacci.Options) (*big.Int, error) {
+				if name == "c" {
+					return nil, errors.New("boom")
+				}
+				return big.NewInt(55), nil
+			},
+		}
+	}
+
+	starts := make(map[string]int)
+	finishes := make(map[string]int)
+	failures := 0
+	hooks := ExecHooks{
+		OnStart: func(name string) { starts[name]++ },
+		OnFinish: func(name string, d time.Duration, err error) {
+			finishes[name]++
+			if err != nil {
+				failures++
+			}
+		},
+	}
+
+	ExecuteCalculationsWithHooks(context.Background(), calculators, 10, fibonacci.Options{}, hooks, NullProgressReporter{}, io.Discard)
+
+	for _, name := range names {
+		if starts[name] != 1 || finishes[name] != 1 {
+			t.Errorf("%s: OnStart called %d times, OnFinish %d times, want 1 and 1", name, starts[name], finishes[name])
+		}
+	}
+	if failures != 1 {
+		t.Errorf("OnFinish reported %d errors, want 1", failures)
+	}
+}
+
+// TestFindBestResult tests the FindBestResult helper function.
+func TestFindBestResult(t *testing.T) {
+	t.Parallel()
+
+	t.Run("All errors returns nil", func(t *testing.T) {
+		t.Parallel()
+		results := []CalculationResult{
+			{Name: "a", Err: errors.New("error a")},
+			{Name: "b", Err: errors.New("error b")},
+		}
+		best := FindBestResult(results)
+		if best != nil {
+			t.Error("Expected nil for all-error results")
+		}
+	})
+
+	t.Run("Selects fastest successful result", func(t *testing.T) {
+		t.Parallel()
+		results := []CalculationResult{
+			{Name: "slow", Result: big.NewInt(55), Duration: 100 * time.Millisecond},
+			{Name: "fast", Result: big.NewInt(55), Duration: 10 * time.Millisecond},
+			{Name: "err", Err: errors.New("failed")},
+		}
+		best := FindBestResult(results)
+		if best == nil {
+			t.Fatal("Expected non-nil result")
+		}
+		if best.Name != "fast" {
+			t.Errorf("Expected fastest result 'fast', got '%s'", best.Name)
+		}
+	})
+
+	t.Run("Empty results returns nil", func(t *testing.T) {
+		t.Parallel()
+		best := FindBestResult(nil)
+		if best != nil {
+			t.Error("Expected nil for nil results")
+		}
+	})
+}
@@ -28,6 +28,17 @@ func NewProgressAggregator(numCalculators int) *ProgressAggregator {
 	}
 }
 
+// SetWeights weights each calculator's progress by its expected share of the
+// total time (see ProgressWeights), so the average advances at a steadier
+// pace when fast and slow algorithms are compared. Invalid weights are
+// ignored (see format.ProgressState.SetWeights).
+//
+// Parameters:
+//   - weights: The relative cost of each calculator, or nil for equal weights.
+func (a *ProgressAggregator) SetWeights(weights []float64) {
+	a.state.SetWeights(weights)
+}
+
 // AggregatedProgress holds the result of processing a single progress update.
 type AggregatedProgress struct {
 	// CalculatorIndex is the index of the calculator that sent the update.
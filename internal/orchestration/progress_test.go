@@ -1,6 +1,7 @@
 package orchestration
 
 import (
+	"math"
 	"testing"
 
 	"github.com/agbru/fibcalc/internal/progress"
@@ -90,6 +91,32 @@ func TestProgressAggregator_GetETA(t *testing.T) {
 	}
 }
 
+// TestProgressAggregator_WeightsSmoothCurve simulates a comparison where one
+// calculator takes four times longer than the other, both progressing
+// linearly, and checks that weighting the slow one by its cost keeps the
+// combined progress closer to the elapsed share of the total time.
+func TestProgressAggregator_WeightsSmoothCurve(t *testing.T) {
+	const steps = 100
+	maxDeviation := func(weights []float64) float64 {
+		agg := NewProgressAggregator(2)
+		agg.SetWeights(weights)
+		worst := 0.0
+		for step := 1; step <= steps; step++ {
+			elapsed := float64(step) / steps // fraction of the slow run
+			agg.Update(progress.ProgressUpdate{CalculatorIndex: 0, Value: min(1, 4*elapsed)})
+			ap := agg.Update(progress.ProgressUpdate{CalculatorIndex: 1, Value: elapsed})
+			worst = max(worst, math.Abs(ap.AverageProgress-elapsed))
+		}
+		return worst
+	}
+
+	equal := maxDeviation(nil)
+	weighted := maxDeviation([]float64{1, 4})
+	if weighted >= equal {
+		t.Errorf("weighted deviation %.3f should be below the equal-weight deviation %.3f", weighted, equal)
+	}
+}
+
 func TestDrainChannel(t *testing.T) {
 	ch := make(chan progress.ProgressUpdate, 5)
 	ch <- progress.ProgressUpdate{CalculatorIndex: 0, Value: 0.1}
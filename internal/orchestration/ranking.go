@@ -0,0 +1,148 @@
+package orchestration
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DurationStats summarizes the durations of repeated runs of one algorithm.
+type DurationStats struct {
+	// Median is the median duration.
+	Median time.Duration
+	// Q1 and Q3 are the first and third quartiles; [Q1, Q3] is the
+	// interquartile range used as the error bar of the median.
+	Q1 time.Duration
+	Q3 time.Duration
+	// Min is the shortest duration.
+	Min time.Duration
+	// Mean is the arithmetic mean of the durations.
+	Mean time.Duration
+	// StdDev is the sample standard deviation of the durations, 0 for a
+	// single run.
+	StdDev time.Duration
+	// Runs is the number of successful runs summarized.
+	Runs int
+}
+
+// RankedAlgorithm is one entry of a Ranking.
+type RankedAlgorithm struct {
+	Name  string
+	Stats DurationStats
+}
+
+// Ranking orders algorithms by median duration over repeated runs.
+type Ranking struct {
+	// Entries lists the algorithms, fastest median first. Algorithms without
+	// a successful run are left out.
+	Entries []RankedAlgorithm
+	// Winner is the name of the fastest algorithm if its lead is significant,
+	// or "" for a tie within noise.
+	Winner string
+}
+
+// ComputeDurationStats returns the median and quartiles of durations, using
+// linear interpolation between the closest ranks, along with their minimum,
+// mean and standard deviation.
+//
+// Parameters:
+//   - durations: The measured durations, in any order.
+//
+// Returns:
+//   - DurationStats: The summary, zero if durations is empty.
+func ComputeDurationStats(durations []time.Duration) DurationStats {
+	if len(durations) == 0 {
+		return DurationStats{}
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum float64
+	for _, d := range sorted {
+		sum += float64(d)
+	}
+	mean := sum / float64(len(sorted))
+	var squares float64
+	for _, d := range sorted {
+		squares += (float64(d) - mean) * (float64(d) - mean)
+	}
+	var stdDev float64
+	if len(sorted) > 1 {
+		stdDev = math.Sqrt(squares / float64(len(sorted)-1))
+	}
+
+	return DurationStats{
+		Median: quantile(sorted, 0.5),
+		Q1:     quantile(sorted, 0.25),
+		Q3:     quantile(sorted, 0.75),
+		Min:    sorted[0],
+		Mean:   time.Duration(math.Round(mean)),
+		StdDev: time.Duration(math.Round(stdDev)),
+		Runs:   len(sorted),
+	}
+}
+
+// quantile returns the p-quantile of sorted, which must not be empty.
+func quantile(sorted []time.Duration, p float64) time.Duration {
+	pos := p * float64(len(sorted)-1)
+	lo := int(pos)
+	if lo+1 >= len(sorted) {
+		return sorted[lo]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo] + time.Duration(frac*float64(sorted[lo+1]-sorted[lo]))
+}
+
+// RankDurations ranks algorithms by their median duration over repeated
+// runs. The fastest algorithm is declared the winner only if its
+// interquartile range lies entirely below the runner-up's, i.e. its third
+// quartile is below the runner-up's first quartile; otherwise the ranking is
+// a tie within noise. A single ranked algorithm wins by default.
+//
+// Parameters:
+//   - samples: The successful durations of each algorithm, by name.
+//
+// Returns:
+//   - Ranking: The algorithms by median duration, and the winner if any.
+func RankDurations(samples map[string][]time.Duration) Ranking {
+	var ranking Ranking
+	for name, durations := range samples {
+		if len(durations) == 0 {
+			continue
+		}
+		ranking.Entries = append(ranking.Entries, RankedAlgorithm{Name: name, Stats: ComputeDurationStats(durations)})
+	}
+	sort.Slice(ranking.Entries, func(i, j int) bool {
+		a, b := ranking.Entries[i], ranking.Entries[j]
+		if a.Stats.Median != b.Stats.Median {
+			return a.Stats.Median < b.Stats.Median
+		}
+		return a.Name < b.Name
+	})
+
+	switch len(ranking.Entries) {
+	case 0:
+	case 1:
+		ranking.Winner = ranking.Entries[0].Name
+	default:
+		leader, runnerUp := ranking.Entries[0], ranking.Entries[1]
+		if leader.Stats.Q3 < runnerUp.Stats.Q1 {
+			ranking.Winner = leader.Name
+		}
+	}
+	return ranking
+}
+
+// CollectDurations appends the duration of every successful result to
+// samples, keyed by algorithm name.
+//
+// Parameters:
+//   - samples: The durations collected so far; must not be nil.
+//   - results: The results of one run.
+func CollectDurations(samples map[string][]time.Duration, results []CalculationResult) {
+	for _, res := range results {
+		if res.Err == nil {
+			samples[res.Name] = append(samples[res.Name], res.Duration)
+		}
+	}
+}
@@ -0,0 +1,156 @@
+package orchestration
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// ms builds a distribution of durations in milliseconds.
+func ms(values ...int) []time.Duration {
+	durations := make([]time.Duration, len(values))
+	for i, v := range values {
+		durations[i] = time.Duration(v) * time.Millisecond
+	}
+	return durations
+}
+
+func TestComputeDurationStats(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		median    time.Duration
+		q1, q3    time.Duration
+		min, mean time.Duration
+		stdDev    time.Duration
+		wantRuns  int
+	}{
+		{"empty", nil, 0, 0, 0, 0, 0, 0, 0},
+		{"single", ms(7), 7 * time.Millisecond, 7 * time.Millisecond, 7 * time.Millisecond, 7 * time.Millisecond, 7 * time.Millisecond, 0, 1},
+		{"odd", ms(5, 1, 3, 2, 4), 3 * time.Millisecond, 2 * time.Millisecond, 4 * time.Millisecond, time.Millisecond, 3 * time.Millisecond, 1581139, 5},
+		{"even", ms(10, 40, 20, 30), 25 * time.Millisecond, 17500 * time.Microsecond, 32500 * time.Microsecond, 10 * time.Millisecond, 25 * time.Millisecond, 12909944, 4},
+	}
+	for _, tt := range tests {
+		got := ComputeDurationStats(tt.durations)
+		want := DurationStats{Median: tt.median, Q1: tt.q1, Q3: tt.q3, Min: tt.min, Mean: tt.mean, StdDev: tt.stdDev, Runs: tt.wantRuns}
+		if got != want {
+			t.Errorf("%s: got %+v, want %+v", tt.name, got, want)
+		}
+	}
+}
+
+func TestComputeDurationStatsKeepsInput(t *testing.T) {
+	t.Parallel()
+	durations := ms(3, 1, 2)
+	ComputeDurationStats(durations)
+	if durations[0] != 3*time.Millisecond {
+		t.Error("ComputeDurationStats must not reorder its input")
+	}
+}
+
+func TestRankDurations(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		samples    map[string][]time.Duration
+		wantOrder  []string
+		wantWinner string
+	}{
+		{
+			name: "clear winner",
+			samples: map[string][]time.Duration{
+				"slow": ms(30, 31, 29, 32, 30),
+				"fast": ms(10, 11, 9, 12, 10),
+			},
+			wantOrder:  []string{"fast", "slow"},
+			wantWinner: "fast",
+		},
+		{
+			// The medians differ but one noisy run makes the ranges overlap.
+			name: "overlapping ranges tie",
+			samples: map[string][]time.Duration{
+				"a": ms(10, 11, 12, 20, 21),
+				"b": ms(13, 14, 15, 16, 17),
+			},
+			wantOrder:  []string{"a", "b"},
+			wantWinner: "",
+		},
+		{
+			// Only the runner-up matters: a distant third does not help.
+			name: "tie with the runner-up only",
+			samples: map[string][]time.Duration{
+				"a": ms(10, 10, 11, 12, 12),
+				"b": ms(11, 11, 12, 13, 13),
+				"c": ms(50, 50, 51, 52, 52),
+			},
+			wantOrder:  []string{"a", "b", "c"},
+			wantWinner: "",
+		},
+		{
+			name: "touching ranges tie",
+			samples: map[string][]time.Duration{
+				"a": ms(1, 2, 3, 4, 5), // Q3 = 4ms
+				"b": ms(3, 4, 5, 6, 7), // Q1 = 4ms
+			},
+			wantOrder:  []string{"a", "b"},
+			wantWinner: "",
+		},
+		{
+			name: "single algorithm wins by default",
+			samples: map[string][]time.Duration{
+				"only":   ms(5, 6),
+				"failed": nil,
+			},
+			wantOrder:  []string{"only"},
+			wantWinner: "only",
+		},
+		{
+			name: "equal medians ordered by name",
+			samples: map[string][]time.Duration{
+				"b": ms(10),
+				"a": ms(10),
+			},
+			wantOrder:  []string{"a", "b"},
+			wantWinner: "",
+		},
+		{
+			name:       "no samples",
+			samples:    map[string][]time.Duration{},
+			wantWinner: "",
+		},
+	}
+	for _, tt := range tests {
+		ranking := RankDurations(tt.samples)
+		var order []string
+		for _, entry := range ranking.Entries {
+			order = append(order, entry.Name)
+		}
+		if len(order) != len(tt.wantOrder) {
+			t.Errorf("%s: order %v, want %v", tt.name, order, tt.wantOrder)
+			continue
+		}
+		for i := range order {
+			if order[i] != tt.wantOrder[i] {
+				t.Errorf("%s: order %v, want %v", tt.name, order, tt.wantOrder)
+				break
+			}
+		}
+		if ranking.Winner != tt.wantWinner {
+			t.Errorf("%s: winner %q, want %q", tt.name, ranking.Winner, tt.wantWinner)
+		}
+	}
+}
+
+func TestCollectDurationsSkipsFailures(t *testing.T) {
+	t.Parallel()
+	samples := make(map[string][]time.Duration)
+	CollectDurations(samples, []CalculationResult{
+		{Name: "a", Duration: time.Millisecond},
+		{Name: "b", Duration: 2 * time.Millisecond, Err: errors.New("boom")},
+	})
+	CollectDurations(samples, []CalculationResult{{Name: "a", Duration: 3 * time.Millisecond}})
+	if len(samples["a"]) != 2 || len(samples["b"]) != 0 {
+		t.Errorf("got %v, want two durations for a and none for b", samples)
+	}
+}
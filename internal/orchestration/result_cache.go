@@ -0,0 +1,170 @@
+package orchestration
+
+import (
+	"container/list"
+	"context"
+	"io"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+// DefaultResultCacheEntries is the entry cap used by NewResultCache when a
+// non-positive capacity is given. Results for large n can weigh hundreds of
+// megabytes, so the default is deliberately small.
+const DefaultResultCacheEntries = 16
+
+// resultCacheKey identifies a cached result. Only the options that change the
+// value are part of the key (see fibonacci.ValueOptions); thresholds change
+// how fast F(n) is computed, never its value.
+type resultCacheKey struct {
+	algo   string
+	n      uint64
+	values fibonacci.ValueOptions
+}
+
+// resultCacheEntry is the value stored in the LRU list.
+type resultCacheEntry struct {
+	key      resultCacheKey
+	value    *big.Int
+	duration time.Duration
+}
+
+// ResultCache is an in-memory LRU cache of calculation results keyed by
+// (algorithm, n, value options). It lets interactive sessions re-display an F(n) without
+// recomputing it. The duration of the original calculation is stored
+// alongside the value. ResultCache is safe for concurrent use.
+type ResultCache struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // front = most recently used
+	entries  map[resultCacheKey]*list.Element
+}
+
+// NewResultCache creates an LRU result cache holding at most capacity entries.
+//
+// Parameters:
+//   - capacity: The maximum number of cached results. Values <= 0 select
+//     DefaultResultCacheEntries.
+//
+// Returns:
+//   - *ResultCache: An empty cache.
+func NewResultCache(capacity int) *ResultCache {
+	if capacity <= 0 {
+		capacity = DefaultResultCacheEntries
+	}
+	return &ResultCache{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[resultCacheKey]*list.Element),
+	}
+}
+
+// Get returns a copy of the cached F(n) computed by algo.
+//
+// Parameters:
+//   - algo: The calculator name.
+//   - n: The Fibonacci index.
+//   - opts: The calculation options. Only those that affect the value are
+//     used for the lookup (see fibonacci.ValueOptions).
+//
+// Returns:
+//   - *big.Int: A copy of the cached value owned by the caller.
+//   - bool: true if the result was cached.
+func (c *ResultCache) Get(algo string, n uint64, opts fibonacci.Options) (*big.Int, bool) {
+	value, _, ok := c.Lookup(algo, n, opts)
+	return value, ok
+}
+
+// Lookup is like Get but also returns the duration of the calculation that
+// produced the cached value.
+func (c *ResultCache) Lookup(algo string, n uint64, opts fibonacci.Options) (*big.Int, time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.entries[resultCacheKey{algo: algo, n: n, values: opts.ValueOptions()}]
+	if !ok {
+		return nil, 0, false
+	}
+	c.order.MoveToFront(elem)
+	entry := elem.Value.(*resultCacheEntry)
+	return new(big.Int).Set(entry.value), entry.duration, true
+}
+
+// Put stores a copy of F(n) computed by algo, evicting the least recently
+// used entry when the cache is full. A nil value is ignored.
+//
+// Parameters:
+//   - algo: The calculator name.
+//   - n: The Fibonacci index.
+//   - opts: The calculation options (see Get for the part used as key).
+//   - value: The calculated Fibonacci number.
+//   - duration: The time it took to calculate value.
+func (c *ResultCache) Put(algo string, n uint64, opts fibonacci.Options, value *big.Int, duration time.Duration) {
+	if value == nil {
+		return
+	}
+	key := resultCacheKey{algo: algo, n: n, values: opts.ValueOptions()}
+	entry := &resultCacheEntry{key: key, value: new(big.Int).Set(value), duration: duration}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if elem, ok := c.entries[key]; ok {
+		elem.Value = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+	c.entries[key] = c.order.PushFront(entry)
+	for c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*resultCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached results.
+func (c *ResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// ExecuteCalculationsCached is like ExecuteCalculations but consults cache
+// before dispatching work. Calculators with a cached result for n are not
+// run; their result carries the cached value and the duration of the original
+// calculation. The remaining calculators are executed concurrently and their
+// successful results are added to the cache. A nil cache disables caching.
+//
+// Returns:
+//   - []CalculationResult: A slice containing the results of each calculation,
+//     in the same order as calculators.
+func ExecuteCalculationsCached(ctx context.Context, cache *ResultCache, calculators []fibonacci.Calculator, n uint64, opts fibonacci.Options, progressReporter ProgressReporter, out io.Writer) []CalculationResult {
+	if cache == nil {
+		return ExecuteCalculations(ctx, calculators, n, opts, progressReporter, out)
+	}
+
+	results := make([]CalculationResult, len(calculators))
+	var pending []fibonacci.Calculator
+	var pendingIndex []int
+	for i, calc := range calculators {
+		if value, duration, ok := cache.Lookup(calc.Name(), n, opts); ok {
+			results[i] = CalculationResult{Name: calc.Name(), Result: value, Duration: duration, Index: i}
+			continue
+		}
+		pending = append(pending, calc)
+		pendingIndex = append(pendingIndex, i)
+	}
+	if len(pending) == 0 {
+		return results
+	}
+
+	for _, res := range ExecuteCalculations(ctx, pending, n, opts, progressReporter, out) {
+		if res.Err == nil {
+			cache.Put(res.Name, n, opts, res.Result, res.Duration)
+		}
+		res.Index = pendingIndex[res.Index]
+		results[res.Index] = res
+	}
+	return results
+}
@@ -0,0 +1,119 @@
+package orchestration
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+func TestResultCacheGetPut(t *testing.T) {
+	t.Parallel()
+	cache := NewResultCache(4)
+
+	if _, ok := cache.Get("fast", 10, fibonacci.Options{}); ok {
+		t.Fatal("expected miss on empty cache")
+	}
+
+	value := big.NewInt(55)
+	cache.Put("fast", 10, fibonacci.Options{}, value, time.Millisecond)
+	value.SetInt64(0) // the cache must hold its own copy
+
+	// Thresholds do not change the value, so a different Options still hits.
+	got, ok := cache.Get("fast", 10, fibonacci.Options{FFTThreshold: 1234})
+	if !ok || got.Int64() != 55 {
+		t.Fatalf("Get() = %v, %v; want 55, true", got, ok)
+	}
+	got.SetInt64(0) // callers receive copies
+	if _, duration, _ := cache.Lookup("fast", 10, fibonacci.Options{}); duration != time.Millisecond {
+		t.Errorf("Lookup() duration = %v, want %v", duration, time.Millisecond)
+	}
+	if again, _ := cache.Get("fast", 10, fibonacci.Options{}); again.Int64() != 55 {
+		t.Errorf("cached value was mutated through a returned copy: %v", again)
+	}
+
+	if _, ok := cache.Get("matrix", 10, fibonacci.Options{}); ok {
+		t.Error("expected miss for a different algorithm")
+	}
+}
+
+// TestResultCacheValueOptions verifies that options changing the value, the
+// k-bonacci order and the Binet precision, are part of the key.
+func TestResultCacheValueOptions(t *testing.T) {
+	t.Parallel()
+	cache := NewResultCache(4)
+	cache.Put("kbonacci", 200, fibonacci.Options{KBonacciOrder: 3}, big.NewInt(3), time.Millisecond)
+
+	if got, ok := cache.Get("kbonacci", 200, fibonacci.Options{KBonacciOrder: 4}); ok {
+		t.Errorf("Get(K=4) = %v, want a miss: the cached value is for K=3", got)
+	}
+	if got, ok := cache.Get("kbonacci", 200, fibonacci.Options{}); !ok || got.Int64() != 3 {
+		t.Errorf("Get(default K) = %v, %v; want the K=3 value, as 3 is the default order", got, ok)
+	}
+
+	cache.Put("binet", 300, fibonacci.Options{BinetPrecision: 32}, big.NewInt(32), time.Millisecond)
+	if got, ok := cache.Get("binet", 300, fibonacci.Options{BinetPrecision: 1024}); ok {
+		t.Errorf("Get(precision 1024) = %v, want a miss: the cached value is at precision 32", got)
+	}
+}
+
+func TestResultCacheEviction(t *testing.T) {
+	t.Parallel()
+	cache := NewResultCache(2)
+	cache.Put("fast", 1, fibonacci.Options{}, big.NewInt(1), 0)
+	cache.Put("fast", 2, fibonacci.Options{}, big.NewInt(1), 0)
+	cache.Get("fast", 1, fibonacci.Options{}) // n=2 becomes least recently used
+	cache.Put("fast", 3, fibonacci.Options{}, big.NewInt(2), 0)
+
+	if cache.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", cache.Len())
+	}
+	if _, ok := cache.Get("fast", 2, fibonacci.Options{}); ok {
+		t.Error("least recently used entry was not evicted")
+	}
+	for _, n := range []uint64{1, 3} {
+		if _, ok := cache.Get("fast", n, fibonacci.Options{}); !ok {
+			t.Errorf("entry n=%d unexpectedly evicted", n)
+		}
+	}
+
+	if NewResultCache(0).capacity != DefaultResultCacheEntries {
+		t.Error("non-positive capacity should select DefaultResultCacheEntries")
+	}
+}
+
+func TestExecuteCalculationsCached(t *testing.T) {
+	t.Parallel()
+	var calls atomic.Int32
+	calc := &fibonacci.MockCalculator{Fn: func(ctx context.Context, n uint64) (*big.Int, error) {
+		calls.Add(1)
+		return big.NewInt(55), nil
+	}}
+	failing := &fibonacci.MockCalculator{Err: errors.New("boom")}
+	cache := NewResultCache(0)
+
+	for i := 0; i < 3; i++ {
+		results := ExecuteCalculationsCached(context.Background(), cache, []fibonacci.Calculator{calc}, 10, fibonacci.Options{}, NullProgressReporter{}, &DiscardWriter{})
+		if len(results) != 1 || results[0].Err != nil || results[0].Result.Int64() != 55 {
+			t.Fatalf("run %d: unexpected results %+v", i, results)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("calculator invoked %d times, want 1", got)
+	}
+
+	// Failed results are not cached. The failing mock shares the "mock"
+	// name, so use a fresh cache and n to keep it independent.
+	failCache := NewResultCache(0)
+	results := ExecuteCalculationsCached(context.Background(), failCache, []fibonacci.Calculator{failing}, 11, fibonacci.Options{}, NullProgressReporter{}, &DiscardWriter{})
+	if results[0].Err == nil {
+		t.Fatal("expected an error from the failing calculator")
+	}
+	if failCache.Len() != 0 {
+		t.Errorf("failed result was cached (Len() = %d)", failCache.Len())
+	}
+}
@@ -154,7 +154,7 @@ func (c ChartModel) renderBrailleSection() string {
 	cpuLabel := fmt.Sprintf("  %s %s [%s]",
 		metricLabelStyle.Render("CPU:"),
 		metricValueStyle.Render(fmt.Sprintf("%5.1f%%", cpuPct)),
-		cpuSparklineStyle.Render(RenderSparkline(c.cpuHistory.Slice())))
+		cpuSparklineStyle.Render(RenderSparklineWidth(c.cpuHistory.Slice(), c.sparklineWidth())))
 	b.WriteString(cpuLabel)
 
 	// MEM label: percentage after colon, then sparkline
@@ -162,7 +162,7 @@ func (c ChartModel) renderBrailleSection() string {
 	memLabel := fmt.Sprintf("\n  %s %s [%s]",
 		metricLabelStyle.Render("MEM:"),
 		metricValueStyle.Render(fmt.Sprintf("%5.1f%%", memPct)),
-		memSparklineStyle.Render(RenderSparkline(c.memHistory.Slice())))
+		memSparklineStyle.Render(RenderSparklineWidth(c.memHistory.Slice(), c.sparklineWidth())))
 	b.WriteString(memLabel)
 
 	return b.String()
@@ -4,6 +4,8 @@ import (
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/charmbracelet/lipgloss"
 )
 
 func TestChartModel_AddDataPoint(t *testing.T) {
@@ -184,3 +186,29 @@ func TestChartModel_SetSize_ResizesBuffers(t *testing.T) {
 		t.Errorf("expected mem buffer cap %d, got %d", expectedWidth, chart.memHistory.Cap())
 	}
 }
+
+func TestChartModel_View_SparklineWidth(t *testing.T) {
+	chart := NewChartModel()
+	chart.SetSize(50, 15)
+	want := 50 - 18 // sparklineWidth
+
+	sparkline := func() string {
+		section := chart.renderBrailleSection()
+		line := strings.Split(section, "\n")[0]
+		return line[strings.Index(line, "[")+1 : strings.LastIndex(line, "]")]
+	}
+	if got := lipgloss.Width(sparkline()); got != want {
+		t.Errorf("empty history: sparkline width %d, want %d", got, want)
+	}
+	for i := range 100 {
+		chart.UpdateSysStats(float64(i), 50)
+		if got := lipgloss.Width(sparkline()); got != want {
+			t.Fatalf("after %d samples: sparkline width %d, want %d", i+1, got, want)
+		}
+	}
+
+	chart.SetSize(30, 15)
+	if got := lipgloss.Width(sparkline()); got != 30-18 {
+		t.Errorf("after resize: sparkline width %d, want %d", got, 30-18)
+	}
+}
@@ -142,7 +142,7 @@ func TestNewModel_ConfigPropagation(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			m := NewModel(context.Background(), nil, tt.cfg, "v1.0.0")
+			m := NewModel(context.Background(), nil, tt.cfg, "v1.0.0", Preferences{})
 			t.Cleanup(m.cancel)
 
 			if m.config.N != tt.cfg.N {
@@ -193,7 +193,7 @@ func TestNewModel_ConfigPreservedAfterWindowResize(t *testing.T) {
 		ShowValue:         true,
 		TUI:               true,
 	}
-	m := NewModel(context.Background(), nil, cfg, "v1.0.0")
+	m := NewModel(context.Background(), nil, cfg, "v1.0.0", Preferences{})
 	t.Cleanup(m.cancel)
 
 	updated, _ := m.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
@@ -228,7 +228,7 @@ func TestNewModel_ConfigPreservedAfterRestart(t *testing.T) {
 		TUI:               true,
 	}
 	calcs := []fibonacci.Calculator{mockCalculator{name: "Fast"}}
-	m := NewModel(context.Background(), calcs, cfg, "v1.0.0")
+	m := NewModel(context.Background(), calcs, cfg, "v1.0.0", Preferences{})
 	t.Cleanup(m.cancel)
 
 	// Set size so restart works properly
@@ -312,7 +312,7 @@ func TestModel_CalculatorSelection(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			m := NewModel(context.Background(), tt.calcs, cfg, "v1.0.0")
+			m := NewModel(context.Background(), tt.calcs, cfg, "v1.0.0", Preferences{})
 			t.Cleanup(m.cancel)
 
 			if len(m.calculators) != tt.wantCount {
@@ -334,7 +334,7 @@ func TestModel_CalculatorsPreservedAfterRestart(t *testing.T) {
 		mockCalculator{name: "Matrix"},
 	}
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	m := NewModel(context.Background(), calcs, cfg, "v1.0.0")
+	m := NewModel(context.Background(), calcs, cfg, "v1.0.0", Preferences{})
 	t.Cleanup(m.cancel)
 
 	// Set size and mark done
@@ -588,7 +588,7 @@ func TestFinalResultMsg_DisplayFlags(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			cfg := config.AppConfig{N: 10, Timeout: time.Minute}
-			m := NewModel(context.Background(), nil, cfg, "v1.0.0")
+			m := NewModel(context.Background(), nil, cfg, "v1.0.0", Preferences{})
 			t.Cleanup(m.cancel)
 
 			// Set size first
@@ -776,7 +776,7 @@ func TestNewModel_AlgoConfigStored(t *testing.T) {
 				Algo:    tt.algo,
 				TUI:     true,
 			}
-			m := NewModel(context.Background(), nil, cfg, "v1.0.0")
+			m := NewModel(context.Background(), nil, cfg, "v1.0.0", Preferences{})
 			t.Cleanup(m.cancel)
 
 			if m.config.Algo != tt.algo {
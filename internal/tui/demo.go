@@ -0,0 +1,118 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/config"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// Demo timing: demoSteps progress frames, demoStepInterval apart (about three
+// seconds in total). The slowest calculator finishes on the last frame.
+const (
+	demoSteps        = 30
+	demoStepInterval = 100 * time.Millisecond
+)
+
+// demoFinishStep returns the frame on which calculator i of count finishes.
+// Later calculators are slower, so a comparison has distinct timings.
+func demoFinishStep(i, count int) int {
+	return demoSteps * (i + 2) / (count + 1)
+}
+
+// demoFrames builds the synthetic message sequence fed to the dashboard by
+// --tui-demo. Each frame is sent at once, demoStepInterval after the previous
+// one. The sequence depends only on its arguments, so demo runs are
+// reproducible for screenshots and snapshot tests.
+//
+// Parameters:
+//   - names: The calculator names, in display order.
+//   - cfg: The configuration providing N and the presentation flags.
+//
+// Returns:
+//   - [][]tea.Msg: The progress frames followed by one frame with the
+//     ProgressDoneMsg, the comparison (for several calculators) and the
+//     FinalResultMsg.
+func demoFrames(names []string, cfg config.AppConfig) [][]tea.Msg {
+	count := len(names)
+	if count == 0 {
+		return nil
+	}
+
+	frames := make([][]tea.Msg, 0, demoSteps+1)
+	for step := 1; step <= demoSteps; step++ {
+		values := make([]float64, count)
+		sum := 0.0
+		for i := range names {
+			values[i] = min(1, float64(step)/float64(demoFinishStep(i, count)))
+			sum += values[i]
+		}
+		eta := time.Duration(demoSteps-step) * demoStepInterval
+
+		frame := make([]tea.Msg, 0, count)
+		for i := range names {
+			// Finished calculators stop reporting, as real ones do.
+			if prev := float64(step-1) / float64(demoFinishStep(i, count)); prev >= 1 {
+				continue
+			}
+			frame = append(frame, ProgressMsg{
+				CalculatorIndex: i,
+				Value:           values[i],
+				AverageProgress: sum / float64(count),
+				ETA:             eta,
+			})
+		}
+		frames = append(frames, frame)
+	}
+
+	// Fabricated timings scale the frame count up to a plausible heavy run.
+	results := make([]orchestration.CalculationResult, count)
+	for i, name := range names {
+		results[i] = orchestration.CalculationResult{
+			Name:     name,
+			Duration: time.Duration(demoFinishStep(i, count)) * 40 * demoStepInterval,
+		}
+	}
+
+	final := []tea.Msg{ProgressDoneMsg{}}
+	if count > 1 {
+		final = append(final, ComparisonResultsMsg{Results: results})
+	}
+	final = append(final, FinalResultMsg{
+		Result:    results[0],
+		N:         cfg.N,
+		Verbose:   cfg.Verbose,
+		Details:   cfg.Details,
+		ShowValue: cfg.ShowValue,
+	})
+	return append(frames, final)
+}
+
+// demoCalculationCmd returns a tea.Cmd that replays demoFrames in place of
+// the real orchestration (see startCalculationCmd).
+func demoCalculationCmd(ref *programRef, ctx context.Context, calculators []fibonacci.Calculator, cfg config.AppConfig, gen uint64) tea.Cmd {
+	names := make([]string, len(calculators))
+	for i, c := range calculators {
+		names[i] = c.Name()
+	}
+	return func() tea.Msg {
+		ticker := time.NewTicker(demoStepInterval)
+		defer ticker.Stop()
+		for _, frame := range demoFrames(names, cfg) {
+			select {
+			case <-ctx.Done():
+				return CalculationCompleteMsg{ExitCode: apperrors.ExitErrorCanceled, Generation: gen}
+			case <-ticker.C:
+			}
+			for _, msg := range frame {
+				ref.Send(msg)
+			}
+		}
+		return CalculationCompleteMsg{ExitCode: apperrors.ExitSuccess, Generation: gen}
+	}
+}
@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/config"
+	apperrors "github.com/agbru/fibcalc/internal/errors"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+)
+
+func TestDemoFrames_DeterministicAndBounded(t *testing.T) {
+	names := []string{"Fast", "Matrix", "FFT"}
+	cfg := config.AppConfig{N: 1_000_000}
+
+	frames := demoFrames(names, cfg)
+	if !reflect.DeepEqual(frames, demoFrames(names, cfg)) {
+		t.Fatal("demoFrames is not deterministic")
+	}
+	if len(frames) != demoSteps+1 {
+		t.Fatalf("expected %d frames, got %d", demoSteps+1, len(frames))
+	}
+
+	total := 0
+	last := make([]float64, len(names))
+	for _, frame := range frames[:demoSteps] {
+		for _, msg := range frame {
+			p, ok := msg.(ProgressMsg)
+			if !ok {
+				t.Fatalf("unexpected message %T in a progress frame", msg)
+			}
+			if p.Value < last[p.CalculatorIndex] || p.Value > 1 {
+				t.Errorf("calculator %d progress went from %f to %f", p.CalculatorIndex, last[p.CalculatorIndex], p.Value)
+			}
+			last[p.CalculatorIndex] = p.Value
+			total++
+		}
+	}
+	if total > demoSteps*len(names) {
+		t.Errorf("expected at most %d progress messages, got %d", demoSteps*len(names), total)
+	}
+	for i, v := range last {
+		if v != 1 {
+			t.Errorf("calculator %d ended at %f, want 1", i, v)
+		}
+	}
+
+	final := frames[demoSteps]
+	if len(final) != 3 {
+		t.Fatalf("expected done, comparison and final messages, got %d", len(final))
+	}
+	if _, ok := final[0].(ProgressDoneMsg); !ok {
+		t.Errorf("expected ProgressDoneMsg, got %T", final[0])
+	}
+	cmp, ok := final[1].(ComparisonResultsMsg)
+	if !ok || len(cmp.Results) != len(names) {
+		t.Fatalf("expected a ComparisonResultsMsg with %d results, got %#v", len(names), final[1])
+	}
+	for i := 1; i < len(cmp.Results); i++ {
+		if cmp.Results[i].Duration <= cmp.Results[i-1].Duration {
+			t.Errorf("expected distinct increasing timings, got %v", cmp.Results)
+		}
+	}
+	if res, ok := final[2].(FinalResultMsg); !ok || res.N != cfg.N || res.Result.Name != "Fast" {
+		t.Errorf("unexpected final message %#v", final[2])
+	}
+
+	single := demoFrames([]string{"Fast"}, cfg)
+	if got := len(single[len(single)-1]); got != 2 {
+		t.Errorf("a single calculator should not get a comparison, got %d final messages", got)
+	}
+	if demoFrames(nil, cfg) != nil {
+		t.Error("expected no frames without calculators")
+	}
+}
+
+func TestDemoFrames_DriveModel(t *testing.T) {
+	calcs := []fibonacci.Calculator{mockCalculator{name: "Fast"}, mockCalculator{name: "Matrix"}}
+	cfg := config.AppConfig{N: 1000, TUIDemo: true}
+	m := NewModel(context.Background(), calcs, cfg, "v0.1.0", Preferences{})
+	t.Cleanup(m.cancel)
+
+	var model tea.Model = m
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	for _, frame := range demoFrames([]string{"Fast", "Matrix"}, cfg) {
+		for _, msg := range frame {
+			model, _ = model.Update(msg)
+		}
+	}
+	model, _ = model.Update(CalculationCompleteMsg{ExitCode: apperrors.ExitSuccess})
+
+	state := model.(Model).SessionState()
+	if !state.Done || state.Mode != "comparison" {
+		t.Errorf("expected a finished comparison, got done=%v mode=%q", state.Done, state.Mode)
+	}
+	for _, algo := range state.Algorithms {
+		if algo.Status != SessionStatusOK || algo.Progress != 1 {
+			t.Errorf("algorithm %s: status %q progress %f", algo.Name, algo.Status, algo.Progress)
+		}
+	}
+}
+
+func TestDemoCalculationCmd_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	cmd := demoCalculationCmd(&programRef{}, ctx, []fibonacci.Calculator{mockCalculator{name: "Fast"}}, config.AppConfig{N: 10}, 7)
+	msg, ok := cmd().(CalculationCompleteMsg)
+	if !ok || msg.ExitCode != apperrors.ExitErrorCanceled || msg.Generation != 7 {
+		t.Errorf("expected a canceled completion for generation 7, got %#v", msg)
+	}
+}
@@ -12,11 +12,21 @@ type FooterModel struct {
 	done   bool
 	hasErr bool
 	width  int
+	keys   KeyMap
+
+	// message replaces the shortcuts until the next key press.
+	message    string
+	messageErr bool
 }
 
-// NewFooterModel creates a new footer.
+// NewFooterModel creates a new footer showing the default keys.
 func NewFooterModel() FooterModel {
-	return FooterModel{}
+	return FooterModel{keys: DefaultKeyMap()}
+}
+
+// SetKeyMap sets the keys shown in the shortcuts.
+func (f *FooterModel) SetKeyMap(km KeyMap) {
+	f.keys = km
 }
 
 // SetWidth updates the available width.
@@ -39,14 +49,29 @@ func (f *FooterModel) SetError(e bool) {
 	f.hasErr = e
 }
 
+// SetMessage shows text in place of the shortcuts, styled as an error if
+// isErr is set; an empty text restores the shortcuts.
+func (f *FooterModel) SetMessage(text string, isErr bool) {
+	f.message = text
+	f.messageErr = isErr
+}
+
 // View renders the footer.
 func (f FooterModel) View() string {
 	shortcuts := fmt.Sprintf(
-		"%s: %s   %s: %s   %s: %s",
-		footerKeyStyle.Render("q"), footerDescStyle.Render("Quit"),
-		footerKeyStyle.Render("r"), footerDescStyle.Render("Restart"),
-		footerKeyStyle.Render("space"), footerDescStyle.Render("Pause/Resume"),
+		"%s: %s   %s: %s   %s: %s   %s: %s   %s: %s",
+		footerKeyStyle.Render(f.keys.Quit.Help().Key), footerDescStyle.Render("Quit"),
+		footerKeyStyle.Render(f.keys.Reset.Help().Key), footerDescStyle.Render("Restart"),
+		footerKeyStyle.Render(f.keys.Pause.Help().Key), footerDescStyle.Render("Pause/Resume"),
+		footerKeyStyle.Render(f.keys.Export.Help().Key), footerDescStyle.Render("Export"),
+		footerKeyStyle.Render(f.keys.Theme.Help().Key), footerDescStyle.Render("Theme"),
 	)
+	switch {
+	case f.message != "" && f.messageErr:
+		shortcuts = statusErrorStyle.Render(f.message)
+	case f.message != "":
+		shortcuts = statusDoneStyle.Render(f.message)
+	}
 
 	var status string
 	switch {
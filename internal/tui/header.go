@@ -36,6 +36,14 @@ func (h *HeaderModel) Reset() {
 	h.endTime = time.Time{}
 }
 
+// elapsed returns the time since the start, frozen once SetDone is called.
+func (h HeaderModel) elapsed() time.Duration {
+	if !h.endTime.IsZero() {
+		return h.endTime.Sub(h.startTime)
+	}
+	return time.Since(h.startTime)
+}
+
 // SetWidth updates the available width.
 func (h *HeaderModel) SetWidth(w int) {
 	h.width = w
@@ -51,13 +59,7 @@ func (h HeaderModel) View() string {
 
 	pipe := versionStyle.Render(" | ")
 
-	var duration time.Duration
-	if !h.endTime.IsZero() {
-		duration = h.endTime.Sub(h.startTime)
-	} else {
-		duration = time.Since(h.startTime)
-	}
-	elapsed := elapsedStyle.Render(fmt.Sprintf("Elapsed: %s", format.FormatExecutionDuration(duration)))
+	elapsed := elapsedStyle.Render(fmt.Sprintf("Elapsed: %s", format.FormatExecutionDuration(h.elapsed())))
 
 	leftPart := title + pipe + elapsed
 	leftLen := lipgloss.Width(leftPart)
@@ -1,16 +1,24 @@
 package tui
 
-import "github.com/charmbracelet/bubbles/key"
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+)
 
 // KeyMap defines keyboard bindings for the TUI.
 type KeyMap struct {
-	Quit       key.Binding
-	Pause      key.Binding
-	Reset      key.Binding
-	Up         key.Binding
-	Down       key.Binding
-	PageUp     key.Binding
-	PageDown   key.Binding
+	Quit     key.Binding
+	Pause    key.Binding
+	Reset    key.Binding
+	Export   key.Binding
+	Theme    key.Binding
+	Value    key.Binding
+	Save     key.Binding
+	Up       key.Binding
+	Down     key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
 }
 
 // DefaultKeyMap returns the default keyboard bindings.
@@ -28,6 +36,22 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "Reset"),
 		),
+		Export: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "Export session"),
+		),
+		Theme: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "Theme"),
+		),
+		Value: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "Full value"),
+		),
+		Save: key.NewBinding(
+			key.WithKeys("ctrl+s"),
+			key.WithHelp("ctrl+s", "Save result"),
+		),
 		Up: key.NewBinding(
 			key.WithKeys("up", "k"),
 			key.WithHelp("up/k", "Scroll up"),
@@ -46,3 +70,39 @@ func DefaultKeyMap() KeyMap {
 		),
 	}
 }
+
+// bindings returns the remappable bindings by action name, as used in the
+// "keys" object of the preferences file.
+func (km *KeyMap) bindings() map[string]*key.Binding {
+	return map[string]*key.Binding{
+		"quit":     &km.Quit,
+		"pause":    &km.Pause,
+		"reset":    &km.Reset,
+		"export":   &km.Export,
+		"theme":    &km.Theme,
+		"value":    &km.Value,
+		"save":     &km.Save,
+		"up":       &km.Up,
+		"down":     &km.Down,
+		"pageup":   &km.PageUp,
+		"pagedown": &km.PageDown,
+	}
+}
+
+// Remap replaces the keys of the named actions. Unknown actions and empty key
+// lists are ignored, so a stale preferences file cannot leave an action
+// without a key.
+//
+// Parameters:
+//   - keys: The new keys of each action, by action name (e.g. "quit").
+func (km *KeyMap) Remap(keys map[string][]string) {
+	bindings := km.bindings()
+	for action, newKeys := range keys {
+		binding, ok := bindings[action]
+		if !ok || len(newKeys) == 0 {
+			continue
+		}
+		binding.SetKeys(newKeys...)
+		binding.SetHelp(strings.Join(newKeys, "/"), binding.Help().Desc)
+	}
+}
@@ -1,6 +1,7 @@
 package tui
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/charmbracelet/bubbles/key"
@@ -16,6 +17,10 @@ func TestDefaultKeyMap_AllBindingsDefined(t *testing.T) {
 		{"Quit", km.Quit},
 		{"Pause", km.Pause},
 		{"Reset", km.Reset},
+		{"Export", km.Export},
+		{"Theme", km.Theme},
+		{"Value", km.Value},
+		{"Save", km.Save},
 		{"Up", km.Up},
 		{"Down", km.Down},
 		{"PageUp", km.PageUp},
@@ -57,3 +62,26 @@ func TestDefaultKeyMap_QuitKeys(t *testing.T) {
 		t.Error("expected Quit binding to include 'ctrl+c'")
 	}
 }
+
+func TestKeyMap_Remap(t *testing.T) {
+	km := DefaultKeyMap()
+	km.Remap(map[string][]string{
+		"quit":    {"x", "ctrl+c"},
+		"theme":   {"f2"},
+		"pause":   {},    // ignored: would leave the action without a key
+		"unknown": {"u"}, // ignored
+	})
+
+	if !slices.Equal(km.Quit.Keys(), []string{"x", "ctrl+c"}) || km.Quit.Help().Key != "x/ctrl+c" {
+		t.Errorf("unexpected Quit binding: keys %v, help %q", km.Quit.Keys(), km.Quit.Help().Key)
+	}
+	if km.Quit.Help().Desc != "Quit" {
+		t.Errorf("expected the help description to be kept, got %q", km.Quit.Help().Desc)
+	}
+	if !slices.Equal(km.Theme.Keys(), []string{"f2"}) {
+		t.Errorf("unexpected Theme keys %v", km.Theme.Keys())
+	}
+	if !slices.Equal(km.Pause.Keys(), DefaultKeyMap().Pause.Keys()) {
+		t.Errorf("expected Pause to keep its default keys, got %v", km.Pause.Keys())
+	}
+}
@@ -158,6 +158,14 @@ func (l *LogsModel) AddError(msg ErrorMsg) {
 	l.updateContent()
 }
 
+// AddInfo adds a timestamped informational entry to the log.
+func (l *LogsModel) AddInfo(text string) {
+	ts := logTimeStyle.Render(time.Now().Format("15:04:05"))
+	l.entries = append(l.entries, fmt.Sprintf("[%s] %s", ts, logSuccessStyle.Render(text)))
+	l.trimEntries()
+	l.updateContent()
+}
+
 // Update handles viewport keyboard events.
 func (l *LogsModel) Update(msg tea.Msg) {
 	var cmd tea.Cmd
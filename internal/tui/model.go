@@ -2,7 +2,9 @@ package tui
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"os"
 	"runtime"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/agbru/fibcalc/internal/metrics"
 	"github.com/agbru/fibcalc/internal/orchestration"
 	"github.com/agbru/fibcalc/internal/sysmon"
+	"github.com/agbru/fibcalc/internal/ui"
 )
 
 // ExecutionState holds the execution-related fields of a TUI session.
@@ -43,14 +46,24 @@ func (l LayoutManager) bodyHeight() int {
 	return h
 }
 
-// logsWidth returns the width allocated to the logs panel.
+// logsWidth returns the width allocated to the logs panel. It leaves at least
+// minPanelWidth columns to the right column, so that both fit in terminals of
+// minTerminalWidth columns or more.
 func (l LayoutManager) logsWidth() int {
-	return l.width * LogsPanelWidthPercent / 100
+	w := min(l.width*LogsPanelWidthPercent/100, l.width-minPanelWidth)
+	return max(w, minPanelWidth)
 }
 
-// rightWidth returns the width allocated to the right column (metrics + chart).
+// rightWidth returns the width allocated to the right column (metrics + chart):
+// the rest of the terminal width.
 func (l LayoutManager) rightWidth() int {
-	return l.width - l.logsWidth()
+	return max(l.width-l.logsWidth(), minPanelWidth)
+}
+
+// tooSmall reports whether the terminal is below the minimum usable size,
+// in which case the dashboard is replaced by a notice.
+func (l LayoutManager) tooSmall() bool {
+	return l.width < minTerminalWidth || l.height < minTerminalHeight
 }
 
 // metricsHeight returns the height allocated to the metrics panel.
@@ -80,6 +93,8 @@ type Model struct {
 	metrics MetricsModel
 	chart   ChartModel
 	footer  FooterModel
+	viewer  ValueViewerModel
+	save    SaveDialogModel
 
 	keymap KeyMap
 
@@ -90,10 +105,17 @@ type Model struct {
 	config    config.AppConfig
 	ref       *programRef
 	paused    bool
+	session   sessionState
+
+	// prefs are saved to prefsPath when the theme changes and on exit; an
+	// empty prefsPath disables saving.
+	prefs     Preferences
+	prefsPath string
 }
 
-// NewModel creates a new TUI model.
-func NewModel(parentCtx context.Context, calculators []fibonacci.Calculator, cfg config.AppConfig, version string) Model {
+// NewModel creates a new TUI model. The key remaps of prefs are applied to
+// the keymap; its theme is expected to be active already.
+func NewModel(parentCtx context.Context, calculators []fibonacci.Calculator, cfg config.AppConfig, version string, prefs Preferences) Model {
 	algoNames := make([]string, len(calculators))
 	for i, c := range calculators {
 		algoNames[i] = c.Name()
@@ -101,16 +123,26 @@ func NewModel(parentCtx context.Context, calculators []fibonacci.Calculator, cfg
 
 	ctx, cancel := context.WithCancel(parentCtx)
 
+	keymap := DefaultKeyMap()
+	keymap.Remap(prefs.Keys)
+	footer := NewFooterModel()
+	footer.SetKeyMap(keymap)
+
 	logs := NewLogsModel(algoNames)
 	logs.AddExecutionConfig(cfg)
+	if cfg.TUIDemo {
+		logs.AddInfo("Demo mode: synthetic progress and timings, no calculation is performed")
+	}
 
 	return Model{
 		header:  NewHeaderModel(version),
 		logs:    logs,
 		metrics: NewMetricsModel(),
 		chart:   NewChartModel(),
-		footer:  NewFooterModel(),
-		keymap:  DefaultKeyMap(),
+		footer:  footer,
+		viewer:  NewValueViewerModel(),
+		save:    NewSaveDialogModel(),
+		keymap:  keymap,
 		ExecutionState: ExecutionState{
 			ctx:         ctx,
 			cancel:      cancel,
@@ -120,6 +152,8 @@ func NewModel(parentCtx context.Context, calculators []fibonacci.Calculator, cfg
 		parentCtx: parentCtx,
 		config:    cfg,
 		ref:       &programRef{},
+		session:   sessionState{progress: make([]float64, len(calculators))},
+		prefs:     prefs,
 	}
 }
 
@@ -127,7 +161,7 @@ func NewModel(parentCtx context.Context, calculators []fibonacci.Calculator, cfg
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
 		tickCmd(),
-		startCalculationCmd(m.ref, m.ctx, m.calculators, m.config, m.generation),
+		m.calculationCmd(),
 		watchContextCmd(m.ctx, m.generation),
 	)
 }
@@ -145,6 +179,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ProgressMsg:
+		m.session.recordProgress(msg.CalculatorIndex, msg.Value)
 		if !m.paused {
 			m.logs.AddProgressEntry(msg)
 			m.chart.AddDataPoint(msg.Value, msg.AverageProgress, msg.ETA)
@@ -159,10 +194,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ComparisonResultsMsg:
+		m.session.results = msg.Results
 		m.logs.AddResults(msg.Results)
 		return m, nil
 
 	case FinalResultMsg:
+		if len(m.session.results) == 0 {
+			m.session.results = []orchestration.CalculationResult{msg.Result}
+		}
 		m.logs.AddFinalResult(msg)
 		// Compute indicators asynchronously to avoid blocking the UI
 		if msg.Result.Result != nil {
@@ -175,6 +214,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case ErrorMsg:
+		m.session.lastErr = msg.Err
 		m.logs.AddError(msg)
 		m.footer.SetError(true)
 		m.done = true
@@ -224,6 +264,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.save.IsOpen() {
+		return m.handleSaveKey(msg)
+	}
+	if m.viewer.IsOpen() && !key.Matches(msg, m.keymap.Quit) {
+		return m.handleViewerKey(msg)
+	}
+	m.footer.SetMessage("", false)
+
 	switch {
 	case key.Matches(msg, m.keymap.Quit):
 		if m.cancel != nil {
@@ -236,6 +284,40 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.footer.SetPaused(m.paused)
 		return m, nil
 
+	case key.Matches(msg, m.keymap.Export):
+		path := m.sessionExportPath()
+		if err := WriteSessionExport(path, m.SessionState()); err != nil {
+			m.logs.AddError(ErrorMsg{Err: err})
+		} else {
+			m.logs.AddInfo(fmt.Sprintf("Session exported to %s", path))
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keymap.Theme):
+		if ui.GetCurrentTheme().Name == ui.NoColorTheme.Name {
+			return m, nil
+		}
+		m.prefs.Theme = nextTheme(ui.GetCurrentTheme().Name)
+		ui.SetTheme(m.prefs.Theme)
+		initTUIStyles()
+		if err := SavePreferences(m.prefsPath, m.prefs); err != nil {
+			m.logs.AddError(ErrorMsg{Err: err})
+		}
+		return m, nil
+
+	case key.Matches(msg, m.keymap.Value):
+		best := orchestration.FindBestResult(m.session.results)
+		if best == nil {
+			m.logs.AddInfo("No result to view yet")
+			return m, nil
+		}
+		m.viewer.Open(fmt.Sprintf("F(%d)", m.config.N), best.Result.String())
+		return m, nil
+
+	case key.Matches(msg, m.keymap.Save):
+		m.save.Open(defaultResultPath(m.config.OutputFile, m.config.N))
+		return m, nil
+
 	case key.Matches(msg, m.keymap.Reset):
 		// Cancel the current calculation
 		if m.cancel != nil {
@@ -251,6 +333,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Reset all UI components
 		m.header.Reset()
 		m.logs.Reset()
+		m.session.reset()
 		m.chart.Reset()
 		m.metrics = NewMetricsModel()
 		m.metrics.SetSize(m.metricsWidth(), m.metricsHeight())
@@ -264,7 +347,7 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		// Restart calculation and watchers
 		return m, tea.Batch(
 			tickCmd(),
-			startCalculationCmd(m.ref, m.ctx, m.calculators, m.config, m.generation),
+			m.calculationCmd(),
 			watchContextCmd(m.ctx, m.generation),
 		)
 
@@ -277,15 +360,69 @@ func (m Model) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleViewerKey handles the keys while the value viewer is open: the value
+// key or Esc closes it, and the navigation keys scroll it.
+func (m Model) handleViewerKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keymap.Value) || msg.Type == tea.KeyEsc {
+		m.viewer.Close()
+		return m, nil
+	}
+	m.viewer.Update(msg, m.keymap)
+	return m, nil
+}
+
+// handleSaveKey handles the keys while the save dialog is open: Enter saves,
+// Tab toggles the format, Esc cancels, Ctrl+C still quits, and the other keys
+// edit the file name.
+func (m Model) handleSaveKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyCtrlC:
+		m.save.Close()
+		return m.handleKey(msg)
+	case tea.KeyEsc:
+		m.save.Close()
+		return m, nil
+	case tea.KeyTab:
+		m.save.ToggleFormat()
+		return m, nil
+	case tea.KeyEnter:
+		path := m.save.Path()
+		if path == "" {
+			return m, nil
+		}
+		m.save.Close()
+		presOpts := orchestration.PresentationOptions{N: m.config.N, Expected: m.config.ExpectedValue()}
+		if err := saveResult(path, m.save.AsJSON(), m.session.results, presOpts); err != nil {
+			m.footer.SetMessage(fmt.Sprintf("Save failed: %v", err), true)
+		} else {
+			m.footer.SetMessage(fmt.Sprintf("Result saved to %s", path), false)
+		}
+		return m, nil
+	}
+	m.save.Update(msg)
+	return m, nil
+}
+
 // View renders the entire dashboard.
 func (m Model) View() string {
 	if m.width == 0 || m.height == 0 {
 		return "Initializing..."
 	}
+	if m.tooSmall() {
+		return fmt.Sprintf("Terminal too small (%dx%d, need at least %dx%d).\nResize the window or press q to quit.",
+			m.width, m.height, minTerminalWidth, minTerminalHeight)
+	}
 
 	header := m.header.View()
 	footer := m.footer.View()
 
+	if m.viewer.IsOpen() {
+		return lipgloss.JoinVertical(lipgloss.Left, header, m.viewer.View(), footer)
+	}
+	if m.save.IsOpen() {
+		footer = m.save.View()
+	}
+
 	metrics := m.metrics.View()
 	chart := m.chart.View()
 
@@ -309,6 +446,13 @@ const (
 	minBodyHeight        = 4
 	LogsPanelWidthPercent = 60
 	MetricsPanelHeight   = 7 // compact: top line + 1 data row + borders; expands to ~9 with indicators
+	// minPanelWidth is the smallest width given to a panel, so that the
+	// widths inside its borders stay positive.
+	minPanelWidth = 20
+	// minTerminalWidth and minTerminalHeight are the smallest terminal size
+	// for which the dashboard is rendered.
+	minTerminalWidth  = 2 * minPanelWidth
+	minTerminalHeight = headerHeight + footerHeight + minBodyHeight
 )
 
 func (m *Model) layoutPanels() {
@@ -317,15 +461,22 @@ func (m *Model) layoutPanels() {
 	m.logs.SetSize(m.logsWidth(), m.bodyHeight())
 	m.metrics.SetSize(m.rightWidth(), m.metricsHeight())
 	m.chart.SetSize(m.rightWidth(), m.chartHeight())
+	m.viewer.SetSize(max(m.width, minPanelWidth), m.bodyHeight())
+	m.save.SetWidth(m.width)
 }
 
 // Run is the public entry point for the TUI mode.
 // It creates the bubbletea program, runs it, and returns the exit code.
 func Run(ctx context.Context, calculators []fibonacci.Calculator, cfg config.AppConfig, version string) int {
-	// Rebuild styles from the current ui theme (set by app.Run via InitTheme).
+	// Rebuild styles from the current ui theme (set by app.Run via InitTheme),
+	// switched to the saved theme if any.
+	prefsPath := DefaultPreferencesPath()
+	prefs := LoadPreferences(prefsPath)
+	prefs.applyTheme()
 	initTUIStyles()
 
-	model := NewModel(ctx, calculators, cfg, version)
+	model := NewModel(ctx, calculators, cfg, version, prefs)
+	model.prefsPath = prefsPath
 	defer model.cancel()
 
 	p := tea.NewProgram(model, tea.WithAltScreen())
@@ -339,11 +490,28 @@ func Run(ctx context.Context, calculators []fibonacci.Calculator, cfg config.App
 
 	if m, ok := finalModel.(Model); ok {
 		m.cancel()
+		if err := SavePreferences(m.prefsPath, m.prefs); err != nil {
+			fmt.Fprintf(os.Stderr, "Error saving TUI preferences: %v\n", err)
+		}
+		if cfg.TUIExportOnExit != "" {
+			if err := WriteSessionExport(cfg.TUIExportOnExit, m.SessionState()); err != nil {
+				fmt.Fprintf(os.Stderr, "Error exporting TUI session: %v\n", err)
+			}
+		}
 		return m.exitCode
 	}
 	return apperrors.ExitSuccess
 }
 
+// calculationCmd returns the command that drives the dashboard: the real
+// orchestration, or the synthetic demo driver under --tui-demo.
+func (m Model) calculationCmd() tea.Cmd {
+	if m.config.TUIDemo {
+		return demoCalculationCmd(m.ref, m.ctx, m.calculators, m.config, m.generation)
+	}
+	return startCalculationCmd(m.ref, m.ctx, m.calculators, m.config, m.generation)
+}
+
 // startCalculationCmd returns a tea.Cmd that launches the orchestration.
 func startCalculationCmd(ref *programRef, ctx context.Context, calculators []fibonacci.Calculator, cfg config.AppConfig, gen uint64) tea.Cmd {
 	return func() tea.Msg {
@@ -354,6 +522,8 @@ func startCalculationCmd(ref *programRef, ctx context.Context, calculators []fib
 			ParallelThreshold: cfg.Threshold,
 			FFTThreshold:      cfg.FFTThreshold,
 			StrassenThreshold: cfg.StrassenThreshold,
+			DisablePooling:    cfg.NoPooling,
+			KBonacciOrder:     cfg.KBonacci,
 		}
 		results := orchestration.ExecuteCalculations(ctx, calculators, cfg.N, opts, progressReporter, io.Discard)
 		presOpts := orchestration.PresentationOptions{
@@ -361,6 +531,7 @@ func startCalculationCmd(ref *programRef, ctx context.Context, calculators []fib
 			Verbose:   cfg.Verbose,
 			Details:   cfg.Details,
 			ShowValue: cfg.ShowValue,
+			Expected:  cfg.ExpectedValue(),
 		}
 		exitCode := orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, io.Discard)
 
@@ -3,16 +3,19 @@ package tui
 import (
 	"context"
 	"math/big"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 
 	"github.com/agbru/fibcalc/internal/config"
 	apperrors "github.com/agbru/fibcalc/internal/errors"
 	"github.com/agbru/fibcalc/internal/fibonacci"
 	"github.com/agbru/fibcalc/internal/orchestration"
+	"github.com/agbru/fibcalc/internal/ui"
 )
 
 // mockCalculator implements fibonacci.Calculator for testing.
@@ -33,7 +36,7 @@ func newTestModel(t *testing.T) Model {
 	t.Helper()
 	ctx := context.Background()
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	m := NewModel(ctx, nil, cfg, "v0.1.0")
+	m := NewModel(ctx, nil, cfg, "v0.1.0", Preferences{})
 	t.Cleanup(m.cancel)
 	return m
 }
@@ -65,7 +68,7 @@ func TestNewModel_WithCalculators(t *testing.T) {
 		mockCalculator{name: "Matrix"},
 	}
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), calcs, cfg, "v1.0.0")
+	model := NewModel(context.Background(), calcs, cfg, "v1.0.0", Preferences{})
 	defer model.cancel()
 
 	if len(model.calculators) != 2 {
@@ -75,7 +78,7 @@ func TestNewModel_WithCalculators(t *testing.T) {
 
 func TestModel_Update_WindowSize(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	msg := tea.WindowSizeMsg{Width: 120, Height: 40}
@@ -95,7 +98,7 @@ func TestModel_Update_WindowSize(t *testing.T) {
 
 func TestModel_Update_ProgressMsg(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	// Set size first so viewport is initialized
@@ -118,7 +121,7 @@ func TestModel_Update_ProgressMsg(t *testing.T) {
 
 func TestModel_Update_ProgressMsg_Paused(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 	model.paused = true
 
@@ -138,7 +141,7 @@ func TestModel_Update_ProgressMsg_Paused(t *testing.T) {
 
 func TestModel_Update_CalculationComplete(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	msg := CalculationCompleteMsg{ExitCode: 0}
@@ -155,7 +158,7 @@ func TestModel_Update_CalculationComplete(t *testing.T) {
 
 func TestModel_Update_ErrorMsg(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	// Set size first
@@ -176,7 +179,7 @@ func TestModel_Update_ErrorMsg(t *testing.T) {
 
 func TestModel_View_Initializing(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	view := model.View()
@@ -187,7 +190,7 @@ func TestModel_View_Initializing(t *testing.T) {
 
 func TestModel_View_WithSize(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	sized, _ := model.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
@@ -204,7 +207,7 @@ func TestModel_View_WithSize(t *testing.T) {
 
 func TestModel_HandleKey_Pause(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	model := NewModel(context.Background(), nil, cfg, "v0.1.0")
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", Preferences{})
 	defer model.cancel()
 
 	// Press space to pause
@@ -254,6 +257,89 @@ func TestModel_HandleKey_Restart(t *testing.T) {
 	}
 }
 
+// TestModel_HandleKey_Theme cycles the theme and checks that the choice is
+// saved. Not parallel: the theme is process-wide.
+func TestModel_HandleKey_Theme(t *testing.T) {
+	original := ui.GetCurrentTheme()
+	t.Cleanup(func() {
+		ui.SetCurrentTheme(original)
+		initTUIStyles()
+	})
+	ui.SetTheme("dark")
+
+	m := newTestModelWithSize(t, 80, 24)
+	m.prefsPath = filepath.Join(t.TempDir(), "tui.json")
+
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	m = updated.(Model)
+	if got := ui.GetCurrentTheme().Name; got != "light" || m.prefs.Theme != "light" {
+		t.Errorf("expected the light theme after 't', got theme %q and preference %q", got, m.prefs.Theme)
+	}
+	if saved := LoadPreferences(m.prefsPath); saved.Theme != "light" {
+		t.Errorf("expected the theme to be saved, got %+v", saved)
+	}
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if got := updated.(Model).prefs.Theme; got != "dark" {
+		t.Errorf("expected the theme to cycle back to dark, got %q", got)
+	}
+
+	// Without colors, the theme key does nothing.
+	ui.SetTheme("none")
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	if ui.GetCurrentTheme().Name != "none" || updated.(Model).prefs.Theme != "light" {
+		t.Error("expected the theme key to be ignored when colors are disabled")
+	}
+}
+
+func TestModel_HandleKey_Value(t *testing.T) {
+	m := newTestModelWithSize(t, 80, 24)
+	press := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+	valueKey := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'f'}}
+
+	press(valueKey)
+	if m.viewer.IsOpen() {
+		t.Fatal("expected the viewer to stay closed without a result")
+	}
+
+	value, _ := new(big.Int).SetString(strings.Repeat("9", 2000), 10)
+	m.session.results = []orchestration.CalculationResult{{Name: "Fast", Result: value}}
+	press(valueKey)
+	if !m.viewer.IsOpen() || !strings.Contains(m.View(), "2,000 digits") {
+		t.Fatalf("expected the viewer to show the value, got:\n%s", m.View())
+	}
+
+	// Navigation keys scroll the value rather than the logs.
+	press(tea.KeyMsg{Type: tea.KeyDown})
+	if m.viewer.offset != 1 {
+		t.Errorf("expected the viewer to scroll, offset = %d", m.viewer.offset)
+	}
+	press(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.viewer.IsOpen() {
+		t.Error("expected Esc to close the viewer")
+	}
+}
+
+func TestNewModel_RemappedKeys(t *testing.T) {
+	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
+	prefs := Preferences{Keys: map[string][]string{"pause": {"p"}}}
+	model := NewModel(context.Background(), nil, cfg, "v0.1.0", prefs)
+	defer model.cancel()
+
+	updated, _ := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'p'}})
+	m := updated.(Model)
+	if !m.paused {
+		t.Error("expected the remapped key to pause")
+	}
+	m.footer.SetWidth(120)
+	if view := m.footer.View(); !strings.Contains(view, "p: Pause") || strings.Contains(view, "space") {
+		t.Errorf("expected the footer to show the remapped key, got %q", view)
+	}
+}
+
 func TestModel_Update_ComparisonResultsMsg(t *testing.T) {
 	m := newTestModelWithSize(t, 120, 40)
 
@@ -501,6 +587,43 @@ func TestModel_LayoutPanels_MinBodyHeight(t *testing.T) {
 	}
 }
 
+func TestModel_LayoutPanels_TinyTerminal(t *testing.T) {
+	sizes := []struct{ w, h int }{{1, 1}, {5, 3}, {20, 8}, {39, 24}, {80, 5}, {minTerminalWidth, minTerminalHeight}, {120, 40}}
+	for w := minTerminalWidth; w < 50; w++ {
+		sizes = append(sizes, struct{ w, h int }{w, 24})
+	}
+	for _, size := range sizes {
+		m := newTestModelWithSize(t, size.w, size.h)
+		for name, got := range map[string]int{
+			"logsWidth":     m.logsWidth(),
+			"rightWidth":    m.rightWidth(),
+			"metricsWidth":  m.metricsWidth(),
+			"metricsHeight": m.metricsHeight(),
+			"chartHeight":   m.chartHeight(),
+			"viewport":      m.logs.viewport.Width,
+		} {
+			if got <= 0 {
+				t.Errorf("%dx%d: %s = %d, want a positive size", size.w, size.h, name, got)
+			}
+		}
+
+		view := m.View()
+		tooSmall := size.w < minTerminalWidth || size.h < minTerminalHeight
+		if got := strings.Contains(view, "Terminal too small"); got != tooSmall {
+			t.Errorf("%dx%d: too small notice shown = %v, want %v", size.w, size.h, got, tooSmall)
+		}
+		if tooSmall {
+			continue
+		}
+		for i, line := range strings.Split(view, "\n") {
+			if got := lipgloss.Width(line); got > size.w {
+				t.Errorf("%dx%d: line %d is %d columns wide", size.w, size.h, i, got)
+				break
+			}
+		}
+	}
+}
+
 func TestModel_HandleKey_Restart_ClearsMetrics(t *testing.T) {
 	m := newTestModelWithSize(t, 80, 24)
 
@@ -737,7 +860,7 @@ func TestModel_HandleKey_Restart_ClearsSysStats(t *testing.T) {
 
 func TestModel_HandleKey_Quit_CancelsContext(t *testing.T) {
 	cfg := config.AppConfig{N: 1000, Timeout: time.Minute}
-	m := NewModel(context.Background(), nil, cfg, "v1.0.0")
+	m := NewModel(context.Background(), nil, cfg, "v1.0.0", Preferences{})
 
 	calcCtx := m.ctx
 	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
@@ -0,0 +1,101 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/agbru/fibcalc/internal/ui"
+)
+
+// themeCycle lists the themes the theme key cycles through, in order.
+var themeCycle = []string{"dark", "light"}
+
+// Preferences are the dashboard settings kept between TUI launches.
+type Preferences struct {
+	// Theme is the last theme selected with the theme key, or "" for the
+	// default.
+	Theme string `json:"theme,omitempty"`
+	// Keys remaps actions to new keys, by action name (see KeyMap.Remap).
+	Keys map[string][]string `json:"keys,omitempty"`
+}
+
+// DefaultPreferencesPath returns the preferences file in the user
+// configuration directory, e.g. ~/.config/fibcalc/tui.json on Linux.
+//
+// Returns:
+//   - string: The path, or "" if there is no user configuration directory.
+func DefaultPreferencesPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "fibcalc", "tui.json")
+}
+
+// LoadPreferences reads the preferences file. A missing, unreadable or
+// corrupt file, or an unknown theme, falls back to the defaults: preferences
+// must never prevent the dashboard from starting.
+//
+// Parameters:
+//   - path: The preferences file; "" returns the defaults.
+//
+// Returns:
+//   - Preferences: The loaded preferences.
+func LoadPreferences(path string) Preferences {
+	var prefs Preferences
+	if path == "" {
+		return prefs
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return prefs
+	}
+	if err := json.Unmarshal(data, &prefs); err != nil {
+		return Preferences{}
+	}
+	if !slices.Contains(themeCycle, prefs.Theme) {
+		prefs.Theme = ""
+	}
+	return prefs
+}
+
+// SavePreferences writes the preferences file, creating its directory.
+//
+// Parameters:
+//   - path: The preferences file; "" disables saving.
+//   - prefs: The preferences to save.
+//
+// Returns:
+//   - error: An error if the file cannot be written.
+func SavePreferences(path string, prefs Preferences) error {
+	if path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(prefs, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode preferences: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create preferences directory: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write preferences: %w", err)
+	}
+	return nil
+}
+
+// applyTheme activates the preferred theme, unless colors are disabled.
+func (p Preferences) applyTheme() {
+	if p.Theme != "" && ui.GetCurrentTheme().Name != ui.NoColorTheme.Name {
+		ui.SetTheme(p.Theme)
+	}
+}
+
+// nextTheme returns the theme following current in themeCycle.
+func nextTheme(current string) string {
+	i := slices.Index(themeCycle, current)
+	return themeCycle[(i+1)%len(themeCycle)]
+}
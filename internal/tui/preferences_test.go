@@ -0,0 +1,65 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestPreferences_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fibcalc", "tui.json")
+	want := Preferences{
+		Theme: "light",
+		Keys:  map[string][]string{"quit": {"x"}, "theme": {"T", "f2"}},
+	}
+
+	if err := SavePreferences(path, want); err != nil {
+		t.Fatalf("SavePreferences: %v", err)
+	}
+	got := LoadPreferences(path)
+	if got.Theme != want.Theme || len(got.Keys) != len(want.Keys) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for action, keys := range want.Keys {
+		if !slices.Equal(got.Keys[action], keys) {
+			t.Errorf("keys for %q = %v, want %v", action, got.Keys[action], keys)
+		}
+	}
+}
+
+func TestLoadPreferences_FallsBackToDefaults(t *testing.T) {
+	dir := t.TempDir()
+	corrupt := filepath.Join(dir, "corrupt.json")
+	if err := os.WriteFile(corrupt, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	unknownTheme := filepath.Join(dir, "unknown.json")
+	if err := os.WriteFile(unknownTheme, []byte(`{"theme":"neon","keys":{"quit":["x"]}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"", filepath.Join(dir, "missing.json"), corrupt} {
+		if got := LoadPreferences(path); got.Theme != "" || got.Keys != nil {
+			t.Errorf("LoadPreferences(%q) = %+v, want the defaults", path, got)
+		}
+	}
+	if got := LoadPreferences(unknownTheme); got.Theme != "" || len(got.Keys["quit"]) != 1 {
+		t.Errorf("expected the unknown theme to be dropped and the keys kept, got %+v", got)
+	}
+}
+
+func TestSavePreferences_EmptyPath(t *testing.T) {
+	if err := SavePreferences("", Preferences{Theme: "light"}); err != nil {
+		t.Errorf("expected saving to be disabled without a path, got %v", err)
+	}
+}
+
+func TestNextTheme(t *testing.T) {
+	tests := map[string]string{"dark": "light", "light": "dark", "orange": "dark"}
+	for current, want := range tests {
+		if got := nextTheme(current); got != want {
+			t.Errorf("nextTheme(%q) = %q, want %q", current, got, want)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/cli"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// Result file extensions swapped by the format toggle of the save dialog.
+const (
+	plainResultExt = ".txt"
+	jsonResultExt  = ".json"
+)
+
+// SaveDialogModel is the prompt, shown in place of the footer, that asks for
+// the file and format the result is saved to. The file name is edited at its
+// end only: typed characters are appended, Backspace deletes the last one and
+// Ctrl+U clears the field.
+type SaveDialogModel struct {
+	path   []rune
+	asJSON bool
+	open   bool
+	width  int
+}
+
+// NewSaveDialogModel creates a closed save dialog.
+func NewSaveDialogModel() SaveDialogModel {
+	return SaveDialogModel{}
+}
+
+// Open shows the dialog with path as the suggested file. The JSON format is
+// preselected when path has the JSON extension.
+func (d *SaveDialogModel) Open(path string) {
+	d.path = []rune(path)
+	d.asJSON = strings.HasSuffix(path, jsonResultExt)
+	d.open = true
+}
+
+// Close hides the dialog.
+func (d *SaveDialogModel) Close() {
+	d.open = false
+}
+
+// IsOpen reports whether the dialog is shown.
+func (d SaveDialogModel) IsOpen() bool {
+	return d.open
+}
+
+// SetWidth updates the available width.
+func (d *SaveDialogModel) SetWidth(w int) {
+	d.width = w
+}
+
+// Path returns the file typed so far, without surrounding spaces.
+func (d SaveDialogModel) Path() string {
+	return strings.TrimSpace(string(d.path))
+}
+
+// AsJSON reports whether the JSON format is selected.
+func (d SaveDialogModel) AsJSON() bool {
+	return d.asJSON
+}
+
+// ToggleFormat switches between the plain and JSON formats, swapping the
+// file extension when it matches the previous format.
+func (d *SaveDialogModel) ToggleFormat() {
+	from, to := plainResultExt, jsonResultExt
+	if d.asJSON {
+		from, to = to, from
+	}
+	if value := string(d.path); strings.HasSuffix(value, from) {
+		d.path = []rune(strings.TrimSuffix(value, from) + to)
+	}
+	d.asJSON = !d.asJSON
+}
+
+// Update edits the file name.
+func (d *SaveDialogModel) Update(msg tea.KeyMsg) {
+	switch msg.Type {
+	case tea.KeyRunes:
+		d.path = append(d.path, msg.Runes...)
+	case tea.KeySpace:
+		d.path = append(d.path, ' ')
+	case tea.KeyBackspace:
+		if len(d.path) > 0 {
+			d.path = d.path[:len(d.path)-1]
+		}
+	case tea.KeyCtrlU:
+		d.path = d.path[:0]
+	}
+}
+
+// View renders the dialog as a one-line bar, in place of the footer.
+func (d SaveDialogModel) View() string {
+	formatName := "plain"
+	if d.asJSON {
+		formatName = "JSON"
+	}
+	help := fmt.Sprintf("%s: %s   %s: %s (%s)   %s: %s",
+		footerKeyStyle.Render("enter"), footerDescStyle.Render("Save"),
+		footerKeyStyle.Render("tab"), footerDescStyle.Render("Format"), metricValueStyle.Render(formatName),
+		footerKeyStyle.Render("esc"), footerDescStyle.Render("Cancel"))
+	input := fmt.Sprintf("%s %s%s", footerDescStyle.Render("Save to:"), metricValueStyle.Render(string(d.path)), footerKeyStyle.Render("_"))
+	return headerStyle.Width(d.width).Render(input + "   " + help)
+}
+
+// defaultResultPath returns the file suggested by the save dialog: the
+// --output file if set, otherwise one named after the index.
+func defaultResultPath(outputFile string, n uint64) string {
+	if outputFile != "" {
+		return outputFile
+	}
+	return fmt.Sprintf("fibonacci_%d%s", n, plainResultExt)
+}
+
+// saveResult writes the results of a calculation to path: the fastest result
+// in the --output file format, or, for JSON, the document of --json with the
+// values included.
+//
+// Parameters:
+//   - path: The destination file.
+//   - asJSON: Whether to write the JSON document.
+//   - results: The results of the calculation; not modified.
+//   - presOpts: The index and expected value of the calculation.
+//
+// Returns:
+//   - error: An error if there is no successful result or the file cannot be
+//     written.
+func saveResult(path string, asJSON bool, results []orchestration.CalculationResult, presOpts orchestration.PresentationOptions) error {
+	best := orchestration.FindBestResult(results)
+	if best == nil {
+		return fmt.Errorf("no result to save yet")
+	}
+	if !asJSON {
+		return cli.WriteResultToFile(best.Result, presOpts.N, best.Duration, best.Name, cli.OutputConfig{OutputFile: path})
+	}
+
+	// The analysis sorts the results in place.
+	results = append([]orchestration.CalculationResult(nil), results...)
+	presenter := &cli.JSONResultPresenter{N: presOpts.N, IncludeValue: true, Expected: presOpts.Expected}
+	orchestration.AnalyzeComparisonResults(results, presOpts, presenter, presenter, io.Discard)
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	if err := presenter.WriteJSON(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+	return file.Close()
+}
@@ -0,0 +1,132 @@
+package tui
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/cli"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+func TestSaveDialogModel_Editing(t *testing.T) {
+	d := NewSaveDialogModel()
+	d.Open("out.txt")
+
+	d.Update(tea.KeyMsg{Type: tea.KeyBackspace})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("xt")})
+	if got := d.Path(); got != "out.txxt" {
+		t.Errorf("Path() = %q, want %q", got, "out.txxt")
+	}
+	d.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	d.Update(tea.KeyMsg{Type: tea.KeySpace})
+	d.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("1.txt")})
+	if got := d.Path(); got != "r 1.txt" {
+		t.Errorf("Path() = %q, want %q", got, "r 1.txt")
+	}
+
+	d.ToggleFormat()
+	if !d.AsJSON() || d.Path() != "r 1.json" {
+		t.Errorf("expected JSON with a .json file, got JSON=%v and %q", d.AsJSON(), d.Path())
+	}
+	d.ToggleFormat()
+	if d.AsJSON() || d.Path() != "r 1.txt" {
+		t.Errorf("expected plain with a .txt file, got JSON=%v and %q", d.AsJSON(), d.Path())
+	}
+}
+
+func TestSaveResult(t *testing.T) {
+	dir := t.TempDir()
+	results := []orchestration.CalculationResult{
+		{Name: "Slow", Result: big.NewInt(55), Duration: 2 * time.Millisecond},
+		{Name: "Fast", Result: big.NewInt(55), Duration: time.Millisecond},
+	}
+	presOpts := orchestration.PresentationOptions{N: 10}
+
+	plain := filepath.Join(dir, "result.txt")
+	if err := saveResult(plain, false, results, presOpts); err != nil {
+		t.Fatalf("plain: %v", err)
+	}
+	value, _, err := cli.ReadResultFromFile(plain)
+	if err != nil || value.Cmp(big.NewInt(55)) != 0 {
+		t.Errorf("plain: read back %v, %v; want 55", value, err)
+	}
+
+	doc := filepath.Join(dir, "result.json")
+	if err := saveResult(doc, true, results, presOpts); err != nil {
+		t.Fatalf("JSON: %v", err)
+	}
+	data, err := os.ReadFile(doc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var report cli.JSONReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		t.Fatalf("JSON: invalid document: %v", err)
+	}
+	if report.N != 10 || report.Fastest != "Fast" || len(report.Results) != 2 || report.Results[0].Value != "55" {
+		t.Errorf("JSON: unexpected report %+v", report)
+	}
+	if results[0].Name != "Slow" {
+		t.Error("saveResult must not reorder the results")
+	}
+
+	if err := saveResult(filepath.Join(dir, "none.txt"), false, nil, presOpts); err == nil {
+		t.Error("expected an error without a result")
+	}
+}
+
+func TestModel_HandleKey_Save(t *testing.T) {
+	m := newTestModelWithSize(t, 120, 24)
+	m.session.results = []orchestration.CalculationResult{{Name: "Fast", Result: big.NewInt(43466557686937456), Duration: time.Millisecond}}
+	path := filepath.Join(t.TempDir(), "f80.txt")
+	press := func(msg tea.KeyMsg) {
+		updated, _ := m.Update(msg)
+		m = updated.(Model)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	if !m.save.IsOpen() || m.save.Path() != "fibonacci_1000.txt" {
+		t.Fatalf("expected the save prompt with the default file, got open=%v and %q", m.save.IsOpen(), m.save.Path())
+	}
+	// Keys bound to dashboard actions are typed into the file name.
+	press(tea.KeyMsg{Type: tea.KeyCtrlU})
+	press(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(path)})
+	if m.paused || m.save.Path() != path {
+		t.Fatalf("expected %q to be typed, got %q", path, m.save.Path())
+	}
+	if view := m.View(); !strings.Contains(view, "Save to:") {
+		t.Errorf("expected the prompt in the view, got:\n%s", view)
+	}
+
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.save.IsOpen() {
+		t.Error("expected the prompt to close after saving")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.Contains(string(data), "43466557686937456") {
+		t.Fatalf("expected the result in %s, got %q, %v", path, data, err)
+	}
+	if view := m.footer.View(); !strings.Contains(view, "Result saved to") {
+		t.Errorf("expected a success message in the footer, got %q", view)
+	}
+
+	// A failure is reported in the footer too, until the next key press.
+	m.session.results = nil
+	press(tea.KeyMsg{Type: tea.KeyCtrlS})
+	press(tea.KeyMsg{Type: tea.KeyEnter})
+	if view := m.footer.View(); !strings.Contains(view, "Save failed: no result to save yet") {
+		t.Errorf("expected a failure message in the footer, got %q", view)
+	}
+	press(tea.KeyMsg{Type: tea.KeySpace})
+	if view := m.footer.View(); strings.Contains(view, "Save failed") {
+		t.Errorf("expected the message to be cleared, got %q", view)
+	}
+}
@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+// DefaultSessionExportPath is where the export key writes the dashboard state
+// when --tui-export-on-exit is not set.
+const DefaultSessionExportPath = "fibcalc-tui-session.json"
+
+// Algorithm statuses reported in a SessionExport.
+const (
+	SessionStatusRunning = "running"
+	SessionStatusOK      = "ok"
+	SessionStatusFailed  = "failed"
+)
+
+// SessionExport is a JSON snapshot of the dashboard state, used to attach the
+// exact state of a TUI run to a bug report.
+type SessionExport struct {
+	ExportedAt time.Time          `json:"exported_at"`
+	N          uint64             `json:"n"`
+	Mode       string             `json:"mode"` // "single" or "comparison"
+	Algorithms []SessionAlgorithm `json:"algorithms"`
+	Done       bool               `json:"done"`
+	Paused     bool               `json:"paused"`
+	ExitCode   int                `json:"exit_code"`
+	LastError  string             `json:"last_error,omitempty"`
+	Width      int                `json:"width"`
+	Height     int                `json:"height"`
+	Elapsed    string             `json:"elapsed"`
+}
+
+// SessionAlgorithm is the state of one calculator in a SessionExport.
+type SessionAlgorithm struct {
+	Name       string  `json:"name"`
+	Status     string  `json:"status"`
+	Progress   float64 `json:"progress"`
+	Duration   string  `json:"duration,omitempty"`
+	ResultBits int     `json:"result_bits,omitempty"`
+	Error      string  `json:"error,omitempty"`
+}
+
+// sessionState tracks the per-algorithm data that the dashboard panels only
+// keep as rendered text.
+type sessionState struct {
+	progress []float64
+	results  []orchestration.CalculationResult
+	lastErr  error
+}
+
+// recordProgress stores the latest progress of calculator index.
+func (s *sessionState) recordProgress(index int, value float64) {
+	if index >= 0 && index < len(s.progress) {
+		s.progress[index] = value
+	}
+}
+
+// reset clears the state for a restarted calculation.
+func (s *sessionState) reset() {
+	s.progress = make([]float64, len(s.progress))
+	s.results = nil
+	s.lastErr = nil
+}
+
+// SessionState returns a snapshot of the dashboard for export.
+//
+// Returns:
+//   - SessionExport: The current dashboard state.
+func (m Model) SessionState() SessionExport {
+	export := SessionExport{
+		ExportedAt: time.Now(),
+		N:          m.config.N,
+		Mode:       "single",
+		Algorithms: make([]SessionAlgorithm, 0, len(m.calculators)),
+		Done:       m.done,
+		Paused:     m.paused,
+		ExitCode:   m.exitCode,
+		Width:      m.width,
+		Height:     m.height,
+		Elapsed:    m.header.elapsed().Round(time.Millisecond).String(),
+	}
+	if len(m.calculators) > 1 {
+		export.Mode = "comparison"
+	}
+	if m.session.lastErr != nil {
+		export.LastError = m.session.lastErr.Error()
+	}
+
+	for i, calc := range m.calculators {
+		algo := SessionAlgorithm{Name: calc.Name(), Status: SessionStatusRunning}
+		if i < len(m.session.progress) {
+			algo.Progress = m.session.progress[i]
+		}
+		for _, res := range m.session.results {
+			if res.Name != algo.Name {
+				continue
+			}
+			algo.Duration = res.Duration.String()
+			if res.Err != nil {
+				algo.Status = SessionStatusFailed
+				algo.Error = res.Err.Error()
+			} else {
+				algo.Status = SessionStatusOK
+				algo.Progress = 1
+				if res.Result != nil {
+					algo.ResultBits = res.Result.BitLen()
+				}
+			}
+		}
+		export.Algorithms = append(export.Algorithms, algo)
+	}
+	return export
+}
+
+// WriteSessionExport writes the dashboard state as indented JSON.
+//
+// Parameters:
+//   - path: The destination file.
+//   - export: The state to write.
+//
+// Returns:
+//   - error: An error if the state cannot be encoded or written.
+func WriteSessionExport(path string, export SessionExport) error {
+	data, err := json.MarshalIndent(export, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+	return nil
+}
+
+// sessionExportPath returns the file used by the export key.
+func (m Model) sessionExportPath() string {
+	if m.config.TUIExportOnExit != "" {
+		return m.config.TUIExportOnExit
+	}
+	return DefaultSessionExportPath
+}
@@ -0,0 +1,88 @@
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/config"
+	"github.com/agbru/fibcalc/internal/fibonacci"
+	"github.com/agbru/fibcalc/internal/orchestration"
+)
+
+func TestSessionExport_JSON(t *testing.T) {
+	calcs := []fibonacci.Calculator{mockCalculator{name: "Fast"}, mockCalculator{name: "Matrix"}, mockCalculator{name: "FFT"}}
+	cfg := config.AppConfig{N: 4242, Timeout: time.Minute}
+	m := NewModel(context.Background(), calcs, cfg, "v0.1.0", Preferences{})
+	t.Cleanup(m.cancel)
+
+	var model tea.Model = m
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model, _ = model.Update(ProgressMsg{CalculatorIndex: 2, Value: 0.5, AverageProgress: 0.5})
+	model, _ = model.Update(ComparisonResultsMsg{Results: []orchestration.CalculationResult{
+		{Name: "Fast", Result: big.NewInt(255), Duration: time.Millisecond},
+		{Name: "Matrix", Err: errors.New("boom"), Duration: 2 * time.Millisecond},
+	}})
+
+	path := filepath.Join(t.TempDir(), "session.json")
+	if err := WriteSessionExport(path, model.(Model).SessionState()); err != nil {
+		t.Fatalf("WriteSessionExport failed: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got SessionExport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("export is not valid JSON: %v\n%s", err, data)
+	}
+
+	if got.N != 4242 || got.Mode != "comparison" || got.Width != 120 || got.Height != 40 {
+		t.Errorf("unexpected header fields: %+v", got)
+	}
+	want := []struct {
+		name, status string
+		progress     float64
+	}{
+		{"Fast", SessionStatusOK, 1},
+		{"Matrix", SessionStatusFailed, 0},
+		{"FFT", SessionStatusRunning, 0.5},
+	}
+	if len(got.Algorithms) != len(want) {
+		t.Fatalf("got %d algorithms, want %d", len(got.Algorithms), len(want))
+	}
+	for i, w := range want {
+		a := got.Algorithms[i]
+		if a.Name != w.name || a.Status != w.status || a.Progress != w.progress {
+			t.Errorf("algorithm %d = %+v, want name=%s status=%s progress=%v", i, a, w.name, w.status, w.progress)
+		}
+	}
+	if got.Algorithms[0].ResultBits != 8 || got.Algorithms[1].Error != "boom" {
+		t.Errorf("missing result summary: %+v", got.Algorithms[:2])
+	}
+}
+
+func TestModel_HandleKey_Export(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	cfg := config.AppConfig{N: 10, Timeout: time.Minute, TUIExportOnExit: path}
+	m := NewModel(context.Background(), []fibonacci.Calculator{mockCalculator{name: "Fast"}}, cfg, "v0.1.0", Preferences{})
+	t.Cleanup(m.cancel)
+
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("export key did not write the session: %v", err)
+	}
+	var got SessionExport
+	if err := json.Unmarshal(data, &got); err != nil || got.N != 10 || got.Mode != "single" {
+		t.Errorf("unexpected export %s (err %v)", data, err)
+	}
+}
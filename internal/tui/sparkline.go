@@ -1,5 +1,7 @@
 package tui
 
+import "strings"
+
 // sparklineChars maps values 0..7 to Unicode block elements ▁▂▃▄▅▆▇█.
 var sparklineChars = [8]rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
 
@@ -110,6 +112,20 @@ func RenderSparkline(values []float64) string {
 	return string(runes)
 }
 
+// RenderSparklineWidth renders the most recent values (0..100) as a
+// sparkline of exactly width cells, right-aligned: a short or empty history
+// is padded with spaces on the left, so the sparkline does not grow while the
+// history fills up.
+func RenderSparklineWidth(values []float64, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+	return strings.Repeat(" ", width-len(values)) + RenderSparkline(values)
+}
+
 // brailleDots maps (col 0-1, row 0-3) to the braille dot bit offsets.
 // Braille character = U+2800 + sum of activated dot bits.
 // Column 0: dots 1,2,3,7 (bits 0,1,2,6)
@@ -204,3 +204,23 @@ func TestRenderSparkline_MidValue(t *testing.T) {
 		t.Errorf("expected '▄' for 50%%, got %c", runes[0])
 	}
 }
+
+func TestRenderSparklineWidth(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"empty history", nil, 4, "    "},
+		{"partial history", []float64{0, 100}, 4, "  ▁█"},
+		{"full history", []float64{0, 100, 0, 100}, 4, "▁█▁█"},
+		{"keeps the most recent", []float64{100, 100, 0, 0, 0}, 3, "▁▁▁"},
+		{"no room", []float64{50}, 0, ""},
+	}
+	for _, tt := range tests {
+		if got := RenderSparklineWidth(tt.values, tt.width); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
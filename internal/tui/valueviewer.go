@@ -0,0 +1,124 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/agbru/fibcalc/internal/format"
+)
+
+// ValueViewerModel is a full-screen pager over the decimal value of a result.
+// Values can have millions of digits, so only the visible lines are sliced
+// out of the digit string and styled on each render.
+type ValueViewerModel struct {
+	digits string
+	title  string
+	open   bool
+	width  int
+	height int
+	// offset is the index of the first visible line.
+	offset int
+}
+
+// NewValueViewerModel creates a closed value viewer.
+func NewValueViewerModel() ValueViewerModel {
+	return ValueViewerModel{}
+}
+
+// Open shows value, scrolled to the top.
+//
+// Parameters:
+//   - title: The caption of the panel, e.g. "F(1000)".
+//   - value: The decimal digits of the value.
+func (v *ValueViewerModel) Open(title, value string) {
+	v.title = title
+	v.digits = value
+	v.offset = 0
+	v.open = true
+}
+
+// Close hides the viewer and releases the value.
+func (v *ValueViewerModel) Close() {
+	v.open = false
+	v.digits = ""
+}
+
+// IsOpen reports whether the viewer is shown.
+func (v ValueViewerModel) IsOpen() bool {
+	return v.open
+}
+
+// SetSize updates the panel dimensions, keeping the first visible digit in
+// view when the line width changes.
+func (v *ValueViewerModel) SetSize(w, h int) {
+	firstDigit := v.offset * v.lineWidth()
+	v.width = w
+	v.height = h
+	v.offset = firstDigit / v.lineWidth()
+	v.clampOffset()
+}
+
+// lineWidth returns the number of digits per line, inside the panel border.
+func (v ValueViewerModel) lineWidth() int {
+	return max(v.width-2, 1)
+}
+
+// pageHeight returns the number of value lines shown, below the caption.
+func (v ValueViewerModel) pageHeight() int {
+	return max(v.height-3, 1)
+}
+
+// lineCount returns the number of lines of the value.
+func (v ValueViewerModel) lineCount() int {
+	w := v.lineWidth()
+	return (len(v.digits) + w - 1) / w
+}
+
+func (v *ValueViewerModel) clampOffset() {
+	v.offset = min(v.offset, v.lineCount()-v.pageHeight())
+	v.offset = max(v.offset, 0)
+}
+
+// Update scrolls the value with the navigation keys of km.
+func (v *ValueViewerModel) Update(msg tea.KeyMsg, km KeyMap) {
+	switch {
+	case key.Matches(msg, km.Up):
+		v.offset--
+	case key.Matches(msg, km.Down):
+		v.offset++
+	case key.Matches(msg, km.PageUp):
+		v.offset -= v.pageHeight()
+	case key.Matches(msg, km.PageDown):
+		v.offset += v.pageHeight()
+	}
+	v.clampOffset()
+}
+
+// visibleLines returns the lines of digits currently in view.
+func (v ValueViewerModel) visibleLines() []string {
+	w := v.lineWidth()
+	lines := make([]string, 0, v.pageHeight())
+	for i := v.offset; i < v.offset+v.pageHeight() && i*w < len(v.digits); i++ {
+		lines = append(lines, v.digits[i*w:min((i+1)*w, len(v.digits))])
+	}
+	return lines
+}
+
+// View renders the caption and the visible lines in a panel.
+func (v ValueViewerModel) View() string {
+	lines := v.visibleLines()
+	last := v.offset + len(lines)
+	caption := fmt.Sprintf("%s  %s digits  lines %d-%d of %d",
+		v.title, format.FormatNumberString(fmt.Sprint(len(v.digits))),
+		min(v.offset+1, last), last, v.lineCount())
+	// Keep the caption on one line so that it does not push the page down.
+	caption = caption[:min(len(caption), v.lineWidth())]
+	body := metricValueStyle.Render(strings.Join(lines, "\n"))
+	return panelStyle.
+		Width(max(v.width-2, 0)).
+		Height(max(v.height-2, 0)).
+		Render(logAlgoStyle.Render(caption) + "\n" + body)
+}
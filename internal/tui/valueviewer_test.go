@@ -0,0 +1,96 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// longDigits returns n digits cycling through 0-9.
+func longDigits(n int) string {
+	var b strings.Builder
+	for i := range n {
+		b.WriteByte(byte('0' + i%10))
+	}
+	return b.String()
+}
+
+func TestValueViewerModel_Scrolling(t *testing.T) {
+	km := DefaultKeyMap()
+	v := NewValueViewerModel()
+	v.SetSize(12, 8) // 10 digits per line, 5 lines per page
+	v.Open("F(1)", longDigits(1000))
+
+	first := func() string { return v.visibleLines()[0] }
+	if lines := v.visibleLines(); len(lines) != 5 || first() != "0123456789" {
+		t.Fatalf("unexpected first page %q", lines)
+	}
+
+	v.Update(tea.KeyMsg{Type: tea.KeyDown}, km)
+	if v.offset != 1 {
+		t.Errorf("offset after Down = %d, want 1", v.offset)
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyPgDown}, km)
+	if v.offset != 6 {
+		t.Errorf("offset after PageDown = %d, want 6", v.offset)
+	}
+	v.Update(tea.KeyMsg{Type: tea.KeyUp}, km)
+	if v.offset != 5 {
+		t.Errorf("offset after Up = %d, want 5", v.offset)
+	}
+
+	// Scrolling stops at the last full page and at the top.
+	for range 50 {
+		v.Update(tea.KeyMsg{Type: tea.KeyPgDown}, km)
+	}
+	if v.offset != 95 || len(v.visibleLines()) != 5 {
+		t.Errorf("offset at the end = %d with %d lines, want 95 with 5", v.offset, len(v.visibleLines()))
+	}
+	for range 50 {
+		v.Update(tea.KeyMsg{Type: tea.KeyPgUp}, km)
+	}
+	if v.offset != 0 {
+		t.Errorf("offset at the top = %d, want 0", v.offset)
+	}
+}
+
+func TestValueViewerModel_ResizeKeepsPosition(t *testing.T) {
+	v := NewValueViewerModel()
+	v.SetSize(12, 8)
+	v.Open("F(1)", longDigits(1000))
+	v.offset = 30 // first visible digit: 300
+
+	v.SetSize(22, 8) // 20 digits per line
+	if v.offset != 15 {
+		t.Errorf("offset after resize = %d, want 15", v.offset)
+	}
+
+	// A page taller than the value shows all of it.
+	v.SetSize(22, 100)
+	if v.offset != 0 || len(v.visibleLines()) != 50 {
+		t.Errorf("expected the whole value from the top, got offset %d and %d lines", v.offset, len(v.visibleLines()))
+	}
+}
+
+func TestValueViewerModel_View(t *testing.T) {
+	v := NewValueViewerModel()
+	v.SetSize(42, 8) // 40 digits per line
+	v.Open("F(1)", longDigits(85))
+
+	view := v.View()
+	for _, want := range []string{"F(1)", "85 digits", "lines 1-3 of 3", "\n│01234 "} {
+		if !strings.Contains(view, want) {
+			t.Errorf("expected view to contain %q, got:\n%s", want, view)
+		}
+	}
+	v.SetSize(12, 8)
+	if view := v.View(); !strings.Contains(view, "│F(1)  85 d│") {
+		t.Errorf("expected the caption to be cut to the panel width, got:\n%s", view)
+	}
+
+	v.Close()
+	if v.IsOpen() || v.digits != "" {
+		t.Error("expected Close to hide the viewer and release the value")
+	}
+}
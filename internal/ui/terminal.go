@@ -0,0 +1,62 @@
+package ui
+
+import (
+	"io"
+
+	"golang.org/x/term"
+)
+
+// DefaultTerminalWidth is the number of columns assumed when the width of the
+// output terminal cannot be determined (output redirected to a file or pipe,
+// failed size query, or a terminal reporting zero columns as some CI runners do).
+const DefaultTerminalWidth = 80
+
+// TerminalWidth returns the number of columns of the terminal behind w.
+// It never returns a non-positive value: when w is not backed by a file
+// descriptor, is not a terminal, or reports a zero width, DefaultTerminalWidth
+// is returned so that width-dependent layouts degrade to a fixed 80-column
+// rendering instead of producing garbage.
+//
+// Parameters:
+//   - w: The writer whose terminal width should be queried.
+//
+// Returns:
+//   - int: The terminal width in columns, or DefaultTerminalWidth.
+func TerminalWidth(w io.Writer) int {
+	f, ok := w.(interface{ Fd() uintptr })
+	if !ok {
+		return DefaultTerminalWidth
+	}
+	width, _, err := term.GetSize(int(f.Fd()))
+	if err != nil || width <= 0 {
+		return DefaultTerminalWidth
+	}
+	return width
+}
+
+// IsTerminal reports whether f is a terminal. Values that are not backed by a
+// file descriptor, such as in-memory buffers, are not terminals.
+//
+// Parameters:
+//   - f: The writer or reader to check, typically os.Stdout or os.Stdin.
+//
+// Returns:
+//   - bool: true if f is a terminal.
+func IsTerminal(f any) bool {
+	fd, ok := f.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(int(fd.Fd()))
+}
+
+// IsRedirected reports whether f is backed by a file descriptor that is not
+// a terminal, such as stdout redirected to a file or a pipe. In-memory
+// buffers are neither terminals nor redirected.
+//
+// Parameters:
+//   - f: The writer or reader to check, typically os.Stdout.
+//
+// Returns:
+//   - bool: true if f is a file descriptor other than a terminal.
+func IsRedirected(f any) bool {
+	fd, ok := f.(interface{ Fd() uintptr })
+	return ok && !term.IsTerminal(int(fd.Fd()))
+}
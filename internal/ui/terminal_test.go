@@ -0,0 +1,40 @@
+package ui
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestTerminalWidthFallback verifies that writers without a usable terminal
+// yield DefaultTerminalWidth instead of zero.
+func TestTerminalWidthFallback(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Non-file writer", func(t *testing.T) {
+		t.Parallel()
+		if got := TerminalWidth(&bytes.Buffer{}); got != DefaultTerminalWidth {
+			t.Errorf("TerminalWidth(buffer) = %d, want %d", got, DefaultTerminalWidth)
+		}
+	})
+
+	t.Run("Regular file", func(t *testing.T) {
+		t.Parallel()
+		f, err := os.Create(filepath.Join(t.TempDir(), "out.txt"))
+		if err != nil {
+			t.Fatalf("Create failed: %v", err)
+		}
+		defer f.Close()
+		if got := TerminalWidth(f); got != DefaultTerminalWidth {
+			t.Errorf("TerminalWidth(file) = %d, want %d", got, DefaultTerminalWidth)
+		}
+	})
+
+	t.Run("Nil writer", func(t *testing.T) {
+		t.Parallel()
+		if got := TerminalWidth(nil); got != DefaultTerminalWidth {
+			t.Errorf("TerminalWidth(nil) = %d, want %d", got, DefaultTerminalWidth)
+		}
+	})
+}
@@ -128,6 +128,19 @@ var (
 		Info:    lipgloss.Color("#4488FF"),
 	}
 
+	// LightTUITheme is the TUI palette for light terminal backgrounds.
+	LightTUITheme = TUITheme{
+		Bg:      lipgloss.Color("#FFFFFF"),
+		Text:    lipgloss.Color("#1A1A1A"),
+		Border:  lipgloss.Color("#C05000"),
+		Accent:  lipgloss.Color("#A04000"),
+		Success: lipgloss.Color("#2E7D32"),
+		Warning: lipgloss.Color("#B26A00"),
+		Error:   lipgloss.Color("#C62828"),
+		Dim:     lipgloss.Color("#8A8A8A"),
+		Info:    lipgloss.Color("#1E5BC6"),
+	}
+
 	// NoColorTUITheme disables all TUI colors.
 	// lipgloss.NoColor{} renders text with the terminal's default colors.
 	NoColorTUITheme = TUITheme{
@@ -144,15 +157,20 @@ var (
 )
 
 // GetCurrentTUITheme returns the TUI theme matching the currently active theme.
-// When NoColorTheme is active, returns NoColorTUITheme; otherwise DarkTUITheme.
+// NoColorTheme maps to NoColorTUITheme, LightTheme to LightTUITheme, and the
+// other themes to DarkTUITheme.
 func GetCurrentTUITheme() TUITheme {
 	themeMutex.RLock()
 	defer themeMutex.RUnlock()
 
-	if currentTheme.Name == "none" {
+	switch currentTheme.Name {
+	case "none":
 		return NoColorTUITheme
+	case "light":
+		return LightTUITheme
+	default:
+		return DarkTUITheme
 	}
-	return DarkTUITheme
 }
 
 // GetCurrentTheme returns the currently active theme in a thread-safe manner.
@@ -35,6 +35,29 @@ func TestSetTheme(t *testing.T) {
 	}
 }
 
+// TestGetCurrentTUITheme verifies the TUI palette chosen for each theme.
+func TestGetCurrentTUITheme(t *testing.T) {
+	originalTheme := GetCurrentTheme()
+	defer func() { SetCurrentTheme(originalTheme) }()
+
+	testCases := []struct {
+		themeName string
+		expected  TUITheme
+	}{
+		{"dark", DarkTUITheme},
+		{"orange", DarkTUITheme},
+		{"light", LightTUITheme},
+		{"none", NoColorTUITheme},
+	}
+
+	for _, tc := range testCases {
+		SetTheme(tc.themeName)
+		if got := GetCurrentTUITheme(); got != tc.expected {
+			t.Errorf("theme %q: got TUI theme %+v, want %+v", tc.themeName, got, tc.expected)
+		}
+	}
+}
+
 // TestInitThemeWithNoColorFlag verifies that InitTheme respects the noColor flag.
 func TestInitThemeWithNoColorFlag(t *testing.T) {
 	// Save original theme and env to restore after test
@@ -252,6 +252,67 @@ func TestCLI_OutputFile(t *testing.T) {
 	}
 }
 
+// TestCLI_RoundTrip verifies that --round-trip saves F(1000), reads it back
+// and verifies the re-parsed value.
+func TestCLI_RoundTrip(t *testing.T) {
+	binPath := buildBinary(t)
+
+	outFile := filepath.Join(t.TempDir(), "roundtrip.txt")
+	cmd := exec.Command(binPath, "-n", "1000", "--algo", "fast", "--round-trip", "--output", outFile)
+	cmd.Env = append(os.Environ(), "NO_COLOR=1")
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("Round-trip failed: %v\nOutput: %s", err, string(output))
+	}
+	if !strings.Contains(string(output), "Round-trip OK") {
+		t.Errorf("Output missing round-trip confirmation.\nGot:\n%s", string(output))
+	}
+
+	content, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("Failed to read round-trip file: %v", err)
+	}
+	// F(1000) ends in ...849228875
+	if !strings.Contains(string(content), "849228875") {
+		t.Errorf("Round-trip file does not contain F(1000).\nGot:\n%s", string(content))
+	}
+}
+
+// TestCLI_Expect verifies that --expect flags algorithms deviating from a known value.
+func TestCLI_Expect(t *testing.T) {
+	binPath := buildBinary(t)
+
+	tests := []struct {
+		name     string
+		expect   string
+		wantCode int
+		wantText string
+	}{
+		{"Correct", "354224848179261915075", 0, "match the expected value"},
+		{"Wrong", "354224848179261915076", 3, "differs from the expected value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(binPath, "-n", "100", "--algo", "all", "--expect", tt.expect)
+			cmd.Env = append(os.Environ(), "NO_COLOR=1")
+			output, err := cmd.CombinedOutput()
+			code := 0
+			if exitErr, ok := err.(*exec.ExitError); ok {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatalf("Failed to run binary: %v", err)
+			}
+			if code != tt.wantCode {
+				t.Errorf("Exit code = %d, want %d.\nOutput:\n%s", code, tt.wantCode, string(output))
+			}
+			if !strings.Contains(string(output), tt.wantText) {
+				t.Errorf("Output missing %q.\nGot:\n%s", tt.wantText, string(output))
+			}
+		})
+	}
+}
+
 // TestCLI_TimeoutLargeN verifies that a very short timeout with a huge N triggers timeout behavior.
 func TestCLI_TimeoutLargeN(t *testing.T) {
 	binPath := buildBinary(t)
@@ -303,6 +364,16 @@ func TestCLI_Completion(t *testing.T) {
 			shell:   "powershell",
 			wantOut: "Register-ArgumentCompleter",
 		},
+		{
+			name:    "Nushell",
+			shell:   "nushell",
+			wantOut: `export extern "fibcalc"`,
+		},
+		{
+			name:    "Elvish",
+			shell:   "elvish",
+			wantOut: "edit:completion:arg-completer[fibcalc]",
+		},
 	}
 
 	for _, tt := range shells {